@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+)
+
+// AccountStatusChanger defines the behaviour about how to change the status of an account
+type AccountStatusChanger interface {
+	Change(context.Context, *domain.ID, domain.AccountStatus, domain.StatusReason) (*domain.Account, error)
+}
+
+// ChangeAccountStatus contains the dependencies to change the status of an account
+type ChangeAccountStatus struct {
+	logger               domain.Logger
+	accountStatusChanger AccountStatusChanger
+}
+
+// NewChangeAccountStatus creates a new ChangeAccountStatus struct with its dependencies
+func NewChangeAccountStatus(logger domain.Logger, accountStatusChanger AccountStatusChanger) *ChangeAccountStatus {
+	return &ChangeAccountStatus{logger: logger, accountStatusChanger: accountStatusChanger}
+}
+
+// Handler exposes the http handler
+func (h ChangeAccountStatus) Handler(rw http.ResponseWriter, req *http.Request) {
+	const idPosition = 2
+
+	responder := newResponder(rw)
+
+	idParam, err := extractParamID(req, idPosition)
+	if err != nil {
+		h.logger.Info(req.Context(), "invalid account id", domain.NewErrLogField(err))
+
+		errResponse := newErrorResponse(map[string]string{"id": err.Error()})
+		responder.badRequest(errResponse.Encode())
+		return
+	}
+
+	payload, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		h.logger.Error(req.Context(), "read payload error", domain.NewErrLogField(err))
+		responder.internalServerError()
+		return
+	}
+	defer req.Body.Close()
+
+	request := changeAccountStatusPayloadRequest{}
+
+	if err := json.Unmarshal(payload, &request); err != nil {
+		h.logger.Info(req.Context(), "invalid payload", domain.NewErrLogField(err))
+
+		errResponse := newErrorResponse(map[string]string{"root": "invalid payload"})
+		responder.badRequest(errResponse.Encode())
+
+		return
+	}
+
+	if errs := request.validate(); errs != nil {
+		errResponse := newErrorResponse(errs)
+
+		h.logger.Info(req.Context(), "change account status payload doesn't match with the specifications", domain.NewLogField("errors", errs))
+		responder.badRequest(errResponse.Encode())
+		return
+	}
+
+	account, err := h.accountStatusChanger.Change(req.Context(), domain.NewID(idParam), request.status(), request.reason())
+	if err != nil {
+		h.logger.Warn(req.Context(), "unable to change the account status", domain.NewErrLogField(err))
+
+		if _, ok := err.(*repository.ErrRegisterNotFound); ok {
+			errResponse := newErrorResponse(map[string]string{"id": fmt.Sprintf("%d not found", idParam)})
+			responder.notFound(errResponse.Encode())
+			return
+		}
+
+		if v, ok := err.(*domain.ErrDomain); ok {
+			translateDomainError(responder, v)
+			return
+		}
+
+		// unknown error
+		responder.internalServerError()
+		return
+	}
+
+	response := newAccountResponse(
+		account.ID().Value(),
+		account.Document().Number().String(),
+		account.CreatedAt(),
+	).
+		withDocumentType(account.Document().Type()).
+		withCurrency(account.Currency()).
+		withCreditLimit(account.CreditLimit(), account.AvailableCreditLimit()).
+		withBillingCycle(account.BillingCycle()).
+		withStatus(account.Status(), account.StatusReason(), account.StatusChangedAt())
+
+	responder.ok(response.Encode())
+}
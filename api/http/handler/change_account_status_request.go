@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+type changeAccountStatusPayloadRequest struct {
+	Status string `json:"status" validate:"required"`
+	Reason string `json:"reason" validate:"required"`
+}
+
+// validate returns a map where the key is the field and the value the error description
+func (c *changeAccountStatusPayloadRequest) validate() map[string]string {
+	errs := map[string]string{}
+
+	if err := validate.Struct(c); err != nil {
+		errs = translateValidations(err.(validator.ValidationErrors))
+	}
+
+	if _, ok := errs["status"]; !ok {
+		if _, err := domain.NewAccountStatus(c.Status); err != nil {
+			errs["status"] = err.Error()
+		}
+	}
+
+	if _, ok := errs["reason"]; !ok {
+		if _, err := domain.NewStatusReason(c.Reason); err != nil {
+			errs["reason"] = err.Error()
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// status returns the new status of the account. It must be called only after a successful validation.
+func (c *changeAccountStatusPayloadRequest) status() domain.AccountStatus {
+	status, _ := domain.NewAccountStatus(c.Status)
+
+	return status
+}
+
+// reason returns the reason code of the change. It must be called only after a successful validation.
+func (c *changeAccountStatusPayloadRequest) reason() domain.StatusReason {
+	reason, _ := domain.NewStatusReason(c.Reason)
+
+	return reason
+}
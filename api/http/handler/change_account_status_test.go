@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+)
+
+func TestChangeAccountStatus_Handler(t *testing.T) {
+	var (
+		logger        = domain.NewLoggerMock()
+		datetimeRegex = `[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}:[0-9]{2}Z`
+	)
+
+	accountOK, _ := domain.NewAccount("00000000191")
+	accountOK = accountOK.
+		WithID(domain.NewID(1)).
+		WithCreateAt(time.Now()).
+		WithCreditLimit(domain.NewMoney(100000, domain.CurrencyBRL)).
+		WithStatus(domain.AccountStatusBlocked, domain.StatusReasonFraudSuspected, time.Now())
+
+	type fields struct {
+		accountStatusChanger AccountStatusChanger
+	}
+	type args struct {
+		path    string
+		payload io.Reader
+	}
+	tests := []struct {
+		name                string
+		fields              fields
+		args                args
+		wantPayloadResponse string
+		wantHTTPStatusCode  int
+	}{
+		// fails
+		{
+			name: "bad request when the account id is invalid",
+			fields: fields{
+				accountStatusChanger: newFakeAccountStatusChanger(nil, nil),
+			},
+			args: args{
+				path:    "/accounts/0/status",
+				payload: bytes.NewReader([]byte(`{"status": "BLOCKED", "reason": "FRAUD_SUSPECTED"}`)),
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"id","description":"id must be greater than zero"}\]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name: "bad request when the payload is empty",
+			fields: fields{
+				accountStatusChanger: newFakeAccountStatusChanger(nil, nil),
+			},
+			args: args{
+				path:    "/accounts/1/status",
+				payload: bytes.NewReader([]byte("")),
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"root","description":"invalid payload"}\]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name: "bad request when the status is invalid",
+			fields: fields{
+				accountStatusChanger: newFakeAccountStatusChanger(nil, nil),
+			},
+			args: args{
+				path:    "/accounts/1/status",
+				payload: bytes.NewReader([]byte(`{"status": "SUSPENDED", "reason": "FRAUD_SUSPECTED"}`)),
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"status","description":"status 'SUSPENDED' is not a valid account status"}\]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name: "bad request when the reason is not informed",
+			fields: fields{
+				accountStatusChanger: newFakeAccountStatusChanger(nil, nil),
+			},
+			args: args{
+				path:    "/accounts/1/status",
+				payload: bytes.NewReader([]byte(`{"status": "BLOCKED"}`)),
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"reason","description":"reason is a required field"}\]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name: "not found when the account does not exist",
+			fields: fields{
+				accountStatusChanger: newFakeAccountStatusChanger(nil, repository.NewErrRegisterNotFound("id", "1")),
+			},
+			args: args{
+				path:    "/accounts/1/status",
+				payload: bytes.NewReader([]byte(`{"status": "BLOCKED", "reason": "FRAUD_SUSPECTED"}`)),
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"id","description":"1 not found"}\]}`,
+			wantHTTPStatusCode:  http.StatusNotFound,
+		},
+		{
+			name: "unprocessable entity when the transition is not allowed",
+			fields: fields{
+				accountStatusChanger: newFakeAccountStatusChanger(nil, domain.NewErrDomain("status", "cannot be changed from 'CLOSED' to 'ACTIVE'")),
+			},
+			args: args{
+				path:    "/accounts/1/status",
+				payload: bytes.NewReader([]byte(`{"status": "ACTIVE", "reason": "ISSUE_RESOLVED"}`)),
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"status","description":"status cannot be changed from 'CLOSED' to 'ACTIVE'"}\]}`,
+			wantHTTPStatusCode:  http.StatusUnprocessableEntity,
+		},
+		{
+			name: "internal server error when returns an unknown error",
+			fields: fields{
+				accountStatusChanger: newFakeAccountStatusChanger(nil, errors.New("unknown error")),
+			},
+			args: args{
+				path:    "/accounts/1/status",
+				payload: bytes.NewReader([]byte(`{"status": "BLOCKED", "reason": "FRAUD_SUSPECTED"}`)),
+			},
+			wantPayloadResponse: ``,
+			wantHTTPStatusCode:  http.StatusInternalServerError,
+		},
+
+		// successes
+		{
+			name: "account blocked successfully",
+			fields: fields{
+				accountStatusChanger: newFakeAccountStatusChanger(accountOK, nil),
+			},
+			args: args{
+				path:    "/accounts/1/status",
+				payload: bytes.NewReader([]byte(`{"status": "blocked", "reason": "FRAUD_SUSPECTED"}`)),
+			},
+			wantPayloadResponse: fmt.Sprintf(`{"id":1,"document":{"type":"CPF","number":"00000000191"},"currency":"BRL","credit_limit":1000.00,"available_credit_limit":1000.00,"closing_day":25,"due_day":5,"status":"BLOCKED","status_reason":"FRAUD_SUSPECTED","status_changed_at":"%s","created_at":"%s"}`, datetimeRegex, datetimeRegex),
+			wantHTTPStatusCode:  http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			httpHandler := http.HandlerFunc(NewChangeAccountStatus(logger, tt.fields.accountStatusChanger).Handler)
+			req, err := http.NewRequest("PATCH", tt.args.path, tt.args.payload)
+			if err != nil {
+				t.Error("error to perform PATCH /accounts/:id/status request")
+			}
+
+			httpHandler.ServeHTTP(rr, req)
+
+			var (
+				gotHTTPStatusCode = rr.Code
+				gotPayload        = rr.Body.String()
+			)
+
+			if gotHTTPStatusCode != tt.wantHTTPStatusCode {
+				t.Errorf("HTTP Status Code is different from expected, got = %v, want %v", gotHTTPStatusCode, tt.wantHTTPStatusCode)
+				return
+			}
+
+			match := regexp.MustCompile(tt.wantPayloadResponse).MatchString(gotPayload)
+			if !match {
+				t.Errorf("Payload Response is different from expected, got = %v, want %v", gotPayload, tt.wantPayloadResponse)
+				return
+			}
+		})
+	}
+}
+
+type fakeAccountStatusChanger struct {
+	account *domain.Account
+	err     error
+}
+
+func newFakeAccountStatusChanger(account *domain.Account, err error) *fakeAccountStatusChanger {
+	return &fakeAccountStatusChanger{account: account, err: err}
+}
+
+func (f fakeAccountStatusChanger) Change(context.Context, *domain.ID, domain.AccountStatus, domain.StatusReason) (*domain.Account, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return f.account, nil
+}
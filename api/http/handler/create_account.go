@@ -1,9 +1,9 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"io/ioutil"
-	"log"
 	"net/http"
 
 	"github.com/tonytcb/bank-transactions-go/domain"
@@ -12,17 +12,17 @@ import (
 
 // AccountCreator defines the behaviour about how to create an account
 type AccountCreator interface {
-	Create(string) (*domain.Account, error)
+	Create(context.Context, string, domain.Currency, domain.Money, domain.BillingCycle) (*domain.Account, error)
 }
 
 // CreateAccount contains the dependencies to create an account
 type CreateAccount struct {
-	logger         *log.Logger
+	logger         domain.Logger
 	accountCreator AccountCreator
 }
 
 // NewCreateAccount creates a new CreateAccount struct with its dependencies
-func NewCreateAccount(logger *log.Logger, accountCreator AccountCreator) *CreateAccount {
+func NewCreateAccount(logger domain.Logger, accountCreator AccountCreator) *CreateAccount {
 	return &CreateAccount{logger: logger, accountCreator: accountCreator}
 }
 
@@ -32,14 +32,14 @@ func (h CreateAccount) Handler(rw http.ResponseWriter, req *http.Request) {
 
 	payload, err := ioutil.ReadAll(req.Body)
 	if err != nil {
-		h.logger.Println("read payload error:", err)
+		h.logger.Error(req.Context(), "read payload error", domain.NewErrLogField(err))
 		responder.internalServerError()
 		return
 	}
 
 	request := &createAccountPayloadRequest{}
 	if err := json.Unmarshal(payload, request); err != nil {
-		h.logger.Println("invalid payload:", err)
+		h.logger.Info(req.Context(), "invalid payload", domain.NewErrLogField(err))
 
 		errResponse := newErrorResponse(map[string]string{"root": "payload must be a valid JSON"})
 		responder.badRequest(errResponse.Encode())
@@ -53,14 +53,20 @@ func (h CreateAccount) Handler(rw http.ResponseWriter, req *http.Request) {
 	if errs := request.validate(); errs != nil {
 		errResponse := newErrorResponse(errs)
 
-		h.logger.Println("create account payload doesn't match with the specifications:", errs)
+		h.logger.Info(req.Context(), "create account payload doesn't match with the specifications", domain.NewLogField("errors", errs))
 		responder.badRequest(errResponse.Encode())
 		return
 	}
 
-	account, err := h.accountCreator.Create(request.Document.Number)
+	account, err := h.accountCreator.Create(
+		req.Context(),
+		request.Document.Number,
+		request.currency(),
+		request.creditLimit(),
+		request.billingCycle(),
+	)
 	if err != nil {
-		h.logger.Println("unable to create account:", err)
+		h.logger.Warn(req.Context(), "unable to create account", domain.NewErrLogField(err))
 
 		if v, ok := err.(*repository.ErrDuplicateEntry); ok {
 			h.translateDuplicateError(responder, v)
@@ -81,7 +87,12 @@ func (h CreateAccount) Handler(rw http.ResponseWriter, req *http.Request) {
 		account.ID().Value(),
 		account.Document().Number().String(),
 		account.CreatedAt(),
-	)
+	).
+		withDocumentType(account.Document().Type()).
+		withCurrency(account.Currency()).
+		withCreditLimit(account.CreditLimit(), account.AvailableCreditLimit()).
+		withBillingCycle(account.BillingCycle()).
+		withStatus(account.Status(), account.StatusReason(), account.StatusChangedAt())
 
 	responder.created(response.Encode())
 }
@@ -1,16 +1,27 @@
 package handler
 
 import (
+	"encoding/json"
 	"regexp"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+const (
+	cpfLength  = 11
+	cnpjLength = 14
 )
 
 type createAccountPayloadRequest struct {
 	Document struct {
-		Number string `json:"number" validate:"required,number,len=11"`
+		Number string `json:"number" validate:"required,number"`
 	}
+	Currency    string      `json:"currency"`
+	CreditLimit json.Number `json:"credit_limit"`
+	ClosingDay  *int        `json:"closing_day"`
+	DueDay      *int        `json:"due_day"`
 }
 
 func (c *createAccountPayloadRequest) sanitize() {
@@ -22,9 +33,87 @@ func (c *createAccountPayloadRequest) sanitize() {
 }
 
 func (c *createAccountPayloadRequest) validate() map[string]string {
+	errs := map[string]string{}
+
 	if err := validate.Struct(c); err != nil {
-		return translateValidations(err.(validator.ValidationErrors))
+		errs = translateValidations(err.(validator.ValidationErrors))
+	}
+
+	if _, ok := errs["document.number"]; !ok {
+		if n := len(c.Document.Number); n != cpfLength && n != cnpjLength {
+			errs["document.number"] = "number must be 11 (CPF) or 14 (CNPJ) characters in length"
+		}
+	}
+
+	if c.Currency != "" {
+		if _, err := domain.NewCurrency(c.Currency); err != nil {
+			errs["currency"] = err.Error()
+		}
+	}
+
+	if c.CreditLimit != "" {
+		if limit, err := parseMoney(c.CreditLimit.String(), c.currency()); err != nil {
+			errs["credit_limit"] = "credit_limit " + err.Error()
+		} else if limit.IsNegative() {
+			errs["credit_limit"] = "credit_limit must be 0 or greater"
+		}
+	}
+
+	if _, err := domain.NewBillingCycle(c.closingDay(), c.dueDay()); err != nil {
+		errs[err.(*domain.ErrDomain).Field()] = err.Error()
+	}
+
+	if len(errs) > 0 {
+		return errs
 	}
 
 	return nil
 }
+
+// currency returns the currency of the account, BRL when it is not informed.
+// It must be called only after a successful validation.
+func (c *createAccountPayloadRequest) currency() domain.Currency {
+	if c.Currency == "" {
+		return domain.CurrencyBRL
+	}
+
+	currency, _ := domain.NewCurrency(c.Currency)
+
+	return currency
+}
+
+// creditLimit returns the exact credit limit of the account in its currency, zero when it is not informed.
+// It must be called only after a successful validation.
+func (c *createAccountPayloadRequest) creditLimit() domain.Money {
+	if c.CreditLimit == "" {
+		return domain.NewMoney(0, c.currency())
+	}
+
+	limit, _ := parseMoney(c.CreditLimit.String(), c.currency())
+
+	return limit
+}
+
+// billingCycle returns the billing cycle of the account, the informed days replacing the default ones.
+// It must be called only after a successful validation.
+func (c *createAccountPayloadRequest) billingCycle() domain.BillingCycle {
+	cycle, _ := domain.NewBillingCycle(c.closingDay(), c.dueDay())
+
+	return cycle
+}
+
+func (c *createAccountPayloadRequest) closingDay() int {
+	if c.ClosingDay == nil {
+		return domain.DefaultClosingDay
+	}
+
+	return *c.ClosingDay
+}
+
+func (c *createAccountPayloadRequest) dueDay() int {
+	if c.DueDay == nil {
+		return domain.DefaultDueDay
+	}
+
+	return *c.DueDay
+}
@@ -3,16 +3,27 @@ package handler
 import (
 	"encoding/json"
 	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
 )
 
 type documentResponse struct {
+	Type   string `json:"type,omitempty"`
 	Number string `json:"number,omitempty"`
 }
 
 type accountResponse struct {
-	ID        uint64           `json:"id,omitempty"`
-	Document  documentResponse `json:"document,omitempty"`
-	CreatedAt string           `json:"created_at,omitempty"`
+	ID                   uint64           `json:"id,omitempty"`
+	Document             documentResponse `json:"document,omitempty"`
+	Currency             string           `json:"currency,omitempty"`
+	CreditLimit          json.Number      `json:"credit_limit,omitempty"`
+	AvailableCreditLimit json.Number      `json:"available_credit_limit,omitempty"`
+	ClosingDay           int              `json:"closing_day,omitempty"`
+	DueDay               int              `json:"due_day,omitempty"`
+	Status               string           `json:"status,omitempty"`
+	StatusReason         string           `json:"status_reason,omitempty"`
+	StatusChangedAt      string           `json:"status_changed_at,omitempty"`
+	CreatedAt            string           `json:"created_at,omitempty"`
 }
 
 func newAccountResponse(ID uint64, documentNumber string, createdAt time.Time) accountResponse {
@@ -28,6 +39,44 @@ func newAccountResponse(ID uint64, documentNumber string, createdAt time.Time) a
 	}
 }
 
+func (c accountResponse) withDocumentType(documentType domain.DocumentType) accountResponse {
+	c.Document.Type = documentType.String()
+
+	return c
+}
+
+func (c accountResponse) withCurrency(currency domain.Currency) accountResponse {
+	c.Currency = currency.String()
+
+	return c
+}
+
+func (c accountResponse) withCreditLimit(limit, available domain.Money) accountResponse {
+	c.CreditLimit = json.Number(limit.String())
+	c.AvailableCreditLimit = json.Number(available.String())
+
+	return c
+}
+
+func (c accountResponse) withBillingCycle(cycle domain.BillingCycle) accountResponse {
+	c.ClosingDay = cycle.ClosingDay()
+	c.DueDay = cycle.DueDay()
+
+	return c
+}
+
+// withStatus describes the account status, and the reason and time of its last change when it was changed
+func (c accountResponse) withStatus(status domain.AccountStatus, reason domain.StatusReason, changedAt time.Time) accountResponse {
+	c.Status = status.String()
+	c.StatusReason = reason.String()
+
+	if !changedAt.IsZero() {
+		c.StatusChangedAt = changedAt.UTC().Format(time.RFC3339)
+	}
+
+	return c
+}
+
 func (c accountResponse) Encode() []byte {
 	res, _ := json.Marshal(c)
 
@@ -2,10 +2,10 @@ package handler
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"net/http/httptest"
 	"regexp"
@@ -17,9 +17,14 @@ import (
 )
 
 func TestCreateAccount_Handler(t *testing.T) {
-	var logger = log.New(fakeWriter{}, "", log.LstdFlags)
+	var logger = domain.NewLoggerMock()
 
 	accountOK, _ := domain.NewAccount("00000000191")
+	accountOK = accountOK.WithCreditLimit(domain.NewMoney(100000, domain.CurrencyBRL))
+
+	businessAccountOK, _ := domain.NewAccount("11222333000181")
+
+	cycle, _ := domain.NewBillingCycle(10, 20)
 
 	datetimeRegex := `[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}:[0-9]{2}Z`
 
@@ -56,7 +61,51 @@ func TestCreateAccount_Handler(t *testing.T) {
 			args: args{
 				payload: bytes.NewReader([]byte(`{"document": {"number": "000"} }`)),
 			},
-			wantPayloadResponse: `{"errors":\[{"field":"document.number","description":"number must be 11 characters in length"}\]}`,
+			wantPayloadResponse: `{"errors":\[{"field":"document.number","description":"number must be 11 \(CPF\) or 14 \(CNPJ\) characters in length"}\]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name: "bad request when the payload has a negative credit limit",
+			fields: fields{
+				accountCreator: newFakeAccountCreator(nil, nil),
+			},
+			args: args{
+				payload: bytes.NewReader([]byte(`{"document": {"number": "00000000191"}, "credit_limit": -1 }`)),
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"credit_limit","description":"credit_limit must be 0 or greater"}\]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name: "bad request when the payload has a credit limit with more than 2 decimal places",
+			fields: fields{
+				accountCreator: newFakeAccountCreator(nil, nil),
+			},
+			args: args{
+				payload: bytes.NewReader([]byte(`{"document": {"number": "00000000191"}, "credit_limit": 0.001 }`)),
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"credit_limit","description":"credit_limit must have at most 2 decimal places"}\]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name: "bad request when the payload has an invalid currency",
+			fields: fields{
+				accountCreator: newFakeAccountCreator(nil, nil),
+			},
+			args: args{
+				payload: bytes.NewReader([]byte(`{"document": {"number": "00000000191"}, "currency": "BRLX" }`)),
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"currency","description":"currency 'BRLX' is not a supported ISO 4217 currency code"}\]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name: "bad request when the payload has an invalid closing day",
+			fields: fields{
+				accountCreator: newFakeAccountCreator(nil, nil),
+			},
+			args: args{
+				payload: bytes.NewReader([]byte(`{"document": {"number": "00000000191"}, "closing_day": 31 }`)),
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"closing_day","description":"closing_day must be between 1 and 28"}\]}`,
 			wantHTTPStatusCode:  http.StatusBadRequest,
 		},
 		{
@@ -113,7 +162,18 @@ func TestCreateAccount_Handler(t *testing.T) {
 			args: args{
 				payload: bytes.NewReader([]byte(`{"document": {"number": "00000000191"} }`)),
 			},
-			wantPayloadResponse: fmt.Sprintf(`{"id":100,"document":{"number":"00000000191"},"created_at":"%s"}`, datetimeRegex),
+			wantPayloadResponse: fmt.Sprintf(`{"id":100,"document":{"type":"CPF","number":"00000000191"},"currency":"BRL","credit_limit":1000.00,"available_credit_limit":1000.00,"closing_day":25,"due_day":5,"status":"ACTIVE","created_at":"%s"}`, datetimeRegex),
+			wantHTTPStatusCode:  http.StatusCreated,
+		},
+		{
+			name: "account created successfully with a credit limit",
+			fields: fields{
+				accountCreator: newFakeAccountCreator(accountOK.WithID(domain.NewID(300)).WithCreateAt(time.Now()), nil),
+			},
+			args: args{
+				payload: bytes.NewReader([]byte(`{"document": {"number": "00000000191"}, "credit_limit": 1000 }`)),
+			},
+			wantPayloadResponse: fmt.Sprintf(`{"id":300,"document":{"type":"CPF","number":"00000000191"},"currency":"BRL","credit_limit":1000.00,"available_credit_limit":1000.00,"closing_day":25,"due_day":5,"status":"ACTIVE","created_at":"%s"}`, datetimeRegex),
 			wantHTTPStatusCode:  http.StatusCreated,
 		},
 		{
@@ -124,7 +184,29 @@ func TestCreateAccount_Handler(t *testing.T) {
 			args: args{
 				payload: bytes.NewReader([]byte(`{"document": {"number": "000.000.001-91"} }`)),
 			},
-			wantPayloadResponse: fmt.Sprintf(`{"id":200,"document":{"number":"00000000191"},"created_at":"%s"}`, datetimeRegex),
+			wantPayloadResponse: fmt.Sprintf(`{"id":200,"document":{"type":"CPF","number":"00000000191"},"currency":"BRL","credit_limit":1000.00,"available_credit_limit":1000.00,"closing_day":25,"due_day":5,"status":"ACTIVE","created_at":"%s"}`, datetimeRegex),
+			wantHTTPStatusCode:  http.StatusCreated,
+		},
+		{
+			name: "account created successfully with a billing cycle",
+			fields: fields{
+				accountCreator: newFakeAccountCreator(accountOK.WithID(domain.NewID(500)).WithBillingCycle(cycle).WithCreateAt(time.Now()), nil),
+			},
+			args: args{
+				payload: bytes.NewReader([]byte(`{"document": {"number": "00000000191"}, "closing_day": 10, "due_day": 20 }`)),
+			},
+			wantPayloadResponse: fmt.Sprintf(`{"id":500,"document":{"type":"CPF","number":"00000000191"},"currency":"BRL","credit_limit":1000.00,"available_credit_limit":1000.00,"closing_day":10,"due_day":20,"status":"ACTIVE","created_at":"%s"}`, datetimeRegex),
+			wantHTTPStatusCode:  http.StatusCreated,
+		},
+		{
+			name: "business account created successfully with a formatted cnpj",
+			fields: fields{
+				accountCreator: newFakeAccountCreator(businessAccountOK.WithID(domain.NewID(400)).WithCreateAt(time.Now()), nil),
+			},
+			args: args{
+				payload: bytes.NewReader([]byte(`{"document": {"number": "11.222.333/0001-81"} }`)),
+			},
+			wantPayloadResponse: fmt.Sprintf(`{"id":400,"document":{"type":"CNPJ","number":"11222333000181"},"currency":"BRL","credit_limit":0.00,"available_credit_limit":0.00,"closing_day":25,"due_day":5,"status":"ACTIVE","created_at":"%s"}`, datetimeRegex),
 			wantHTTPStatusCode:  http.StatusCreated,
 		},
 	}
@@ -159,13 +241,6 @@ func TestCreateAccount_Handler(t *testing.T) {
 	}
 }
 
-type fakeWriter struct {
-}
-
-func (f fakeWriter) Write(_ []byte) (n int, err error) {
-	return 0, nil
-}
-
 type fakeAccountCreator struct {
 	account *domain.Account
 	err     error
@@ -175,7 +250,7 @@ func newFakeAccountCreator(account *domain.Account, err error) *fakeAccountCreat
 	return &fakeAccountCreator{account: account, err: err}
 }
 
-func (f fakeAccountCreator) Create(_ string) (*domain.Account, error) {
+func (f fakeAccountCreator) Create(_ context.Context, _ string, _ domain.Currency, _ domain.Money, _ domain.BillingCycle) (*domain.Account, error) {
 	if f.err != nil {
 		return nil, f.err
 	}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+)
+
+// OperationCreator defines the behaviour about how to create an operation
+type OperationCreator interface {
+	Create(context.Context, string, domain.OperationDirection, domain.OperationKind) (*domain.Operation, error)
+}
+
+// CreateOperation contains the dependencies to create an operation
+type CreateOperation struct {
+	logger           domain.Logger
+	operationCreator OperationCreator
+}
+
+// NewCreateOperation creates a new CreateOperation struct with its dependencies
+func NewCreateOperation(logger domain.Logger, operationCreator OperationCreator) *CreateOperation {
+	return &CreateOperation{logger: logger, operationCreator: operationCreator}
+}
+
+// Handler exposes the http handler
+func (h CreateOperation) Handler(rw http.ResponseWriter, req *http.Request) {
+	responder := newResponder(rw)
+
+	payload, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		h.logger.Error(req.Context(), "read payload error", domain.NewErrLogField(err))
+		responder.internalServerError()
+		return
+	}
+	defer req.Body.Close()
+
+	request := &createOperationPayloadRequest{}
+	if err := json.Unmarshal(payload, request); err != nil {
+		h.logger.Info(req.Context(), "invalid payload", domain.NewErrLogField(err))
+
+		errResponse := newErrorResponse(map[string]string{"root": "payload must be a valid JSON"})
+		responder.badRequest(errResponse.Encode())
+
+		return
+	}
+
+	if errs := request.validate(); errs != nil {
+		errResponse := newErrorResponse(errs)
+
+		h.logger.Info(req.Context(), "create operation payload doesn't match with the specifications", domain.NewLogField("errors", errs))
+		responder.badRequest(errResponse.Encode())
+		return
+	}
+
+	operation, err := h.operationCreator.Create(req.Context(), request.Description, request.direction(), request.kind())
+	if err != nil {
+		h.logger.Warn(req.Context(), "unable to create operation", domain.NewErrLogField(err))
+
+		if _, ok := err.(*repository.ErrDuplicateEntry); ok {
+			errResponse := newErrorResponse(map[string]string{"type": fmt.Sprintf("'%s' already exists", request.Description)})
+			responder.conflict(errResponse.Encode())
+			return
+		}
+
+		if v, ok := err.(*domain.ErrDomain); ok {
+			translateDomainError(responder, v)
+			return
+		}
+
+		// unknown error
+		responder.internalServerError()
+		return
+	}
+
+	responder.created(newOperationTypeResponse(operation).Encode())
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+type createOperationPayloadRequest struct {
+	Description string `json:"type" validate:"required"`
+	Direction   string `json:"direction" validate:"required"`
+	Kind        string `json:"kind"`
+}
+
+func (c *createOperationPayloadRequest) validate() map[string]string {
+	errs := map[string]string{}
+
+	if err := validate.Struct(c); err != nil {
+		errs = translateValidations(err.(validator.ValidationErrors))
+	}
+
+	if _, ok := errs["direction"]; !ok {
+		if _, err := domain.NewOperationDirection(c.Direction); err != nil {
+			errs["direction"] = err.Error()
+		}
+	}
+
+	if c.Kind != "" {
+		if _, err := domain.NewOperationKind(c.Kind); err != nil {
+			errs["kind"] = err.Error()
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// direction returns the direction of the operation. It must be called only after a successful validation.
+func (c *createOperationPayloadRequest) direction() domain.OperationDirection {
+	direction, _ := domain.NewOperationDirection(c.Direction)
+
+	return direction
+}
+
+// kind returns the kind of the operation, standard when not informed. It must be called only after a successful
+// validation.
+func (c *createOperationPayloadRequest) kind() domain.OperationKind {
+	if c.Kind == "" {
+		return domain.OperationKindStandard
+	}
+
+	kind, _ := domain.NewOperationKind(c.Kind)
+
+	return kind
+}
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+)
+
+func TestCreateOperation_Handler(t *testing.T) {
+	var logger = domain.NewLoggerMock()
+
+	cashback, _ := domain.NewOperationType("cashback", domain.OperationDirectionCredit, domain.OperationKindStandard)
+	cashback = cashback.WithID(domain.NewID(8))
+
+	tests := []struct {
+		name                string
+		operationCreator    OperationCreator
+		payload             io.Reader
+		wantPayloadResponse string
+		wantHTTPStatusCode  int
+	}{
+		// fails
+		{
+			name:                "internal server error when the payload is corrupted",
+			operationCreator:    newFakeOperationCreator(nil, nil),
+			payload:             &errReader{},
+			wantPayloadResponse: ``,
+			wantHTTPStatusCode:  http.StatusInternalServerError,
+		},
+		{
+			name:                "bad request when the payload is not a JSON",
+			operationCreator:    newFakeOperationCreator(nil, nil),
+			payload:             bytes.NewReader([]byte(`type=cashback`)),
+			wantPayloadResponse: `{"errors":[{"field":"root","description":"payload must be a valid JSON"}]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name:                "bad request when the type is not informed",
+			operationCreator:    newFakeOperationCreator(nil, nil),
+			payload:             bytes.NewReader([]byte(`{"direction": "CREDIT"}`)),
+			wantPayloadResponse: `{"errors":[{"field":"type","description":"type is a required field"}]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name:                "bad request when the direction is invalid",
+			operationCreator:    newFakeOperationCreator(nil, nil),
+			payload:             bytes.NewReader([]byte(`{"type": "cashback", "direction": "IN"}`)),
+			wantPayloadResponse: `{"errors":[{"field":"direction","description":"direction 'IN' is not a valid operation direction"}]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name:                "bad request when the kind is invalid",
+			operationCreator:    newFakeOperationCreator(nil, nil),
+			payload:             bytes.NewReader([]byte(`{"type": "cashback", "direction": "CREDIT", "kind": "REFUND"}`)),
+			wantPayloadResponse: `{"errors":[{"field":"kind","description":"kind 'REFUND' is not a valid operation kind"}]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name:                "unprocessable entity when returns a domain error",
+			operationCreator:    newFakeOperationCreator(nil, domain.NewErrDomain("type", "must be at most 50 characters in length")),
+			payload:             bytes.NewReader([]byte(`{"type": "cashback", "direction": "CREDIT"}`)),
+			wantPayloadResponse: `{"errors":[{"field":"type","description":"type must be at most 50 characters in length"}]}`,
+			wantHTTPStatusCode:  http.StatusUnprocessableEntity,
+		},
+		{
+			name:                "conflict when the operation already exists",
+			operationCreator:    newFakeOperationCreator(nil, repository.NewErrDuplicatedEntry("description", "CASHBACK")),
+			payload:             bytes.NewReader([]byte(`{"type": "cashback", "direction": "CREDIT"}`)),
+			wantPayloadResponse: `{"errors":[{"field":"type","description":"'cashback' already exists"}]}`,
+			wantHTTPStatusCode:  http.StatusConflict,
+		},
+		{
+			name:                "internal server error when returns an unknown error",
+			operationCreator:    newFakeOperationCreator(nil, errors.New("unknown error")),
+			payload:             bytes.NewReader([]byte(`{"type": "cashback", "direction": "CREDIT"}`)),
+			wantPayloadResponse: ``,
+			wantHTTPStatusCode:  http.StatusInternalServerError,
+		},
+
+		// successes
+		{
+			name:                "operation created successfully",
+			operationCreator:    newFakeOperationCreator(cashback, nil),
+			payload:             bytes.NewReader([]byte(`{"type": "cashback", "direction": "credit"}`)),
+			wantPayloadResponse: `{"id":8,"type":"CASHBACK","direction":"CREDIT","kind":"STANDARD","enabled":true}`,
+			wantHTTPStatusCode:  http.StatusCreated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			httpHandler := http.HandlerFunc(NewCreateOperation(logger, tt.operationCreator).Handler)
+			req, err := http.NewRequest("POST", "/admin/operations", tt.payload)
+			if err != nil {
+				t.Error("error to perform POST /admin/operations request")
+			}
+
+			httpHandler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.wantHTTPStatusCode {
+				t.Errorf("HTTP Status Code is different from expected, got = %v, want %v", rr.Code, tt.wantHTTPStatusCode)
+				return
+			}
+
+			if got := rr.Body.String(); got != tt.wantPayloadResponse {
+				t.Errorf("Payload Response is different from expected, got = %v, want %v", got, tt.wantPayloadResponse)
+			}
+		})
+	}
+}
+
+type fakeOperationCreator struct {
+	operation *domain.Operation
+	err       error
+}
+
+func newFakeOperationCreator(operation *domain.Operation, err error) *fakeOperationCreator {
+	return &fakeOperationCreator{operation: operation, err: err}
+}
+
+func (f fakeOperationCreator) Create(context.Context, string, domain.OperationDirection, domain.OperationKind) (*domain.Operation, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return f.operation, nil
+}
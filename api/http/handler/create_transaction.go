@@ -1,9 +1,9 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"io/ioutil"
-	"log"
 	"net/http"
 
 	"github.com/tonytcb/bank-transactions-go/infra/repository"
@@ -13,17 +13,17 @@ import (
 
 // TransactionCreator defines the behaviour about how to create a transaction
 type TransactionCreator interface {
-	Create(*domain.ID, *domain.ID, float64) (*domain.Transaction, error)
+	Create(context.Context, *domain.ID, *domain.ID, domain.Money, int) (*domain.Transaction, error)
 }
 
 // CreateTransaction contains the dependencies to create a transaction
 type CreateTransaction struct {
-	logger             *log.Logger
+	logger             domain.Logger
 	transactionCreator TransactionCreator
 }
 
 // NewCreateTransaction creates a new CreateTransaction struct with its dependencies
-func NewCreateTransaction(logger *log.Logger, transactionCreator TransactionCreator) *CreateTransaction {
+func NewCreateTransaction(logger domain.Logger, transactionCreator TransactionCreator) *CreateTransaction {
 	return &CreateTransaction{logger: logger, transactionCreator: transactionCreator}
 }
 
@@ -33,7 +33,7 @@ func (h CreateTransaction) Handler(rw http.ResponseWriter, req *http.Request) {
 
 	payload, err := ioutil.ReadAll(req.Body)
 	if err != nil {
-		h.logger.Println("read payload error:", err)
+		h.logger.Error(req.Context(), "read payload error", domain.NewErrLogField(err))
 		responder.internalServerError()
 		return
 	}
@@ -41,7 +41,7 @@ func (h CreateTransaction) Handler(rw http.ResponseWriter, req *http.Request) {
 	request := createTransactionPayloadRequest{}
 
 	if err := json.Unmarshal(payload, &request); err != nil {
-		h.logger.Println("invalid payload:", err)
+		h.logger.Info(req.Context(), "invalid payload", domain.NewErrLogField(err))
 
 		errResponse := newErrorResponse(map[string]string{"root": "invalid payload"})
 		responder.badRequest(errResponse.Encode())
@@ -53,24 +53,32 @@ func (h CreateTransaction) Handler(rw http.ResponseWriter, req *http.Request) {
 	if errs := request.validate(); errs != nil {
 		errResponse := newErrorResponse(errs)
 
-		h.logger.Println("create transaction payload doesn't match with the specifications:", errs)
+		h.logger.Info(req.Context(), "create transaction payload doesn't match with the specifications", domain.NewLogField("errors", errs))
 		responder.badRequest(errResponse.Encode())
 		return
 	}
 
 	transaction, err := h.transactionCreator.Create(
+		req.Context(),
 		domain.NewID(request.AccountID),
 		domain.NewID(request.OperationID),
-		request.Amount,
+		request.amount(),
+		request.Installments,
 	)
 	if err != nil {
-		h.logger.Println("unable to create transaction:", err)
+		h.logger.Warn(req.Context(), "unable to create transaction", domain.NewErrLogField(err))
 
 		if v, ok := err.(*repository.ErrForeignKeyConstraint); ok {
 			translateForeignKeyError(responder, v)
 			return
 		}
 
+		if _, ok := err.(*repository.ErrRegisterNotFound); ok {
+			errResponse := newErrorResponse(map[string]string{"account_id": "'account_id' not found"})
+			responder.unprocessableEntity(errResponse.Encode())
+			return
+		}
+
 		if v, ok := err.(*domain.ErrDomain); ok {
 			translateDomainError(responder, v)
 			return
@@ -92,7 +100,11 @@ func (h CreateTransaction) Handler(rw http.ResponseWriter, req *http.Request) {
 		newOperationResponse(operation.ID().Value(), operation.Description()),
 		transaction.Amount(),
 		transaction.CreatedAt(),
-	)
+	).
+		withBalance(transaction.Balance()).
+		withConversion(transaction.OriginalAmount(), transaction.ExchangeRate()).
+		withInstallmentPlan(transaction.InstallmentPlan()).
+		withAllocations(transaction.Allocations())
 
 	responder.created(response.Encode())
 }
@@ -1,18 +1,60 @@
 package handler
 
-import "github.com/go-playground/validator/v10"
+import (
+	"encoding/json"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
 
 type createTransactionPayloadRequest struct {
-	AccountID   uint64  `json:"account_id" validate:"required,number,gt=0"`
-	OperationID uint64  `json:"operation_id" validate:"required,number,gt=0"`
-	Amount      float64 `json:"amount" validate:"required,number,gt=0"`
+	AccountID    uint64      `json:"account_id" validate:"required,number,gt=0"`
+	OperationID  uint64      `json:"operation_id" validate:"required,number,gt=0"`
+	Amount       json.Number `json:"amount" validate:"required"`
+	Currency     string      `json:"currency"`
+	Installments int         `json:"installments" validate:"omitempty,gt=0"`
 }
 
 // validate returns a map where the key is the field and the value the error description
 func (c *createTransactionPayloadRequest) validate() map[string]string {
+	errs := map[string]string{}
+
 	if err := validate.Struct(c); err != nil {
-		return translateValidations(err.(validator.ValidationErrors))
+		errs = translateValidations(err.(validator.ValidationErrors))
+	}
+
+	if _, ok := errs["amount"]; !ok {
+		if amount, err := parseMoney(c.Amount.String(), c.currency()); err != nil {
+			errs["amount"] = "amount " + err.Error()
+		} else if !amount.IsPositive() {
+			errs["amount"] = "amount must be greater than 0"
+		}
+	}
+
+	if c.Currency != "" {
+		if _, err := domain.NewCurrency(c.Currency); err != nil {
+			errs["currency"] = err.Error()
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
 	}
 
 	return nil
 }
+
+// amount returns the exact amount of the transaction in the informed currency, or without currency when it is not
+// informed. It must be called only after a successful validation.
+func (c *createTransactionPayloadRequest) amount() domain.Money {
+	amount, _ := parseMoney(c.Amount.String(), c.currency())
+
+	return amount
+}
+
+// currency returns the informed currency, or no currency when it is not informed or is invalid
+func (c *createTransactionPayloadRequest) currency() domain.Currency {
+	currency, _ := domain.NewCurrency(c.Currency)
+
+	return currency
+}
@@ -3,6 +3,8 @@ package handler
 import (
 	"encoding/json"
 	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
 )
 
 type operationResponse struct {
@@ -14,24 +16,124 @@ func newOperationResponse(ID uint64, description string) operationResponse {
 	return operationResponse{ID: ID, Description: description}
 }
 
+type installmentResponse struct {
+	Number  int         `json:"number"`
+	Amount  json.Number `json:"amount"`
+	DueDate string      `json:"due_date"`
+}
+
+type conversionResponse struct {
+	OriginalAmount   json.Number `json:"original_amount"`
+	OriginalCurrency string      `json:"original_currency"`
+	ExchangeRate     json.Number `json:"exchange_rate"`
+}
+
+type allocationResponse struct {
+	TransactionID uint64      `json:"transaction_id"`
+	Amount        json.Number `json:"amount"`
+}
+
 type transactionResponse struct {
-	ID        uint64            `json:"id"`
-	Account   accountResponse   `json:"account,omitempty"`
-	Operation operationResponse `json:"operation"`
-	Amount    float64           `json:"amount"`
-	CreatedAt string            `json:"created_at"`
+	ID             uint64                `json:"id"`
+	Account        accountResponse       `json:"account,omitempty"`
+	Operation      operationResponse     `json:"operation"`
+	Amount         json.Number           `json:"amount"`
+	Currency       string                `json:"currency,omitempty"`
+	Balance        json.Number           `json:"balance,omitempty"`
+	Conversion     *conversionResponse   `json:"conversion,omitempty"`
+	Installments   []installmentResponse `json:"installments,omitempty"`
+	Allocations    []allocationResponse  `json:"allocations,omitempty"`
+	TransferID     uint64                `json:"transfer_id,omitempty"`
+	ReversalOf     uint64                `json:"reversal_of,omitempty"`
+	ReversedAmount json.Number           `json:"reversed_amount,omitempty"`
+	CreatedAt      string                `json:"created_at"`
 }
 
-func newTransactionResponse(id uint64, acc accountResponse, op operationResponse, amount float64, t time.Time) transactionResponse {
+func newTransactionResponse(id uint64, acc accountResponse, op operationResponse, amount domain.Money, t time.Time) transactionResponse {
 	return transactionResponse{
 		ID:        id,
 		Account:   acc,
 		Operation: op,
-		Amount:    amount,
+		Amount:    json.Number(amount.String()),
+		Currency:  amount.Currency().String(),
 		CreatedAt: t.UTC().Format(time.RFC3339),
 	}
 }
 
+// withConversion describes the conversion only when the transaction was created in a foreign currency
+func (c transactionResponse) withConversion(original domain.Money, rate *domain.ExchangeRate) transactionResponse {
+	if rate == nil || rate.From() == rate.To() {
+		return c
+	}
+
+	c.Conversion = &conversionResponse{
+		OriginalAmount:   json.Number(original.String()),
+		OriginalCurrency: original.Currency().String(),
+		ExchangeRate:     json.Number(rate.Rate()),
+	}
+
+	return c
+}
+
+func (c transactionResponse) withInstallmentPlan(plan *domain.InstallmentPlan) transactionResponse {
+	if plan == nil {
+		return c
+	}
+
+	for _, v := range plan.Installments() {
+		c.Installments = append(c.Installments, installmentResponse{
+			Number:  v.Number(),
+			Amount:  json.Number(v.Amount().String()),
+			DueDate: v.DueDate().Format("2006-01-02"),
+		})
+	}
+
+	return c
+}
+
+// withBalance describes the amount of the transaction not settled yet
+func (c transactionResponse) withBalance(balance domain.Money) transactionResponse {
+	c.Balance = json.Number(balance.String())
+
+	return c
+}
+
+// withAllocations describes the debits discharged by a payment
+func (c transactionResponse) withAllocations(allocations []*domain.PaymentAllocation) transactionResponse {
+	for _, v := range allocations {
+		c.Allocations = append(c.Allocations, allocationResponse{
+			TransactionID: v.TransactionID().Value(),
+			Amount:        json.Number(v.Amount().String()),
+		})
+	}
+
+	return c
+}
+
+// withTransferID identifies the transfer when the transaction is one of its postings
+func (c transactionResponse) withTransferID(id *domain.ID) transactionResponse {
+	if id == nil {
+		return c
+	}
+
+	c.TransferID = id.Value()
+
+	return c
+}
+
+// withReversal identifies the reversed transaction of a reversal, and the amount already reversed of other transactions
+func (c transactionResponse) withReversal(reversalOf *domain.ID, reversedAmount domain.Money) transactionResponse {
+	if reversalOf != nil {
+		c.ReversalOf = reversalOf.Value()
+	}
+
+	if !reversedAmount.IsZero() {
+		c.ReversedAmount = json.Number(reversedAmount.String())
+	}
+
+	return c
+}
+
 func (c transactionResponse) Encode() []byte {
 	res, _ := json.Marshal(c)
 
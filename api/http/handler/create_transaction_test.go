@@ -2,14 +2,15 @@ package handler
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"net/http/httptest"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/tonytcb/bank-transactions-go/infra/repository"
 
@@ -18,13 +19,29 @@ import (
 
 func TestCreateTransaction_Handler(t *testing.T) {
 	var (
-		logger                 = log.New(fakeWriter{}, "", log.LstdFlags)
+		logger                 = domain.NewLoggerMock()
 		foreignKeyAccountError = repository.NewErrForeignKeyConstraint("accounts", "accountfk1", "account_id", "id")
 		operationError         = domain.NewErrDomain("operation", "'10' is not a valid operation id")
+		creditLimitError       = domain.NewErrDomain("amount", "'100.00' exceeds the available credit limit '50.00'")
 		datetimeRegex          = `[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}:[0-9]{2}Z`
 	)
 
-	transactionOK, _ := domain.NewTransaction(domain.NewID(1), domain.NewID(4), 100)
+	transactionOK, _ := domain.NewTransaction(domain.NewID(1), domain.OperationPagamento, domain.NewMoney(10000, domain.CurrencyBRL))
+
+	installmentPurchaseOK, _ := domain.NewTransaction(domain.NewID(1), domain.OperationCompraParcelada, domain.NewMoney(10000, domain.CurrencyBRL))
+	installmentPurchaseOK, _ = installmentPurchaseOK.WithInstallments(3, time.Date(2020, 10, 4, 13, 0, 0, 0, time.UTC))
+
+	usdToBrl, _ := domain.NewExchangeRate("USD", domain.CurrencyBRL, "5.25")
+	foreignPurchaseOK, _ := domain.NewTransaction(domain.NewID(1), domain.OperationCompraAVista, domain.NewMoney(10000, "USD"))
+	foreignPurchaseOK, _ = foreignPurchaseOK.ConvertTo(domain.CurrencyBRL, domain.NewStaticExchangeRateProvider(usdToBrl))
+
+	debit := func(id uint64) *domain.Transaction {
+		purchase, _ := domain.NewTransaction(domain.NewID(1), domain.OperationCompraAVista, domain.NewMoney(10000, domain.CurrencyBRL))
+		return purchase.WithID(domain.NewID(id))
+	}
+
+	dischargingPaymentOK, _ := domain.NewTransaction(domain.NewID(1), domain.OperationPagamento, domain.NewMoney(15000, domain.CurrencyBRL))
+	dischargingPaymentOK, _ = dischargingPaymentOK.Discharge([]*domain.Transaction{debit(10), debit(11)})
 
 	type fields struct {
 		transactionCreator TransactionCreator
@@ -84,6 +101,39 @@ func TestCreateTransaction_Handler(t *testing.T) {
 			wantPayloadResponse: `{"errors":\[{"field":"amount","description":"amount must be greater than 0"}\]}`,
 			wantHTTPStatusCode:  http.StatusBadRequest,
 		},
+		{
+			name: "bad request when the payload has an amount with more decimal places than its currency",
+			fields: fields{
+				transactionCreator: newFakeTransactionCreator(nil, nil),
+			},
+			args: args{
+				payload: bytes.NewReader([]byte(`{"account_id": 1, "operation_id": 1, "amount": 10.005, "currency": "BRL"}`)),
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"amount","description":"amount must have at most 2 decimal places"}\]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name: "bad request when the payload has an amount without currency with more than 4 decimal places",
+			fields: fields{
+				transactionCreator: newFakeTransactionCreator(nil, nil),
+			},
+			args: args{
+				payload: bytes.NewReader([]byte(`{"account_id": 1, "operation_id": 1, "amount": 10.00001}`)),
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"amount","description":"amount must have at most 4 decimal places"}\]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name: "bad request when the payload has an invalid currency",
+			fields: fields{
+				transactionCreator: newFakeTransactionCreator(nil, nil),
+			},
+			args: args{
+				payload: bytes.NewReader([]byte(`{"account_id": 1, "operation_id": 1, "amount": 10.00, "currency": "XYZ"}`)),
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"currency","description":"currency 'XYZ' is not a supported ISO 4217 currency code"}\]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
 		{
 			name: "bad request when the payload has not an account_id",
 			fields: fields{
@@ -106,6 +156,17 @@ func TestCreateTransaction_Handler(t *testing.T) {
 			wantPayloadResponse: `{"errors":\[{"field":"account_id","description":"'account_id' not found"}\]}`,
 			wantHTTPStatusCode:  http.StatusUnprocessableEntity,
 		},
+		{
+			name: "unprocessable entity when the account was not found",
+			fields: fields{
+				transactionCreator: newFakeTransactionCreator(nil, repository.NewErrRegisterNotFound("id", "101")),
+			},
+			args: args{
+				payload: bytes.NewReader([]byte(`{"account_id": 101, "operation_id": 1, "amount": 100.00}`)),
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"account_id","description":"'account_id' not found"}\]}`,
+			wantHTTPStatusCode:  http.StatusUnprocessableEntity,
+		},
 		{
 			name: "bad request when the the operation is invalid",
 			fields: fields{
@@ -117,6 +178,39 @@ func TestCreateTransaction_Handler(t *testing.T) {
 			wantPayloadResponse: `{"errors":\[{"field":"operation","description":"operation '10' is not a valid operation id"}\]}`,
 			wantHTTPStatusCode:  http.StatusUnprocessableEntity,
 		},
+		{
+			name: "unprocessable entity when the amount exceeds the available credit limit",
+			fields: fields{
+				transactionCreator: newFakeTransactionCreator(nil, creditLimitError),
+			},
+			args: args{
+				payload: bytes.NewReader([]byte(`{"account_id": 1, "operation_id": 1, "amount": 100.00}`)),
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"amount","description":"amount '100.00' exceeds the available credit limit '50.00'"}\]}`,
+			wantHTTPStatusCode:  http.StatusUnprocessableEntity,
+		},
+		{
+			name: "bad request when the installments are negative",
+			fields: fields{
+				transactionCreator: newFakeTransactionCreator(nil, nil),
+			},
+			args: args{
+				payload: bytes.NewReader([]byte(`{"account_id": 1, "operation_id": 2, "amount": 100.00, "installments": -1}`)),
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"installments","description":"installments must be greater than 0"}\]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name: "unprocessable entity when the installments are informed to a payment",
+			fields: fields{
+				transactionCreator: newFakeTransactionCreator(nil, domain.NewErrDomain("installments", "are allowed only for the operation 'COMPRA PARCELADA'")),
+			},
+			args: args{
+				payload: bytes.NewReader([]byte(`{"account_id": 1, "operation_id": 4, "amount": 100.00, "installments": 2}`)),
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"installments","description":"installments are allowed only for the operation 'COMPRA PARCELADA'"}\]}`,
+			wantHTTPStatusCode:  http.StatusUnprocessableEntity,
+		},
 		{
 			name: "internal server error when returns an unknown error",
 			fields: fields{
@@ -136,7 +230,40 @@ func TestCreateTransaction_Handler(t *testing.T) {
 			args: args{
 				payload: bytes.NewReader([]byte(`{"account_id": 1, "operation_id": 4, "amount": 100.00}`)),
 			},
-			wantPayloadResponse: fmt.Sprintf(`{"id":50,"account":{"id":1,"document":{}},"operation":{"id":4,"type":"PAGAMENTO"},"amount":100,"created_at":"%s"}`, datetimeRegex),
+			wantPayloadResponse: fmt.Sprintf(`{"id":50,"account":{"id":1,"document":{}},"operation":{"id":4,"type":"PAGAMENTO"},"amount":100.00,"currency":"BRL","balance":100.00,"created_at":"%s"}`, datetimeRegex),
+			wantHTTPStatusCode:  http.StatusCreated,
+		},
+		{
+			name: "payment discharging the oldest debits created successfully",
+			fields: fields{
+				transactionCreator: newFakeTransactionCreator(dischargingPaymentOK.WithID(domain.NewID(53)), nil),
+			},
+			args: args{
+				payload: bytes.NewReader([]byte(`{"account_id": 1, "operation_id": 4, "amount": 150.00}`)),
+			},
+			wantPayloadResponse: fmt.Sprintf(`{"id":53,"account":{"id":1,"document":{}},"operation":{"id":4,"type":"PAGAMENTO"},"amount":150.00,"currency":"BRL","balance":0.00,"allocations":\[{"transaction_id":10,"amount":100.00},{"transaction_id":11,"amount":50.00}\],"created_at":"%s"}`, datetimeRegex),
+			wantHTTPStatusCode:  http.StatusCreated,
+		},
+		{
+			name: "installment purchase created successfully",
+			fields: fields{
+				transactionCreator: newFakeTransactionCreator(installmentPurchaseOK.WithID(domain.NewID(51)), nil),
+			},
+			args: args{
+				payload: bytes.NewReader([]byte(`{"account_id": 1, "operation_id": 2, "amount": 100.00, "installments": 3}`)),
+			},
+			wantPayloadResponse: fmt.Sprintf(`{"id":51,"account":{"id":1,"document":{}},"operation":{"id":2,"type":"COMPRA PARCELADA"},"amount":-100.00,"currency":"BRL","balance":-100.00,"installments":\[{"number":1,"amount":-33.34,"due_date":"2020-11-04"},{"number":2,"amount":-33.33,"due_date":"2020-12-04"},{"number":3,"amount":-33.33,"due_date":"2021-01-04"}\],"created_at":"%s"}`, datetimeRegex),
+			wantHTTPStatusCode:  http.StatusCreated,
+		},
+		{
+			name: "foreign currency purchase created successfully",
+			fields: fields{
+				transactionCreator: newFakeTransactionCreator(foreignPurchaseOK.WithID(domain.NewID(52)), nil),
+			},
+			args: args{
+				payload: bytes.NewReader([]byte(`{"account_id": 1, "operation_id": 1, "amount": 100.00, "currency": "USD"}`)),
+			},
+			wantPayloadResponse: fmt.Sprintf(`{"id":52,"account":{"id":1,"document":{}},"operation":{"id":1,"type":"COMPRA A VISTA"},"amount":-525.00,"currency":"BRL","balance":-525.00,"conversion":{"original_amount":-100.00,"original_currency":"USD","exchange_rate":5.25000000},"created_at":"%s"}`, datetimeRegex),
 			wantHTTPStatusCode:  http.StatusCreated,
 		},
 	}
@@ -180,7 +307,7 @@ func newFakeTransactionCreator(transaction *domain.Transaction, err error) *fake
 	return &fakeTransactionCreator{transaction: transaction, err: err}
 }
 
-func (f fakeTransactionCreator) Create(*domain.ID, *domain.ID, float64) (*domain.Transaction, error) {
+func (f fakeTransactionCreator) Create(context.Context, *domain.ID, *domain.ID, domain.Money, int) (*domain.Transaction, error) {
 	if f.err != nil {
 		return nil, f.err
 	}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+)
+
+// TransferCreator defines the behaviour about how to create a transfer
+type TransferCreator interface {
+	Create(context.Context, *domain.ID, *domain.ID, domain.Money) (*domain.Transfer, error)
+}
+
+// CreateTransfer contains the dependencies to create a transfer
+type CreateTransfer struct {
+	logger          domain.Logger
+	transferCreator TransferCreator
+}
+
+// NewCreateTransfer creates a new CreateTransfer struct with its dependencies
+func NewCreateTransfer(logger domain.Logger, transferCreator TransferCreator) *CreateTransfer {
+	return &CreateTransfer{logger: logger, transferCreator: transferCreator}
+}
+
+// Handler exposes the http handler
+func (h CreateTransfer) Handler(rw http.ResponseWriter, req *http.Request) {
+	responder := newResponder(rw)
+
+	payload, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		h.logger.Error(req.Context(), "read payload error", domain.NewErrLogField(err))
+		responder.internalServerError()
+		return
+	}
+
+	request := createTransferPayloadRequest{}
+
+	if err := json.Unmarshal(payload, &request); err != nil {
+		h.logger.Info(req.Context(), "invalid payload", domain.NewErrLogField(err))
+
+		errResponse := newErrorResponse(map[string]string{"root": "invalid payload"})
+		responder.badRequest(errResponse.Encode())
+
+		return
+	}
+	defer req.Body.Close()
+
+	if errs := request.validate(); errs != nil {
+		errResponse := newErrorResponse(errs)
+
+		h.logger.Info(req.Context(), "create transfer payload doesn't match with the specifications", domain.NewLogField("errors", errs))
+		responder.badRequest(errResponse.Encode())
+		return
+	}
+
+	transfer, err := h.transferCreator.Create(
+		req.Context(),
+		domain.NewID(request.SourceAccountID),
+		domain.NewID(request.DestinationAccountID),
+		request.amount(),
+	)
+	if err != nil {
+		h.logger.Warn(req.Context(), "unable to create transfer", domain.NewErrLogField(err))
+
+		if v, ok := err.(*repository.ErrForeignKeyConstraint); ok {
+			translateForeignKeyError(responder, v)
+			return
+		}
+
+		if v, ok := err.(*domain.ErrDomain); ok {
+			translateDomainError(responder, v)
+			return
+		}
+
+		// unknown error
+		responder.internalServerError()
+		return
+	}
+
+	responder.created(newTransferResponse(transfer).Encode())
+}
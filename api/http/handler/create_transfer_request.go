@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"encoding/json"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+type createTransferPayloadRequest struct {
+	SourceAccountID      uint64      `json:"source_account_id" validate:"required,number,gt=0"`
+	DestinationAccountID uint64      `json:"destination_account_id" validate:"required,number,gt=0"`
+	Amount               json.Number `json:"amount" validate:"required"`
+}
+
+// validate returns a map where the key is the field and the value the error description
+func (c *createTransferPayloadRequest) validate() map[string]string {
+	errs := map[string]string{}
+
+	if err := validate.Struct(c); err != nil {
+		errs = translateValidations(err.(validator.ValidationErrors))
+	}
+
+	if _, ok := errs["amount"]; !ok {
+		if amount, err := parseMoney(c.Amount.String(), ""); err != nil {
+			errs["amount"] = "amount " + err.Error()
+		} else if !amount.IsPositive() {
+			errs["amount"] = "amount must be greater than 0"
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// amount returns the exact amount of the transfer without currency, as it is always in the source account currency.
+// It must be called only after a successful validation.
+func (c *createTransferPayloadRequest) amount() domain.Money {
+	amount, _ := parseMoney(c.Amount.String(), "")
+
+	return amount
+}
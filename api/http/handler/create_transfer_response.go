@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+type transferResponse struct {
+	ID                   uint64      `json:"id"`
+	SourceAccountID      uint64      `json:"source_account_id"`
+	DestinationAccountID uint64      `json:"destination_account_id"`
+	Amount               json.Number `json:"amount"`
+	Currency             string      `json:"currency"`
+	CreatedAt            string      `json:"created_at"`
+}
+
+func newTransferResponse(transfer *domain.Transfer) transferResponse {
+	return transferResponse{
+		ID:                   transfer.ID().Value(),
+		SourceAccountID:      transfer.Source().ID().Value(),
+		DestinationAccountID: transfer.Destination().ID().Value(),
+		Amount:               json.Number(transfer.Amount().String()),
+		Currency:             transfer.Amount().Currency().String(),
+		CreatedAt:            transfer.CreatedAt().UTC().Format(time.RFC3339),
+	}
+}
+
+func (c transferResponse) Encode() []byte {
+	res, _ := json.Marshal(c)
+
+	return res
+}
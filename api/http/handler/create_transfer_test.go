@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+)
+
+func TestCreateTransfer_Handler(t *testing.T) {
+	var (
+		logger                     = domain.NewLoggerMock()
+		foreignKeyDestinationError = repository.NewErrForeignKeyConstraint("transfers", "", "destination_account_id", "id")
+		sameAccountError           = domain.NewErrDomain("destination_account_id", "must be different from the source account")
+		creditLimitError           = domain.NewErrDomain("amount", "'100.00' exceeds the available credit limit '50.00'")
+		datetimeRegex              = `[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}:[0-9]{2}Z`
+		source                     = new(domain.Account).WithID(domain.NewID(1)).WithCurrency(domain.CurrencyBRL).WithCreditLimit(domain.NewMoney(50000, domain.CurrencyBRL))
+		destination                = new(domain.Account).WithID(domain.NewID(2)).WithCurrency(domain.CurrencyBRL)
+	)
+
+	transferOK, _ := domain.NewTransfer(source.ID(), destination.ID(), domain.NewMoney(1000000, ""))
+	transferOK, _ = transferOK.Apply(source, destination)
+
+	type fields struct {
+		transferCreator TransferCreator
+	}
+	type args struct {
+		payload io.Reader
+	}
+	tests := []struct {
+		name                string
+		fields              fields
+		args                args
+		wantPayloadResponse string
+		wantHTTPStatusCode  int
+	}{
+		// fails
+		{
+			name: "internal server error when the payload is corrupted",
+			fields: fields{
+				transferCreator: newFakeTransferCreator(nil, nil),
+			},
+			args: args{
+				payload: &errReader{},
+			},
+			wantPayloadResponse: ``,
+			wantHTTPStatusCode:  http.StatusInternalServerError,
+		},
+		{
+			name: "bad request when the payload is empty",
+			fields: fields{
+				transferCreator: newFakeTransferCreator(nil, nil),
+			},
+			args: args{
+				payload: bytes.NewReader([]byte("")),
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"root","description":"invalid payload"}\]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name: "bad request when the payload has not a destination_account_id",
+			fields: fields{
+				transferCreator: newFakeTransferCreator(nil, nil),
+			},
+			args: args{
+				payload: bytes.NewReader([]byte(`{"source_account_id": 1, "amount": 100.00}`)),
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"destination_account_id","description":"destination_account_id is a required field"}\]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name: "bad request when the payload has an invalid amount",
+			fields: fields{
+				transferCreator: newFakeTransferCreator(nil, nil),
+			},
+			args: args{
+				payload: bytes.NewReader([]byte(`{"source_account_id": 1, "destination_account_id": 2, "amount": -100.00}`)),
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"amount","description":"amount must be greater than 0"}\]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name: "unprocessable entity when the destination account was not found",
+			fields: fields{
+				transferCreator: newFakeTransferCreator(nil, foreignKeyDestinationError),
+			},
+			args: args{
+				payload: bytes.NewReader([]byte(`{"source_account_id": 1, "destination_account_id": 101, "amount": 100.00}`)),
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"destination_account_id","description":"'destination_account_id' not found"}\]}`,
+			wantHTTPStatusCode:  http.StatusUnprocessableEntity,
+		},
+		{
+			name: "unprocessable entity when the transfer is to the same account",
+			fields: fields{
+				transferCreator: newFakeTransferCreator(nil, sameAccountError),
+			},
+			args: args{
+				payload: bytes.NewReader([]byte(`{"source_account_id": 1, "destination_account_id": 1, "amount": 100.00}`)),
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"destination_account_id","description":"destination_account_id must be different from the source account"}\]}`,
+			wantHTTPStatusCode:  http.StatusUnprocessableEntity,
+		},
+		{
+			name: "unprocessable entity when the amount exceeds the available credit limit",
+			fields: fields{
+				transferCreator: newFakeTransferCreator(nil, creditLimitError),
+			},
+			args: args{
+				payload: bytes.NewReader([]byte(`{"source_account_id": 1, "destination_account_id": 2, "amount": 100.00}`)),
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"amount","description":"amount '100.00' exceeds the available credit limit '50.00'"}\]}`,
+			wantHTTPStatusCode:  http.StatusUnprocessableEntity,
+		},
+		{
+			name: "internal server error when returns an unknown error",
+			fields: fields{
+				transferCreator: newFakeTransferCreator(nil, errors.New("unknown error")),
+			},
+			args: args{
+				payload: bytes.NewReader([]byte(`{"source_account_id": 1, "destination_account_id": 2, "amount": 100.00}`)),
+			},
+			wantPayloadResponse: ``,
+			wantHTTPStatusCode:  http.StatusInternalServerError,
+		},
+
+		// successes
+		{
+			name: "transfer created successfully",
+			fields: fields{
+				transferCreator: newFakeTransferCreator(transferOK.WithID(domain.NewID(10)), nil),
+			},
+			args: args{
+				payload: bytes.NewReader([]byte(`{"source_account_id": 1, "destination_account_id": 2, "amount": 100.00}`)),
+			},
+			wantPayloadResponse: fmt.Sprintf(`{"id":10,"source_account_id":1,"destination_account_id":2,"amount":100.00,"currency":"BRL","created_at":"%s"}`, datetimeRegex),
+			wantHTTPStatusCode:  http.StatusCreated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			httpHandler := http.HandlerFunc(NewCreateTransfer(logger, tt.fields.transferCreator).Handler)
+			req, err := http.NewRequest("POST", "/transfers", tt.args.payload)
+			if err != nil {
+				t.Error("error to perform POST /transfers request")
+			}
+
+			httpHandler.ServeHTTP(rr, req)
+
+			var (
+				gotHTTPStatusCode = rr.Code
+				gotPayload        = rr.Body.String()
+			)
+
+			if gotHTTPStatusCode != tt.wantHTTPStatusCode {
+				t.Errorf("HTTP Status Code is different from expected, got = %v, want %v", gotHTTPStatusCode, tt.wantHTTPStatusCode)
+				return
+			}
+
+			match := regexp.MustCompile(tt.wantPayloadResponse).MatchString(gotPayload)
+			if !match {
+				t.Errorf("Payload Response is different from expected, got = %v, want %v", gotPayload, tt.wantPayloadResponse)
+				return
+			}
+		})
+	}
+}
+
+type fakeTransferCreator struct {
+	transfer *domain.Transfer
+	err      error
+}
+
+func newFakeTransferCreator(transfer *domain.Transfer, err error) *fakeTransferCreator {
+	return &fakeTransferCreator{transfer: transfer, err: err}
+}
+
+func (f fakeTransferCreator) Create(context.Context, *domain.ID, *domain.ID, domain.Money) (*domain.Transfer, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return f.transfer, nil
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// WebhookCreator defines the behaviour about how to create a webhook
+type WebhookCreator interface {
+	Create(context.Context, string, []string) (*domain.Webhook, error)
+}
+
+// CreateWebhook contains the dependencies to create a webhook
+type CreateWebhook struct {
+	logger         domain.Logger
+	webhookCreator WebhookCreator
+}
+
+// NewCreateWebhook creates a new CreateWebhook struct with its dependencies
+func NewCreateWebhook(logger domain.Logger, webhookCreator WebhookCreator) *CreateWebhook {
+	return &CreateWebhook{logger: logger, webhookCreator: webhookCreator}
+}
+
+// Handler exposes the http handler. The secret to verify the deliveries is returned only in the creation.
+func (h CreateWebhook) Handler(rw http.ResponseWriter, req *http.Request) {
+	responder := newResponder(rw)
+
+	payload, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		h.logger.Error(req.Context(), "read payload error", domain.NewErrLogField(err))
+		responder.internalServerError()
+		return
+	}
+	defer req.Body.Close()
+
+	request := &createWebhookPayloadRequest{}
+	if err := json.Unmarshal(payload, request); err != nil {
+		h.logger.Info(req.Context(), "invalid payload", domain.NewErrLogField(err))
+
+		errResponse := newErrorResponse(map[string]string{"root": "payload must be a valid JSON"})
+		responder.badRequest(errResponse.Encode())
+
+		return
+	}
+
+	if errs := request.validate(); errs != nil {
+		errResponse := newErrorResponse(errs)
+
+		h.logger.Info(req.Context(), "create webhook payload doesn't match with the specifications", domain.NewLogField("errors", errs))
+		responder.badRequest(errResponse.Encode())
+		return
+	}
+
+	webhook, err := h.webhookCreator.Create(req.Context(), request.URL, request.EventTypes)
+	if err != nil {
+		h.logger.Warn(req.Context(), "unable to create webhook", domain.NewErrLogField(err))
+
+		if v, ok := err.(*domain.ErrDomain); ok {
+			translateDomainError(responder, v)
+			return
+		}
+
+		// unknown error
+		responder.internalServerError()
+		return
+	}
+
+	responder.created(newWebhookResponse(webhook).Encode())
+}
@@ -0,0 +1,18 @@
+package handler
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+type createWebhookPayloadRequest struct {
+	URL        string   `json:"url" validate:"required"`
+	EventTypes []string `json:"event_types" validate:"required,min=1"`
+}
+
+func (c *createWebhookPayloadRequest) validate() map[string]string {
+	if err := validate.Struct(c); err != nil {
+		return translateValidations(err.(validator.ValidationErrors))
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+type webhookResponse struct {
+	ID         uint64   `json:"id"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Secret     string   `json:"secret"`
+	CreatedAt  string   `json:"created_at"`
+}
+
+func newWebhookResponse(webhook *domain.Webhook) webhookResponse {
+	response := webhookResponse{
+		ID:         webhook.ID().Value(),
+		URL:        webhook.URL(),
+		EventTypes: make([]string, 0, len(webhook.EventTypes())),
+		Secret:     webhook.Secret(),
+		CreatedAt:  webhook.CreatedAt().UTC().Format(time.RFC3339),
+	}
+
+	for _, v := range webhook.EventTypes() {
+		response.EventTypes = append(response.EventTypes, v.String())
+	}
+
+	return response
+}
+
+func (c webhookResponse) Encode() []byte {
+	res, _ := json.Marshal(c)
+
+	return res
+}
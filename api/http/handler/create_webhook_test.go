@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+func TestCreateWebhook_Handler(t *testing.T) {
+	var (
+		logger  = domain.NewLoggerMock()
+		webhook = domain.LoadWebhook(
+			domain.NewID(1),
+			"https://partner.com/events",
+			[]domain.EventType{domain.EventAccountCreated, domain.EventTransactionCreated},
+			"4f6b",
+			time.Date(2020, 10, 25, 10, 0, 0, 0, time.UTC),
+		)
+	)
+
+	tests := []struct {
+		name                string
+		webhookCreator      WebhookCreator
+		payload             io.Reader
+		wantPayloadResponse string
+		wantHTTPStatusCode  int
+	}{
+		// fails
+		{
+			name:                "internal server error when the payload is corrupted",
+			webhookCreator:      newFakeWebhookCreator(nil, nil),
+			payload:             &errReader{},
+			wantPayloadResponse: ``,
+			wantHTTPStatusCode:  http.StatusInternalServerError,
+		},
+		{
+			name:                "bad request when the payload is not a JSON",
+			webhookCreator:      newFakeWebhookCreator(nil, nil),
+			payload:             bytes.NewReader([]byte(`url=https://partner.com`)),
+			wantPayloadResponse: `{"errors":[{"field":"root","description":"payload must be a valid JSON"}]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name:                "bad request when the url is not informed",
+			webhookCreator:      newFakeWebhookCreator(nil, nil),
+			payload:             bytes.NewReader([]byte(`{"event_types": ["account.created"]}`)),
+			wantPayloadResponse: `{"errors":[{"field":"url","description":"url is a required field"}]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name:                "bad request when the event types are empty",
+			webhookCreator:      newFakeWebhookCreator(nil, nil),
+			payload:             bytes.NewReader([]byte(`{"url": "https://partner.com/events", "event_types": []}`)),
+			wantPayloadResponse: `{"errors":[{"field":"event_types","description":"event_types must contain at least 1 item"}]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name:                "unprocessable entity when returns a domain error",
+			webhookCreator:      newFakeWebhookCreator(nil, domain.NewErrDomain("event_types", "'account.deleted' is not a valid event type")),
+			payload:             bytes.NewReader([]byte(`{"url": "https://partner.com/events", "event_types": ["account.deleted"]}`)),
+			wantPayloadResponse: `{"errors":[{"field":"event_types","description":"event_types 'account.deleted' is not a valid event type"}]}`,
+			wantHTTPStatusCode:  http.StatusUnprocessableEntity,
+		},
+		{
+			name:                "internal server error when returns an unknown error",
+			webhookCreator:      newFakeWebhookCreator(nil, errors.New("unknown error")),
+			payload:             bytes.NewReader([]byte(`{"url": "https://partner.com/events", "event_types": ["account.created"]}`)),
+			wantPayloadResponse: ``,
+			wantHTTPStatusCode:  http.StatusInternalServerError,
+		},
+
+		// successes
+		{
+			name:                "webhook created successfully",
+			webhookCreator:      newFakeWebhookCreator(webhook, nil),
+			payload:             bytes.NewReader([]byte(`{"url": "https://partner.com/events", "event_types": ["account.created", "transaction.created"]}`)),
+			wantPayloadResponse: `{"id":1,"url":"https://partner.com/events","event_types":["account.created","transaction.created"],"secret":"4f6b","created_at":"2020-10-25T10:00:00Z"}`,
+			wantHTTPStatusCode:  http.StatusCreated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			httpHandler := http.HandlerFunc(NewCreateWebhook(logger, tt.webhookCreator).Handler)
+			req, err := http.NewRequest("POST", "/webhooks", tt.payload)
+			if err != nil {
+				t.Error("error to perform POST /webhooks request")
+			}
+
+			httpHandler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.wantHTTPStatusCode {
+				t.Errorf("HTTP Status Code is different from expected, got = %v, want %v", rr.Code, tt.wantHTTPStatusCode)
+				return
+			}
+
+			if got := rr.Body.String(); got != tt.wantPayloadResponse {
+				t.Errorf("Payload Response is different from expected, got = %v, want %v", got, tt.wantPayloadResponse)
+			}
+		})
+	}
+}
+
+type fakeWebhookCreator struct {
+	webhook *domain.Webhook
+	err     error
+}
+
+func newFakeWebhookCreator(webhook *domain.Webhook, err error) *fakeWebhookCreator {
+	return &fakeWebhookCreator{webhook: webhook, err: err}
+}
+
+func (f fakeWebhookCreator) Create(context.Context, string, []string) (*domain.Webhook, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return f.webhook, nil
+}
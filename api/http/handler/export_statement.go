@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+)
+
+// StatementExporter defines the behaviour about how to export the statement of an account
+type StatementExporter interface {
+	Export(context.Context, *domain.ID, time.Time, time.Time, domain.StatementWriter) error
+}
+
+// ExportStatement contains the dependencies to export the statement of an account
+type ExportStatement struct {
+	logger            domain.Logger
+	statementExporter StatementExporter
+}
+
+// NewExportStatement creates a new ExportStatement struct
+func NewExportStatement(logger domain.Logger, statementExporter StatementExporter) *ExportStatement {
+	return &ExportStatement{logger: logger, statementExporter: statementExporter}
+}
+
+// Handler exposes the http handler. The statement is streamed as it is read, so once the response has started,
+// errors can only interrupt it.
+func (e ExportStatement) Handler(rw http.ResponseWriter, req *http.Request) {
+	const idPosition = 2
+
+	responder := newResponder(rw)
+
+	idParam, err := extractParamID(req, idPosition)
+	if err != nil {
+		e.logger.Info(req.Context(), "invalid account id", domain.NewErrLogField(err))
+
+		errResponse := newErrorResponse(map[string]string{"id": err.Error()})
+		responder.badRequest(errResponse.Encode())
+		return
+	}
+
+	from, to, format, errs := e.parseParams(req.URL.Query())
+	if errs != nil {
+		e.logger.Info(req.Context(), "export statement parameters don't match with the specifications", domain.NewLogField("errors", errs))
+
+		errResponse := newErrorResponse(errs)
+		responder.badRequest(errResponse.Encode())
+		return
+	}
+
+	w := newStatementResponseWriter(rw, idParam, format)
+
+	err = e.statementExporter.Export(req.Context(), domain.NewID(idParam), from, to, w)
+	if err == nil {
+		return
+	}
+
+	if w.started {
+		e.logger.Warn(req.Context(), "statement interrupted", domain.NewErrLogField(err))
+		return
+	}
+
+	e.logger.Error(req.Context(), "unable to export the statement", domain.NewErrLogField(err))
+
+	if _, ok := err.(*repository.ErrRegisterNotFound); ok {
+		errResponse := newErrorResponse(map[string]string{"id": fmt.Sprintf("%d not found", idParam)})
+		responder.notFound(errResponse.Encode())
+		return
+	}
+
+	if v, ok := err.(*domain.ErrDomain); ok {
+		translateDomainError(responder, v)
+		return
+	}
+
+	// unknown error
+	responder.internalServerError()
+}
+
+// parseParams translates the query parameters to the statement period and format, returning the errors by parameter
+func (e ExportStatement) parseParams(query url.Values) (time.Time, time.Time, string, map[string]string) {
+	var (
+		errs   = make(map[string]string)
+		from   time.Time
+		to     time.Time
+		format = query.Get("format")
+		err    error
+	)
+
+	if v := query.Get("from"); v == "" {
+		errs["from"] = "from is a required parameter"
+	} else if from, err = parseDateTime(v, false); err != nil {
+		errs["from"] = "from " + err.Error()
+	}
+
+	if v := query.Get("to"); v == "" {
+		errs["to"] = "to is a required parameter"
+	} else if to, err = parseDateTime(v, true); err != nil {
+		errs["to"] = "to " + err.Error()
+	}
+
+	if format == "" {
+		format = statementFormatCSV
+	}
+
+	if _, ok := statementEncoders[format]; !ok {
+		errs["format"] = fmt.Sprintf("format must be one of %s, %s or %s", statementFormatCSV, statementFormatOFX, statementFormatPDF)
+	}
+
+	if len(errs) > 0 {
+		return time.Time{}, time.Time{}, "", errs
+	}
+
+	return from, to, format, nil
+}
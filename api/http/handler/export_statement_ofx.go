@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// ofxStatementEncoder encodes a statement as an OFX 2.2 credit card statement. OFX has no fields for the opening and
+// the running balances, so the running balance goes in the memo of each transaction and the closing balance in the
+// ledger balance.
+type ofxStatementEncoder struct {
+	w *bufio.Writer
+}
+
+func newOFXStatementEncoder(w io.Writer) statementEncoder {
+	return &ofxStatementEncoder{w: bufio.NewWriter(w)}
+}
+
+func (o *ofxStatementEncoder) contentType() string {
+	return "application/x-ofx"
+}
+
+func (o *ofxStatementEncoder) Begin(statement *domain.Statement) error {
+	_, err := fmt.Fprintf(o.w, `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+<SIGNONMSGSRSV1>
+<SONRS>
+<STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>
+<DTSERVER>%s</DTSERVER>
+<LANGUAGE>POR</LANGUAGE>
+</SONRS>
+</SIGNONMSGSRSV1>
+<CREDITCARDMSGSRSV1>
+<CCSTMTTRNRS>
+<TRNUID>0</TRNUID>
+<STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>
+<CCSTMTRS>
+<CURDEF>%s</CURDEF>
+<CCACCTFROM><ACCTID>%d</ACCTID></CCACCTFROM>
+<BANKTRANLIST>
+<DTSTART>%s</DTSTART>
+<DTEND>%s</DTEND>
+`,
+		formatOFXTime(time.Now()),
+		statement.Account().Currency(),
+		statement.Account().ID().Value(),
+		formatOFXTime(statement.From()),
+		formatOFXTime(statement.To()),
+	)
+
+	return err
+}
+
+func (o *ofxStatementEncoder) Line(line *domain.StatementLine) error {
+	var (
+		t       = line.Transaction()
+		trnType = "DEBIT"
+	)
+
+	if t.Amount().IsPositive() {
+		trnType = "CREDIT"
+	}
+
+	if _, err := fmt.Fprintf(
+		o.w,
+		"<STMTTRN><TRNTYPE>%s</TRNTYPE><DTPOSTED>%s</DTPOSTED><TRNAMT>%s</TRNAMT><FITID>%d</FITID><NAME>",
+		trnType,
+		formatOFXTime(t.CreatedAt()),
+		t.Amount(),
+		t.ID().Value(),
+	); err != nil {
+		return err
+	}
+
+	if err := xml.EscapeText(o.w, []byte(t.Operation().Description())); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(o.w, "</NAME><MEMO>BALANCE %s</MEMO></STMTTRN>\n", line.Balance())
+
+	return err
+}
+
+func (o *ofxStatementEncoder) End(statement *domain.Statement) error {
+	if _, err := fmt.Fprintf(o.w, `</BANKTRANLIST>
+<LEDGERBAL><BALAMT>%s</BALAMT><DTASOF>%s</DTASOF></LEDGERBAL>
+</CCSTMTRS>
+</CCSTMTTRNRS>
+</CREDITCARDMSGSRSV1>
+</OFX>
+`,
+		statement.ClosingBalance(),
+		formatOFXTime(statement.To()),
+	); err != nil {
+		return err
+	}
+
+	return o.w.Flush()
+}
+
+func formatOFXTime(t time.Time) string {
+	return t.UTC().Format("20060102150405") + "[0:GMT]"
+}
@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/tonytcb/bank-transactions-go/api/http/middleware"
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+const (
+	pdfCatalogObject = 1
+	pdfPagesObject   = 2
+	pdfFontObject    = 3
+	pdfBoldObject    = 4
+
+	pdfPageWidth   = 595
+	pdfPageHeight  = 842
+	pdfMargin      = 50
+	pdfFontSize    = 9
+	pdfLineHeight  = 12
+	pdfTypeColumns = 26
+)
+
+// pdfStatementEncoder encodes a statement as an A4 PDF. Each page is written as soon as it is full, and the page
+// tree and the cross-reference table, which depend on all the pages, are written at the end.
+type pdfStatementEncoder struct {
+	w       io.Writer
+	offset  int
+	offsets map[int]int
+	next    int
+	pages   []int
+	page    bytes.Buffer
+	y       int
+	err     error
+}
+
+func newPDFStatementEncoder(w io.Writer) statementEncoder {
+	return &pdfStatementEncoder{w: w, offsets: make(map[int]int), next: pdfBoldObject + 1}
+}
+
+func (p *pdfStatementEncoder) contentType() string {
+	return "application/pdf"
+}
+
+func (p *pdfStatementEncoder) Begin(statement *domain.Statement) error {
+	p.write([]byte("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n"))
+	p.object(pdfFontObject, "<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+	p.object(pdfBoldObject, "<< /Type /Font /Subtype /Type1 /BaseFont /Courier-Bold /Encoding /WinAnsiEncoding >>")
+
+	account := statement.Account()
+
+	p.newPage()
+	p.text(true, "STATEMENT")
+	p.text(false, fmt.Sprintf("Account: %d", account.ID().Value()))
+	if account.Document() != nil {
+		number := middleware.MaskDocument(account.Document().Number().String())
+		p.text(false, fmt.Sprintf("Document: %s %s", account.Document().Type(), number))
+	}
+	p.text(false, fmt.Sprintf("Currency: %s", account.Currency()))
+	p.text(false, fmt.Sprintf("Period: %s to %s", formatStatementTime(statement.From()), formatStatementTime(statement.To())))
+	p.text(false, "")
+	p.header()
+	p.row(formatStatementTime(statement.From()), "", "OPENING BALANCE", "", statement.OpeningBalance().String())
+
+	return p.err
+}
+
+func (p *pdfStatementEncoder) Line(line *domain.StatementLine) error {
+	t := line.Transaction()
+
+	p.row(
+		formatStatementTime(t.CreatedAt()),
+		strconv.FormatUint(t.ID().Value(), 10),
+		t.Operation().Description(),
+		t.Amount().String(),
+		line.Balance().String(),
+	)
+
+	return p.err
+}
+
+func (p *pdfStatementEncoder) End(statement *domain.Statement) error {
+	p.row(formatStatementTime(statement.To()), "", "CLOSING BALANCE", "", statement.ClosingBalance().String())
+	p.flushPage()
+
+	kids := make([]string, len(p.pages))
+	for i, v := range p.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", v)
+	}
+
+	p.object(pdfPagesObject, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(p.pages)))
+	p.object(pdfCatalogObject, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pdfPagesObject))
+
+	xref := p.offset
+
+	p.write([]byte(fmt.Sprintf("xref\n0 %d\n0000000000 65535 f \n", p.next)))
+	for i := 1; i < p.next; i++ {
+		p.write([]byte(fmt.Sprintf("%010d 00000 n \n", p.offsets[i])))
+	}
+
+	p.write([]byte(fmt.Sprintf("trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", p.next, pdfCatalogObject, xref)))
+
+	return p.err
+}
+
+// header writes the header of the transactions table
+func (p *pdfStatementEncoder) header() {
+	p.text(true, formatPDFRow("DATE", "ID", "TYPE", "AMOUNT", "BALANCE"))
+}
+
+// row writes a row of the transactions table, starting a new page when the current one is full
+func (p *pdfStatementEncoder) row(date, id, description, amount, balance string) {
+	if p.y < pdfMargin {
+		p.flushPage()
+		p.newPage()
+		p.header()
+	}
+
+	p.text(false, formatPDFRow(date, id, description, amount, balance))
+}
+
+func (p *pdfStatementEncoder) newPage() {
+	p.page.Reset()
+	p.y = pdfPageHeight - pdfMargin
+}
+
+// flushPage writes the content of the current page and the page itself
+func (p *pdfStatementEncoder) flushPage() {
+	var (
+		content = p.next
+		page    = p.next + 1
+	)
+
+	p.next += 2
+	p.pages = append(p.pages, page)
+
+	p.object(content, fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", p.page.Len(), p.page.String()))
+	p.object(page, fmt.Sprintf(
+		"<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 %d 0 R /F2 %d 0 R >> >> /Contents %d 0 R >>",
+		pdfPagesObject,
+		pdfPageWidth,
+		pdfPageHeight,
+		pdfFontObject,
+		pdfBoldObject,
+		content,
+	))
+}
+
+// text writes a line of text in the current page
+func (p *pdfStatementEncoder) text(bold bool, v string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+
+	fmt.Fprintf(&p.page, "BT /%s %d Tf %d %d Td (%s) Tj ET\n", font, pdfFontSize, pdfMargin, p.y, escapePDFText(v))
+
+	p.y -= pdfLineHeight
+}
+
+func (p *pdfStatementEncoder) object(number int, body string) {
+	p.offsets[number] = p.offset
+	p.write([]byte(fmt.Sprintf("%d 0 obj\n%s\nendobj\n", number, body)))
+}
+
+// write writes to the output, keeping the offset of the next byte and the first error found
+func (p *pdfStatementEncoder) write(b []byte) {
+	if p.err != nil {
+		return
+	}
+
+	n, err := p.w.Write(b)
+	p.offset += n
+	p.err = err
+}
+
+func formatPDFRow(date, id, description, amount, balance string) string {
+	if r := []rune(description); len(r) > pdfTypeColumns {
+		description = string(r[:pdfTypeColumns])
+	}
+
+	return fmt.Sprintf("%-20s %8s %-*s %14s %14s", date, id, pdfTypeColumns, description, amount, balance)
+}
+
+// escapePDFText escapes a text to a PDF string in the WinAnsi encoding, characters out of it are replaced by "?"
+func escapePDFText(v string) string {
+	var b strings.Builder
+
+	for _, r := range v {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteByte(byte(r))
+		case r < 32 || r > 255:
+			b.WriteByte('?')
+		default:
+			b.WriteByte(byte(r))
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+const (
+	statementFormatCSV = "csv"
+	statementFormatOFX = "ofx"
+	statementFormatPDF = "pdf"
+)
+
+// statementEncoder encodes a statement in a file format as it is written
+type statementEncoder interface {
+	domain.StatementWriter
+	contentType() string
+}
+
+var statementEncoders = map[string]func(io.Writer) statementEncoder{
+	statementFormatCSV: newCSVStatementEncoder,
+	statementFormatOFX: newOFXStatementEncoder,
+	statementFormatPDF: newPDFStatementEncoder,
+}
+
+// statementResponseWriter starts the http response when the statement begins, so errors found before it can still
+// be answered with the proper status code
+type statementResponseWriter struct {
+	rw        http.ResponseWriter
+	accountID uint64
+	format    string
+	encoder   statementEncoder
+	started   bool
+}
+
+func newStatementResponseWriter(rw http.ResponseWriter, accountID uint64, format string) *statementResponseWriter {
+	return &statementResponseWriter{
+		rw:        rw,
+		accountID: accountID,
+		format:    format,
+		encoder:   statementEncoders[format](rw),
+	}
+}
+
+func (s *statementResponseWriter) Begin(statement *domain.Statement) error {
+	filename := fmt.Sprintf(
+		"statement-%d-%s-%s.%s",
+		s.accountID,
+		statement.From().UTC().Format("20060102"),
+		statement.To().UTC().Format("20060102"),
+		s.format,
+	)
+
+	s.rw.Header().Set("Content-Type", s.encoder.contentType())
+	s.rw.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	s.rw.WriteHeader(http.StatusOK)
+	s.started = true
+
+	return s.encoder.Begin(statement)
+}
+
+func (s *statementResponseWriter) Line(line *domain.StatementLine) error {
+	return s.encoder.Line(line)
+}
+
+func (s *statementResponseWriter) End(statement *domain.Statement) error {
+	return s.encoder.End(statement)
+}
+
+// csvStatementEncoder encodes a statement as CSV, the opening and closing balances are the first and last rows
+type csvStatementEncoder struct {
+	w *csv.Writer
+}
+
+func newCSVStatementEncoder(w io.Writer) statementEncoder {
+	return &csvStatementEncoder{w: csv.NewWriter(w)}
+}
+
+func (c *csvStatementEncoder) contentType() string {
+	return "text/csv; charset=utf-8"
+}
+
+func (c *csvStatementEncoder) Begin(statement *domain.Statement) error {
+	c.w.Write([]string{"date", "transaction_id", "type", "amount", "balance"})
+	c.w.Write([]string{formatStatementTime(statement.From()), "", "OPENING BALANCE", "", statement.OpeningBalance().String()})
+
+	return c.w.Error()
+}
+
+func (c *csvStatementEncoder) Line(line *domain.StatementLine) error {
+	t := line.Transaction()
+
+	c.w.Write([]string{
+		formatStatementTime(t.CreatedAt()),
+		strconv.FormatUint(t.ID().Value(), 10),
+		t.Operation().Description(),
+		t.Amount().String(),
+		line.Balance().String(),
+	})
+
+	return c.w.Error()
+}
+
+func (c *csvStatementEncoder) End(statement *domain.Statement) error {
+	c.w.Write([]string{formatStatementTime(statement.To()), "", "CLOSING BALANCE", "", statement.ClosingBalance().String()})
+	c.w.Flush()
+
+	return c.w.Error()
+}
+
+func formatStatementTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+)
+
+func TestExportStatement_Handler(t *testing.T) {
+	var (
+		logger      = domain.NewLoggerMock()
+		createdAt   = time.Date(2020, 10, 4, 13, 44, 59, 0, time.UTC)
+		purchase, _ = domain.NewTransaction(domain.NewID(1), domain.OperationCompraAVista, domain.NewMoney(5000, domain.CurrencyBRL))
+		payment, _  = domain.NewTransaction(domain.NewID(1), domain.OperationPagamento, domain.NewMoney(2000, domain.CurrencyBRL))
+		account, _  = domain.NewAccount("00000000191")
+	)
+
+	account = account.WithID(domain.NewID(1))
+
+	transactions := []*domain.Transaction{
+		purchase.WithID(domain.NewID(10)).WithCreatedAt(createdAt),
+		payment.WithID(domain.NewID(11)).WithCreatedAt(createdAt.Add(time.Hour)),
+	}
+
+	tests := []struct {
+		name                string
+		statementExporter   StatementExporter
+		path                string
+		wantPayloadResponse string
+		wantContentType     string
+		wantHTTPStatusCode  int
+	}{
+		// fails
+		{
+			name:                "bad request when the account id is invalid",
+			statementExporter:   newFakeStatementExporter(account, nil, nil, nil),
+			path:                "/accounts/abc/statement?from=2020-10-01&to=2020-10-31",
+			wantPayloadResponse: `^{"errors":\[{"field":"id","description":"id must be a valid number"}\]}$`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name:                "bad request when the period is not informed",
+			statementExporter:   newFakeStatementExporter(account, nil, nil, nil),
+			path:                "/accounts/1/statement?to=2020-10-31",
+			wantPayloadResponse: `^{"errors":\[{"field":"from","description":"from is a required parameter"}\]}$`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name:                "bad request when the format is invalid",
+			statementExporter:   newFakeStatementExporter(account, nil, nil, nil),
+			path:                "/accounts/1/statement?from=2020-10-01&to=2020-10-31&format=xls",
+			wantPayloadResponse: `^{"errors":\[{"field":"format","description":"format must be one of csv, ofx or pdf"}\]}$`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name:                "not found when the account does not exist",
+			statementExporter:   newFakeStatementExporter(account, nil, repository.NewErrRegisterNotFound("id", "1"), nil),
+			path:                "/accounts/1/statement?from=2020-10-01&to=2020-10-31",
+			wantPayloadResponse: `^{"errors":\[{"field":"id","description":"1 not found"}\]}$`,
+			wantHTTPStatusCode:  http.StatusNotFound,
+		},
+		{
+			name:                "unprocessable entity when the period is invalid",
+			statementExporter:   newFakeStatementExporter(account, nil, domain.NewErrDomain("from", "must be before to"), nil),
+			path:                "/accounts/1/statement?from=2020-10-31&to=2020-10-01",
+			wantPayloadResponse: `^{"errors":\[{"field":"from","description":"from must be before to"}\]}$`,
+			wantHTTPStatusCode:  http.StatusUnprocessableEntity,
+		},
+		{
+			name:                "internal server error when returns an unknown error",
+			statementExporter:   newFakeStatementExporter(account, nil, errors.New("unknown error"), nil),
+			path:                "/accounts/1/statement?from=2020-10-01&to=2020-10-31",
+			wantPayloadResponse: `^$`,
+			wantHTTPStatusCode:  http.StatusInternalServerError,
+		},
+		{
+			name:                "interrupted statement without closing balance when the transactions cannot be read",
+			statementExporter:   newFakeStatementExporter(account, transactions, nil, errors.New("database error")),
+			path:                "/accounts/1/statement?from=2020-10-01&to=2020-10-31",
+			wantPayloadResponse: `^$`,
+			wantContentType:     "text/csv; charset=utf-8",
+			wantHTTPStatusCode:  http.StatusOK,
+		},
+
+		// successes
+		{
+			name:              "csv statement",
+			statementExporter: newFakeStatementExporter(account, transactions, nil, nil),
+			path:              "/accounts/1/statement?from=2020-10-01&to=2020-10-31",
+			wantPayloadResponse: `^date,transaction_id,type,amount,balance\n` +
+				`2020-10-01T00:00:00Z,,OPENING BALANCE,,100.00\n` +
+				`2020-10-04T13:44:59Z,10,COMPRA A VISTA,-50.00,50.00\n` +
+				`2020-10-04T14:44:59Z,11,PAGAMENTO,20.00,70.00\n` +
+				`2020-10-31T23:59:59Z,,CLOSING BALANCE,,70.00\n$`,
+			wantContentType:    "text/csv; charset=utf-8",
+			wantHTTPStatusCode: http.StatusOK,
+		},
+		{
+			name:              "ofx statement",
+			statementExporter: newFakeStatementExporter(account, transactions, nil, nil),
+			path:              "/accounts/1/statement?from=2020-10-01&to=2020-10-31&format=ofx",
+			wantPayloadResponse: `(?s)^<\?xml version="1.0".*<CURDEF>BRL</CURDEF>\n<CCACCTFROM><ACCTID>1</ACCTID></CCACCTFROM>.*` +
+				`<DTSTART>20201001000000\[0:GMT\]</DTSTART>\n<DTEND>20201031235959\[0:GMT\]</DTEND>\n` +
+				`<STMTTRN><TRNTYPE>DEBIT</TRNTYPE><DTPOSTED>20201004134459\[0:GMT\]</DTPOSTED><TRNAMT>-50.00</TRNAMT><FITID>10</FITID><NAME>COMPRA A VISTA</NAME><MEMO>BALANCE 50.00</MEMO></STMTTRN>\n` +
+				`<STMTTRN><TRNTYPE>CREDIT</TRNTYPE><DTPOSTED>20201004144459\[0:GMT\]</DTPOSTED><TRNAMT>20.00</TRNAMT><FITID>11</FITID><NAME>PAGAMENTO</NAME><MEMO>BALANCE 70.00</MEMO></STMTTRN>\n` +
+				`</BANKTRANLIST>\n<LEDGERBAL><BALAMT>70.00</BALAMT><DTASOF>20201031235959\[0:GMT\]</DTASOF></LEDGERBAL>.*</OFX>\n$`,
+			wantContentType:    "application/x-ofx",
+			wantHTTPStatusCode: http.StatusOK,
+		},
+		{
+			name:              "pdf statement",
+			statementExporter: newFakeStatementExporter(account, transactions, nil, nil),
+			path:              "/accounts/1/statement?from=2020-10-01&to=2020-10-31&format=pdf",
+			wantPayloadResponse: `(?s)^%PDF-1.4\n.*Document: CPF \*\*\*\.\*\*\*\.\*\*\*-91.*` +
+				`OPENING BALANCE +100.00.*` +
+				`2020-10-04T13:44:59Z +10 COMPRA A VISTA +-50.00 +50.00.*` +
+				`CLOSING BALANCE +70.00.*%%EOF\n$`,
+			wantContentType:    "application/pdf",
+			wantHTTPStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			httpHandler := http.HandlerFunc(NewExportStatement(logger, tt.statementExporter).Handler)
+			req, err := http.NewRequest("GET", tt.path, nil)
+			if err != nil {
+				t.Error("error to perform GET /accounts/:id/statement request")
+			}
+
+			httpHandler.ServeHTTP(rr, req)
+
+			var (
+				gotHTTPStatusCode = rr.Code
+				gotPayload        = rr.Body.String()
+			)
+
+			if gotHTTPStatusCode != tt.wantHTTPStatusCode {
+				t.Errorf("HTTP Status Code is different from expected, got = %v, want %v", gotHTTPStatusCode, tt.wantHTTPStatusCode)
+				return
+			}
+
+			if tt.wantContentType != "" && rr.Header().Get("Content-Type") != tt.wantContentType {
+				t.Errorf("Content-Type is different from expected, got = %v, want %v", rr.Header().Get("Content-Type"), tt.wantContentType)
+				return
+			}
+
+			match := regexp.MustCompile(tt.wantPayloadResponse).MatchString(gotPayload)
+			if !match {
+				t.Errorf("Payload Response is different from expected, got = %v, want %v", gotPayload, tt.wantPayloadResponse)
+				return
+			}
+		})
+	}
+}
+
+func TestPDFStatementEncoder_CrossReferences(t *testing.T) {
+	var (
+		account, _  = domain.NewAccount("00000000191")
+		purchase, _ = domain.NewTransaction(domain.NewID(1), domain.OperationCompraAVista, domain.NewMoney(5000, domain.CurrencyBRL))
+		transaction = purchase.WithID(domain.NewID(10)).WithCreatedAt(time.Now())
+		from        = time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+		to          = time.Date(2020, 10, 31, 23, 59, 59, 0, time.UTC)
+		out         strings.Builder
+	)
+
+	transactions := make([]*domain.Transaction, 150)
+	for i := range transactions {
+		transactions[i] = transaction
+	}
+
+	statement, _ := domain.NewStatement(account.WithID(domain.NewID(1)), from, to)
+	if err := statement.Write(context.Background(), domain.NewStatementRepositoryMock(transactions, nil), newPDFStatementEncoder(&out)); err != nil {
+		t.Errorf("Write() error = %v", err)
+		return
+	}
+
+	pdf := out.String()
+
+	match := regexp.MustCompile(`startxref\n([0-9]+)\n%%EOF\n$`).FindStringSubmatch(pdf)
+	if match == nil {
+		t.Errorf("startxref not found")
+		return
+	}
+
+	xref, _ := strconv.Atoi(match[1])
+	if !strings.HasPrefix(pdf[xref:], "xref\n") {
+		t.Errorf("startxref %d does not point to the cross-reference table", xref)
+		return
+	}
+
+	entries := regexp.MustCompile(`([0-9]{10}) 00000 n \n`).FindAllStringSubmatch(pdf[xref:], -1)
+	for i, v := range entries {
+		offset, _ := strconv.Atoi(v[1])
+
+		if want := fmt.Sprintf("%d 0 obj\n", i+1); !strings.HasPrefix(pdf[offset:], want) {
+			t.Errorf("cross-reference of the object %d points to %q", i+1, pdf[offset:offset+len(want)])
+		}
+	}
+
+	if got := strings.Count(pdf, "/Type /Page "); got != 3 {
+		t.Errorf("pages = %d, want 3", got)
+	}
+}
+
+type fakeStatementExporter struct {
+	account      *domain.Account
+	transactions []*domain.Transaction
+	err          error
+	walkErr      error
+}
+
+func newFakeStatementExporter(account *domain.Account, transactions []*domain.Transaction, err, walkErr error) *fakeStatementExporter {
+	return &fakeStatementExporter{account: account, transactions: transactions, err: err, walkErr: walkErr}
+}
+
+func (f fakeStatementExporter) Export(ctx context.Context, _ *domain.ID, from, to time.Time, w domain.StatementWriter) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	statement, _ := domain.NewStatement(f.account, from, to)
+	statement, _ = statement.Open(ctx, domain.NewAccountRepositoryMock(nil, f.account, nil).WithBalance(domain.NewMoney(10000, domain.CurrencyBRL)))
+
+	return statement.Write(ctx, domain.NewStatementRepositoryMock(f.transactions, f.walkErr), w)
+}
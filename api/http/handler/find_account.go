@@ -1,12 +1,9 @@
 package handler
 
 import (
-	"errors"
+	"context"
 	"fmt"
-	"log"
 	"net/http"
-	"strconv"
-	"strings"
 
 	"github.com/tonytcb/bank-transactions-go/domain"
 	"github.com/tonytcb/bank-transactions-go/infra/repository"
@@ -14,17 +11,17 @@ import (
 
 // AccountFinder defines the behaviour about how to find an account
 type AccountFinder interface {
-	Find(*domain.ID) (*domain.Account, error)
+	Find(context.Context, *domain.ID) (*domain.Account, error)
 }
 
 // FindAccount contains the dependencies to find an account
 type FindAccount struct {
-	logger        *log.Logger
+	logger        domain.Logger
 	accountFinder AccountFinder
 }
 
 // NewFindAccount creates a new FindAccount struct
-func NewFindAccount(logger *log.Logger, accountFinder AccountFinder) *FindAccount {
+func NewFindAccount(logger domain.Logger, accountFinder AccountFinder) *FindAccount {
 	return &FindAccount{logger: logger, accountFinder: accountFinder}
 }
 
@@ -34,23 +31,23 @@ func (f FindAccount) Handler(rw http.ResponseWriter, req *http.Request) {
 
 	idParam, err := f.extractParamGetID(req)
 	if err != nil {
-		f.logger.Println("invalid account id:", err)
+		f.logger.Info(req.Context(), "invalid account id", domain.NewErrLogField(err))
 
 		errResponse := newErrorResponse(map[string]string{"id": err.Error()})
 		responder.badRequest(errResponse.Encode())
 		return
 	}
 
-	account, err := f.accountFinder.Find(domain.NewID(idParam))
+	account, err := f.accountFinder.Find(req.Context(), domain.NewID(idParam))
 	if err != nil {
 		if _, ok := err.(*repository.ErrRegisterNotFound); ok {
-			f.logger.Println("account not found:", err)
+			f.logger.Info(req.Context(), "account not found", domain.NewErrLogField(err))
 			errResponse := newErrorResponse(map[string]string{"id": fmt.Sprintf("%d not found", idParam)})
 			responder.notFound(errResponse.Encode())
 			return
 		}
 
-		f.logger.Println("unknown error:", err)
+		f.logger.Error(req.Context(), "unknown error", domain.NewErrLogField(err))
 		responder.internalServerError()
 		return
 	}
@@ -59,9 +56,14 @@ func (f FindAccount) Handler(rw http.ResponseWriter, req *http.Request) {
 		account.ID().Value(),
 		account.Document().Number().String(),
 		account.CreatedAt(),
-	)
+	).
+		withDocumentType(account.Document().Type()).
+		withCurrency(account.Currency()).
+		withCreditLimit(account.CreditLimit(), account.AvailableCreditLimit()).
+		withBillingCycle(account.BillingCycle()).
+		withStatus(account.Status(), account.StatusReason(), account.StatusChangedAt())
 
-	f.logger.Println("account found:", string(response.Encode()))
+	f.logger.Debug(req.Context(), "account found", domain.NewLogField("account_id", account.ID().Value()))
 
 	responder.ok(response.Encode())
 }
@@ -69,20 +71,5 @@ func (f FindAccount) Handler(rw http.ResponseWriter, req *http.Request) {
 func (f FindAccount) extractParamGetID(req *http.Request) (uint64, error) {
 	const position = 2
 
-	p := strings.Split(req.URL.Path, "/")
-
-	if len(p) < (position + 1) {
-		return 0, errors.New("parameter id not found")
-	}
-
-	id, err := strconv.Atoi(p[position])
-	if err != nil {
-		return 0, errors.New("id must be a valid number")
-	}
-
-	if id <= 0 {
-		return 0, errors.New("id must be greater than zero")
-	}
-
-	return uint64(id), nil
+	return extractParamID(req, position)
 }
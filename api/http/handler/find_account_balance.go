@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+)
+
+// BalanceFinder defines the behaviour about how to find the balance of an account
+type BalanceFinder interface {
+	Find(context.Context, *domain.ID, time.Time) (*domain.Balance, error)
+}
+
+// FindAccountBalance contains the dependencies to find the balance of an account
+type FindAccountBalance struct {
+	logger        domain.Logger
+	balanceFinder BalanceFinder
+}
+
+// NewFindAccountBalance creates a new FindAccountBalance struct
+func NewFindAccountBalance(logger domain.Logger, balanceFinder BalanceFinder) *FindAccountBalance {
+	return &FindAccountBalance{logger: logger, balanceFinder: balanceFinder}
+}
+
+// Handler exposes the http handler
+func (f FindAccountBalance) Handler(rw http.ResponseWriter, req *http.Request) {
+	const idPosition = 2
+
+	responder := newResponder(rw)
+
+	idParam, err := extractParamID(req, idPosition)
+	if err != nil {
+		f.logger.Info(req.Context(), "invalid account id", domain.NewErrLogField(err))
+
+		errResponse := newErrorResponse(map[string]string{"id": err.Error()})
+		responder.badRequest(errResponse.Encode())
+		return
+	}
+
+	var asOf *time.Time
+	if v := req.URL.Query().Get("as_of"); v != "" {
+		t, err := parseDateTime(v, true)
+		if err != nil {
+			f.logger.Info(req.Context(), "invalid as_of parameter", domain.NewErrLogField(err))
+
+			errResponse := newErrorResponse(map[string]string{"as_of": err.Error()})
+			responder.badRequest(errResponse.Encode())
+			return
+		}
+
+		asOf = &t
+	}
+
+	current, err := f.balanceFinder.Find(req.Context(), domain.NewID(idParam), time.Now())
+	if err != nil {
+		f.translateError(req.Context(), responder, idParam, err)
+		return
+	}
+
+	response := newAccountBalanceResponse(
+		idParam,
+		current.Amount().Currency(),
+		newBalanceResponse(current.Amount(), current.At()),
+		nil,
+	)
+
+	if asOf != nil {
+		balance, err := f.balanceFinder.Find(req.Context(), domain.NewID(idParam), *asOf)
+		if err != nil {
+			f.translateError(req.Context(), responder, idParam, err)
+			return
+		}
+
+		response.AsOf = newBalanceResponse(balance.Amount(), balance.At())
+	}
+
+	responder.ok(response.Encode())
+}
+
+func (f FindAccountBalance) translateError(ctx context.Context, r *responder, id uint64, err error) {
+	if _, ok := err.(*repository.ErrRegisterNotFound); ok {
+		f.logger.Info(ctx, "account not found", domain.NewErrLogField(err))
+		errResponse := newErrorResponse(map[string]string{"id": fmt.Sprintf("%d not found", id)})
+		r.notFound(errResponse.Encode())
+		return
+	}
+
+	f.logger.Error(ctx, "unknown error", domain.NewErrLogField(err))
+	r.internalServerError()
+}
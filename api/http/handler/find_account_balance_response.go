@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+type balanceResponse struct {
+	Amount json.Number `json:"amount"`
+	Date   string      `json:"date"`
+}
+
+func newBalanceResponse(amount domain.Money, at time.Time) *balanceResponse {
+	return &balanceResponse{Amount: json.Number(amount.String()), Date: at.UTC().Format(time.RFC3339)}
+}
+
+type accountBalanceResponse struct {
+	AccountID uint64           `json:"account_id"`
+	Currency  string           `json:"currency"`
+	Current   *balanceResponse `json:"current"`
+	AsOf      *balanceResponse `json:"as_of,omitempty"`
+}
+
+func newAccountBalanceResponse(accountID uint64, currency domain.Currency, current, asOf *balanceResponse) accountBalanceResponse {
+	return accountBalanceResponse{AccountID: accountID, Currency: currency.String(), Current: current, AsOf: asOf}
+}
+
+func (c accountBalanceResponse) Encode() []byte {
+	res, _ := json.Marshal(c)
+
+	return res
+}
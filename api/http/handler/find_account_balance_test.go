@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+)
+
+func TestFindAccountBalance_Handler(t *testing.T) {
+	var logger = domain.NewLoggerMock()
+
+	datetimeRegex := `[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}:[0-9]{2}Z`
+
+	type fields struct {
+		balanceFinder BalanceFinder
+	}
+
+	type args struct {
+		path string
+	}
+
+	tests := []struct {
+		name                string
+		fields              fields
+		args                args
+		wantPayloadResponse string
+		wantHTTPStatusCode  int
+	}{
+		// fails
+		{
+			name: "bad request when the id is not a number",
+			fields: fields{
+				balanceFinder: newFakeBalanceFinder(domain.Money{}, nil),
+			},
+			args: args{
+				path: "/accounts/x/balance",
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"id","description":"id must be a valid number"}\]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name: "bad request when as_of is invalid",
+			fields: fields{
+				balanceFinder: newFakeBalanceFinder(domain.Money{}, nil),
+			},
+			args: args{
+				path: "/accounts/1/balance?as_of=yesterday",
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"as_of","description":"must be a valid RFC3339 date time or a date in the format YYYY-MM-DD"}\]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name: "account not found when the id is not in the storage",
+			fields: fields{
+				balanceFinder: newFakeBalanceFinder(domain.Money{}, repository.NewErrRegisterNotFound("account", "1")),
+			},
+			args: args{
+				path: "/accounts/1/balance",
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"id","description":"1 not found"}\]}`,
+			wantHTTPStatusCode:  http.StatusNotFound,
+		},
+		{
+			name: "unknown error from balance finder",
+			fields: fields{
+				balanceFinder: newFakeBalanceFinder(domain.Money{}, errors.New("some error")),
+			},
+			args: args{
+				path: "/accounts/1/balance",
+			},
+			wantPayloadResponse: ``,
+			wantHTTPStatusCode:  http.StatusInternalServerError,
+		},
+
+		// success
+		{
+			name: "current balance found successfully",
+			fields: fields{
+				balanceFinder: newFakeBalanceFinder(domain.NewMoney(-5025, domain.CurrencyBRL), nil),
+			},
+			args: args{
+				path: "/accounts/1/balance",
+			},
+			wantPayloadResponse: fmt.Sprintf(`^{"account_id":1,"currency":"BRL","current":{"amount":-50.25,"date":"%s"}}$`, datetimeRegex),
+			wantHTTPStatusCode:  http.StatusOK,
+		},
+		{
+			name: "current and point-in-time balance found successfully with a date",
+			fields: fields{
+				balanceFinder: newFakeBalanceFinder(domain.NewMoney(10000, domain.CurrencyBRL), nil),
+			},
+			args: args{
+				path: "/accounts/1/balance?as_of=2020-10-04",
+			},
+			wantPayloadResponse: fmt.Sprintf(`^{"account_id":1,"currency":"BRL","current":{"amount":100.00,"date":"%s"},"as_of":{"amount":100.00,"date":"2020-10-04T23:59:59Z"}}$`, datetimeRegex),
+			wantHTTPStatusCode:  http.StatusOK,
+		},
+		{
+			name: "current and point-in-time balance found successfully with a date time",
+			fields: fields{
+				balanceFinder: newFakeBalanceFinder(domain.NewMoney(10000, domain.CurrencyBRL), nil),
+			},
+			args: args{
+				path: "/accounts/1/balance?as_of=2020-10-04T10:00:00-03:00",
+			},
+			wantPayloadResponse: fmt.Sprintf(`^{"account_id":1,"currency":"BRL","current":{"amount":100.00,"date":"%s"},"as_of":{"amount":100.00,"date":"2020-10-04T13:00:00Z"}}$`, datetimeRegex),
+			wantHTTPStatusCode:  http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			httpHandler := http.HandlerFunc(NewFindAccountBalance(logger, tt.fields.balanceFinder).Handler)
+			req, err := http.NewRequest("GET", tt.args.path, nil)
+			if err != nil {
+				t.Errorf("error to perform GET %s request", tt.args.path)
+			}
+
+			httpHandler.ServeHTTP(rr, req)
+
+			var (
+				gotHTTPStatusCode = rr.Code
+				gotPayload        = rr.Body.String()
+			)
+
+			if gotHTTPStatusCode != tt.wantHTTPStatusCode {
+				t.Errorf("HTTP Status Code is different from expected, got = %v, want %v", gotHTTPStatusCode, tt.wantHTTPStatusCode)
+				return
+			}
+
+			match := regexp.MustCompile(tt.wantPayloadResponse).MatchString(gotPayload)
+			if !match {
+				t.Errorf("Payload Response is different from expected, got = %v, want %v", gotPayload, tt.wantPayloadResponse)
+				return
+			}
+		})
+	}
+}
+
+type fakeBalanceFinder struct {
+	amount domain.Money
+	err    error
+}
+
+func newFakeBalanceFinder(amount domain.Money, err error) *fakeBalanceFinder {
+	return &fakeBalanceFinder{amount: amount, err: err}
+}
+
+func (f fakeBalanceFinder) Find(_ context.Context, id *domain.ID, at time.Time) (*domain.Balance, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return domain.NewBalance(new(domain.Account).WithID(id), f.amount, at), nil
+}
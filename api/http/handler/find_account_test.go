@@ -1,9 +1,9 @@
 package handler
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"log"
 	"net/http"
 	"net/http/httptest"
 	"regexp"
@@ -15,10 +15,10 @@ import (
 )
 
 func TestFindAccount_Handler(t *testing.T) {
-	var logger = log.New(fakeWriter{}, "", log.LstdFlags)
+	var logger = domain.NewLoggerMock()
 
 	accountOK, _ := domain.NewAccount("00000000191")
-	accountOK = accountOK.WithID(domain.NewID(uint64(100))).WithCreateAt(time.Now())
+	accountOK = accountOK.WithID(domain.NewID(uint64(100))).WithCreateAt(time.Now()).WithCreditLimit(domain.NewMoney(100000, domain.CurrencyBRL)).WithAvailableCreditLimit(domain.NewMoney(25050, domain.CurrencyBRL))
 
 	type fields struct {
 		accountFinder AccountFinder
@@ -91,7 +91,7 @@ func TestFindAccount_Handler(t *testing.T) {
 			args: args{
 				id: "100",
 			},
-			wantPayloadResponse: fmt.Sprintf(`{"id":100,"document":{"number":"00000000191"},"created_at":"%s"}`, datetimeRegex),
+			wantPayloadResponse: fmt.Sprintf(`{"id":100,"document":{"type":"CPF","number":"00000000191"},"currency":"BRL","credit_limit":1000.00,"available_credit_limit":250.50,"closing_day":25,"due_day":5,"status":"ACTIVE","created_at":"%s"}`, datetimeRegex),
 			wantHTTPStatusCode:  http.StatusOK,
 		},
 	}
@@ -139,7 +139,7 @@ func newFakeAccountFinder(account *domain.Account, err error) *fakeAccountFinder
 	return &fakeAccountFinder{account: account, err: err}
 }
 
-func (f fakeAccountFinder) Find(*domain.ID) (*domain.Account, error) {
+func (f fakeAccountFinder) Find(context.Context, *domain.ID) (*domain.Account, error) {
 	if f.err != nil {
 		return nil, f.err
 	}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+)
+
+// InvoiceFinder defines the behaviour about how to find an invoice
+type InvoiceFinder interface {
+	Find(context.Context, *domain.ID) (*domain.Invoice, error)
+}
+
+// FindInvoice contains the dependencies to find an invoice
+type FindInvoice struct {
+	logger        domain.Logger
+	invoiceFinder InvoiceFinder
+}
+
+// NewFindInvoice creates a new FindInvoice struct
+func NewFindInvoice(logger domain.Logger, invoiceFinder InvoiceFinder) *FindInvoice {
+	return &FindInvoice{logger: logger, invoiceFinder: invoiceFinder}
+}
+
+// Handler exposes the http handler
+func (f FindInvoice) Handler(rw http.ResponseWriter, req *http.Request) {
+	responder := newResponder(rw)
+
+	idParam, err := f.extractParamGetID(req)
+	if err != nil {
+		f.logger.Info(req.Context(), "invalid invoice id", domain.NewErrLogField(err))
+
+		errResponse := newErrorResponse(map[string]string{"id": err.Error()})
+		responder.badRequest(errResponse.Encode())
+		return
+	}
+
+	invoice, err := f.invoiceFinder.Find(req.Context(), domain.NewID(idParam))
+	if err != nil {
+		if _, ok := err.(*repository.ErrRegisterNotFound); ok {
+			f.logger.Info(req.Context(), "invoice not found", domain.NewErrLogField(err))
+			errResponse := newErrorResponse(map[string]string{"id": fmt.Sprintf("%d not found", idParam)})
+			responder.notFound(errResponse.Encode())
+			return
+		}
+
+		f.logger.Error(req.Context(), "unknown error", domain.NewErrLogField(err))
+		responder.internalServerError()
+		return
+	}
+
+	responder.ok(newInvoiceResponse(invoice).withItems(invoice.Items()).Encode())
+}
+
+func (f FindInvoice) extractParamGetID(req *http.Request) (uint64, error) {
+	const position = 2
+
+	return extractParamID(req, position)
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+type invoiceInstallmentResponse struct {
+	Number int `json:"number"`
+	Count  int `json:"count"`
+}
+
+type invoiceItemResponse struct {
+	TransactionID uint64                      `json:"transaction_id"`
+	Description   string                      `json:"type"`
+	Amount        json.Number                 `json:"amount"`
+	Date          string                      `json:"date"`
+	Installment   *invoiceInstallmentResponse `json:"installment,omitempty"`
+}
+
+type invoiceResponse struct {
+	ID              uint64                `json:"id"`
+	AccountID       uint64                `json:"account_id"`
+	PeriodStart     string                `json:"period_start"`
+	PeriodEnd       string                `json:"period_end"`
+	DueDate         string                `json:"due_date"`
+	Currency        string                `json:"currency"`
+	PreviousBalance json.Number           `json:"previous_balance"`
+	Total           json.Number           `json:"total"`
+	MinimumPayment  json.Number           `json:"minimum_payment"`
+	Items           []invoiceItemResponse `json:"items,omitempty"`
+	CreatedAt       string                `json:"created_at,omitempty"`
+}
+
+func newInvoiceResponse(invoice *domain.Invoice) invoiceResponse {
+	response := invoiceResponse{
+		ID:              invoice.ID().Value(),
+		AccountID:       invoice.AccountID().Value(),
+		PeriodStart:     invoice.From().UTC().Format(time.RFC3339),
+		PeriodEnd:       invoice.To().UTC().Format(time.RFC3339),
+		DueDate:         invoice.DueDate().Format("2006-01-02"),
+		Currency:        invoice.Total().Currency().String(),
+		PreviousBalance: json.Number(invoice.PreviousBalance().String()),
+		Total:           json.Number(invoice.Total().String()),
+		MinimumPayment:  json.Number(invoice.MinimumPayment().String()),
+	}
+
+	if !invoice.CreatedAt().IsZero() {
+		response.CreatedAt = invoice.CreatedAt().UTC().Format(time.RFC3339)
+	}
+
+	return response
+}
+
+// withItems describes the transactions and installments billed in the invoice
+func (c invoiceResponse) withItems(items []*domain.InvoiceItem) invoiceResponse {
+	c.Items = make([]invoiceItemResponse, 0, len(items))
+
+	for _, v := range items {
+		item := invoiceItemResponse{
+			TransactionID: v.TransactionID().Value(),
+			Description:   v.Description(),
+			Amount:        json.Number(v.Amount().String()),
+			Date:          v.Date().UTC().Format(time.RFC3339),
+		}
+
+		if v.Installment() != nil {
+			item.Installment = &invoiceInstallmentResponse{Number: v.Installment().Number(), Count: v.Installments()}
+		}
+
+		c.Items = append(c.Items, item)
+	}
+
+	return c
+}
+
+func (c invoiceResponse) Encode() []byte {
+	res, _ := json.Marshal(c)
+
+	return res
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+)
+
+func TestFindInvoice_Handler(t *testing.T) {
+	var (
+		logger      = domain.NewLoggerMock()
+		brl         = func(cents int64) domain.Money { return domain.NewMoney(cents, domain.CurrencyBRL) }
+		from        = time.Date(2020, 9, 26, 0, 0, 0, 0, time.UTC)
+		to          = time.Date(2020, 10, 25, 23, 59, 59, 0, time.UTC)
+		installment = domain.NewInstallment(2, brl(-10000), time.Date(2020, 10, 10, 0, 0, 0, 0, time.UTC))
+		items       = []*domain.InvoiceItem{
+			domain.LoadInvoiceItem(domain.NewID(7), "COMPRA A VISTA", brl(-5000), time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)),
+			domain.NewInstallmentInvoiceItem(domain.NewID(5), "COMPRA PARCELADA", installment, 3),
+		}
+		dueDate   = time.Date(2020, 11, 5, 0, 0, 0, 0, time.UTC)
+		invoiceOK = domain.LoadInvoice(domain.NewID(10), domain.NewID(1), from, to, dueDate, brl(0), items, to.Add(time.Hour))
+	)
+
+	type fields struct {
+		invoiceFinder InvoiceFinder
+	}
+	type args struct {
+		id string
+	}
+	tests := []struct {
+		name                string
+		fields              fields
+		args                args
+		wantPayloadResponse string
+		wantHTTPStatusCode  int
+	}{
+		// fails
+		{
+			name: "bad request when the id is invalid",
+			fields: fields{
+				invoiceFinder: newFakeInvoiceFinder(nil, nil),
+			},
+			args: args{
+				id: "x",
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"id","description":"id must be a valid number"}\]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name: "invoice not found when the id is not in the storage",
+			fields: fields{
+				invoiceFinder: newFakeInvoiceFinder(nil, repository.NewErrRegisterNotFound("id", "10")),
+			},
+			args: args{
+				id: "10",
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"id","description":"10 not found"}\]}`,
+			wantHTTPStatusCode:  http.StatusNotFound,
+		},
+		{
+			name: "internal server error when the usecase returns an unknown error",
+			fields: fields{
+				invoiceFinder: newFakeInvoiceFinder(nil, errors.New("some error")),
+			},
+			args: args{
+				id: "10",
+			},
+			wantPayloadResponse: ``,
+			wantHTTPStatusCode:  http.StatusInternalServerError,
+		},
+
+		// successes
+		{
+			name: "invoice found successfully",
+			fields: fields{
+				invoiceFinder: newFakeInvoiceFinder(invoiceOK, nil),
+			},
+			args: args{
+				id: "10",
+			},
+			wantPayloadResponse: `^{"id":10,"account_id":1,"period_start":"2020-09-26T00:00:00Z","period_end":"2020-10-25T23:59:59Z","due_date":"2020-11-05","currency":"BRL","previous_balance":0.00,"total":150.00,"minimum_payment":22.50,"items":\[{"transaction_id":7,"type":"COMPRA A VISTA","amount":-50.00,"date":"2020-10-01T12:00:00Z"},{"transaction_id":5,"type":"COMPRA PARCELADA","amount":-100.00,"date":"2020-10-10T00:00:00Z","installment":{"number":2,"count":3}}\],"created_at":"2020-10-26T00:59:59Z"}$`,
+			wantHTTPStatusCode:  http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			httpHandler := http.HandlerFunc(NewFindInvoice(logger, tt.fields.invoiceFinder).Handler)
+			req, err := http.NewRequest("GET", fmt.Sprintf("/invoices/%s", tt.args.id), nil)
+			if err != nil {
+				t.Errorf("error to perform GET /invoices/%s request", tt.args.id)
+			}
+
+			httpHandler.ServeHTTP(rr, req)
+
+			var (
+				gotHTTPStatusCode = rr.Code
+				gotPayload        = rr.Body.String()
+			)
+
+			if gotHTTPStatusCode != tt.wantHTTPStatusCode {
+				t.Errorf("HTTP Status Code is different from expected, got = %v, want %v", gotHTTPStatusCode, tt.wantHTTPStatusCode)
+				return
+			}
+
+			if !regexp.MustCompile(tt.wantPayloadResponse).MatchString(gotPayload) {
+				t.Errorf("Payload Response is different from expected, got = %v, want %v", gotPayload, tt.wantPayloadResponse)
+				return
+			}
+		})
+	}
+}
+
+type fakeInvoiceFinder struct {
+	invoice *domain.Invoice
+	err     error
+}
+
+func newFakeInvoiceFinder(invoice *domain.Invoice, err error) *fakeInvoiceFinder {
+	return &fakeInvoiceFinder{invoice: invoice, err: err}
+}
+
+func (f fakeInvoiceFinder) Find(context.Context, *domain.ID) (*domain.Invoice, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return f.invoice, nil
+}
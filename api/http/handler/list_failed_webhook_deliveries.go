@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// FailedWebhookDeliveryLister defines the behaviour about how to list the failed webhook deliveries
+type FailedWebhookDeliveryLister interface {
+	List(context.Context) ([]*domain.WebhookDelivery, error)
+}
+
+// ListFailedWebhookDeliveries contains the dependencies to list the failed webhook deliveries
+type ListFailedWebhookDeliveries struct {
+	logger         domain.Logger
+	deliveryLister FailedWebhookDeliveryLister
+}
+
+// NewListFailedWebhookDeliveries creates a new ListFailedWebhookDeliveries struct
+func NewListFailedWebhookDeliveries(
+	logger domain.Logger,
+	deliveryLister FailedWebhookDeliveryLister,
+) *ListFailedWebhookDeliveries {
+	return &ListFailedWebhookDeliveries{logger: logger, deliveryLister: deliveryLister}
+}
+
+// Handler exposes the http handler
+func (l ListFailedWebhookDeliveries) Handler(rw http.ResponseWriter, req *http.Request) {
+	responder := newResponder(rw)
+
+	deliveries, err := l.deliveryLister.List(req.Context())
+	if err != nil {
+		l.logger.Error(req.Context(), "unable to list the failed webhook deliveries", domain.NewErrLogField(err))
+		responder.internalServerError()
+		return
+	}
+
+	responder.ok(newWebhookDeliveryListResponse(deliveries).Encode())
+}
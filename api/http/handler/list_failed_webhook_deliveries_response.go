@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+type webhookDeliveryResponse struct {
+	ID            uint64 `json:"id"`
+	WebhookID     uint64 `json:"webhook_id"`
+	URL           string `json:"url"`
+	EventID       uint64 `json:"event_id"`
+	EventType     string `json:"event_type"`
+	Status        string `json:"status"`
+	Attempts      int    `json:"attempts"`
+	NextAttemptAt string `json:"next_attempt_at,omitempty"`
+	LastAttemptAt string `json:"last_attempt_at,omitempty"`
+	LastError     string `json:"last_error,omitempty"`
+}
+
+func newWebhookDeliveryResponse(delivery *domain.WebhookDelivery) webhookDeliveryResponse {
+	response := webhookDeliveryResponse{
+		ID:        delivery.ID().Value(),
+		WebhookID: delivery.Webhook().ID().Value(),
+		URL:       delivery.Webhook().URL(),
+		EventID:   delivery.Event().ID().Value(),
+		EventType: delivery.Event().Type().String(),
+		Status:    delivery.Status().String(),
+		Attempts:  delivery.Attempts(),
+		LastError: delivery.LastError(),
+	}
+
+	if delivery.Status() == domain.WebhookDeliveryPending {
+		response.NextAttemptAt = delivery.NextAttemptAt().UTC().Format(time.RFC3339)
+	}
+
+	if v := delivery.LastAttemptAt(); !v.IsZero() {
+		response.LastAttemptAt = v.UTC().Format(time.RFC3339)
+	}
+
+	return response
+}
+
+func (c webhookDeliveryResponse) Encode() []byte {
+	res, _ := json.Marshal(c)
+
+	return res
+}
+
+type webhookDeliveryListResponse struct {
+	Deliveries []webhookDeliveryResponse `json:"deliveries"`
+}
+
+func newWebhookDeliveryListResponse(deliveries []*domain.WebhookDelivery) webhookDeliveryListResponse {
+	response := webhookDeliveryListResponse{Deliveries: make([]webhookDeliveryResponse, 0, len(deliveries))}
+
+	for _, d := range deliveries {
+		response.Deliveries = append(response.Deliveries, newWebhookDeliveryResponse(d))
+	}
+
+	return response
+}
+
+func (c webhookDeliveryListResponse) Encode() []byte {
+	res, _ := json.Marshal(c)
+
+	return res
+}
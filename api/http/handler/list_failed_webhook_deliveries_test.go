@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+func TestListFailedWebhookDeliveries_Handler(t *testing.T) {
+	var (
+		logger  = domain.NewLoggerMock()
+		at      = time.Date(2020, 10, 25, 10, 0, 0, 0, time.UTC)
+		webhook = domain.LoadWebhook(domain.NewID(1), "https://partner.com/events", nil, "4f6b", at)
+		event   = domain.LoadEvent(domain.NewID(7), domain.EventAccountCreated, domain.NewID(1), []byte(`{"id":1}`), at)
+		failed  = domain.LoadWebhookDelivery(
+			domain.NewID(3),
+			webhook,
+			event,
+			domain.WebhookDeliveryFailed,
+			8,
+			at,
+			at.Add(time.Hour),
+			"webhook answered with the status code 500",
+		)
+	)
+
+	tests := []struct {
+		name                string
+		deliveryLister      FailedWebhookDeliveryLister
+		wantPayloadResponse string
+		wantHTTPStatusCode  int
+	}{
+		// fails
+		{
+			name:                "internal server error when returns an unknown error",
+			deliveryLister:      newFakeFailedWebhookDeliveryLister(nil, errors.New("unknown error")),
+			wantPayloadResponse: ``,
+			wantHTTPStatusCode:  http.StatusInternalServerError,
+		},
+
+		// successes
+		{
+			name:                "empty list",
+			deliveryLister:      newFakeFailedWebhookDeliveryLister(nil, nil),
+			wantPayloadResponse: `{"deliveries":[]}`,
+			wantHTTPStatusCode:  http.StatusOK,
+		},
+		{
+			name:                "failed deliveries listed successfully",
+			deliveryLister:      newFakeFailedWebhookDeliveryLister([]*domain.WebhookDelivery{failed}, nil),
+			wantPayloadResponse: `{"deliveries":[{"id":3,"webhook_id":1,"url":"https://partner.com/events","event_id":7,"event_type":"account.created","status":"failed","attempts":8,"last_attempt_at":"2020-10-25T11:00:00Z","last_error":"webhook answered with the status code 500"}]}`,
+			wantHTTPStatusCode:  http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			httpHandler := http.HandlerFunc(NewListFailedWebhookDeliveries(logger, tt.deliveryLister).Handler)
+			req, err := http.NewRequest("GET", "/webhooks/deliveries/failed", nil)
+			if err != nil {
+				t.Error("error to perform GET /webhooks/deliveries/failed request")
+			}
+
+			httpHandler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.wantHTTPStatusCode {
+				t.Errorf("HTTP Status Code is different from expected, got = %v, want %v", rr.Code, tt.wantHTTPStatusCode)
+				return
+			}
+
+			if got := rr.Body.String(); got != tt.wantPayloadResponse {
+				t.Errorf("Payload Response is different from expected, got = %v, want %v", got, tt.wantPayloadResponse)
+			}
+		})
+	}
+}
+
+type fakeFailedWebhookDeliveryLister struct {
+	deliveries []*domain.WebhookDelivery
+	err        error
+}
+
+func newFakeFailedWebhookDeliveryLister(deliveries []*domain.WebhookDelivery, err error) *fakeFailedWebhookDeliveryLister {
+	return &fakeFailedWebhookDeliveryLister{deliveries: deliveries, err: err}
+}
+
+func (f fakeFailedWebhookDeliveryLister) List(context.Context) ([]*domain.WebhookDelivery, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return f.deliveries, nil
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+)
+
+// InvoiceLister defines the behaviour about how to list the invoices of an account
+type InvoiceLister interface {
+	List(context.Context, *domain.ID) ([]*domain.Invoice, error)
+}
+
+// ListInvoices contains the dependencies to list the invoices of an account
+type ListInvoices struct {
+	logger        domain.Logger
+	invoiceLister InvoiceLister
+}
+
+// NewListInvoices creates a new ListInvoices struct
+func NewListInvoices(logger domain.Logger, invoiceLister InvoiceLister) *ListInvoices {
+	return &ListInvoices{logger: logger, invoiceLister: invoiceLister}
+}
+
+// Handler exposes the http handler
+func (l ListInvoices) Handler(rw http.ResponseWriter, req *http.Request) {
+	responder := newResponder(rw)
+
+	idParam, err := l.extractParamGetID(req)
+	if err != nil {
+		l.logger.Info(req.Context(), "invalid account id", domain.NewErrLogField(err))
+
+		errResponse := newErrorResponse(map[string]string{"id": err.Error()})
+		responder.badRequest(errResponse.Encode())
+		return
+	}
+
+	invoices, err := l.invoiceLister.List(req.Context(), domain.NewID(idParam))
+	if err != nil {
+		if _, ok := err.(*repository.ErrRegisterNotFound); ok {
+			l.logger.Info(req.Context(), "account not found", domain.NewErrLogField(err))
+			errResponse := newErrorResponse(map[string]string{"id": fmt.Sprintf("%d not found", idParam)})
+			responder.notFound(errResponse.Encode())
+			return
+		}
+
+		l.logger.Error(req.Context(), "unable to list the invoices", domain.NewErrLogField(err))
+		responder.internalServerError()
+		return
+	}
+
+	responder.ok(newInvoiceListResponse(invoices).Encode())
+}
+
+func (l ListInvoices) extractParamGetID(req *http.Request) (uint64, error) {
+	const position = 2
+
+	return extractParamID(req, position)
+}
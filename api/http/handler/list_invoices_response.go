@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"encoding/json"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+type invoiceListResponse struct {
+	Invoices []invoiceResponse `json:"invoices"`
+}
+
+// newInvoiceListResponse describes the invoices without their items, which are described by the invoice itself
+func newInvoiceListResponse(invoices []*domain.Invoice) invoiceListResponse {
+	response := invoiceListResponse{Invoices: make([]invoiceResponse, 0, len(invoices))}
+
+	for _, v := range invoices {
+		response.Invoices = append(response.Invoices, newInvoiceResponse(v))
+	}
+
+	return response
+}
+
+func (c invoiceListResponse) Encode() []byte {
+	res, _ := json.Marshal(c)
+
+	return res
+}
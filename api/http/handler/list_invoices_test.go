@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+)
+
+func TestListInvoices_Handler(t *testing.T) {
+	var (
+		logger   = domain.NewLoggerMock()
+		from     = time.Date(2020, 9, 26, 0, 0, 0, 0, time.UTC)
+		to       = time.Date(2020, 10, 25, 23, 59, 59, 0, time.UTC)
+		dueDate  = time.Date(2020, 11, 5, 0, 0, 0, 0, time.UTC)
+		previous = domain.NewMoney(-2000, domain.CurrencyBRL)
+		invoices = []*domain.Invoice{
+			domain.LoadInvoice(domain.NewID(10), domain.NewID(1), from, to, dueDate, previous, nil, time.Time{}),
+		}
+	)
+
+	type fields struct {
+		invoiceLister InvoiceLister
+	}
+	type args struct {
+		id string
+	}
+	tests := []struct {
+		name                string
+		fields              fields
+		args                args
+		wantPayloadResponse string
+		wantHTTPStatusCode  int
+	}{
+		// fails
+		{
+			name: "bad request when the id is zero",
+			fields: fields{
+				invoiceLister: newFakeInvoiceLister(nil, nil),
+			},
+			args: args{
+				id: "0",
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"id","description":"id must be greater than zero"}\]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name: "account not found when the id is not in the storage",
+			fields: fields{
+				invoiceLister: newFakeInvoiceLister(nil, repository.NewErrRegisterNotFound("id", "1")),
+			},
+			args: args{
+				id: "1",
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"id","description":"1 not found"}\]}`,
+			wantHTTPStatusCode:  http.StatusNotFound,
+		},
+		{
+			name: "internal server error when the usecase returns an unknown error",
+			fields: fields{
+				invoiceLister: newFakeInvoiceLister(nil, errors.New("some error")),
+			},
+			args: args{
+				id: "1",
+			},
+			wantPayloadResponse: ``,
+			wantHTTPStatusCode:  http.StatusInternalServerError,
+		},
+
+		// successes
+		{
+			name: "account without invoices",
+			fields: fields{
+				invoiceLister: newFakeInvoiceLister(nil, nil),
+			},
+			args: args{
+				id: "1",
+			},
+			wantPayloadResponse: `^{"invoices":\[\]}$`,
+			wantHTTPStatusCode:  http.StatusOK,
+		},
+		{
+			name: "invoices listed successfully with a credit balance",
+			fields: fields{
+				invoiceLister: newFakeInvoiceLister(invoices, nil),
+			},
+			args: args{
+				id: "1",
+			},
+			wantPayloadResponse: `^{"invoices":\[{"id":10,"account_id":1,"period_start":"2020-09-26T00:00:00Z","period_end":"2020-10-25T23:59:59Z","due_date":"2020-11-05","currency":"BRL","previous_balance":-20.00,"total":-20.00,"minimum_payment":0.00}\]}$`,
+			wantHTTPStatusCode:  http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			httpHandler := http.HandlerFunc(NewListInvoices(logger, tt.fields.invoiceLister).Handler)
+			req, err := http.NewRequest("GET", fmt.Sprintf("/accounts/%s/invoices", tt.args.id), nil)
+			if err != nil {
+				t.Errorf("error to perform GET /accounts/%s/invoices request", tt.args.id)
+			}
+
+			httpHandler.ServeHTTP(rr, req)
+
+			var (
+				gotHTTPStatusCode = rr.Code
+				gotPayload        = rr.Body.String()
+			)
+
+			if gotHTTPStatusCode != tt.wantHTTPStatusCode {
+				t.Errorf("HTTP Status Code is different from expected, got = %v, want %v", gotHTTPStatusCode, tt.wantHTTPStatusCode)
+				return
+			}
+
+			if !regexp.MustCompile(tt.wantPayloadResponse).MatchString(gotPayload) {
+				t.Errorf("Payload Response is different from expected, got = %v, want %v", gotPayload, tt.wantPayloadResponse)
+				return
+			}
+		})
+	}
+}
+
+type fakeInvoiceLister struct {
+	invoices []*domain.Invoice
+	err      error
+}
+
+func newFakeInvoiceLister(invoices []*domain.Invoice, err error) *fakeInvoiceLister {
+	return &fakeInvoiceLister{invoices: invoices, err: err}
+}
+
+func (f fakeInvoiceLister) List(context.Context, *domain.ID) ([]*domain.Invoice, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return f.invoices, nil
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// OperationLister defines the behaviour about how to list the operations
+type OperationLister interface {
+	List(context.Context) ([]*domain.Operation, error)
+}
+
+// ListOperations contains the dependencies to list the operations
+type ListOperations struct {
+	logger          domain.Logger
+	operationLister OperationLister
+}
+
+// NewListOperations creates a new ListOperations struct
+func NewListOperations(logger domain.Logger, operationLister OperationLister) *ListOperations {
+	return &ListOperations{logger: logger, operationLister: operationLister}
+}
+
+// Handler exposes the http handler
+func (l ListOperations) Handler(rw http.ResponseWriter, req *http.Request) {
+	responder := newResponder(rw)
+
+	operations, err := l.operationLister.List(req.Context())
+	if err != nil {
+		l.logger.Error(req.Context(), "unable to list the operations", domain.NewErrLogField(err))
+		responder.internalServerError()
+		return
+	}
+
+	responder.ok(newOperationListResponse(operations).Encode())
+}
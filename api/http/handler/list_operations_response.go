@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"encoding/json"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+type operationTypeResponse struct {
+	ID          uint64 `json:"id"`
+	Description string `json:"type"`
+	Direction   string `json:"direction"`
+	Kind        string `json:"kind"`
+	Enabled     bool   `json:"enabled"`
+}
+
+func newOperationTypeResponse(operation *domain.Operation) operationTypeResponse {
+	return operationTypeResponse{
+		ID:          operation.ID().Value(),
+		Description: operation.Description(),
+		Direction:   operation.Direction().String(),
+		Kind:        operation.Kind().String(),
+		Enabled:     operation.IsEnabled(),
+	}
+}
+
+func (c operationTypeResponse) Encode() []byte {
+	res, _ := json.Marshal(c)
+
+	return res
+}
+
+type operationListResponse struct {
+	Operations []operationTypeResponse `json:"operations"`
+}
+
+func newOperationListResponse(operations []*domain.Operation) operationListResponse {
+	response := operationListResponse{Operations: make([]operationTypeResponse, 0, len(operations))}
+
+	for _, o := range operations {
+		response.Operations = append(response.Operations, newOperationTypeResponse(o))
+	}
+
+	return response
+}
+
+func (c operationListResponse) Encode() []byte {
+	res, _ := json.Marshal(c)
+
+	return res
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+func TestListOperations_Handler(t *testing.T) {
+	var logger = domain.NewLoggerMock()
+
+	fee, _ := domain.NewOperationType("tarifa", domain.OperationDirectionDebit, domain.OperationKindStandard)
+	fee = fee.WithID(domain.NewID(8)).WithEnabled(false)
+
+	tests := []struct {
+		name                string
+		operationLister     OperationLister
+		wantPayloadResponse string
+		wantHTTPStatusCode  int
+	}{
+		// fails
+		{
+			name:                "internal server error when returns an unknown error",
+			operationLister:     newFakeOperationLister(nil, errors.New("unknown error")),
+			wantPayloadResponse: ``,
+			wantHTTPStatusCode:  http.StatusInternalServerError,
+		},
+
+		// successes
+		{
+			name:                "empty list",
+			operationLister:     newFakeOperationLister(nil, nil),
+			wantPayloadResponse: `{"operations":[]}`,
+			wantHTTPStatusCode:  http.StatusOK,
+		},
+		{
+			name:                "operations listed successfully",
+			operationLister:     newFakeOperationLister([]*domain.Operation{domain.OperationCompraAVista, domain.OperationPagamento, fee}, nil),
+			wantPayloadResponse: `{"operations":[{"id":1,"type":"COMPRA A VISTA","direction":"DEBIT","kind":"STANDARD","enabled":true},{"id":4,"type":"PAGAMENTO","direction":"CREDIT","kind":"PAYMENT","enabled":true},{"id":8,"type":"TARIFA","direction":"DEBIT","kind":"STANDARD","enabled":false}]}`,
+			wantHTTPStatusCode:  http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			httpHandler := http.HandlerFunc(NewListOperations(logger, tt.operationLister).Handler)
+			req, err := http.NewRequest("GET", "/operations", nil)
+			if err != nil {
+				t.Error("error to perform GET /operations request")
+			}
+
+			httpHandler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.wantHTTPStatusCode {
+				t.Errorf("HTTP Status Code is different from expected, got = %v, want %v", rr.Code, tt.wantHTTPStatusCode)
+				return
+			}
+
+			if got := rr.Body.String(); got != tt.wantPayloadResponse {
+				t.Errorf("Payload Response is different from expected, got = %v, want %v", got, tt.wantPayloadResponse)
+			}
+		})
+	}
+}
+
+type fakeOperationLister struct {
+	operations []*domain.Operation
+	err        error
+}
+
+func newFakeOperationLister(operations []*domain.Operation, err error) *fakeOperationLister {
+	return &fakeOperationLister{operations: operations, err: err}
+}
+
+func (f fakeOperationLister) List(context.Context) ([]*domain.Operation, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return f.operations, nil
+}
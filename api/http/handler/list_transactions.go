@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+)
+
+// TransactionLister defines the behaviour about how to list the transactions of an account
+type TransactionLister interface {
+	List(context.Context, *domain.TransactionFilter) (*domain.TransactionPage, error)
+}
+
+// ListTransactions contains the dependencies to list the transactions of an account
+type ListTransactions struct {
+	logger            domain.Logger
+	transactionLister TransactionLister
+}
+
+// NewListTransactions creates a new ListTransactions struct
+func NewListTransactions(logger domain.Logger, transactionLister TransactionLister) *ListTransactions {
+	return &ListTransactions{logger: logger, transactionLister: transactionLister}
+}
+
+// Handler exposes the http handler
+func (l ListTransactions) Handler(rw http.ResponseWriter, req *http.Request) {
+	const idPosition = 2
+
+	responder := newResponder(rw)
+
+	idParam, err := extractParamID(req, idPosition)
+	if err != nil {
+		l.logger.Info(req.Context(), "invalid account id", domain.NewErrLogField(err))
+
+		errResponse := newErrorResponse(map[string]string{"id": err.Error()})
+		responder.badRequest(errResponse.Encode())
+		return
+	}
+
+	filter, errs := l.buildFilter(domain.NewID(idParam), req.URL.Query())
+	if errs != nil {
+		l.logger.Info(req.Context(), "list transactions parameters don't match with the specifications", domain.NewLogField("errors", errs))
+
+		errResponse := newErrorResponse(errs)
+		responder.badRequest(errResponse.Encode())
+		return
+	}
+
+	page, err := l.transactionLister.List(req.Context(), filter)
+	if err != nil {
+		if _, ok := err.(*repository.ErrRegisterNotFound); ok {
+			l.logger.Info(req.Context(), "account not found", domain.NewErrLogField(err))
+			errResponse := newErrorResponse(map[string]string{"id": fmt.Sprintf("%d not found", idParam)})
+			responder.notFound(errResponse.Encode())
+			return
+		}
+
+		if _, ok := err.(*domain.ErrDomain); ok {
+			l.logger.Info(req.Context(), "list transactions parameters don't match with the specifications", domain.NewErrLogField(err))
+			errResponse := newErrorResponse(domainErrorToMap(err))
+			responder.badRequest(errResponse.Encode())
+			return
+		}
+
+		l.logger.Error(req.Context(), "unknown error", domain.NewErrLogField(err))
+		responder.internalServerError()
+		return
+	}
+
+	response := newTransactionListResponse(page)
+
+	responder.ok(response.Encode())
+}
+
+// buildFilter translates the query parameters to a transaction filter, returning the errors by parameter
+func (l ListTransactions) buildFilter(accountID *domain.ID, query url.Values) (*domain.TransactionFilter, map[string]string) {
+	var errs = make(map[string]string)
+
+	limit, err := parseOptionalInt(query.Get("limit"))
+	if err != nil {
+		errs["limit"] = "limit must be a valid number"
+	}
+
+	operations, err := parseIDList(query.Get("operation_id"))
+	if err != nil {
+		errs["operation_id"] = "operation_id must be a comma separated list of valid ids"
+	}
+
+	from, err := parseOptionalDateTime(query.Get("from"), false)
+	if err != nil {
+		errs["from"] = "from " + err.Error()
+	}
+
+	to, err := parseOptionalDateTime(query.Get("to"), true)
+	if err != nil {
+		errs["to"] = "to " + err.Error()
+	}
+
+	minAmount, err := parseOptionalMoney(query.Get("min_amount"))
+	if err != nil {
+		errs["min_amount"] = "min_amount " + err.Error()
+	}
+
+	maxAmount, err := parseOptionalMoney(query.Get("max_amount"))
+	if err != nil {
+		errs["max_amount"] = "max_amount " + err.Error()
+	}
+
+	var cursor *domain.Cursor
+	if v := query.Get("cursor"); v != "" {
+		if cursor, err = domain.DecodeCursor(v); err != nil {
+			errs["cursor"] = err.Error()
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	filter, err := domain.NewTransactionFilter(accountID, limit)
+	if err != nil {
+		return nil, domainErrorToMap(err)
+	}
+
+	if len(operations) > 0 {
+		filter = filter.WithOperations(operations...)
+	}
+
+	if filter, err = filter.WithPeriod(from, to); err != nil {
+		return nil, domainErrorToMap(err)
+	}
+
+	if filter, err = filter.WithAmountRange(minAmount, maxAmount); err != nil {
+		return nil, domainErrorToMap(err)
+	}
+
+	return filter.WithCursor(cursor), nil
+}
+
+func domainErrorToMap(err error) map[string]string {
+	if v, ok := err.(*domain.ErrDomain); ok {
+		return map[string]string{v.Field(): v.Error()}
+	}
+
+	return map[string]string{"root": err.Error()}
+}
+
+func parseOptionalInt(v string) (int, error) {
+	if v == "" {
+		return 0, nil
+	}
+
+	return strconv.Atoi(v)
+}
+
+func parseOptionalMoney(v string) (*domain.Money, error) {
+	if v == "" {
+		return nil, nil
+	}
+
+	amount, err := parseMoney(v, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &amount, nil
+}
+
+func parseOptionalDateTime(v string, endOfDay bool) (*time.Time, error) {
+	if v == "" {
+		return nil, nil
+	}
+
+	t, err := parseDateTime(v, endOfDay)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+func parseIDList(v string) ([]*domain.ID, error) {
+	if v == "" {
+		return nil, nil
+	}
+
+	var ids []*domain.ID
+
+	for _, part := range strings.Split(v, ",") {
+		id, err := strconv.ParseUint(strings.TrimSpace(part), 10, 64)
+		if err != nil || id == 0 {
+			return nil, fmt.Errorf("'%s' is not a valid id", part)
+		}
+
+		ids = append(ids, domain.NewID(id))
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"encoding/json"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+type pagingResponse struct {
+	Next string `json:"next,omitempty"`
+	Prev string `json:"prev,omitempty"`
+}
+
+type transactionListResponse struct {
+	Transactions []transactionResponse `json:"transactions"`
+	Paging       pagingResponse        `json:"paging"`
+}
+
+func newTransactionListResponse(page *domain.TransactionPage) transactionListResponse {
+	response := transactionListResponse{Transactions: make([]transactionResponse, 0, len(page.Transactions()))}
+
+	for _, t := range page.Transactions() {
+		var (
+			account   = t.Account()
+			operation = t.Operation()
+		)
+
+		response.Transactions = append(response.Transactions, newTransactionResponse(
+			t.ID().Value(),
+			newAccountResponse(account.ID().Value(), "", account.CreatedAt()),
+			newOperationResponse(operation.ID().Value(), operation.Description()),
+			t.Amount(),
+			t.CreatedAt(),
+		).
+			withBalance(t.Balance()).
+			withConversion(t.OriginalAmount(), t.ExchangeRate()).
+			withInstallmentPlan(t.InstallmentPlan()).
+			withTransferID(t.TransferID()).
+			withReversal(t.ReversalOf(), t.ReversedAmount()))
+	}
+
+	if v := page.Next(); v != nil {
+		response.Paging.Next = v.Encode()
+	}
+
+	if v := page.Prev(); v != nil {
+		response.Paging.Prev = v.Encode()
+	}
+
+	return response
+}
+
+func (c transactionListResponse) Encode() []byte {
+	res, _ := json.Marshal(c)
+
+	return res
+}
@@ -0,0 +1,218 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+)
+
+func TestListTransactions_Handler(t *testing.T) {
+	var (
+		logger      = domain.NewLoggerMock()
+		createdAt   = time.Date(2020, 10, 4, 13, 0, 0, 0, time.UTC)
+		filter, _   = domain.NewTransactionFilter(domain.NewID(1), 1)
+		purchase, _ = domain.NewTransaction(domain.NewID(1), domain.OperationCompraAVista, domain.NewMoney(5000, domain.CurrencyBRL))
+		payment, _  = domain.NewTransaction(domain.NewID(1), domain.OperationPagamento, domain.NewMoney(10000, domain.CurrencyBRL))
+		pageOK      = domain.NewTransactionPage([]*domain.Transaction{
+			payment.WithID(domain.NewID(11)).WithCreatedAt(createdAt),
+			purchase.WithID(domain.NewID(10)).WithCreatedAt(createdAt),
+		}, filter)
+		emptyPage  = domain.NewTransactionPage(nil, filter)
+		nextCursor = domain.NewCursor(domain.NewID(11), domain.CursorNext).Encode()
+	)
+
+	type fields struct {
+		transactionLister TransactionLister
+	}
+
+	type args struct {
+		path string
+	}
+
+	tests := []struct {
+		name                string
+		fields              fields
+		args                args
+		wantPayloadResponse string
+		wantHTTPStatusCode  int
+	}{
+		// fails
+		{
+			name: "bad request when the id is not a number",
+			fields: fields{
+				transactionLister: newFakeTransactionLister(nil, nil),
+			},
+			args: args{
+				path: "/accounts/x/transactions",
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"id","description":"id must be a valid number"}\]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name: "bad request when the limit is too big",
+			fields: fields{
+				transactionLister: newFakeTransactionLister(nil, nil),
+			},
+			args: args{
+				path: "/accounts/1/transactions?limit=1000",
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"limit","description":"limit must be between 1 and 100"}\]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name: "bad request when the operation is invalid",
+			fields: fields{
+				transactionLister: newFakeTransactionLister(nil, domain.NewErrDomain("operation", "'99' is not a valid operation id")),
+			},
+			args: args{
+				path: "/accounts/1/transactions?operation_id=1,99",
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"operation","description":"operation '99' is not a valid operation id"}\]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name: "bad request when the operation is not a number",
+			fields: fields{
+				transactionLister: newFakeTransactionLister(nil, nil),
+			},
+			args: args{
+				path: "/accounts/1/transactions?operation_id=abc",
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"operation_id","description":"operation_id must be a comma separated list of valid ids"}\]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name: "bad request when the period is invalid",
+			fields: fields{
+				transactionLister: newFakeTransactionLister(nil, nil),
+			},
+			args: args{
+				path: "/accounts/1/transactions?from=2020-10-05&to=2020-10-04",
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"from","description":"from must be before to"}\]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name: "bad request when the amount range is invalid",
+			fields: fields{
+				transactionLister: newFakeTransactionLister(nil, nil),
+			},
+			args: args{
+				path: "/accounts/1/transactions?min_amount=x",
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"min_amount","description":"min_amount must be a valid decimal number"}\]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name: "bad request when the cursor is invalid",
+			fields: fields{
+				transactionLister: newFakeTransactionLister(nil, nil),
+			},
+			args: args{
+				path: "/accounts/1/transactions?cursor=abc",
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"cursor","description":"cursor is invalid"}\]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name: "account not found",
+			fields: fields{
+				transactionLister: newFakeTransactionLister(nil, repository.NewErrRegisterNotFound("id", "1")),
+			},
+			args: args{
+				path: "/accounts/1/transactions",
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"id","description":"1 not found"}\]}`,
+			wantHTTPStatusCode:  http.StatusNotFound,
+		},
+		{
+			name: "unknown error from transaction lister",
+			fields: fields{
+				transactionLister: newFakeTransactionLister(nil, errors.New("some error")),
+			},
+			args: args{
+				path: "/accounts/1/transactions",
+			},
+			wantPayloadResponse: ``,
+			wantHTTPStatusCode:  http.StatusInternalServerError,
+		},
+
+		// successes
+		{
+			name: "empty list",
+			fields: fields{
+				transactionLister: newFakeTransactionLister(emptyPage, nil),
+			},
+			args: args{
+				path: "/accounts/1/transactions",
+			},
+			wantPayloadResponse: `^{"transactions":\[\],"paging":{}}$`,
+			wantHTTPStatusCode:  http.StatusOK,
+		},
+		{
+			name: "transactions listed successfully with all filters",
+			fields: fields{
+				transactionLister: newFakeTransactionLister(pageOK, nil),
+			},
+			args: args{
+				path: "/accounts/1/transactions?limit=1&operation_id=1,4&from=2020-10-01&to=2020-10-31T23:59:59Z&min_amount=10&max_amount=100",
+			},
+			wantPayloadResponse: fmt.Sprintf(`^{"transactions":\[{"id":11,"account":{"id":1,"document":{}},"operation":{"id":4,"type":"PAGAMENTO"},"amount":100.00,"currency":"BRL","balance":100.00,"created_at":"2020-10-04T13:00:00Z"}\],"paging":{"next":"%s"}}$`, nextCursor),
+			wantHTTPStatusCode:  http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			httpHandler := http.HandlerFunc(NewListTransactions(logger, tt.fields.transactionLister).Handler)
+			req, err := http.NewRequest("GET", tt.args.path, nil)
+			if err != nil {
+				t.Errorf("error to perform GET %s request", tt.args.path)
+			}
+
+			httpHandler.ServeHTTP(rr, req)
+
+			var (
+				gotHTTPStatusCode = rr.Code
+				gotPayload        = rr.Body.String()
+			)
+
+			if gotHTTPStatusCode != tt.wantHTTPStatusCode {
+				t.Errorf("HTTP Status Code is different from expected, got = %v, want %v", gotHTTPStatusCode, tt.wantHTTPStatusCode)
+				return
+			}
+
+			match := regexp.MustCompile(tt.wantPayloadResponse).MatchString(gotPayload)
+			if !match {
+				t.Errorf("Payload Response is different from expected, got = %v, want %v", gotPayload, tt.wantPayloadResponse)
+				return
+			}
+		})
+	}
+}
+
+type fakeTransactionLister struct {
+	page *domain.TransactionPage
+	err  error
+}
+
+func newFakeTransactionLister(page *domain.TransactionPage, err error) *fakeTransactionLister {
+	return &fakeTransactionLister{page: page, err: err}
+}
+
+func (f fakeTransactionLister) List(context.Context, *domain.TransactionFilter) (*domain.TransactionPage, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return f.page, nil
+}
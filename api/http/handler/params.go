@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// extractParamID extracts a valid id from the informed position of the request path
+func extractParamID(req *http.Request, position int) (uint64, error) {
+	p := strings.Split(req.URL.Path, "/")
+
+	if len(p) < (position + 1) {
+		return 0, errors.New("parameter id not found")
+	}
+
+	id, err := strconv.Atoi(p[position])
+	if err != nil {
+		return 0, errors.New("id must be a valid number")
+	}
+
+	if id <= 0 {
+		return 0, errors.New("id must be greater than zero")
+	}
+
+	return uint64(id), nil
+}
+
+// parseMoney parses a decimal amount, as "100" or "10.50", with at most the minor units of the currency. Amounts
+// without currency are checked against the minor units of their currency once it is known.
+func parseMoney(v string, currency domain.Currency) (domain.Money, error) {
+	amount, err := domain.ParseMoney(v, currency)
+	if err != nil {
+		if e, ok := err.(*domain.ErrDomain); ok {
+			return domain.Money{}, errors.New(e.Description())
+		}
+
+		return domain.Money{}, err
+	}
+
+	return amount, nil
+}
+
+// parseDateTime parses a RFC3339 date time or a date (YYYY-MM-DD), in this case, returns the first or the last
+// second of the day, according to endOfDay
+func parseDateTime(v string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse("2006-01-02", v)
+	if err != nil {
+		return time.Time{}, errors.New("must be a valid RFC3339 date time or a date in the format YYYY-MM-DD")
+	}
+
+	if !endOfDay {
+		return t, nil
+	}
+
+	return t.AddDate(0, 0, 1).Add(-time.Second), nil
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+)
+
+// WebhookDeliveryReplayer defines the behaviour about how to replay a failed webhook delivery
+type WebhookDeliveryReplayer interface {
+	Replay(context.Context, *domain.ID) (*domain.WebhookDelivery, error)
+}
+
+// ReplayWebhookDelivery contains the dependencies to replay a failed webhook delivery
+type ReplayWebhookDelivery struct {
+	logger           domain.Logger
+	deliveryReplayer WebhookDeliveryReplayer
+}
+
+// NewReplayWebhookDelivery creates a new ReplayWebhookDelivery struct with its dependencies
+func NewReplayWebhookDelivery(logger domain.Logger, deliveryReplayer WebhookDeliveryReplayer) *ReplayWebhookDelivery {
+	return &ReplayWebhookDelivery{logger: logger, deliveryReplayer: deliveryReplayer}
+}
+
+// Handler exposes the http handler. Only the deliveries in the dead letters can be replayed.
+func (h ReplayWebhookDelivery) Handler(rw http.ResponseWriter, req *http.Request) {
+	const idPosition = 3
+
+	responder := newResponder(rw)
+
+	idParam, err := extractParamID(req, idPosition)
+	if err != nil {
+		h.logger.Info(req.Context(), "invalid webhook delivery id", domain.NewErrLogField(err))
+
+		errResponse := newErrorResponse(map[string]string{"id": err.Error()})
+		responder.badRequest(errResponse.Encode())
+		return
+	}
+
+	delivery, err := h.deliveryReplayer.Replay(req.Context(), domain.NewID(idParam))
+	if err != nil {
+		h.logger.Warn(req.Context(), "unable to replay webhook delivery", domain.NewErrLogField(err))
+
+		if _, ok := err.(*repository.ErrRegisterNotFound); ok {
+			errResponse := newErrorResponse(map[string]string{"id": fmt.Sprintf("%d not found", idParam)})
+			responder.notFound(errResponse.Encode())
+			return
+		}
+
+		if _, ok := err.(*repository.ErrDuplicateEntry); ok {
+			errResponse := newErrorResponse(map[string]string{"id": fmt.Sprintf("%d is already being delivered", idParam)})
+			responder.conflict(errResponse.Encode())
+			return
+		}
+
+		if v, ok := err.(*domain.ErrDomain); ok {
+			translateDomainError(responder, v)
+			return
+		}
+
+		// unknown error
+		responder.internalServerError()
+		return
+	}
+
+	responder.ok(newWebhookDeliveryResponse(delivery).Encode())
+}
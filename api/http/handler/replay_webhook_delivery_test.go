@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+)
+
+func TestReplayWebhookDelivery_Handler(t *testing.T) {
+	var (
+		logger   = domain.NewLoggerMock()
+		at       = time.Date(2020, 10, 25, 10, 0, 0, 0, time.UTC)
+		webhook  = domain.LoadWebhook(domain.NewID(1), "https://partner.com/events", nil, "4f6b", at)
+		event    = domain.LoadEvent(domain.NewID(7), domain.EventAccountCreated, domain.NewID(1), []byte(`{"id":1}`), at)
+		replayed = domain.LoadWebhookDelivery(domain.NewID(3), webhook, event, domain.WebhookDeliveryPending, 0, at, at, "")
+	)
+
+	tests := []struct {
+		name                string
+		deliveryReplayer    WebhookDeliveryReplayer
+		url                 string
+		wantPayloadResponse string
+		wantHTTPStatusCode  int
+	}{
+		// fails
+		{
+			name:                "bad request when the id is invalid",
+			deliveryReplayer:    newFakeWebhookDeliveryReplayer(nil, nil),
+			url:                 "/webhooks/deliveries/abc/replay",
+			wantPayloadResponse: `{"errors":[{"field":"id","description":"id must be a valid number"}]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name:                "not found when the delivery is not in the dead letters",
+			deliveryReplayer:    newFakeWebhookDeliveryReplayer(nil, repository.NewErrRegisterNotFound("id", "3")),
+			url:                 "/webhooks/deliveries/3/replay",
+			wantPayloadResponse: `{"errors":[{"field":"id","description":"3 not found"}]}`,
+			wantHTTPStatusCode:  http.StatusNotFound,
+		},
+		{
+			name:                "conflict when the event is already being delivered to the webhook",
+			deliveryReplayer:    newFakeWebhookDeliveryReplayer(nil, repository.NewErrDuplicatedEntry("webhook_id_event_id", "1-7")),
+			url:                 "/webhooks/deliveries/3/replay",
+			wantPayloadResponse: `{"errors":[{"field":"id","description":"3 is already being delivered"}]}`,
+			wantHTTPStatusCode:  http.StatusConflict,
+		},
+		{
+			name:                "internal server error when returns an unknown error",
+			deliveryReplayer:    newFakeWebhookDeliveryReplayer(nil, errors.New("unknown error")),
+			url:                 "/webhooks/deliveries/3/replay",
+			wantPayloadResponse: ``,
+			wantHTTPStatusCode:  http.StatusInternalServerError,
+		},
+
+		// successes
+		{
+			name:                "delivery replayed successfully",
+			deliveryReplayer:    newFakeWebhookDeliveryReplayer(replayed, nil),
+			url:                 "/webhooks/deliveries/3/replay",
+			wantPayloadResponse: `{"id":3,"webhook_id":1,"url":"https://partner.com/events","event_id":7,"event_type":"account.created","status":"pending","attempts":0,"next_attempt_at":"2020-10-25T10:00:00Z","last_attempt_at":"2020-10-25T10:00:00Z"}`,
+			wantHTTPStatusCode:  http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			httpHandler := http.HandlerFunc(NewReplayWebhookDelivery(logger, tt.deliveryReplayer).Handler)
+			req, err := http.NewRequest("POST", tt.url, nil)
+			if err != nil {
+				t.Errorf("error to perform POST %s request", tt.url)
+			}
+
+			httpHandler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.wantHTTPStatusCode {
+				t.Errorf("HTTP Status Code is different from expected, got = %v, want %v", rr.Code, tt.wantHTTPStatusCode)
+				return
+			}
+
+			if got := rr.Body.String(); got != tt.wantPayloadResponse {
+				t.Errorf("Payload Response is different from expected, got = %v, want %v", got, tt.wantPayloadResponse)
+			}
+		})
+	}
+}
+
+type fakeWebhookDeliveryReplayer struct {
+	delivery *domain.WebhookDelivery
+	err      error
+}
+
+func newFakeWebhookDeliveryReplayer(delivery *domain.WebhookDelivery, err error) *fakeWebhookDeliveryReplayer {
+	return &fakeWebhookDeliveryReplayer{delivery: delivery, err: err}
+}
+
+func (f fakeWebhookDeliveryReplayer) Replay(context.Context, *domain.ID) (*domain.WebhookDelivery, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return f.delivery, nil
+}
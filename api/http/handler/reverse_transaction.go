@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+)
+
+// TransactionReverser defines the behaviour about how to reverse a transaction
+type TransactionReverser interface {
+	Reverse(context.Context, *domain.ID, *domain.Money) (*domain.Reversal, error)
+}
+
+// ReverseTransaction contains the dependencies to reverse a transaction
+type ReverseTransaction struct {
+	logger              domain.Logger
+	transactionReverser TransactionReverser
+}
+
+// NewReverseTransaction creates a new ReverseTransaction struct with its dependencies
+func NewReverseTransaction(logger domain.Logger, transactionReverser TransactionReverser) *ReverseTransaction {
+	return &ReverseTransaction{logger: logger, transactionReverser: transactionReverser}
+}
+
+// Handler exposes the http handler. The payload is optional, without an amount the whole transaction is reversed.
+func (h ReverseTransaction) Handler(rw http.ResponseWriter, req *http.Request) {
+	const idPosition = 2
+
+	responder := newResponder(rw)
+
+	idParam, err := extractParamID(req, idPosition)
+	if err != nil {
+		h.logger.Info(req.Context(), "invalid transaction id", domain.NewErrLogField(err))
+
+		errResponse := newErrorResponse(map[string]string{"id": err.Error()})
+		responder.badRequest(errResponse.Encode())
+		return
+	}
+
+	payload, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		h.logger.Error(req.Context(), "read payload error", domain.NewErrLogField(err))
+		responder.internalServerError()
+		return
+	}
+	defer req.Body.Close()
+
+	request := reverseTransactionPayloadRequest{}
+
+	if len(payload) > 0 {
+		if err := json.Unmarshal(payload, &request); err != nil {
+			h.logger.Info(req.Context(), "invalid payload", domain.NewErrLogField(err))
+
+			errResponse := newErrorResponse(map[string]string{"root": "invalid payload"})
+			responder.badRequest(errResponse.Encode())
+
+			return
+		}
+	}
+
+	if errs := request.validate(); errs != nil {
+		errResponse := newErrorResponse(errs)
+
+		h.logger.Info(req.Context(), "reverse transaction payload doesn't match with the specifications", domain.NewLogField("errors", errs))
+		responder.badRequest(errResponse.Encode())
+		return
+	}
+
+	reversal, err := h.transactionReverser.Reverse(req.Context(), domain.NewID(idParam), request.amount())
+	if err != nil {
+		h.logger.Warn(req.Context(), "unable to reverse transaction", domain.NewErrLogField(err))
+
+		if _, ok := err.(*repository.ErrRegisterNotFound); ok {
+			errResponse := newErrorResponse(map[string]string{"id": fmt.Sprintf("%d not found", idParam)})
+			responder.notFound(errResponse.Encode())
+			return
+		}
+
+		if v, ok := err.(*domain.ErrDomain); ok {
+			translateDomainError(responder, v)
+			return
+		}
+
+		// unknown error
+		responder.internalServerError()
+		return
+	}
+
+	var (
+		transaction = reversal.Transaction()
+		account     = transaction.Account()
+		operation   = transaction.Operation()
+	)
+
+	response := newTransactionResponse(
+		transaction.ID().Value(),
+		newAccountResponse(account.ID().Value(), "", account.CreatedAt()),
+		newOperationResponse(operation.ID().Value(), operation.Description()),
+		transaction.Amount(),
+		transaction.CreatedAt(),
+	).
+		withBalance(transaction.Balance()).
+		withReversal(transaction.ReversalOf(), transaction.ReversedAmount())
+
+	responder.created(response.Encode())
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"encoding/json"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+type reverseTransactionPayloadRequest struct {
+	Amount json.Number `json:"amount"`
+}
+
+// validate returns a map where the key is the field and the value the error description
+func (c *reverseTransactionPayloadRequest) validate() map[string]string {
+	errs := map[string]string{}
+
+	if c.Amount != "" {
+		if amount, err := parseMoney(c.Amount.String(), ""); err != nil {
+			errs["amount"] = "amount " + err.Error()
+		} else if !amount.IsPositive() {
+			errs["amount"] = "amount must be greater than 0"
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// amount returns the exact amount to reverse, in the transaction currency, or nil to reverse the whole amount not
+// reversed yet. It must be called only after a successful validation.
+func (c *reverseTransactionPayloadRequest) amount() *domain.Money {
+	if c.Amount == "" {
+		return nil
+	}
+
+	amount, _ := parseMoney(c.Amount.String(), "")
+
+	return &amount
+}
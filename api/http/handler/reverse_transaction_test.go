@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+)
+
+func TestReverseTransaction_Handler(t *testing.T) {
+	var (
+		logger        = domain.NewLoggerMock()
+		reversedError = domain.NewErrDomain("transaction", "has already been fully reversed")
+		exceededError = domain.NewErrDomain("amount", "'150.00' exceeds the amount available to reverse '100.00'")
+		datetimeRegex = `[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}:[0-9]{2}Z`
+		account       = new(domain.Account).WithID(domain.NewID(1)).WithCurrency(domain.CurrencyBRL)
+		purchase, _   = domain.NewTransaction(account.ID(), domain.OperationCompraAVista, domain.NewMoney(10000, domain.CurrencyBRL))
+		partialAmount = domain.NewMoney(400000, "")
+		partial, _    = domain.NewReversal(domain.NewID(10), &partialAmount)
+		partialOK, _  = partial.Apply(purchase.WithID(domain.NewID(10)), account)
+	)
+
+	type fields struct {
+		transactionReverser TransactionReverser
+	}
+	type args struct {
+		path    string
+		payload io.Reader
+	}
+	tests := []struct {
+		name                string
+		fields              fields
+		args                args
+		wantPayloadResponse string
+		wantHTTPStatusCode  int
+	}{
+		// fails
+		{
+			name: "bad request when the transaction id is invalid",
+			fields: fields{
+				transactionReverser: newFakeTransactionReverser(nil, nil),
+			},
+			args: args{
+				path:    "/transactions/abc/reversal",
+				payload: bytes.NewReader([]byte("")),
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"id","description":"id must be a valid number"}\]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name: "internal server error when the payload is corrupted",
+			fields: fields{
+				transactionReverser: newFakeTransactionReverser(nil, nil),
+			},
+			args: args{
+				path:    "/transactions/10/reversal",
+				payload: &errReader{},
+			},
+			wantPayloadResponse: ``,
+			wantHTTPStatusCode:  http.StatusInternalServerError,
+		},
+		{
+			name: "bad request when the payload is invalid",
+			fields: fields{
+				transactionReverser: newFakeTransactionReverser(nil, nil),
+			},
+			args: args{
+				path:    "/transactions/10/reversal",
+				payload: bytes.NewReader([]byte("{")),
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"root","description":"invalid payload"}\]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name: "bad request when the amount is not positive",
+			fields: fields{
+				transactionReverser: newFakeTransactionReverser(nil, nil),
+			},
+			args: args{
+				path:    "/transactions/10/reversal",
+				payload: bytes.NewReader([]byte(`{"amount": 0}`)),
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"amount","description":"amount must be greater than 0"}\]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name: "not found when the transaction does not exist",
+			fields: fields{
+				transactionReverser: newFakeTransactionReverser(nil, repository.NewErrRegisterNotFound("id", "10")),
+			},
+			args: args{
+				path:    "/transactions/10/reversal",
+				payload: bytes.NewReader([]byte("")),
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"id","description":"10 not found"}\]}`,
+			wantHTTPStatusCode:  http.StatusNotFound,
+		},
+		{
+			name: "unprocessable entity when the transaction has already been fully reversed",
+			fields: fields{
+				transactionReverser: newFakeTransactionReverser(nil, reversedError),
+			},
+			args: args{
+				path:    "/transactions/10/reversal",
+				payload: bytes.NewReader([]byte("")),
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"transaction","description":"transaction has already been fully reversed"}\]}`,
+			wantHTTPStatusCode:  http.StatusUnprocessableEntity,
+		},
+		{
+			name: "unprocessable entity when the amount exceeds the amount available to reverse",
+			fields: fields{
+				transactionReverser: newFakeTransactionReverser(nil, exceededError),
+			},
+			args: args{
+				path:    "/transactions/10/reversal",
+				payload: bytes.NewReader([]byte(`{"amount": 150.00}`)),
+			},
+			wantPayloadResponse: `{"errors":\[{"field":"amount","description":"amount '150.00' exceeds the amount available to reverse '100.00'"}\]}`,
+			wantHTTPStatusCode:  http.StatusUnprocessableEntity,
+		},
+		{
+			name: "internal server error when returns an unknown error",
+			fields: fields{
+				transactionReverser: newFakeTransactionReverser(nil, errors.New("unknown error")),
+			},
+			args: args{
+				path:    "/transactions/10/reversal",
+				payload: bytes.NewReader([]byte("")),
+			},
+			wantPayloadResponse: ``,
+			wantHTTPStatusCode:  http.StatusInternalServerError,
+		},
+
+		// successes
+		{
+			name: "transaction partially reversed",
+			fields: fields{
+				transactionReverser: newFakeTransactionReverser(partialOK.WithID(domain.NewID(20)), nil),
+			},
+			args: args{
+				path:    "/transactions/10/reversal",
+				payload: bytes.NewReader([]byte(`{"amount": 40.00}`)),
+			},
+			wantPayloadResponse: fmt.Sprintf(`{"id":20,"account":{"id":1,"document":{}},"operation":{"id":7,"type":"ESTORNO"},"amount":40.00,"currency":"BRL","balance":0.00,"reversal_of":10,"created_at":"%s"}`, datetimeRegex),
+			wantHTTPStatusCode:  http.StatusCreated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			httpHandler := http.HandlerFunc(NewReverseTransaction(logger, tt.fields.transactionReverser).Handler)
+			req, err := http.NewRequest("POST", tt.args.path, tt.args.payload)
+			if err != nil {
+				t.Error("error to perform POST /transactions/:id/reversal request")
+			}
+
+			httpHandler.ServeHTTP(rr, req)
+
+			var (
+				gotHTTPStatusCode = rr.Code
+				gotPayload        = rr.Body.String()
+			)
+
+			if gotHTTPStatusCode != tt.wantHTTPStatusCode {
+				t.Errorf("HTTP Status Code is different from expected, got = %v, want %v", gotHTTPStatusCode, tt.wantHTTPStatusCode)
+				return
+			}
+
+			match := regexp.MustCompile(tt.wantPayloadResponse).MatchString(gotPayload)
+			if !match {
+				t.Errorf("Payload Response is different from expected, got = %v, want %v", gotPayload, tt.wantPayloadResponse)
+				return
+			}
+		})
+	}
+}
+
+type fakeTransactionReverser struct {
+	reversal *domain.Reversal
+	err      error
+}
+
+func newFakeTransactionReverser(reversal *domain.Reversal, err error) *fakeTransactionReverser {
+	return &fakeTransactionReverser{reversal: reversal, err: err}
+}
+
+func (f fakeTransactionReverser) Reverse(context.Context, *domain.ID, *domain.Money) (*domain.Reversal, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return f.reversal, nil
+}
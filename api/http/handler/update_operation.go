@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+)
+
+// OperationUpdater defines the behaviour about how to enable or disable an operation
+type OperationUpdater interface {
+	Update(context.Context, *domain.ID, bool) (*domain.Operation, error)
+}
+
+// UpdateOperation contains the dependencies to enable or disable an operation
+type UpdateOperation struct {
+	logger           domain.Logger
+	operationUpdater OperationUpdater
+}
+
+// NewUpdateOperation creates a new UpdateOperation struct with its dependencies
+func NewUpdateOperation(logger domain.Logger, operationUpdater OperationUpdater) *UpdateOperation {
+	return &UpdateOperation{logger: logger, operationUpdater: operationUpdater}
+}
+
+// Handler exposes the http handler
+func (h UpdateOperation) Handler(rw http.ResponseWriter, req *http.Request) {
+	const idPosition = 3
+
+	responder := newResponder(rw)
+
+	idParam, err := extractParamID(req, idPosition)
+	if err != nil {
+		h.logger.Info(req.Context(), "invalid operation id", domain.NewErrLogField(err))
+
+		errResponse := newErrorResponse(map[string]string{"id": err.Error()})
+		responder.badRequest(errResponse.Encode())
+		return
+	}
+
+	payload, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		h.logger.Error(req.Context(), "read payload error", domain.NewErrLogField(err))
+		responder.internalServerError()
+		return
+	}
+	defer req.Body.Close()
+
+	request := updateOperationPayloadRequest{}
+
+	if err := json.Unmarshal(payload, &request); err != nil {
+		h.logger.Info(req.Context(), "invalid payload", domain.NewErrLogField(err))
+
+		errResponse := newErrorResponse(map[string]string{"root": "invalid payload"})
+		responder.badRequest(errResponse.Encode())
+
+		return
+	}
+
+	if errs := request.validate(); errs != nil {
+		errResponse := newErrorResponse(errs)
+
+		h.logger.Info(req.Context(), "update operation payload doesn't match with the specifications", domain.NewLogField("errors", errs))
+		responder.badRequest(errResponse.Encode())
+		return
+	}
+
+	operation, err := h.operationUpdater.Update(req.Context(), domain.NewID(idParam), *request.Enabled)
+	if err != nil {
+		h.logger.Warn(req.Context(), "unable to update the operation", domain.NewErrLogField(err))
+
+		if _, ok := err.(*repository.ErrRegisterNotFound); ok {
+			errResponse := newErrorResponse(map[string]string{"id": fmt.Sprintf("%d not found", idParam)})
+			responder.notFound(errResponse.Encode())
+			return
+		}
+
+		if v, ok := err.(*domain.ErrDomain); ok {
+			translateDomainError(responder, v)
+			return
+		}
+
+		// unknown error
+		responder.internalServerError()
+		return
+	}
+
+	responder.ok(newOperationTypeResponse(operation).Encode())
+}
@@ -0,0 +1,17 @@
+package handler
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+type updateOperationPayloadRequest struct {
+	Enabled *bool `json:"enabled" validate:"required"`
+}
+
+func (c *updateOperationPayloadRequest) validate() map[string]string {
+	if err := validate.Struct(c); err != nil {
+		return translateValidations(err.(validator.ValidationErrors))
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+)
+
+func TestUpdateOperation_Handler(t *testing.T) {
+	var logger = domain.NewLoggerMock()
+
+	disabled, _ := domain.OperationSaque.Disable()
+
+	tests := []struct {
+		name                string
+		operationUpdater    OperationUpdater
+		path                string
+		payload             io.Reader
+		wantPayloadResponse string
+		wantHTTPStatusCode  int
+	}{
+		// fails
+		{
+			name:                "bad request when the operation id is invalid",
+			operationUpdater:    newFakeOperationUpdater(nil, nil),
+			path:                "/admin/operations/abc",
+			payload:             bytes.NewReader([]byte(`{"enabled": false}`)),
+			wantPayloadResponse: `{"errors":[{"field":"id","description":"id must be a valid number"}]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name:                "bad request when the enabled flag is not informed",
+			operationUpdater:    newFakeOperationUpdater(nil, nil),
+			path:                "/admin/operations/3",
+			payload:             bytes.NewReader([]byte(`{}`)),
+			wantPayloadResponse: `{"errors":[{"field":"enabled","description":"enabled is a required field"}]}`,
+			wantHTTPStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name:                "not found when the operation does not exist",
+			operationUpdater:    newFakeOperationUpdater(nil, repository.NewErrRegisterNotFound("id", "30")),
+			path:                "/admin/operations/30",
+			payload:             bytes.NewReader([]byte(`{"enabled": false}`)),
+			wantPayloadResponse: `{"errors":[{"field":"id","description":"30 not found"}]}`,
+			wantHTTPStatusCode:  http.StatusNotFound,
+		},
+		{
+			name:                "unprocessable entity when the operation is reserved",
+			operationUpdater:    newFakeOperationUpdater(nil, domain.NewErrDomain("operation", "'7' is reserved and cannot be disabled")),
+			path:                "/admin/operations/7",
+			payload:             bytes.NewReader([]byte(`{"enabled": false}`)),
+			wantPayloadResponse: `{"errors":[{"field":"operation","description":"operation '7' is reserved and cannot be disabled"}]}`,
+			wantHTTPStatusCode:  http.StatusUnprocessableEntity,
+		},
+		{
+			name:                "internal server error when returns an unknown error",
+			operationUpdater:    newFakeOperationUpdater(nil, errors.New("unknown error")),
+			path:                "/admin/operations/3",
+			payload:             bytes.NewReader([]byte(`{"enabled": false}`)),
+			wantPayloadResponse: ``,
+			wantHTTPStatusCode:  http.StatusInternalServerError,
+		},
+
+		// successes
+		{
+			name:                "operation disabled successfully",
+			operationUpdater:    newFakeOperationUpdater(disabled, nil),
+			path:                "/admin/operations/3",
+			payload:             bytes.NewReader([]byte(`{"enabled": false}`)),
+			wantPayloadResponse: `{"id":3,"type":"SAQUE","direction":"DEBIT","kind":"STANDARD","enabled":false}`,
+			wantHTTPStatusCode:  http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			httpHandler := http.HandlerFunc(NewUpdateOperation(logger, tt.operationUpdater).Handler)
+			req, err := http.NewRequest("PATCH", tt.path, tt.payload)
+			if err != nil {
+				t.Error("error to perform PATCH /admin/operations/:id request")
+			}
+
+			httpHandler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.wantHTTPStatusCode {
+				t.Errorf("HTTP Status Code is different from expected, got = %v, want %v", rr.Code, tt.wantHTTPStatusCode)
+				return
+			}
+
+			if got := rr.Body.String(); got != tt.wantPayloadResponse {
+				t.Errorf("Payload Response is different from expected, got = %v, want %v", got, tt.wantPayloadResponse)
+			}
+		})
+	}
+}
+
+type fakeOperationUpdater struct {
+	operation *domain.Operation
+	err       error
+}
+
+func newFakeOperationUpdater(operation *domain.Operation, err error) *fakeOperationUpdater {
+	return &fakeOperationUpdater{operation: operation, err: err}
+}
+
+func (f fakeOperationUpdater) Update(context.Context, *domain.ID, bool) (*domain.Operation, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return f.operation, nil
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+const bearerPrefix = "Bearer "
+
+// Admin restricts the administrative endpoints to the requests authorized by the admin token, informed through the
+// Authorization header as "Bearer <token>". When there is no admin token configured, all the requests are forbidden.
+type Admin struct {
+	log   domain.Logger
+	token string
+}
+
+// NewAdmin builds a new Admin struct
+func NewAdmin(log domain.Logger, token string) *Admin {
+	return &Admin{log: log, token: token}
+}
+
+// Handler exports Admin as an http middleware
+func (a Admin) Handler(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	if a.token == "" {
+		a.log.Warn(r.Context(), "admin endpoints are disabled, there is no admin token configured")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	authorization := r.Header.Get("Authorization")
+
+	if !strings.HasPrefix(authorization, bearerPrefix) ||
+		subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(authorization, bearerPrefix)), []byte(a.token)) != 1 {
+		a.log.Warn(r.Context(), "unauthorized admin request")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	next(w, r)
+}
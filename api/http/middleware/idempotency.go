@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotencyController defines the behaviour about how to control the requests identified by idempotency keys
+type IdempotencyController interface {
+	Start(context.Context, string, string) (*domain.IdempotencyKey, bool, error)
+	Finish(context.Context, *domain.IdempotencyKey, int, []byte) error
+	Release(context.Context, *domain.IdempotencyKey) error
+}
+
+// Idempotency assures that requests with the same Idempotency-Key header are processed only once, replaying
+// the stored response to the retries
+type Idempotency struct {
+	log        domain.Logger
+	controller IdempotencyController
+}
+
+// NewIdempotency builds a new Idempotency struct
+func NewIdempotency(log domain.Logger, controller IdempotencyController) *Idempotency {
+	return &Idempotency{log: log, controller: controller}
+}
+
+// Handler exports Idempotency as an http middleware
+func (i Idempotency) Handler(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	key := r.Header.Get(idempotencyKeyHeader)
+	if key == "" {
+		next(w, r)
+		return
+	}
+
+	// the whole payload and response are kept, as they are stored with the key
+	payload, _, err := getPayload(r, -1)
+	if err != nil {
+		i.log.Error(r.Context(), "read payload error", domain.NewErrLogField(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	idempotencyKey, replay, err := i.controller.Start(r.Context(), key, fingerprint(r, string(payload)))
+	if err != nil {
+		i.translateError(r.Context(), w, err)
+		return
+	}
+
+	if replay {
+		i.log.Info(r.Context(), "replaying the response of the idempotency key", domain.NewLogField("idempotency_key", key))
+
+		if len(idempotencyKey.ResponseBody()) > 0 {
+			w.Header().Set("Content-Type", "application/json")
+		}
+		w.Header().Set("Idempotent-Replayed", "true")
+		w.WriteHeader(idempotencyKey.ResponseStatus())
+		w.Write(idempotencyKey.ResponseBody())
+		return
+	}
+
+	rec := newStatusRecorder(w, -1)
+
+	next(&rec, r)
+
+	// the key is finished or released even when the request deadline has been exceeded, otherwise it would be kept in
+	// use and the retries would be rejected
+	ctx := context.Background()
+
+	// server errors are not stored, so the client is able to retry the request with the same key
+	if rec.status >= http.StatusInternalServerError {
+		if err := i.controller.Release(ctx, idempotencyKey); err != nil {
+			i.log.Error(r.Context(), "error to release the idempotency key", domain.NewErrLogField(err))
+		}
+		return
+	}
+
+	if err := i.controller.Finish(ctx, idempotencyKey, rec.status, rec.body); err != nil {
+		i.log.Error(r.Context(), "error to store the response of the idempotency key", domain.NewErrLogField(err))
+	}
+}
+
+func (i Idempotency) translateError(ctx context.Context, w http.ResponseWriter, err error) {
+	switch v := err.(type) {
+	case *domain.ErrDomain:
+		i.log.Info(ctx, "invalid idempotency key", domain.NewErrLogField(err))
+		writeError(w, http.StatusUnprocessableEntity, v.Field(), v.Error())
+	case *domain.ErrIdempotencyKeyInUse:
+		i.log.Info(ctx, "idempotency key in use", domain.NewErrLogField(err))
+		writeError(w, http.StatusConflict, idempotencyKeyHeader, v.Error())
+	default:
+		i.log.Error(ctx, "unknown idempotency error", domain.NewErrLogField(err))
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// fingerprint identifies a request by its method, path and payload, ignoring the JSON formatting
+func fingerprint(r *http.Request, payload string) string {
+	body := new(bytes.Buffer)
+	if err := json.Compact(body, []byte(payload)); err != nil {
+		body = bytes.NewBufferString(payload)
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	hash.Write(body.Bytes())
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+type errorResponse struct {
+	Errors []errorDescription `json:"errors"`
+}
+
+type errorDescription struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// writeError writes an error response using the same payload of the API handlers
+func writeError(w http.ResponseWriter, status int, field, description string) {
+	payload, _ := json.Marshal(errorResponse{
+		Errors: []errorDescription{{Field: field, Description: description}},
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(payload)
+}
@@ -2,93 +2,112 @@ package middleware
 
 import (
 	"bytes"
-	"encoding/json"
+	"io"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"strings"
 	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
 )
 
-// Logger logs the request and response data of the HTTP API
+// Logger logs the request and response data of the HTTP API, with their sensitive data redacted
 type Logger struct {
-	log *log.Logger
+	log       domain.Logger
+	redaction *Redaction
 }
 
 // NewLogger builds a new Logger struct
-func NewLogger(log *log.Logger) *Logger {
-	return &Logger{log: log}
+func NewLogger(log domain.Logger, redaction *Redaction) *Logger {
+	return &Logger{log: log, redaction: redaction}
 }
 
 // Handler exports Logger as an http middleware
 func (l Logger) Handler(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
 	var (
 		start = time.Now()
-		rec   = newStatusRecorder(w)
+		rec   = newStatusRecorder(w, l.redaction.MaxBodySize())
 	)
 
-	l.log.Println(l.requestData(r))
+	l.log.Info(r.Context(), "request", l.requestData(r)...)
 
 	next.ServeHTTP(&rec, r)
 
-	l.log.Println(rec.responseData(start))
+	l.log.Info(r.Context(), "response", l.responseData(&rec, start)...)
 }
 
-func (l Logger) requestData(r *http.Request) string {
-	payload, _ := getPayload(r)
+func (l Logger) requestData(r *http.Request) []domain.LogField {
+	payload, size, _ := getPayload(r, l.redaction.MaxBodySize())
 
-	v, err := json.Marshal(map[string]interface{}{
-		"request": map[string]interface{}{
-			"http_method": r.Method,
-			"path":        r.URL.Path,
-			"headers":     r.Header,
-			"payload":     compactPayload(payload),
-		},
-	})
+	return []domain.LogField{
+		domain.NewLogField("http_method", r.Method),
+		domain.NewLogField("path", r.URL.Path),
+		domain.NewLogField("headers", l.redaction.Header(r.Header)),
+		domain.NewLogField("payload", l.redaction.BodyPrefix(payload, size)),
+	}
+}
 
-	if err != nil {
-		return ""
+// getPayload reads up to maxSize bytes of the request body, or the whole body when maxSize is negative, returning them
+// with the size of the whole body, negative when the body goes beyond them with an unknown size. The body is filled
+// back, so the handlers read it entirely.
+func getPayload(r *http.Request, maxSize int) ([]byte, int, error) {
+	if r.Body == nil {
+		return nil, 0, nil
 	}
 
-	return string(v)
-}
+	var reader io.Reader = r.Body
+	if maxSize >= 0 {
+		reader = io.LimitReader(r.Body, int64(maxSize))
+	}
 
-func getPayload(r *http.Request) (string, error) {
-	payload, err := ioutil.ReadAll(r.Body)
+	payload, err := ioutil.ReadAll(reader)
 	if err != nil {
-		return "", err
+		return nil, 0, err
 	}
 
-	// fill back the body buffer
-	r.Body = ioutil.NopCloser(bytes.NewBuffer(payload))
+	// reads one more byte to find out whether the body goes beyond the payload
+	next := make([]byte, 1)
 
-	return string(payload), nil
-}
+	n, err := io.ReadFull(r.Body, next)
+	if err != nil && err != io.EOF {
+		return nil, 0, err
+	}
 
-func compactPayload(payload string) string {
-	var (
-		buffer    = new(bytes.Buffer)
-		jsonBytes = []byte(payload)
-	)
+	// fill back the body buffer
+	r.Body = readCloser{
+		Reader: io.MultiReader(bytes.NewReader(payload), bytes.NewReader(next[:n]), r.Body),
+		Closer: r.Body,
+	}
+
+	if n == 0 {
+		return payload, len(payload), nil
+	}
 
-	if err := json.Compact(buffer, jsonBytes); err != nil {
-		return string(jsonBytes)
+	if r.ContentLength > int64(len(payload)) {
+		return payload, int(r.ContentLength), nil
 	}
 
-	// remove line breaks
-	return strings.Replace(payload, "\n", "", -1)
+	return payload, -1, nil
+}
+
+// readCloser reads the body filled back, closing the original one
+type readCloser struct {
+	io.Reader
+	io.Closer
 }
 
 // statusRecorder armazena informações da resposta da API HTTP, sobrescrevendo o http.responseWriter
 type statusRecorder struct {
 	http.ResponseWriter
-	status int
-	body   []byte
+	status  int
+	body    []byte
+	size    int
+	maxSize int
 }
 
 // default response
-func newStatusRecorder(w http.ResponseWriter) statusRecorder {
-	return statusRecorder{ResponseWriter: w, status: http.StatusOK, body: []byte("")}
+func newStatusRecorder(w http.ResponseWriter, maxSize int) statusRecorder {
+	return statusRecorder{ResponseWriter: w, status: http.StatusOK, body: []byte(""), maxSize: maxSize}
 }
 
 func (rec *statusRecorder) WriteHeader(code int) {
@@ -97,31 +116,40 @@ func (rec *statusRecorder) WriteHeader(code int) {
 	rec.ResponseWriter.WriteHeader(code)
 }
 
+// Write keeps up to the maximum size of the body, or the whole body when the maximum size is negative, counting the
+// size of the whole body. With a maximum size, the bodies not in JSON, as the exported statements, are not kept, as
+// they cannot be redacted.
 func (rec *statusRecorder) Write(body []byte) (int, error) {
-	rec.body = body
+	if rec.maxSize < 0 {
+		rec.body = append(rec.body, body...)
+	} else if free := rec.maxSize - len(rec.body); free > 0 && rec.isJSON() {
+		if free > len(body) {
+			free = len(body)
+		}
+
+		rec.body = append(rec.body, body[:free]...)
+	}
 
-	_, err := rec.ResponseWriter.Write(body)
+	rec.size += len(body)
 
-	return rec.status, err
+	return rec.ResponseWriter.Write(body)
 }
 
-func (rec *statusRecorder) responseData(start time.Time) string {
-	var payload = string(rec.body)
+// isJSON tells whether the response body is in JSON
+func (rec *statusRecorder) isJSON() bool {
+	return strings.Contains(rec.Header().Get("Content-Type"), "json")
+}
+
+func (l Logger) responseData(rec *statusRecorder, start time.Time) []domain.LogField {
+	var payload = l.redaction.BodyPrefix(rec.body, rec.size)
 	if payload == "" {
 		payload = `{}`
 	}
 
-	v, err := json.Marshal(map[string]interface{}{
-		"response": map[string]interface{}{
-			"http_status": rec.status,
-			"payload":     payload,
-			"time":        time.Since(start).Seconds(),
-		},
-	})
-
-	if err != nil {
-		return ""
+	return []domain.LogField{
+		domain.NewLogField("http_status", rec.status),
+		domain.NewLogField("headers", l.redaction.Header(rec.Header())),
+		domain.NewLogField("payload", payload),
+		domain.NewLogField("duration", time.Since(start).Seconds()),
 	}
-
-	return string(v)
 }
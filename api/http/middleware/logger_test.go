@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGetPayload(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		contentLength int64
+		maxSize       int
+		wantPayload   string
+		wantSize      int
+	}{
+		{
+			name:          "body shorter than the maximum size",
+			body:          `{"amount": 10}`,
+			contentLength: 14,
+			maxSize:       64,
+			wantPayload:   `{"amount": 10}`,
+			wantSize:      14,
+		},
+		{
+			name:          "body longer than the maximum size",
+			body:          `{"amount": 10}`,
+			contentLength: 14,
+			maxSize:       4,
+			wantPayload:   `{"am`,
+			wantSize:      14,
+		},
+		{
+			name:          "body longer than the maximum size with unknown length",
+			body:          `{"amount": 10}`,
+			contentLength: -1,
+			maxSize:       4,
+			wantPayload:   `{"am`,
+			wantSize:      -1,
+		},
+		{
+			name:          "whole body",
+			body:          `{"amount": 10}`,
+			contentLength: -1,
+			maxSize:       -1,
+			wantPayload:   `{"amount": 10}`,
+			wantSize:      14,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/transactions", strings.NewReader(tt.body))
+			req.ContentLength = tt.contentLength
+
+			payload, size, err := getPayload(req, tt.maxSize)
+			if err != nil {
+				t.Fatalf("getPayload() error = %v", err)
+			}
+
+			if string(payload) != tt.wantPayload || size != tt.wantSize {
+				t.Errorf("getPayload() = %v, %v, want %v, %v", string(payload), size, tt.wantPayload, tt.wantSize)
+			}
+
+			body, _ := ioutil.ReadAll(req.Body)
+			if string(body) != tt.body {
+				t.Errorf("getPayload() must fill back the body, got %v, want %v", string(body), tt.body)
+			}
+		})
+	}
+}
+
+func TestStatusRecorder_Write(t *testing.T) {
+	var (
+		w   = httptest.NewRecorder()
+		rec = newStatusRecorder(w, 4)
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	rec.Write([]byte(`{"am`))
+	rec.Write([]byte(`ount": 10}`))
+
+	if string(rec.body) != `{"am` || rec.size != 14 {
+		t.Errorf("Write() kept %v with size %v, want %v with size %v", string(rec.body), rec.size, `{"am`, 14)
+	}
+
+	if w.Body.String() != `{"amount": 10}` {
+		t.Errorf("Write() must write the whole body, got %v", w.Body.String())
+	}
+}
+
+func TestStatusRecorder_Write_NotJSON(t *testing.T) {
+	var (
+		w   = httptest.NewRecorder()
+		rec = newStatusRecorder(w, 64)
+	)
+
+	w.Header().Set("Content-Type", "text/csv")
+
+	rec.Write([]byte("date,amount\n"))
+
+	if len(rec.body) != 0 || rec.size != 12 {
+		t.Errorf("Write() kept %v with size %v, want no body with size %v", string(rec.body), rec.size, 12)
+	}
+}
@@ -0,0 +1,380 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Mask replaces a sensitive value by a masked one
+type Mask func(string) string
+
+// MaskAll replaces the whole value
+func MaskAll(string) string {
+	return "***"
+}
+
+// MaskDocument keeps only the last two digits of a document number, in the CPF or the CNPJ format by its length, as
+// "***.***.***-91"
+func MaskDocument(v string) string {
+	var digits []rune
+
+	for _, r := range v {
+		if unicode.IsDigit(r) {
+			digits = append(digits, r)
+		}
+	}
+
+	if len(digits) <= 2 {
+		return MaskAll(v)
+	}
+
+	last := string(digits[len(digits)-2:])
+
+	switch len(digits) {
+	case 11:
+		return "***.***.***-" + last
+	case 14:
+		return "**.***.***/****-" + last
+	default:
+		return strings.Repeat("*", len(digits)-2) + last
+	}
+}
+
+// Redaction masks the sensitive data of the requests and responses before they are logged: the JSON fields by their
+// paths, as "document.number", and the headers by their names. The bodies longer than the maximum size are truncated,
+// and the bodies not in JSON are replaced by their size, as their sensitive data cannot be found.
+type Redaction struct {
+	fields      map[string]Mask
+	headers     map[string]Mask
+	maxBodySize int
+}
+
+// NewRedaction builds a new Redaction struct keeping up to maxBodySize bytes of each body
+func NewRedaction(maxBodySize int) *Redaction {
+	return &Redaction{fields: map[string]Mask{}, headers: map[string]Mask{}, maxBodySize: maxBodySize}
+}
+
+// WithField returns a new Redaction struct masking the JSON field of the path, the keys separated by dots. The path
+// applies to each item of the arrays along it.
+func (r Redaction) WithField(path string, mask Mask) *Redaction {
+	redaction := r
+	redaction.fields = make(map[string]Mask, len(r.fields)+1)
+
+	for k, v := range r.fields {
+		redaction.fields[k] = v
+	}
+
+	redaction.fields[path] = mask
+
+	return &redaction
+}
+
+// WithHeader returns a new Redaction struct masking the values of the header, or dropping it when mask is nil
+func (r Redaction) WithHeader(name string, mask Mask) *Redaction {
+	redaction := r
+	redaction.headers = make(map[string]Mask, len(r.headers)+1)
+
+	for k, v := range r.headers {
+		redaction.headers[k] = v
+	}
+
+	redaction.headers[http.CanonicalHeaderKey(name)] = mask
+
+	return &redaction
+}
+
+// Header returns a copy of the headers with the sensitive ones masked or dropped
+func (r Redaction) Header(header http.Header) http.Header {
+	redacted := header.Clone()
+
+	for name, mask := range r.headers {
+		values, ok := redacted[name]
+		if !ok {
+			continue
+		}
+
+		if mask == nil {
+			delete(redacted, name)
+			continue
+		}
+
+		for i, v := range values {
+			values[i] = mask(v)
+		}
+	}
+
+	return redacted
+}
+
+// Body returns the body with the sensitive fields masked, compacted and truncated to the maximum size
+func (r Redaction) Body(body []byte) string {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return ""
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var v interface{}
+
+	if err := decoder.Decode(&v); err != nil || decoder.More() {
+		return r.notJSON(len(body))
+	}
+
+	for path, mask := range r.fields {
+		v = redact(v, strings.Split(path, "."), mask)
+	}
+
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(v); err != nil {
+		return r.notJSON(len(body))
+	}
+
+	return r.truncate(strings.TrimSuffix(buffer.String(), "\n"))
+}
+
+// BodyPrefix returns the first bytes of a body with the informed size, as Body does for the whole body. The fields read
+// before the body is cut are masked and compacted, and a value cut in the middle is dropped, so a sensitive value is
+// never partially logged. A negative size means the body goes beyond the prefix, with an unknown size.
+func (r Redaction) BodyPrefix(prefix []byte, size int) string {
+	if size >= 0 && len(prefix) >= size {
+		return r.Body(prefix)
+	}
+
+	trimmed := bytes.TrimSpace(prefix)
+	if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return r.notJSON(size)
+	}
+
+	return r.cut(r.redactPrefix(prefix), size)
+}
+
+// MaxBodySize returns the maximum size of the bodies kept by the redaction
+func (r Redaction) MaxBodySize() int {
+	return r.maxBodySize
+}
+
+// notJSON replaces a body that cannot be redacted by its size
+func (r Redaction) notJSON(size int) string {
+	if size < 0 {
+		return "[body not in JSON]"
+	}
+
+	return fmt.Sprintf("[%d bytes not in JSON]", size)
+}
+
+// truncate keeps up to the maximum size of the body
+func (r Redaction) truncate(body string) string {
+	if len(body) <= r.maxBodySize {
+		return body
+	}
+
+	return r.cut(body, len(body))
+}
+
+// cut keeps up to the maximum size of a truncated body, never splitting a character, and indicates the size of the
+// whole body when it is known
+func (r Redaction) cut(body string, size int) string {
+	end := len(body)
+	if end > r.maxBodySize {
+		end = r.maxBodySize
+		for end > 0 && !utf8.RuneStart(body[end]) {
+			end--
+		}
+	}
+
+	if size < 0 {
+		return fmt.Sprintf("%s...[truncated]", body[:end])
+	}
+
+	return fmt.Sprintf("%s...[truncated, %d bytes]", body[:end], size)
+}
+
+// prefixFrame is an object or an array opened in the prefix of a JSON body
+type prefixFrame struct {
+	object bool
+	key    string
+	keyed  bool
+	count  int
+}
+
+// redactPrefix masks and compacts the tokens of the prefix of a JSON body, stopping at the first token cut by the end
+// of the prefix
+func (r Redaction) redactPrefix(prefix []byte) string {
+	var (
+		decoder = json.NewDecoder(bytes.NewReader(prefix))
+		buffer  = new(bytes.Buffer)
+		stack   []*prefixFrame
+	)
+
+	decoder.UseNumber()
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return buffer.String()
+		}
+
+		if delim, ok := token.(json.Delim); ok && (delim == '}' || delim == ']') {
+			stack = stack[:len(stack)-1]
+			buffer.WriteRune(rune(delim))
+
+			if len(stack) == 0 {
+				return buffer.String()
+			}
+
+			continue
+		}
+
+		var top *prefixFrame
+		if len(stack) > 0 {
+			top = stack[len(stack)-1]
+		}
+
+		if top != nil && top.object && !top.keyed {
+			if top.count > 0 {
+				buffer.WriteByte(',')
+			}
+
+			writeToken(buffer, token)
+			buffer.WriteByte(':')
+
+			top.key, top.keyed = token.(string), true
+
+			continue
+		}
+
+		if top != nil {
+			if !top.object && top.count > 0 {
+				buffer.WriteByte(',')
+			}
+
+			top.keyed = false
+			top.count++
+		}
+
+		// a number ending with the prefix may be cut
+		if _, ok := token.(json.Number); ok && decoder.InputOffset() == int64(len(prefix)) {
+			return buffer.String()
+		}
+
+		if mask, ok := r.fields[prefixPath(stack)]; ok {
+			if !writeMasked(buffer, decoder, token, mask) {
+				return buffer.String()
+			}
+
+			continue
+		}
+
+		if delim, ok := token.(json.Delim); ok {
+			stack = append(stack, &prefixFrame{object: delim == '{'})
+			buffer.WriteRune(rune(delim))
+
+			continue
+		}
+
+		writeToken(buffer, token)
+
+		if len(stack) == 0 {
+			return buffer.String()
+		}
+	}
+}
+
+// prefixPath returns the path of the value being read, the keys of the objects opened along it separated by dots
+func prefixPath(stack []*prefixFrame) string {
+	var keys []string
+
+	for _, frame := range stack {
+		if frame.object {
+			keys = append(keys, frame.key)
+		}
+	}
+
+	return strings.Join(keys, ".")
+}
+
+// writeMasked writes the masked value of the token, skipping the objects and arrays masked as a whole. It returns false
+// when the prefix ends before the masked value does.
+func writeMasked(buffer *bytes.Buffer, decoder *json.Decoder, token json.Token, mask Mask) bool {
+	switch value := token.(type) {
+	case nil:
+		buffer.WriteString("null")
+	case string:
+		writeToken(buffer, mask(value))
+	case json.Number:
+		writeToken(buffer, mask(value.String()))
+	case json.Delim:
+		for depth := 1; depth > 0; {
+			next, err := decoder.Token()
+			if err != nil {
+				return false
+			}
+
+			if delim, ok := next.(json.Delim); ok {
+				if delim == '{' || delim == '[' {
+					depth++
+				} else {
+					depth--
+				}
+			}
+		}
+
+		writeToken(buffer, MaskAll(""))
+	default:
+		writeToken(buffer, MaskAll(""))
+	}
+
+	return true
+}
+
+// writeToken writes a JSON token, compacted and without escaping the HTML characters
+func writeToken(buffer *bytes.Buffer, token json.Token) {
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+
+	_ = encoder.Encode(token)
+
+	buffer.Truncate(buffer.Len() - 1)
+}
+
+// redact masks the field of the path in a decoded JSON value, in each item when the value is an array
+func redact(v interface{}, path []string, mask Mask) interface{} {
+	switch t := v.(type) {
+	case []interface{}:
+		for i := range t {
+			t[i] = redact(t[i], path, mask)
+		}
+	case map[string]interface{}:
+		field, ok := t[path[0]]
+		if !ok {
+			return v
+		}
+
+		if len(path) > 1 {
+			t[path[0]] = redact(field, path[1:], mask)
+			return v
+		}
+
+		switch value := field.(type) {
+		case nil:
+		case string:
+			t[path[0]] = mask(value)
+		case json.Number:
+			t[path[0]] = mask(value.String())
+		default:
+			// objects, arrays and booleans are masked as a whole
+			t[path[0]] = MaskAll("")
+		}
+	}
+
+	return v
+}
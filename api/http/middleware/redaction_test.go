@@ -0,0 +1,180 @@
+package middleware
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestMaskDocument(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "cpf", value: "00000000191", want: "***.***.***-91"},
+		{name: "formatted cpf", value: "000.000.001-91", want: "***.***.***-91"},
+		{name: "cnpj", value: "11222333000181", want: "**.***.***/****-81"},
+		{name: "other length", value: "123456", want: "****56"},
+		{name: "too short to keep any digit", value: "12", want: "***"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MaskDocument(tt.value); got != tt.want {
+				t.Errorf("MaskDocument() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedaction_Body(t *testing.T) {
+	redaction := NewRedaction(64).
+		WithField("document.number", MaskDocument).
+		WithField("secret", MaskAll).
+		WithField("items.card", MaskAll)
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "empty body",
+			body: " \n",
+			want: "",
+		},
+		{
+			name: "body not in JSON",
+			body: "document=00000000191",
+			want: "[20 bytes not in JSON]",
+		},
+		{
+			name: "nested field",
+			body: `{"document": {"number": "00000000191"}, "credit_limit": 1500.50}`,
+			want: `{"credit_limit":1500.50,"document":{"number":"***.***.***-91"}}`,
+		},
+		{
+			name: "field in the items of an array",
+			body: `{"items": [{"card": "4111"}, {"card": 4222}, {"other": "x"}]}`,
+			want: `{"items":[{"card":"***"},{"card":"***"},{"other":"x"}]}`,
+		},
+		{
+			name: "object masked as a whole",
+			body: `{"secret": {"key": "abc"}}`,
+			want: `{"secret":"***"}`,
+		},
+		{
+			name: "array of objects at the root",
+			body: `[{"document": {"number": "52998224725"}}]`,
+			want: `[{"document":{"number":"***.***.***-25"}}]`,
+		},
+		{
+			name: "body longer than the maximum size",
+			body: `{"description": "pagamento da fatura de março com o cartão de crédito"}`,
+			want: `{"description":"pagamento da fatura de março com o cartão de c...[truncated, 73 bytes]`,
+		},
+		{
+			name: "body truncated without splitting a character",
+			body: `{"description": "pagamentos da fatura de março no cartão de crédito"}`,
+			want: `{"description":"pagamentos da fatura de março no cartão de cr...[truncated, 71 bytes]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redaction.Body([]byte(tt.body)); got != tt.want {
+				t.Errorf("Body() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedaction_BodyPrefix(t *testing.T) {
+	redaction := NewRedaction(64).
+		WithField("document.number", MaskDocument).
+		WithField("secret", MaskAll).
+		WithField("items.card", MaskAll)
+
+	tests := []struct {
+		name   string
+		prefix string
+		size   int
+		want   string
+	}{
+		{
+			name:   "whole body",
+			prefix: `{"secret": "abc"}`,
+			size:   17,
+			want:   `{"secret":"***"}`,
+		},
+		{
+			name:   "prefix not in JSON",
+			prefix: "date,amount\n2021-01-01,",
+			size:   4096,
+			want:   "[4096 bytes not in JSON]",
+		},
+		{
+			name:   "prefix not in JSON with unknown size",
+			prefix: "date,amount\n2021-01-01,",
+			size:   -1,
+			want:   "[body not in JSON]",
+		},
+		{
+			name:   "fields masked before the cut",
+			prefix: `{"document": {"number": "00000000191"}, "items": [{"card": "42`,
+			size:   4096,
+			want:   `{"document":{"number":"***.***.***-91"},"items":[{"card":...[truncated, 4096 bytes]`,
+		},
+		{
+			name:   "number cut by the prefix",
+			prefix: `[{"amount": 10}, {"amount": 12`,
+			size:   -1,
+			want:   `[{"amount":10},{"amount":...[truncated]`,
+		},
+		{
+			name:   "object masked as a whole cut by the prefix",
+			prefix: `{"secret": {"key": "abc"`,
+			size:   128,
+			want:   `{"secret":...[truncated, 128 bytes]`,
+		},
+		{
+			name:   "redacted prefix longer than the maximum size",
+			prefix: `{"description": "pagamento da fatura de março com o cartão de crédito", "amount": 1`,
+			size:   4096,
+			want:   `{"description":"pagamento da fatura de março com o cartão de c...[truncated, 4096 bytes]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redaction.BodyPrefix([]byte(tt.prefix), tt.size); got != tt.want {
+				t.Errorf("BodyPrefix() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedaction_Header(t *testing.T) {
+	var (
+		redaction = NewRedaction(0).WithHeader("authorization", MaskAll).WithHeader("Cookie", nil)
+		header    = http.Header{
+			"Authorization": []string{"Bearer token"},
+			"Cookie":        []string{"session=abc"},
+			"Content-Type":  []string{"application/json"},
+		}
+	)
+
+	want := http.Header{
+		"Authorization": []string{"***"},
+		"Content-Type":  []string{"application/json"},
+	}
+
+	if got := redaction.Header(header); !reflect.DeepEqual(got, want) {
+		t.Errorf("Header() = %v, want %v", got, want)
+	}
+
+	if header.Get("Authorization") != "Bearer token" || header.Get("Cookie") != "session=abc" {
+		t.Errorf("Header() must not change the original headers, got %v", header)
+	}
+}
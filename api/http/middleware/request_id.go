@@ -1,31 +1,48 @@
 package middleware
 
 import (
-	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"net/http"
-	"strconv"
-	"time"
+
+	"github.com/tonytcb/bank-transactions-go/infra/logger"
+)
+
+const (
+	requestIDHeader    = "X-Request-ID"
+	maxRequestIDLength = 128
 )
 
-// Logger stores a request identifier in the request context if it was received, otherwise, generate a new one
+// RequestID stores a request identifier in the request context if it was received, otherwise, generate a new one.
+// The identifier is added to all the log entries of the request and echoed in the X-Request-ID response header.
 type RequestID struct {
 }
 
-// NewRequestID builds a new Logger struct
+// NewRequestID builds a new RequestID struct
 func NewRequestID() *RequestID {
 	return &RequestID{}
 }
 
 // Handler exports RequestID as an http middleware
 func (l RequestID) Handler(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
-	requestID := strconv.FormatInt(time.Now().Unix(), 10)
+	requestID := r.Header.Get(requestIDHeader)
 
-	// if was received the header x-request-id, its value is set in the request context
-	if v := r.Header.Values("x-request-id"); len(v) > 0 {
-		requestID = v[0]
+	// a received identifier too long to be logged is replaced, as well as a missing one
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		requestID = newRequestID()
 	}
 
-	ctx := context.WithValue(r.Context(), "request-id", requestID)
+	w.Header().Set(requestIDHeader, requestID)
+
+	next(w, r.WithContext(logger.WithRequestID(r.Context(), requestID)))
+}
+
+// newRequestID generates a random identifier of 16 bytes, hex encoded
+func newRequestID() string {
+	b := make([]byte, 16)
+
+	// the reader of crypto/rand does not fail in the supported platforms
+	_, _ = rand.Read(b)
 
-	next(w, r.WithContext(ctx))
+	return hex.EncodeToString(b)
 }
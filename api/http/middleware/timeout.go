@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// Timeout sets a deadline to the request context, so the queries of a request are cancelled when it is exceeded or when
+// the client disconnects
+type Timeout struct {
+	log     domain.Logger
+	timeout time.Duration
+}
+
+// NewTimeout builds a new Timeout struct
+func NewTimeout(log domain.Logger, timeout time.Duration) *Timeout {
+	return &Timeout{log: log, timeout: timeout}
+}
+
+// Handler exports Timeout as an http middleware
+func (t Timeout) Handler(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	ctx, cancel := context.WithTimeout(r.Context(), t.timeout)
+	defer cancel()
+
+	next(w, r.WithContext(ctx))
+
+	if ctx.Err() == context.DeadlineExceeded {
+		t.log.Warn(r.Context(), "request deadline exceeded", domain.NewLogField("timeout", t.timeout.String()))
+	}
+}
@@ -1,52 +1,130 @@
 package http
 
 import (
-	"database/sql"
+	"context"
 	"fmt"
-	"log"
 	"net/http"
+	"os"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/tonytcb/bank-transactions-go/api/http/handler"
 	stdmiddleware "github.com/tonytcb/bank-transactions-go/api/http/middleware"
+	"github.com/tonytcb/bank-transactions-go/domain"
 	"github.com/tonytcb/bank-transactions-go/infra/repository"
 	"github.com/tonytcb/bank-transactions-go/usecase"
 )
 
+// statementPath is the route of the statement exports
+const statementPath = "/accounts/:id/statement"
+
 // Server exposes the app through the HTTP protocol
 type Server struct {
-	logger  *log.Logger
-	storage *sql.DB
-	port    int
+	logger            domain.Logger
+	redaction         *stdmiddleware.Redaction
+	repos             *repository.Repositories
+	rates             domain.ExchangeRateProvider
+	adminToken        string
+	timeout           time.Duration
+	statementTimeout  time.Duration
+	idempotencyKeyTTL time.Duration
+	port              int
+}
+
+// NewServer creates a Server struct with its dependencies. The timeout is the deadline of each request, except for the
+// statement exports, streamed with a longer deadline, the idempotency keys expire after their ttl, and the redaction
+// masks the sensitive data of the logged requests and responses.
+func NewServer(
+	logger domain.Logger,
+	redaction *stdmiddleware.Redaction,
+	repos *repository.Repositories,
+	rates domain.ExchangeRateProvider,
+	adminToken string,
+	timeout time.Duration,
+	statementTimeout time.Duration,
+	idempotencyKeyTTL time.Duration,
+	port int,
+) *Server {
+	return &Server{
+		logger:            logger,
+		redaction:         redaction,
+		repos:             repos,
+		rates:             rates,
+		adminToken:        adminToken,
+		timeout:           timeout,
+		statementTimeout:  statementTimeout,
+		idempotencyKeyTTL: idempotencyKeyTTL,
+		port:              port,
+	}
 }
 
-// NewServer creates a Server struct with its dependencies
-func NewServer(logger *log.Logger, storage *sql.DB, port int) *Server {
-	return &Server{logger: logger, storage: storage, port: port}
+// DefaultRedaction builds the redaction of the sensitive data known by the API: the document numbers of the accounts,
+// the secrets of the webhooks, the admin token and the cookies
+func DefaultRedaction(maxBodySize int) *stdmiddleware.Redaction {
+	return stdmiddleware.NewRedaction(maxBodySize).
+		WithField("document.number", stdmiddleware.MaskDocument).
+		WithField("secret", stdmiddleware.MaskAll).
+		WithHeader("Authorization", stdmiddleware.MaskAll).
+		WithHeader("Cookie", nil).
+		WithHeader("Set-Cookie", nil)
 }
 
 // Listen exposes the HTTP server running in the port 8080
 func (s Server) Listen() {
-	s.logger.Println("starting http server")
+	s.logger.Info(context.Background(), "starting http server", domain.NewLogField("port", s.port))
 
 	e := echo.New()
+	e.HideBanner = true
+	e.HidePort = true
 
 	e.Use(middleware.Recover())
 	e.Use(s.middleware(stdmiddleware.NewRequestID().Handler))
-	e.Use(s.middleware(stdmiddleware.NewLogger(s.logger).Handler))
+	e.Use(s.middleware(stdmiddleware.NewLogger(s.logger, s.redaction).Handler))
+	e.Use(s.except(s.middleware(stdmiddleware.NewTimeout(s.logger, s.timeout).Handler), statementPath))
+
+	idempotency := s.middleware(stdmiddleware.NewIdempotency(
+		s.logger,
+		usecase.NewIdempotency(s.repos.Idempotency, time.Now, s.timeout, s.idempotencyKeyTTL),
+	).Handler)
 
-	e.POST("/accounts", s.createAccountHandler())
+	e.POST("/accounts", s.createAccountHandler(), idempotency)
 	e.GET("/accounts/:id", s.findAccountByIDHandler())
-	e.POST("/transactions", s.createTransactionHandler())
+	e.PATCH("/accounts/:id/status", s.changeAccountStatusHandler())
+	e.GET("/accounts/:id/balance", s.findAccountBalanceHandler())
+	e.GET("/accounts/:id/transactions", s.listTransactionsHandler())
+	e.GET(statementPath, s.exportStatementHandler(), s.middleware(stdmiddleware.NewTimeout(s.logger, s.statementTimeout).Handler))
+	e.GET("/accounts/:id/invoices", s.listInvoicesHandler())
+	e.GET("/invoices/:id", s.findInvoiceHandler())
+	e.POST("/transactions", s.createTransactionHandler(), idempotency)
+	e.POST("/transactions/:id/reversal", s.reverseTransactionHandler(), idempotency)
+	e.POST("/transfers", s.createTransferHandler(), idempotency)
+	e.GET("/operations", s.listOperationsHandler())
 
-	s.logger.Fatalln(e.Start(fmt.Sprintf(":%d", s.port)))
+	adminOnly := s.middleware(stdmiddleware.NewAdmin(s.logger, s.adminToken).Handler)
+
+	admin := e.Group("/admin", adminOnly)
+	admin.POST("/operations", s.createOperationHandler())
+	admin.PATCH("/operations/:id", s.updateOperationHandler())
+
+	e.POST("/webhooks", s.createWebhookHandler(), adminOnly)
+	e.GET("/webhooks/deliveries/failed", s.listFailedWebhookDeliveriesHandler(), adminOnly)
+	e.POST("/webhooks/deliveries/:id/replay", s.replayWebhookDeliveryHandler(), adminOnly)
+
+	err := e.Start(fmt.Sprintf(":%d", s.port))
+
+	s.logger.Error(context.Background(), "http server stopped", domain.NewErrLogField(err))
+	os.Exit(1)
 }
 
 func (s Server) createAccountHandler() echo.HandlerFunc {
 	createAccount := handler.NewCreateAccount(
 		s.logger,
-		usecase.NewCreateAccount(repository.NewAccountWriter(s.storage)),
+		usecase.NewCreateAccount(
+			s.repos.AccountWriter,
+			s.repos.EventWriter,
+			s.repos.UnitOfWork,
+		),
 	)
 
 	return s.handler(createAccount.Handler)
@@ -55,21 +133,154 @@ func (s Server) createAccountHandler() echo.HandlerFunc {
 func (s Server) findAccountByIDHandler() echo.HandlerFunc {
 	findAccount := handler.NewFindAccount(
 		s.logger,
-		usecase.NewFindAccount(repository.NewAccountReader(s.storage)),
+		usecase.NewFindAccount(s.repos.AccountReader),
 	)
 
 	return s.handler(findAccount.Handler)
 }
 
+func (s Server) changeAccountStatusHandler() echo.HandlerFunc {
+	changeAccountStatus := handler.NewChangeAccountStatus(
+		s.logger,
+		usecase.NewChangeAccountStatus(s.repos.AccountReader, s.repos.AccountStatusWriter),
+	)
+
+	return s.handler(changeAccountStatus.Handler)
+}
+
+func (s Server) findAccountBalanceHandler() echo.HandlerFunc {
+	findAccountBalance := handler.NewFindAccountBalance(
+		s.logger,
+		usecase.NewFindAccountBalance(s.repos.AccountReader),
+	)
+
+	return s.handler(findAccountBalance.Handler)
+}
+
+func (s Server) listTransactionsHandler() echo.HandlerFunc {
+	listTransactions := handler.NewListTransactions(
+		s.logger,
+		usecase.NewListTransactions(s.repos.AccountReader, s.repos.OperationReader, s.repos.TransactionReader),
+	)
+
+	return s.handler(listTransactions.Handler)
+}
+
+func (s Server) exportStatementHandler() echo.HandlerFunc {
+	exportStatement := handler.NewExportStatement(
+		s.logger,
+		usecase.NewExportStatement(s.repos.AccountReader, s.repos.StatementReader),
+	)
+
+	return s.handler(exportStatement.Handler)
+}
+
+func (s Server) listInvoicesHandler() echo.HandlerFunc {
+	listInvoices := handler.NewListInvoices(
+		s.logger,
+		usecase.NewListInvoices(s.repos.AccountReader, s.repos.InvoiceReader),
+	)
+
+	return s.handler(listInvoices.Handler)
+}
+
+func (s Server) findInvoiceHandler() echo.HandlerFunc {
+	findInvoice := handler.NewFindInvoice(
+		s.logger,
+		usecase.NewFindInvoice(s.repos.InvoiceReader),
+	)
+
+	return s.handler(findInvoice.Handler)
+}
+
 func (s Server) createTransactionHandler() echo.HandlerFunc {
 	createTransaction := handler.NewCreateTransaction(
 		s.logger,
-		usecase.NewCreateTransaction(repository.NewTransaction(s.storage)),
+		usecase.NewCreateTransaction(
+			s.repos.TransactionWriter,
+			s.repos.AccountReader,
+			s.repos.OperationReader,
+			s.repos.EventWriter,
+			s.repos.UnitOfWork,
+			s.rates,
+		),
 	)
 
 	return s.handler(createTransaction.Handler)
 }
 
+func (s Server) reverseTransactionHandler() echo.HandlerFunc {
+	reverseTransaction := handler.NewReverseTransaction(
+		s.logger,
+		usecase.NewReverseTransaction(s.repos.ReversalWriter),
+	)
+
+	return s.handler(reverseTransaction.Handler)
+}
+
+func (s Server) createTransferHandler() echo.HandlerFunc {
+	createTransfer := handler.NewCreateTransfer(
+		s.logger,
+		usecase.NewCreateTransfer(s.repos.TransferWriter),
+	)
+
+	return s.handler(createTransfer.Handler)
+}
+
+func (s Server) listOperationsHandler() echo.HandlerFunc {
+	listOperations := handler.NewListOperations(
+		s.logger,
+		usecase.NewListOperations(s.repos.OperationReader),
+	)
+
+	return s.handler(listOperations.Handler)
+}
+
+func (s Server) createOperationHandler() echo.HandlerFunc {
+	createOperation := handler.NewCreateOperation(
+		s.logger,
+		usecase.NewCreateOperation(s.repos.OperationWriter),
+	)
+
+	return s.handler(createOperation.Handler)
+}
+
+func (s Server) updateOperationHandler() echo.HandlerFunc {
+	updateOperation := handler.NewUpdateOperation(
+		s.logger,
+		usecase.NewUpdateOperation(s.repos.OperationReader, s.repos.OperationWriter),
+	)
+
+	return s.handler(updateOperation.Handler)
+}
+
+func (s Server) createWebhookHandler() echo.HandlerFunc {
+	createWebhook := handler.NewCreateWebhook(
+		s.logger,
+		usecase.NewCreateWebhook(s.repos.WebhookWriter),
+	)
+
+	return s.handler(createWebhook.Handler)
+}
+
+func (s Server) listFailedWebhookDeliveriesHandler() echo.HandlerFunc {
+	listFailedWebhookDeliveries := handler.NewListFailedWebhookDeliveries(
+		s.logger,
+		usecase.NewListFailedWebhookDeliveries(s.repos.WebhookDeliveryReader),
+	)
+
+	return s.handler(listFailedWebhookDeliveries.Handler)
+}
+
+func (s Server) replayWebhookDeliveryHandler() echo.HandlerFunc {
+	replayWebhookDelivery := handler.NewReplayWebhookDelivery(
+		s.logger,
+		usecase.NewReplayWebhookDelivery(s.repos.WebhookDeliveryReader, s.repos.WebhookDeliveryWriter, time.Now),
+	)
+
+	return s.handler(replayWebhookDelivery.Handler)
+}
+
 // handler translates a standard http handler to an echo handler
 func (s Server) handler(fn func(http.ResponseWriter, *http.Request)) echo.HandlerFunc {
 	return func(ctx echo.Context) error {
@@ -79,12 +290,32 @@ func (s Server) handler(fn func(http.ResponseWriter, *http.Request)) echo.Handle
 	}
 }
 
+// except skips the middleware in the routes of the paths
+func (s Server) except(mw echo.MiddlewareFunc, paths ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		withMiddleware := mw(next)
+
+		return func(ctx echo.Context) error {
+			for _, path := range paths {
+				if ctx.Path() == path {
+					return next(ctx)
+				}
+			}
+
+			return withMiddleware(ctx)
+		}
+	}
+}
+
 // middleware translates a standard http middleware to an echo middleware
 func (s Server) middleware(fn func(http.ResponseWriter, *http.Request, http.HandlerFunc)) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(ctx echo.Context) error {
 			var nextFn http.HandlerFunc = func(rw http.ResponseWriter, r *http.Request) {
-				// todo fix translation
+				// the next handlers must receive the request and the response writer changed by the middleware
+				ctx.SetRequest(r)
+				ctx.Response().Writer = rw
+
 				next(ctx)
 			}
 
@@ -0,0 +1,56 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+	"github.com/tonytcb/bank-transactions-go/usecase"
+)
+
+// Billing closes the billing cycles of the accounts periodically, generating their invoices
+type Billing struct {
+	logger   domain.Logger
+	repos    *repository.Repositories
+	interval time.Duration
+}
+
+// NewBilling creates a Billing struct with its dependencies
+func NewBilling(logger domain.Logger, repos *repository.Repositories, interval time.Duration) *Billing {
+	return &Billing{logger: logger.With(domain.NewLogField("job", "billing")), repos: repos, interval: interval}
+}
+
+// Listen closes the billing cycles right away and then at every interval. Cycles not closed because of an error are
+// closed in the next run.
+func (b Billing) Listen() {
+	b.logger.Info(context.Background(), "starting billing job")
+
+	var (
+		closeCycles = usecase.NewCloseBillingCycles(
+			b.repos.AccountReader,
+			b.repos.StatementReader,
+			b.repos.InvoiceReader,
+			b.repos.InvoiceWriter,
+		)
+		ticker = time.NewTicker(b.interval)
+	)
+	defer ticker.Stop()
+
+	for {
+		// a run is not allowed to last longer than the interval, so it never overlaps the next one
+		ctx, cancel := context.WithTimeout(context.Background(), b.interval)
+		closed, err := closeCycles.Close(ctx, time.Now())
+		cancel()
+
+		if err != nil {
+			b.logger.Error(context.Background(), "error to close the billing cycles", domain.NewErrLogField(err))
+		}
+
+		if closed > 0 {
+			b.logger.Info(context.Background(), "invoices generated", domain.NewLogField("count", closed))
+		}
+
+		<-ticker.C
+	}
+}
@@ -0,0 +1,62 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+	"github.com/tonytcb/bank-transactions-go/usecase"
+)
+
+// Idempotency deletes the expired idempotency keys periodically
+type Idempotency struct {
+	logger         domain.Logger
+	repos          *repository.Repositories
+	requestTimeout time.Duration
+	ttl            time.Duration
+	interval       time.Duration
+}
+
+// NewIdempotency creates an Idempotency struct with its dependencies. The keys expire after the ttl, never before the
+// requests they identify, which last up to the request timeout.
+func NewIdempotency(
+	logger domain.Logger,
+	repos *repository.Repositories,
+	requestTimeout, ttl, interval time.Duration,
+) *Idempotency {
+	return &Idempotency{
+		logger:         logger.With(domain.NewLogField("job", "idempotency")),
+		repos:          repos,
+		requestTimeout: requestTimeout,
+		ttl:            ttl,
+		interval:       interval,
+	}
+}
+
+// Listen deletes the expired keys right away and then at every interval
+func (i Idempotency) Listen() {
+	i.logger.Info(context.Background(), "starting idempotency job")
+
+	var (
+		idempotency = usecase.NewIdempotency(i.repos.Idempotency, time.Now, i.requestTimeout, i.ttl)
+		ticker      = time.NewTicker(i.interval)
+	)
+	defer ticker.Stop()
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), i.interval)
+		deleted, err := idempotency.DeleteExpired(ctx)
+		cancel()
+
+		if err != nil {
+			i.logger.Error(context.Background(), "error to delete the expired idempotency keys", domain.NewErrLogField(err))
+		}
+
+		if deleted > 0 {
+			i.logger.Info(context.Background(), "expired idempotency keys deleted", domain.NewLogField("count", deleted))
+		}
+
+		<-ticker.C
+	}
+}
@@ -0,0 +1,59 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+	"github.com/tonytcb/bank-transactions-go/usecase"
+)
+
+// Interest posts the daily charges on the overdue balances of the invoices periodically
+type Interest struct {
+	logger   domain.Logger
+	repos    *repository.Repositories
+	policy   *domain.InterestPolicy
+	interval time.Duration
+}
+
+// NewInterest creates an Interest struct with its dependencies
+func NewInterest(logger domain.Logger, repos *repository.Repositories, policy *domain.InterestPolicy, interval time.Duration) *Interest {
+	return &Interest{logger: logger.With(domain.NewLogField("job", "interest")), repos: repos, policy: policy, interval: interval}
+}
+
+// Listen posts the charges of the day right away and then at every interval. Charges already posted in the day are
+// not posted again, so the interval may be shorter than a day to recover from errors in the same day.
+func (i Interest) Listen() {
+	i.logger.Info(context.Background(), "starting interest job")
+
+	var (
+		accrueInterest = usecase.NewAccrueInterest(
+			i.repos.AccountReader,
+			i.repos.InterestReader,
+			i.repos.StatementReader,
+			i.repos.TransactionWriter,
+			i.repos.UnitOfWork,
+			i.policy,
+			time.Now,
+		)
+		ticker = time.NewTicker(i.interval)
+	)
+	defer ticker.Stop()
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), i.interval)
+		posted, err := accrueInterest.Accrue(ctx)
+		cancel()
+
+		if err != nil {
+			i.logger.Error(context.Background(), "error to accrue the interest", domain.NewErrLogField(err))
+		}
+
+		if posted > 0 {
+			i.logger.Info(context.Background(), "charges posted", domain.NewLogField("count", posted))
+		}
+
+		<-ticker.C
+	}
+}
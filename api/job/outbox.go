@@ -0,0 +1,64 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/publisher"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+	"github.com/tonytcb/bank-transactions-go/usecase"
+)
+
+const outboxBatchSize = 100
+
+// Outbox relays the events stored in the outbox to the publisher periodically, scheduling their deliveries to the
+// webhooks subscribed as well
+type Outbox struct {
+	logger    domain.Logger
+	repos     *repository.Repositories
+	publisher domain.EventPublisher
+	interval  time.Duration
+}
+
+// NewOutbox creates an Outbox struct with its dependencies
+func NewOutbox(logger domain.Logger, repos *repository.Repositories, publisher domain.EventPublisher, interval time.Duration) *Outbox {
+	return &Outbox{logger: logger.With(domain.NewLogField("job", "outbox")), repos: repos, publisher: publisher, interval: interval}
+}
+
+// Listen publishes the pending events right away and then at every interval. When a batch is full, the next one is
+// relayed right away, so a backlog of events does not wait for the interval.
+func (o Outbox) Listen() {
+	o.logger.Info(context.Background(), "starting outbox job")
+
+	var (
+		scheduler = usecase.NewScheduleWebhookDeliveries(
+			o.repos.WebhookReader,
+			o.repos.WebhookDeliveryWriter,
+			time.Now,
+		)
+		relayEvents = usecase.NewRelayEvents(o.repos.EventWriter, publisher.NewFanOut(o.publisher, scheduler), outboxBatchSize)
+		ticker      = time.NewTicker(o.interval)
+	)
+	defer ticker.Stop()
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), o.interval)
+		published, err := relayEvents.Relay(ctx)
+		cancel()
+
+		if err != nil {
+			o.logger.Error(context.Background(), "error to publish the events", domain.NewErrLogField(err))
+		}
+
+		if published > 0 {
+			o.logger.Info(context.Background(), "events published", domain.NewLogField("count", published))
+		}
+
+		if err == nil && published == outboxBatchSize {
+			continue
+		}
+
+		<-ticker.C
+	}
+}
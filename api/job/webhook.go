@@ -0,0 +1,72 @@
+package job
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/publisher"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+	"github.com/tonytcb/bank-transactions-go/usecase"
+)
+
+const (
+	webhookBatchSize = 100
+
+	// webhookRequestTimeout is how long a partner has to acknowledge a delivery
+	webhookRequestTimeout = 10 * time.Second
+)
+
+// Webhook sends the deliveries due to the webhooks periodically
+type Webhook struct {
+	logger   domain.Logger
+	repos    *repository.Repositories
+	policy   *domain.WebhookRetryPolicy
+	interval time.Duration
+}
+
+// NewWebhook creates a Webhook struct with its dependencies
+func NewWebhook(logger domain.Logger, repos *repository.Repositories, policy *domain.WebhookRetryPolicy, interval time.Duration) *Webhook {
+	return &Webhook{logger: logger.With(domain.NewLogField("job", "webhook")), repos: repos, policy: policy, interval: interval}
+}
+
+// Listen sends the deliveries due right away and then at every interval. When a batch is full, the next one is sent
+// right away, so a backlog of deliveries does not wait for the interval.
+func (w Webhook) Listen() {
+	w.logger.Info(context.Background(), "starting webhook job")
+
+	var (
+		deliverWebhooks = usecase.NewDeliverWebhooks(
+			w.repos.WebhookDeliveryReader,
+			w.repos.WebhookDeliveryWriter,
+			publisher.NewWebhookSender(&http.Client{Timeout: webhookRequestTimeout}, time.Now),
+			w.policy,
+			webhookBatchSize,
+			time.Now,
+		)
+		ticker = time.NewTicker(w.interval)
+	)
+	defer ticker.Stop()
+
+	for {
+		// the deadline must fit a full batch of partners not answering
+		ctx, cancel := context.WithTimeout(context.Background(), w.interval+webhookBatchSize*webhookRequestTimeout)
+		delivered, err := deliverWebhooks.Deliver(ctx)
+		cancel()
+
+		if err != nil {
+			w.logger.Error(context.Background(), "error to deliver the webhooks", domain.NewErrLogField(err))
+		}
+
+		if delivered > 0 {
+			w.logger.Info(context.Background(), "webhook deliveries acknowledged", domain.NewLogField("count", delivered))
+		}
+
+		if err == nil && delivered == webhookBatchSize {
+			continue
+		}
+
+		<-ticker.C
+	}
+}
@@ -1,14 +1,23 @@
 package domain
 
 import (
+	"context"
+	"fmt"
 	"time"
 )
 
 // Account contains all account's data
 type Account struct {
-	id        *ID
-	document  *Document
-	createdAt time.Time
+	id                   *ID
+	document             *Document
+	currency             Currency
+	creditLimit          Money
+	availableCreditLimit Money
+	billingCycle         BillingCycle
+	status               AccountStatus
+	statusReason         StatusReason
+	statusChangedAt      time.Time
+	createdAt            time.Time
 }
 
 // NewAccount creates a new Account struct
@@ -19,24 +28,93 @@ func NewAccount(documentNumber DocumentNumber) (*Account, error) {
 	}
 
 	return &Account{
-		id:       NewID(uint64(0)),
-		document: document,
+		id:                   NewID(uint64(0)),
+		document:             document,
+		currency:             CurrencyBRL,
+		creditLimit:          NewMoney(0, CurrencyBRL),
+		availableCreditLimit: NewMoney(0, CurrencyBRL),
+		billingCycle:         DefaultBillingCycle(),
+		status:               AccountStatusActive,
 	}, nil
 }
 
 // Store stores an account given a Repository
-func (a *Account) Store(repo AccountRepositoryWriter) (*Account, error) {
-	id, err := repo.Store(a)
+func (a *Account) Store(ctx context.Context, repo AccountRepositoryWriter) (*Account, error) {
+	id, err := repo.Store(ctx, a)
 	if err != nil {
 		// todo add context to the error
 		return nil, err
 	}
 
-	return &Account{
-		id:        id,
-		document:  a.document,
-		createdAt: time.Now(),
-	}, nil
+	account := *a
+	account.id = id
+	account.createdAt = time.Now()
+
+	return &account, nil
+}
+
+// Balance calculates the account's balance at the informed moment given a repository, in the account currency
+func (a *Account) Balance(ctx context.Context, repo AccountRepositoryReader, at time.Time) (*Balance, error) {
+	amount, err := repo.BalanceByID(ctx, a.ID(), at)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewBalance(a, amount.WithCurrency(a.Currency()), at), nil
+}
+
+// ApplyTransaction returns a new Account struct with the available credit limit updated by the transaction amount.
+// The transaction amount must be in the account currency, and outgoing transactions are rejected when its amount
+// exceeds the available credit limit, except the charges on overdue balances, which are owed regardless of the limit.
+func (a *Account) ApplyTransaction(t *Transaction) (*Account, error) {
+	if err := a.Accepts(t); err != nil {
+		return nil, err
+	}
+
+	if t.Amount().Currency() != a.Currency() {
+		description := fmt.Sprintf("'%s' does not match the account currency '%s'", t.Amount().Currency(), a.Currency())
+
+		return nil, NewErrDomain("currency", description)
+	}
+
+	available := a.AvailableCreditLimit().Add(t.Amount())
+
+	if t.Amount().IsNegative() && available.IsNegative() && !t.Operation().IsCharge() {
+		description := fmt.Sprintf("'%s' exceeds the available credit limit '%s'", t.Amount().Abs(), a.AvailableCreditLimit())
+
+		return nil, NewErrDomain("amount", description)
+	}
+
+	return a.WithAvailableCreditLimit(available), nil
+}
+
+// Accepts checks if the account status accepts the transaction: blocked accounts do not accept debits other than the
+// charges on overdue balances, and closed accounts do not accept any transaction
+func (a *Account) Accepts(t *Transaction) error {
+	switch a.Status() {
+	case AccountStatusClosed:
+		return NewErrDomain("account", "is closed and does not accept transactions")
+	case AccountStatusBlocked:
+		if t.Amount().IsNegative() && !t.Operation().IsCharge() {
+			return NewErrDomain("account", "is blocked and does not accept debits")
+		}
+	}
+
+	return nil
+}
+
+// ChangeStatus returns a new Account struct with the informed status, and the change to be registered. Active and
+// blocked accounts can be changed to each other or closed, closed accounts cannot be changed.
+func (a *Account) ChangeStatus(to AccountStatus, reason StatusReason, at time.Time) (*Account, *AccountStatusChange, error) {
+	from := a.Status()
+
+	if !from.canChangeTo(to) {
+		return nil, nil, NewErrDomain("status", fmt.Sprintf("cannot be changed from '%s' to '%s'", from, to))
+	}
+
+	change := &AccountStatusChange{accountID: a.ID(), from: from, to: to, reason: reason, changedAt: at}
+
+	return a.WithStatus(to, reason, at), change, nil
 }
 
 // Document returns the document value
@@ -49,6 +127,49 @@ func (a *Account) ID() *ID {
 	return a.id
 }
 
+// Currency returns the currency of the account, all its amounts are in this currency
+func (a *Account) Currency() Currency {
+	return a.currency
+}
+
+// CreditLimit returns the credit limit granted to the account
+func (a *Account) CreditLimit() Money {
+	return a.creditLimit
+}
+
+// AvailableCreditLimit returns the remaining credit limit of the account
+func (a *Account) AvailableCreditLimit() Money {
+	return a.availableCreditLimit
+}
+
+// BillingCycle returns the billing cycle of the account, the default one when it was not defined
+func (a *Account) BillingCycle() BillingCycle {
+	if a.billingCycle.IsZero() {
+		return DefaultBillingCycle()
+	}
+
+	return a.billingCycle
+}
+
+// Status returns the status of the account, accounts are active until their status is changed
+func (a *Account) Status() AccountStatus {
+	if a.status == "" {
+		return AccountStatusActive
+	}
+
+	return a.status
+}
+
+// StatusReason returns the reason code of the last status change, empty when the status was never changed
+func (a *Account) StatusReason() StatusReason {
+	return a.statusReason
+}
+
+// StatusChangedAt returns when the status was changed for the last time, zero when the status was never changed
+func (a *Account) StatusChangedAt() time.Time {
+	return a.statusChangedAt
+}
+
 // CreatedAt returns the createdAt value
 func (a *Account) CreatedAt() time.Time {
 	return a.createdAt
@@ -56,18 +177,61 @@ func (a *Account) CreatedAt() time.Time {
 
 // WithID returns a new Account struct with the informed ID value
 func (a *Account) WithID(id *ID) *Account {
-	return &Account{
-		id:        id,
-		document:  a.Document(),
-		createdAt: a.CreatedAt(),
-	}
+	account := *a
+	account.id = id
+
+	return &account
 }
 
 // WithCreateAt returns a new Account struct with the informed createAt value
 func (a *Account) WithCreateAt(t time.Time) *Account {
-	return &Account{
-		id:        a.ID(),
-		document:  a.Document(),
-		createdAt: t,
-	}
+	account := *a
+	account.createdAt = t
+
+	return &account
+}
+
+// WithCurrency returns a new Account struct with the informed currency, also applied to its credit limits
+func (a *Account) WithCurrency(currency Currency) *Account {
+	account := *a
+	account.currency = currency
+	account.creditLimit = a.creditLimit.WithCurrency(currency)
+	account.availableCreditLimit = a.availableCreditLimit.WithCurrency(currency)
+
+	return &account
+}
+
+// WithCreditLimit returns a new Account struct with the informed credit limit, fully available
+func (a *Account) WithCreditLimit(limit Money) *Account {
+	account := *a
+	account.creditLimit = limit
+	account.availableCreditLimit = limit
+
+	return &account
+}
+
+// WithAvailableCreditLimit returns a new Account struct with the informed available credit limit
+func (a *Account) WithAvailableCreditLimit(available Money) *Account {
+	account := *a
+	account.availableCreditLimit = available
+
+	return &account
+}
+
+// WithBillingCycle returns a new Account struct with the informed billing cycle
+func (a *Account) WithBillingCycle(cycle BillingCycle) *Account {
+	account := *a
+	account.billingCycle = cycle
+
+	return &account
+}
+
+// WithStatus returns a new Account struct with the informed status, changed for the informed reason at the informed time
+func (a *Account) WithStatus(status AccountStatus, reason StatusReason, changedAt time.Time) *Account {
+	account := *a
+	account.status = status
+	account.statusReason = reason
+	account.statusChangedAt = changedAt
+
+	return &account
 }
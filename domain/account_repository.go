@@ -1,19 +1,32 @@
 package domain
 
+import (
+	"context"
+	"time"
+)
+
 // AccountRepositoryWriter represents the behaviour of the Account Repository to write operation
 type AccountRepositoryWriter interface {
-	Store(*Account) (*ID, error)
+	Store(context.Context, *Account) (*ID, error)
+}
+
+// AccountStatusRepositoryWriter represents the behaviour of the Account Repository to register status changes.
+// The change must be rejected when the account status is no longer the status it was changed from.
+type AccountStatusRepositoryWriter interface {
+	StoreStatusChange(context.Context, *AccountStatusChange) error
 }
 
 // AccountRepositoryReader represents the behaviour of the Account Repository to read operation
 type AccountRepositoryReader interface {
-	FindOneByID(*ID) (*Account, error)
+	FindOneByID(context.Context, *ID) (*Account, error)
+	BalanceByID(context.Context, *ID, time.Time) (Money, error)
 }
 
 // AccountRepositoryMock is a fake representation of an AccountRepositoryWriter, useful to create unit tests
 type AccountRepositoryMock struct {
 	id      *ID
 	account *Account
+	balance Money
 	err     error
 }
 
@@ -22,8 +35,13 @@ func NewAccountRepositoryMock(id *ID, acc *Account, err error) *AccountRepositor
 	return &AccountRepositoryMock{id: id, account: acc, err: err}
 }
 
+// WithBalance returns a new AccountRepositoryMock struct with the informed balance result
+func (a AccountRepositoryMock) WithBalance(balance Money) *AccountRepositoryMock {
+	return &AccountRepositoryMock{id: a.id, account: a.account, balance: balance, err: a.err}
+}
+
 // Store stores an account
-func (a AccountRepositoryMock) Store(_ *Account) (*ID, error) {
+func (a AccountRepositoryMock) Store(_ context.Context, _ *Account) (*ID, error) {
 	if a.err != nil {
 		return nil, a.err
 	}
@@ -31,11 +49,25 @@ func (a AccountRepositoryMock) Store(_ *Account) (*ID, error) {
 	return a.id, nil
 }
 
+// StoreStatusChange stores a change of the account status
+func (a AccountRepositoryMock) StoreStatusChange(_ context.Context, _ *AccountStatusChange) error {
+	return a.err
+}
+
 // FindOneByID finds an account by its id
-func (a AccountRepositoryMock) FindOneByID(_ *ID) (*Account, error) {
+func (a AccountRepositoryMock) FindOneByID(_ context.Context, _ *ID) (*Account, error) {
 	if a.err != nil {
 		return nil, a.err
 	}
 
 	return a.account, nil
 }
+
+// BalanceByID returns the balance of an account
+func (a AccountRepositoryMock) BalanceByID(_ context.Context, _ *ID, _ time.Time) (Money, error) {
+	if a.err != nil {
+		return Money{}, a.err
+	}
+
+	return a.balance, nil
+}
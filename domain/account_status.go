@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// AccountStatusActive represents an account accepting all the operations
+	AccountStatusActive AccountStatus = "ACTIVE"
+
+	// AccountStatusBlocked represents an account temporarily not accepting debits, as when fraud is suspected
+	AccountStatusBlocked AccountStatus = "BLOCKED"
+
+	// AccountStatusClosed represents an account permanently not accepting any operation
+	AccountStatusClosed AccountStatus = "CLOSED"
+)
+
+const (
+	// StatusReasonFraudSuspected represents a change due to a suspected fraud
+	StatusReasonFraudSuspected StatusReason = "FRAUD_SUSPECTED"
+
+	// StatusReasonCustomerRequest represents a change requested by the customer
+	StatusReasonCustomerRequest StatusReason = "CUSTOMER_REQUEST"
+
+	// StatusReasonDelinquency represents a change due to overdue debts
+	StatusReasonDelinquency StatusReason = "DELINQUENCY"
+
+	// StatusReasonRegulatory represents a change required by a regulator or a court order
+	StatusReasonRegulatory StatusReason = "REGULATORY"
+
+	// StatusReasonIssueResolved represents a change after the issue which blocked the account was resolved
+	StatusReasonIssueResolved StatusReason = "ISSUE_RESOLVED"
+)
+
+var (
+	// accountStatusTransitions contains the allowed transitions from each status, closed accounts cannot be changed
+	accountStatusTransitions = map[AccountStatus][]AccountStatus{
+		AccountStatusActive:  {AccountStatusBlocked, AccountStatusClosed},
+		AccountStatusBlocked: {AccountStatusActive, AccountStatusClosed},
+	}
+
+	statusReasons = map[StatusReason]struct{}{
+		StatusReasonFraudSuspected:  {},
+		StatusReasonCustomerRequest: {},
+		StatusReasonDelinquency:     {},
+		StatusReasonRegulatory:      {},
+		StatusReasonIssueResolved:   {},
+	}
+)
+
+// AccountStatus represents the status of an account in its lifecycle
+type AccountStatus string
+
+// NewAccountStatus builds a valid AccountStatus
+func NewAccountStatus(v string) (AccountStatus, error) {
+	status := AccountStatus(strings.ToUpper(strings.TrimSpace(v)))
+
+	if _, ok := accountStatusTransitions[status]; ok || status == AccountStatusClosed {
+		return status, nil
+	}
+
+	return "", NewErrDomain("status", fmt.Sprintf("'%s' is not a valid account status", v))
+}
+
+// String cast the account status value to string
+func (s AccountStatus) String() string {
+	return string(s)
+}
+
+// canChangeTo checks if the transition to the informed status is allowed
+func (s AccountStatus) canChangeTo(to AccountStatus) bool {
+	for _, v := range accountStatusTransitions[s] {
+		if v == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StatusReason represents the reason code of a change of the account status
+type StatusReason string
+
+// NewStatusReason builds a valid StatusReason
+func NewStatusReason(v string) (StatusReason, error) {
+	reason := StatusReason(strings.ToUpper(strings.TrimSpace(v)))
+
+	if _, ok := statusReasons[reason]; !ok {
+		return "", NewErrDomain("reason", fmt.Sprintf("'%s' is not a valid status reason", v))
+	}
+
+	return reason, nil
+}
+
+// String cast the status reason value to string
+func (r StatusReason) String() string {
+	return string(r)
+}
+
+// AccountStatusChange represents a transition of the account status
+type AccountStatusChange struct {
+	accountID *ID
+	from      AccountStatus
+	to        AccountStatus
+	reason    StatusReason
+	changedAt time.Time
+}
+
+// AccountID returns the id of the changed account
+func (c *AccountStatusChange) AccountID() *ID {
+	return c.accountID
+}
+
+// From returns the status before the change
+func (c *AccountStatusChange) From() AccountStatus {
+	return c.from
+}
+
+// To returns the status after the change
+func (c *AccountStatusChange) To() AccountStatus {
+	return c.to
+}
+
+// Reason returns the reason code of the change
+func (c *AccountStatusChange) Reason() StatusReason {
+	return c.reason
+}
+
+// ChangedAt returns when the status was changed
+func (c *AccountStatusChange) ChangedAt() time.Time {
+	return c.changedAt
+}
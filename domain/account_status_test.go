@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewAccountStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    AccountStatus
+		wantErr error
+	}{
+		// fails
+		{name: "unknown status", value: "SUSPENDED", wantErr: NewErrDomain("status", "'SUSPENDED' is not a valid account status")},
+		{name: "empty status", value: "", wantErr: NewErrDomain("status", "'' is not a valid account status")},
+
+		// successes
+		{name: "active status", value: "ACTIVE", want: AccountStatusActive},
+		{name: "lower case blocked status", value: "blocked", want: AccountStatusBlocked},
+		{name: "closed status", value: "CLOSED", want: AccountStatusClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewAccountStatus(tt.value)
+
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("NewAccountStatus() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("NewAccountStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewStatusReason(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    StatusReason
+		wantErr error
+	}{
+		// fails
+		{name: "unknown reason", value: "BORED", wantErr: NewErrDomain("reason", "'BORED' is not a valid status reason")},
+
+		// successes
+		{name: "fraud suspected", value: "FRAUD_SUSPECTED", want: StatusReasonFraudSuspected},
+		{name: "lower case customer request", value: "customer_request", want: StatusReasonCustomerRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewStatusReason(tt.value)
+
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("NewStatusReason() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("NewStatusReason() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"context"
 	"errors"
 	"reflect"
 	"testing"
@@ -29,7 +30,7 @@ func TestNewAccount(t *testing.T) {
 			args: args{documentNumber: "00000000191"},
 			want: &Account{
 				id:       NewID(uint64(0)),
-				document: &Document{number: DocumentNumber("00000000191")},
+				document: &Document{documentType: DocumentTypeCPF, number: DocumentNumber("00000000191")},
 			},
 			wantErr: nil,
 		},
@@ -98,7 +99,7 @@ func TestAccount_Store(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := baseAccount.Store(tt.args.repo)
+			got, err := baseAccount.Store(context.Background(), tt.args.repo)
 
 			if (err != nil) && !reflect.DeepEqual(err, tt.wantErr) {
 				t.Errorf("Store() error = %v, wantErr %v", err, tt.wantErr)
@@ -214,3 +215,282 @@ func TestAccount_WithCreateAt(t *testing.T) {
 		})
 	}
 }
+
+func TestAccount_Balance(t *testing.T) {
+	var (
+		account = &Account{id: NewID(1), document: &Document{number: "00000000191"}, currency: CurrencyBRL}
+		at      = time.Date(2020, 10, 4, 13, 0, 0, 0, time.UTC)
+	)
+
+	type args struct {
+		repo AccountRepositoryReader
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    Money
+		wantErr error
+	}{
+		{
+			name: "repository error",
+			args: args{
+				repo: NewAccountRepositoryMock(nil, nil, errors.New("unknown repository error")),
+			},
+			wantErr: errors.New("unknown repository error"),
+		},
+		{
+			name: "negative balance",
+			args: args{
+				repo: NewAccountRepositoryMock(nil, nil, nil).WithBalance(brl(-15050)),
+			},
+			want: brl(-15050),
+		},
+		{
+			name: "positive balance",
+			args: args{
+				repo: NewAccountRepositoryMock(nil, nil, nil).WithBalance(brl(20000)),
+			},
+			want: brl(20000),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := account.Balance(context.Background(), tt.args.repo, at)
+
+			if (err != nil) && !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Balance() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.Amount() != tt.want {
+				t.Errorf("Balance().Amount() = %v, want %v", got.Amount(), tt.want)
+			}
+
+			if !got.At().Equal(at) {
+				t.Errorf("Balance().At() = %v, want %v", got.At(), at)
+			}
+
+			if got.Account() != account {
+				t.Errorf("Balance().Account() = %v, want %v", got.Account(), account)
+			}
+		})
+	}
+}
+
+func TestAccount_ApplyTransaction(t *testing.T) {
+	var (
+		purchase, _ = NewTransaction(NewID(1), OperationCompraAVista, brl(30000))
+		withdraw, _ = NewTransaction(NewID(1), OperationSaque, brl(100001))
+		payment, _  = NewTransaction(NewID(1), OperationPagamento, brl(15000))
+		lateFee, _  = NewTransaction(NewID(1), OperationMultaAtraso, brl(1000))
+	)
+
+	type args struct {
+		transaction *Transaction
+	}
+
+	tests := []struct {
+		name          string
+		account       *Account
+		args          args
+		wantAvailable Money
+		wantErr       error
+	}{
+		// fails
+		{
+			name:    "outgoing transaction exceeds the available credit limit",
+			account: (&Account{id: NewID(1), currency: CurrencyBRL}).WithCreditLimit(brl(100000)),
+			args: args{
+				transaction: withdraw,
+			},
+			wantErr: NewErrDomain("amount", "'1000.01' exceeds the available credit limit '1000.00'"),
+		},
+		{
+			name:    "outgoing transaction with no credit limit",
+			account: &Account{id: NewID(1), currency: CurrencyBRL, availableCreditLimit: brl(0)},
+			args: args{
+				transaction: purchase,
+			},
+			wantErr: NewErrDomain("amount", "'300.00' exceeds the available credit limit '0.00'"),
+		},
+		{
+			name:    "transaction in a currency different from the account currency",
+			account: (&Account{id: NewID(1), currency: "USD"}).WithCreditLimit(NewMoney(100000, "USD")),
+			args: args{
+				transaction: purchase,
+			},
+			wantErr: NewErrDomain("currency", "'BRL' does not match the account currency 'USD'"),
+		},
+		{
+			name:    "outgoing transaction in a blocked account",
+			account: (&Account{id: NewID(1), currency: CurrencyBRL, status: AccountStatusBlocked}).WithCreditLimit(brl(100000)),
+			args: args{
+				transaction: purchase,
+			},
+			wantErr: NewErrDomain("account", "is blocked and does not accept debits"),
+		},
+		{
+			name:    "incoming transaction in a closed account",
+			account: (&Account{id: NewID(1), currency: CurrencyBRL, status: AccountStatusClosed}).WithCreditLimit(brl(100000)),
+			args: args{
+				transaction: payment,
+			},
+			wantErr: NewErrDomain("account", "is closed and does not accept transactions"),
+		},
+
+		// successes
+		{
+			name:    "outgoing transaction consumes the available credit limit",
+			account: (&Account{id: NewID(1), currency: CurrencyBRL}).WithCreditLimit(brl(100000)),
+			args: args{
+				transaction: purchase,
+			},
+			wantAvailable: brl(70000),
+		},
+		{
+			name:    "outgoing transaction consumes all the available credit limit",
+			account: (&Account{id: NewID(1), currency: CurrencyBRL}).WithCreditLimit(brl(100000)).WithAvailableCreditLimit(brl(30000)),
+			args: args{
+				transaction: purchase,
+			},
+			wantAvailable: brl(0),
+		},
+		{
+			name:    "incoming transaction in a blocked account",
+			account: (&Account{id: NewID(1), currency: CurrencyBRL, status: AccountStatusBlocked}).WithCreditLimit(brl(100000)).WithAvailableCreditLimit(brl(10000)),
+			args: args{
+				transaction: payment,
+			},
+			wantAvailable: brl(25000),
+		},
+		{
+			name:    "charge exceeds the available credit limit of a blocked account",
+			account: (&Account{id: NewID(1), currency: CurrencyBRL, status: AccountStatusBlocked}).WithCreditLimit(brl(100000)).WithAvailableCreditLimit(brl(500)),
+			args: args{
+				transaction: lateFee,
+			},
+			wantAvailable: brl(-500),
+		},
+		{
+			name:    "incoming transaction restores the available credit limit",
+			account: (&Account{id: NewID(1), currency: CurrencyBRL}).WithCreditLimit(brl(100000)).WithAvailableCreditLimit(brl(10000)),
+			args: args{
+				transaction: payment,
+			},
+			wantAvailable: brl(25000),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.account.ApplyTransaction(tt.args.transaction)
+
+			if (err != nil) && !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("ApplyTransaction() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if (err == nil) && (tt.wantErr != nil) {
+				t.Errorf("ApplyTransaction() error = nil, wantErr %v", tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got == tt.account {
+				t.Error("ApplyTransaction() should return a new Account struct to assure immutability")
+			}
+
+			if got.AvailableCreditLimit() != tt.wantAvailable {
+				t.Errorf("AvailableCreditLimit() = %v, want %v", got.AvailableCreditLimit(), tt.wantAvailable)
+			}
+
+			if got.CreditLimit() != tt.account.CreditLimit() {
+				t.Errorf("CreditLimit() = %v, want %v", got.CreditLimit(), tt.account.CreditLimit())
+			}
+		})
+	}
+}
+
+func TestAccount_ChangeStatus(t *testing.T) {
+	var (
+		at      = time.Date(2020, 10, 4, 13, 0, 0, 0, time.UTC)
+		account = func(status AccountStatus) *Account {
+			return &Account{id: NewID(1), status: status}
+		}
+	)
+
+	type args struct {
+		to     AccountStatus
+		reason StatusReason
+	}
+
+	tests := []struct {
+		name    string
+		account *Account
+		args    args
+		wantErr error
+	}{
+		// fails
+		{
+			name:    "closed account cannot be reopened",
+			account: account(AccountStatusClosed),
+			args:    args{to: AccountStatusActive, reason: StatusReasonIssueResolved},
+			wantErr: NewErrDomain("status", "cannot be changed from 'CLOSED' to 'ACTIVE'"),
+		},
+		{
+			name:    "account already blocked",
+			account: account(AccountStatusBlocked),
+			args:    args{to: AccountStatusBlocked, reason: StatusReasonFraudSuspected},
+			wantErr: NewErrDomain("status", "cannot be changed from 'BLOCKED' to 'BLOCKED'"),
+		},
+
+		// successes
+		{
+			name:    "account without status blocked",
+			account: &Account{id: NewID(1)},
+			args:    args{to: AccountStatusBlocked, reason: StatusReasonFraudSuspected},
+		},
+		{
+			name:    "blocked account unblocked",
+			account: account(AccountStatusBlocked),
+			args:    args{to: AccountStatusActive, reason: StatusReasonIssueResolved},
+		},
+		{
+			name:    "blocked account closed",
+			account: account(AccountStatusBlocked),
+			args:    args{to: AccountStatusClosed, reason: StatusReasonDelinquency},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, change, err := tt.account.ChangeStatus(tt.args.to, tt.args.reason, at)
+
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("ChangeStatus() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.Status() != tt.args.to || got.StatusReason() != tt.args.reason || !got.StatusChangedAt().Equal(at) {
+				t.Errorf("ChangeStatus() = %v %v %v", got.Status(), got.StatusReason(), got.StatusChangedAt())
+			}
+
+			if change.From() != tt.account.Status() || change.To() != tt.args.to || change.AccountID() != tt.account.ID() {
+				t.Errorf("ChangeStatus() change = from %v to %v of %v", change.From(), change.To(), change.AccountID())
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// Balance represents the sum of all transactions of an account at a given moment
+type Balance struct {
+	account *Account
+	amount  Money
+	at      time.Time
+}
+
+// NewBalance builds a new Balance struct
+func NewBalance(account *Account, amount Money, at time.Time) *Balance {
+	return &Balance{account: account, amount: amount, at: at}
+}
+
+// Account returns the account owner of the balance
+func (b *Balance) Account() *Account {
+	return b.account
+}
+
+// Amount returns the balance value
+func (b *Balance) Amount() Money {
+	return b.amount
+}
+
+// At returns the moment in which the balance was calculated
+func (b *Balance) At() time.Time {
+	return b.at
+}
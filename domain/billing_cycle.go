@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultClosingDay is the closing day of the billing cycle used when it is not informed
+	DefaultClosingDay = 25
+
+	// DefaultDueDay is the due day of the invoices used when it is not informed
+	DefaultDueDay = 5
+
+	// MaxBillingDay is the latest day allowed to close a billing cycle or to pay an invoice, so it exists in every month
+	MaxBillingDay = 28
+)
+
+// BillingCycle represents the monthly billing cycle of an account: the cycle closes at the end of the closing day,
+// and its invoice is due in the next due day
+type BillingCycle struct {
+	closingDay int
+	dueDay     int
+}
+
+// NewBillingCycle builds a valid BillingCycle
+func NewBillingCycle(closingDay, dueDay int) (BillingCycle, error) {
+	description := fmt.Sprintf("must be between 1 and %d", MaxBillingDay)
+
+	if closingDay < 1 || closingDay > MaxBillingDay {
+		return BillingCycle{}, NewErrDomain("closing_day", description)
+	}
+
+	if dueDay < 1 || dueDay > MaxBillingDay {
+		return BillingCycle{}, NewErrDomain("due_day", description)
+	}
+
+	return BillingCycle{closingDay: closingDay, dueDay: dueDay}, nil
+}
+
+// DefaultBillingCycle returns the billing cycle used when it is not informed
+func DefaultBillingCycle() BillingCycle {
+	return BillingCycle{closingDay: DefaultClosingDay, dueDay: DefaultDueDay}
+}
+
+// ClosingDay returns the day of the month when the cycle closes
+func (c BillingCycle) ClosingDay() int {
+	return c.closingDay
+}
+
+// DueDay returns the day of the month when the invoice is due
+func (c BillingCycle) DueDay() int {
+	return c.dueDay
+}
+
+// IsZero checks if the billing cycle was not defined
+func (c BillingCycle) IsZero() bool {
+	return c.closingDay == 0
+}
+
+// LastClosing returns the end of the last closing day finished at the informed moment, in UTC
+func (c BillingCycle) LastClosing(at time.Time) time.Time {
+	at = at.UTC()
+
+	closing := endOfDay(time.Date(at.Year(), at.Month(), c.closingDay, 0, 0, 0, 0, time.UTC))
+	if closing.After(at) {
+		closing = endOfDay(time.Date(at.Year(), at.Month()-1, c.closingDay, 0, 0, 0, 0, time.UTC))
+	}
+
+	return closing
+}
+
+// DueDate returns the due date of the invoice closed at the informed closing: the first due day after the closing day
+func (c BillingCycle) DueDate(closing time.Time) time.Time {
+	closing = closing.UTC()
+
+	month := closing.Month()
+	if c.dueDay <= closing.Day() {
+		month++
+	}
+
+	return time.Date(closing.Year(), month, c.dueDay, 0, 0, 0, 0, time.UTC)
+}
+
+func endOfDay(t time.Time) time.Time {
+	return t.AddDate(0, 0, 1).Add(-time.Second)
+}
@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewBillingCycle(t *testing.T) {
+	tests := []struct {
+		name       string
+		closingDay int
+		dueDay     int
+		wantErr    error
+	}{
+		// fails
+		{
+			name:       "closing day before the first day of the month",
+			closingDay: 0,
+			dueDay:     5,
+			wantErr:    NewErrDomain("closing_day", "must be between 1 and 28"),
+		},
+		{
+			name:       "closing day not existing in every month",
+			closingDay: 29,
+			dueDay:     5,
+			wantErr:    NewErrDomain("closing_day", "must be between 1 and 28"),
+		},
+		{
+			name:       "invalid due day",
+			closingDay: 25,
+			dueDay:     31,
+			wantErr:    NewErrDomain("due_day", "must be between 1 and 28"),
+		},
+
+		// successes
+		{
+			name:       "valid billing cycle",
+			closingDay: 25,
+			dueDay:     5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewBillingCycle(tt.closingDay, tt.dueDay)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("NewBillingCycle() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.ClosingDay() != tt.closingDay || got.DueDay() != tt.dueDay {
+				t.Errorf("NewBillingCycle() got = %v", got)
+			}
+		})
+	}
+}
+
+func TestBillingCycle_LastClosing(t *testing.T) {
+	cycle, _ := NewBillingCycle(25, 5)
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want time.Time
+	}{
+		{
+			name: "after the closing day of the month",
+			at:   time.Date(2020, 10, 26, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2020, 10, 25, 23, 59, 59, 0, time.UTC),
+		},
+		{
+			name: "during the closing day of the month",
+			at:   time.Date(2020, 10, 25, 12, 0, 0, 0, time.UTC),
+			want: time.Date(2020, 9, 25, 23, 59, 59, 0, time.UTC),
+		},
+		{
+			name: "at the end of the closing day",
+			at:   time.Date(2020, 10, 25, 23, 59, 59, 0, time.UTC),
+			want: time.Date(2020, 10, 25, 23, 59, 59, 0, time.UTC),
+		},
+		{
+			name: "before the closing day in january",
+			at:   time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2020, 12, 25, 23, 59, 59, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cycle.LastClosing(tt.at); !got.Equal(tt.want) {
+				t.Errorf("LastClosing() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBillingCycle_DueDate(t *testing.T) {
+	tests := []struct {
+		name       string
+		closingDay int
+		dueDay     int
+		closing    time.Time
+		want       time.Time
+	}{
+		{
+			name:       "due in the next month",
+			closingDay: 25,
+			dueDay:     5,
+			closing:    time.Date(2020, 12, 25, 23, 59, 59, 0, time.UTC),
+			want:       time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "due in the same month",
+			closingDay: 1,
+			dueDay:     10,
+			closing:    time.Date(2020, 10, 1, 23, 59, 59, 0, time.UTC),
+			want:       time.Date(2020, 10, 10, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cycle, _ := NewBillingCycle(tt.closingDay, tt.dueDay)
+
+			if got := cycle.DueDate(tt.closing); !got.Equal(tt.want) {
+				t.Errorf("DueDate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
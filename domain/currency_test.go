@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewCurrency(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		want    Currency
+		wantErr error
+	}{
+		// fails
+		{
+			name:    "empty code",
+			code:    "",
+			wantErr: NewErrDomain("currency", "'' is not a supported ISO 4217 currency code"),
+		},
+		{
+			name:    "unknown code",
+			code:    "XYZ",
+			wantErr: NewErrDomain("currency", "'XYZ' is not a supported ISO 4217 currency code"),
+		},
+		{
+			name:    "code without minor units",
+			code:    "XAU",
+			wantErr: NewErrDomain("currency", "'XAU' is not a supported ISO 4217 currency code"),
+		},
+
+		// successes
+		{
+			name: "valid code",
+			code: "USD",
+			want: "USD",
+		},
+		{
+			name: "lowercase code",
+			code: "eur",
+			want: "EUR",
+		},
+		{
+			name: "code without decimal places",
+			code: "JPY",
+			want: "JPY",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewCurrency(tt.code)
+
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("NewCurrency() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("NewCurrency() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCurrency_MinorUnits(t *testing.T) {
+	for currency, want := range map[Currency]int{"BRL": 2, "JPY": 0, "CLP": 0, "KWD": 3, "CLF": 4, "": 4} {
+		if got := currency.MinorUnits(); got != want {
+			t.Errorf("MinorUnits() of '%s' = %v, want %v", currency, got, want)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// CursorDirection represents the direction of the pagination from a cursor
+type CursorDirection string
+
+const (
+	// CursorNext navigates to the older registers, after the cursor
+	CursorNext CursorDirection = "next"
+
+	// CursorPrev navigates to the newer registers, before the cursor
+	CursorPrev CursorDirection = "prev"
+)
+
+// Cursor represents an opaque position in a paginated list
+type Cursor struct {
+	id        *ID
+	direction CursorDirection
+}
+
+type cursorPayload struct {
+	ID        uint64          `json:"id"`
+	Direction CursorDirection `json:"d"`
+}
+
+// NewCursor builds a new Cursor struct
+func NewCursor(id *ID, direction CursorDirection) *Cursor {
+	return &Cursor{id: id, direction: direction}
+}
+
+// DecodeCursor builds a Cursor struct from its encoded value
+func DecodeCursor(v string) (*Cursor, error) {
+	invalid := NewErrDomain("cursor", "is invalid")
+
+	raw, err := base64.RawURLEncoding.DecodeString(v)
+	if err != nil {
+		return nil, invalid
+	}
+
+	payload := cursorPayload{}
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return nil, invalid
+	}
+
+	if payload.ID == 0 || (payload.Direction != CursorNext && payload.Direction != CursorPrev) {
+		return nil, invalid
+	}
+
+	return NewCursor(NewID(payload.ID), payload.Direction), nil
+}
+
+// ID returns the id of the register where the cursor points to
+func (c Cursor) ID() *ID {
+	return c.id
+}
+
+// Direction returns the direction of the pagination
+func (c Cursor) Direction() CursorDirection {
+	return c.direction
+}
+
+// Encode returns the opaque representation of the cursor
+func (c Cursor) Encode() string {
+	raw, _ := json.Marshal(cursorPayload{ID: c.id.Value(), Direction: c.direction})
+
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
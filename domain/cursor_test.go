@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeCursor(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    *Cursor
+		wantErr error
+	}{
+		// fails
+		{
+			name:    "not a base64 value",
+			value:   "!!!",
+			wantErr: NewErrDomain("cursor", "is invalid"),
+		},
+		{
+			name:    "not a json value",
+			value:   "YWJj",
+			wantErr: NewErrDomain("cursor", "is invalid"),
+		},
+		{
+			name:    "invalid direction",
+			value:   NewCursor(NewID(10), CursorDirection("up")).Encode(),
+			wantErr: NewErrDomain("cursor", "is invalid"),
+		},
+		{
+			name:    "invalid id",
+			value:   NewCursor(NewID(0), CursorNext).Encode(),
+			wantErr: NewErrDomain("cursor", "is invalid"),
+		},
+
+		// successes
+		{
+			name:  "next cursor",
+			value: NewCursor(NewID(10), CursorNext).Encode(),
+			want:  NewCursor(NewID(10), CursorNext),
+		},
+		{
+			name:  "prev cursor",
+			value: NewCursor(NewID(999), CursorPrev).Encode(),
+			want:  NewCursor(NewID(999), CursorPrev),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeCursor(tt.value)
+
+			if (err != nil) && !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("DecodeCursor() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if (err == nil) && (tt.wantErr != nil) {
+				t.Errorf("DecodeCursor() error = nil, wantErr %v", tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodeCursor() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,10 +2,29 @@ package domain
 
 import (
 	"fmt"
+	"regexp"
 
 	"github.com/Nhanderu/brdoc"
 )
 
+const (
+	// DocumentTypeCPF represents the document of a natural person (Cadastro de Pessoas Físicas)
+	DocumentTypeCPF DocumentType = "CPF"
+
+	// DocumentTypeCNPJ represents the document of a business (Cadastro Nacional da Pessoa Jurídica)
+	DocumentTypeCNPJ DocumentType = "CNPJ"
+)
+
+var documentFormattingRegex = regexp.MustCompile(`[^0-9]`)
+
+// DocumentType represents the type of the document
+type DocumentType string
+
+// String cast the document type value to string
+func (d DocumentType) String() string {
+	return string(d)
+}
+
 // DocumentNumber represents the document's number
 type DocumentNumber string
 
@@ -16,16 +35,33 @@ func (d DocumentNumber) String() string {
 
 // Document represents a customer's document
 type Document struct {
-	number DocumentNumber
+	documentType DocumentType
+	number       DocumentNumber
 }
 
-// NewDocument build a new Documents with its dependencies
+// NewDocument build a new Documents with its dependencies. The number may be a CPF or a CNPJ, formatted or not, and
+// its type is identified by its check digits. The number is always kept without formatting.
 func NewDocument(number DocumentNumber) (*Document, error) {
-	if brdoc.IsCPF(string(number)) {
-		return &Document{number: number}, nil
+	var documentType DocumentType
+
+	switch {
+	case brdoc.IsCPF(string(number)):
+		documentType = DocumentTypeCPF
+	case brdoc.IsCNPJ(string(number)):
+		documentType = DocumentTypeCNPJ
+	default:
+		return nil, NewErrDomain("document.number", fmt.Sprintf("'%s' is not a valid document number", number))
 	}
 
-	return nil, NewErrDomain("document.number", fmt.Sprintf("'%s' is not a valid document number", number))
+	return &Document{
+		documentType: documentType,
+		number:       DocumentNumber(documentFormattingRegex.ReplaceAllString(string(number), "")),
+	}, nil
+}
+
+// Type returns the type of the document
+func (d Document) Type() DocumentType {
+	return d.documentType
 }
 
 // Number returns the value of document number
@@ -58,10 +58,40 @@ func TestNewDocument(t *testing.T) {
 			want:    nil,
 			wantErr: NewErrDomain("document.number", "'000000001911' is not a valid document number"),
 		},
+		{
+			name:    "invalid cnpj",
+			args:    args{number: "11222333000180"},
+			want:    nil,
+			wantErr: NewErrDomain("document.number", "'11222333000180' is not a valid document number"),
+		},
+		{
+			name:    "cnpj with all digits equal",
+			args:    args{number: "11111111111111"},
+			want:    nil,
+			wantErr: NewErrDomain("document.number", "'11111111111111' is not a valid document number"),
+		},
 		{
 			name:    "valid document",
 			args:    args{number: "00000000191"},
-			want:    &Document{number: "00000000191"},
+			want:    &Document{documentType: DocumentTypeCPF, number: "00000000191"},
+			wantErr: nil,
+		},
+		{
+			name:    "valid formatted cpf",
+			args:    args{number: "000.000.001-91"},
+			want:    &Document{documentType: DocumentTypeCPF, number: "00000000191"},
+			wantErr: nil,
+		},
+		{
+			name:    "valid cnpj",
+			args:    args{number: "11222333000181"},
+			want:    &Document{documentType: DocumentTypeCNPJ, number: "11222333000181"},
+			wantErr: nil,
+		},
+		{
+			name:    "valid formatted cnpj",
+			args:    args{number: "11.222.333/0001-81"},
+			want:    &Document{documentType: DocumentTypeCNPJ, number: "11222333000181"},
 			wantErr: nil,
 		},
 	}
@@ -27,3 +27,23 @@ func NewErrDomain(field string, description string) *ErrDomain {
 func (e ErrDomain) Error() string {
 	return fmt.Sprintf("%s %s", e.Field(), e.Description())
 }
+
+// ErrIdempotencyKeyInUse represents an error when a request with the same idempotency key is still being processed
+type ErrIdempotencyKeyInUse struct {
+	key string
+}
+
+// NewErrIdempotencyKeyInUse builds a new ErrIdempotencyKeyInUse struct
+func NewErrIdempotencyKeyInUse(key string) *ErrIdempotencyKeyInUse {
+	return &ErrIdempotencyKeyInUse{key: key}
+}
+
+// Key returns the idempotency key in use
+func (e ErrIdempotencyKeyInUse) Key() string {
+	return e.key
+}
+
+// Error returns a formatted error message
+func (e ErrIdempotencyKeyInUse) Error() string {
+	return fmt.Sprintf("a request with the idempotency key '%s' is still being processed", e.key)
+}
@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// EventType represents what happened to the aggregate of an event
+type EventType string
+
+const (
+	// EventAccountCreated is raised when an account is created
+	EventAccountCreated EventType = "account.created"
+
+	// EventTransactionCreated is raised when a transaction is created
+	EventTransactionCreated EventType = "transaction.created"
+)
+
+// String returns the event type as string
+func (e EventType) String() string {
+	return string(e)
+}
+
+// Event represents a fact about an account or a transaction, published to the downstream systems. Events are stored
+// with the entity that raised them, and published later, so they are never lost nor published for entities not stored.
+type Event struct {
+	id          *ID
+	eventType   EventType
+	aggregateID *ID
+	payload     []byte
+	occurredAt  time.Time
+}
+
+// eventEnvelope is the representation of an event delivered to the downstream systems, the payload is the aggregate data
+type eventEnvelope struct {
+	ID          uint64          `json:"id"`
+	Type        string          `json:"type"`
+	AggregateID uint64          `json:"aggregate_id"`
+	OccurredAt  string          `json:"occurred_at"`
+	Payload     json.RawMessage `json:"payload"`
+}
+
+type accountCreatedPayload struct {
+	ID             uint64 `json:"id"`
+	DocumentNumber string `json:"document_number"`
+	DocumentType   string `json:"document_type"`
+	Currency       string `json:"currency"`
+	CreditLimit    string `json:"credit_limit"`
+	ClosingDay     int    `json:"closing_day"`
+	DueDay         int    `json:"due_day"`
+	CreatedAt      string `json:"created_at"`
+}
+
+type transactionCreatedPayload struct {
+	ID           uint64 `json:"id"`
+	AccountID    uint64 `json:"account_id"`
+	OperationID  uint64 `json:"operation_id"`
+	Amount       string `json:"amount"`
+	Currency     string `json:"currency"`
+	Installments int    `json:"installments,omitempty"`
+	CreatedAt    string `json:"created_at"`
+}
+
+// NewAccountCreated builds the event of a stored account
+func NewAccountCreated(account *Account) (*Event, error) {
+	payload, err := json.Marshal(accountCreatedPayload{
+		ID:             account.ID().Value(),
+		DocumentNumber: account.Document().Number().String(),
+		DocumentType:   account.Document().Type().String(),
+		Currency:       account.Currency().String(),
+		CreditLimit:    account.CreditLimit().String(),
+		ClosingDay:     account.BillingCycle().ClosingDay(),
+		DueDay:         account.BillingCycle().DueDay(),
+		CreatedAt:      account.CreatedAt().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newEvent(EventAccountCreated, account.ID(), payload, account.CreatedAt()), nil
+}
+
+// NewTransactionCreated builds the event of a stored transaction
+func NewTransactionCreated(t *Transaction) (*Event, error) {
+	var installments int
+	if plan := t.InstallmentPlan(); plan != nil {
+		installments = plan.Count()
+	}
+
+	payload, err := json.Marshal(transactionCreatedPayload{
+		ID:           t.ID().Value(),
+		AccountID:    t.Account().ID().Value(),
+		OperationID:  t.Operation().ID().Value(),
+		Amount:       t.Amount().String(),
+		Currency:     t.Amount().Currency().String(),
+		Installments: installments,
+		CreatedAt:    t.CreatedAt().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newEvent(EventTransactionCreated, t.ID(), payload, t.CreatedAt()), nil
+}
+
+func newEvent(eventType EventType, aggregateID *ID, payload []byte, occurredAt time.Time) *Event {
+	return &Event{eventType: eventType, aggregateID: aggregateID, payload: payload, occurredAt: occurredAt}
+}
+
+// LoadEvent builds an Event struct with the data loaded from the storage
+func LoadEvent(id *ID, eventType EventType, aggregateID *ID, payload []byte, occurredAt time.Time) *Event {
+	event := newEvent(eventType, aggregateID, payload, occurredAt)
+	event.id = id
+
+	return event
+}
+
+// Store stores an event given a repository
+func (e *Event) Store(ctx context.Context, repo EventRepositoryWriter) (*Event, error) {
+	id, err := repo.Store(ctx, e)
+	if err != nil {
+		return nil, err
+	}
+
+	event := *e
+	event.id = id
+
+	return &event, nil
+}
+
+// MarshalJSON encodes the event as it is delivered to the downstream systems
+func (e *Event) MarshalJSON() ([]byte, error) {
+	return json.Marshal(eventEnvelope{
+		ID:          e.id.Value(),
+		Type:        e.eventType.String(),
+		AggregateID: e.aggregateID.Value(),
+		OccurredAt:  e.occurredAt.UTC().Format(time.RFC3339),
+		Payload:     e.payload,
+	})
+}
+
+// ID returns the id value
+func (e *Event) ID() *ID {
+	return e.id
+}
+
+// Type returns what happened to the aggregate
+func (e *Event) Type() EventType {
+	return e.eventType
+}
+
+// AggregateID returns the id of the account or the transaction of the event
+func (e *Event) AggregateID() *ID {
+	return e.aggregateID
+}
+
+// Payload returns the aggregate data as JSON
+func (e *Event) Payload() []byte {
+	return e.payload
+}
+
+// OccurredAt returns when the event happened
+func (e *Event) OccurredAt() time.Time {
+	return e.occurredAt
+}
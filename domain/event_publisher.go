@@ -0,0 +1,38 @@
+package domain
+
+import "context"
+
+// EventPublisher represents the behaviour to deliver an event to the downstream systems. The same event may be
+// delivered more than once, so the consumers must identify the repeated ones by the event id.
+type EventPublisher interface {
+	Publish(context.Context, *Event) error
+}
+
+// EventPublisherMock is a fake representation of an EventPublisher, useful to create unit tests. It fails to publish
+// the events after the informed number of successful publications.
+type EventPublisherMock struct {
+	published *[]*Event
+	failAfter int
+	err       error
+}
+
+// NewEventPublisherMock builds a new EventPublisherMock struct, failing with err after failAfter events are published
+func NewEventPublisherMock(failAfter int, err error) *EventPublisherMock {
+	return &EventPublisherMock{published: new([]*Event), failAfter: failAfter, err: err}
+}
+
+// Publish publishes an event
+func (e EventPublisherMock) Publish(_ context.Context, event *Event) error {
+	if e.err != nil && len(*e.published) >= e.failAfter {
+		return e.err
+	}
+
+	*e.published = append(*e.published, event)
+
+	return nil
+}
+
+// Published returns the events published
+func (e EventPublisherMock) Published() []*Event {
+	return *e.published
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// EventRepositoryWriter represents the behaviour of the Event Repository to write operations. Events must be stored in
+// the same database transaction of the entity that raised them. The events not published yet are claimed from the
+// oldest to the newest, up to the informed limit, until the informed moment: meanwhile, they are not claimed again, so
+// concurrent publishers never publish the same events. Releasing the claims lets them be claimed right away.
+type EventRepositoryWriter interface {
+	Store(context.Context, *Event) (*ID, error)
+	MarkPublished(context.Context, *Event, time.Time) error
+	ClaimUnpublished(context.Context, int, time.Time) ([]*Event, error)
+	ReleaseClaims(context.Context, []*Event) error
+}
+
+// EventRepositoryMock is a fake representation of the Event Repository, useful to create unit tests
+type EventRepositoryMock struct {
+	id     *ID
+	events []*Event
+	err    error
+}
+
+// NewEventRepositoryMock builds a new EventRepositoryMock struct with its mock results
+func NewEventRepositoryMock(id *ID, events []*Event, err error) *EventRepositoryMock {
+	return &EventRepositoryMock{id: id, events: events, err: err}
+}
+
+// Store stores an event
+func (e EventRepositoryMock) Store(_ context.Context, _ *Event) (*ID, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+
+	return e.id, nil
+}
+
+// MarkPublished registers an event as published
+func (e EventRepositoryMock) MarkPublished(_ context.Context, _ *Event, _ time.Time) error {
+	return e.err
+}
+
+// ClaimUnpublished claims the events not published yet
+func (e EventRepositoryMock) ClaimUnpublished(_ context.Context, _ int, _ time.Time) ([]*Event, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+
+	return e.events, nil
+}
+
+// ReleaseClaims releases the claims of the events
+func (e EventRepositoryMock) ReleaseClaims(_ context.Context, _ []*Event) error {
+	return e.err
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewAccountCreated(t *testing.T) {
+	var (
+		createdAt = time.Date(2020, 10, 25, 10, 30, 0, 0, time.UTC)
+		cycle, _  = NewBillingCycle(10, 20)
+	)
+
+	account, _ := NewAccount("00000000191")
+	account = account.
+		WithID(NewID(1)).
+		WithCurrency(CurrencyBRL).
+		WithCreditLimit(brl(100000)).
+		WithBillingCycle(cycle).
+		WithCreateAt(createdAt)
+
+	event, err := NewAccountCreated(account)
+	if err != nil {
+		t.Errorf("NewAccountCreated() error = %v", err)
+		return
+	}
+
+	want := `{"id":1,"document_number":"00000000191","document_type":"CPF","currency":"BRL","credit_limit":"1000.00",` +
+		`"closing_day":10,"due_day":20,"created_at":"2020-10-25T10:30:00Z"}`
+
+	if event.Type() != EventAccountCreated || event.AggregateID().Value() != 1 || !event.OccurredAt().Equal(createdAt) {
+		t.Errorf("NewAccountCreated() = %v %v %v", event.Type(), event.AggregateID(), event.OccurredAt())
+	}
+
+	if string(event.Payload()) != want {
+		t.Errorf("NewAccountCreated() payload = %s, want %s", event.Payload(), want)
+	}
+}
+
+func TestNewTransactionCreated(t *testing.T) {
+	createdAt := time.Date(2020, 10, 25, 10, 30, 0, 0, time.UTC)
+
+	transaction, _ := NewTransaction(NewID(1), OperationCompraParcelada, brl(30000))
+	transaction, _ = transaction.WithInstallments(3, createdAt)
+	transaction = transaction.WithID(NewID(10)).WithCreatedAt(createdAt)
+
+	event, err := NewTransactionCreated(transaction)
+	if err != nil {
+		t.Errorf("NewTransactionCreated() error = %v", err)
+		return
+	}
+
+	want := `{"id":10,"account_id":1,"operation_id":2,"amount":"-300.00","currency":"BRL","installments":3,` +
+		`"created_at":"2020-10-25T10:30:00Z"}`
+
+	if event.Type() != EventTransactionCreated || event.AggregateID().Value() != 10 {
+		t.Errorf("NewTransactionCreated() = %v %v", event.Type(), event.AggregateID())
+	}
+
+	if string(event.Payload()) != want {
+		t.Errorf("NewTransactionCreated() payload = %s, want %s", event.Payload(), want)
+	}
+}
+
+func TestEvent_MarshalJSON(t *testing.T) {
+	occurredAt := time.Date(2020, 10, 25, 10, 30, 0, 0, time.FixedZone("BRT", -3*60*60))
+	event := LoadEvent(NewID(1), EventTransactionCreated, NewID(10), []byte(`{"id":10}`), occurredAt)
+
+	got, err := event.MarshalJSON()
+	if err != nil {
+		t.Errorf("MarshalJSON() error = %v", err)
+		return
+	}
+
+	want := `{"id":1,"type":"transaction.created","aggregate_id":10,"occurred_at":"2020-10-25T13:30:00Z","payload":{"id":10}}`
+	if string(got) != want {
+		t.Errorf("MarshalJSON() = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+)
+
+const exchangeRateDecimalPlaces = 8
+
+var exchangeRateRegex = regexp.MustCompile(`^[0-9]+(\.[0-9]{1,8})?$`)
+
+// ExchangeRateProvider represents the behaviour of a source of exchange rates
+type ExchangeRateProvider interface {
+	Rate(from, to Currency) (*ExchangeRate, error)
+}
+
+// ExchangeRate represents the rate used to convert amounts from a currency to another one
+type ExchangeRate struct {
+	from  Currency
+	to    Currency
+	value *big.Rat
+}
+
+// NewExchangeRate builds a new ExchangeRate struct given a positive decimal rate with at most 8 decimal places
+func NewExchangeRate(from, to Currency, rate string) (*ExchangeRate, error) {
+	if !exchangeRateRegex.MatchString(rate) {
+		description := fmt.Sprintf("'%s' must be a decimal number with at most %d decimal places", rate, exchangeRateDecimalPlaces)
+
+		return nil, NewErrDomain("exchange_rate", description)
+	}
+
+	value, _ := new(big.Rat).SetString(rate)
+	if value.Sign() <= 0 {
+		return nil, NewErrDomain("exchange_rate", fmt.Sprintf("'%s' must be greater than 0", rate))
+	}
+
+	return &ExchangeRate{from: from, to: to, value: value}, nil
+}
+
+// NewIdentityExchangeRate builds the rate between a currency and itself
+func NewIdentityExchangeRate(currency Currency) *ExchangeRate {
+	return &ExchangeRate{from: currency, to: currency, value: big.NewRat(1, 1)}
+}
+
+// From returns the currency converted from
+func (r *ExchangeRate) From() Currency {
+	return r.from
+}
+
+// To returns the currency converted to
+func (r *ExchangeRate) To() Currency {
+	return r.to
+}
+
+// Rate returns the decimal representation of the rate, always with 8 decimal places
+func (r *ExchangeRate) Rate() string {
+	return r.value.FloatString(exchangeRateDecimalPlaces)
+}
+
+// Convert converts an amount to the target currency, rounding half away from zero to its nearest minor unit
+func (r *ExchangeRate) Convert(m Money) Money {
+	return m.rescale(r.to, r.value)
+}
+
+// StaticExchangeRateProvider is an ExchangeRateProvider backed by a fixed table of rates, as the ones configured in
+// the environment or used in unit tests
+type StaticExchangeRateProvider struct {
+	rates map[Currency]map[Currency]*ExchangeRate
+}
+
+// NewStaticExchangeRateProvider builds a new StaticExchangeRateProvider struct with the informed rates
+func NewStaticExchangeRateProvider(rates ...*ExchangeRate) *StaticExchangeRateProvider {
+	provider := &StaticExchangeRateProvider{rates: make(map[Currency]map[Currency]*ExchangeRate)}
+
+	for _, v := range rates {
+		if _, ok := provider.rates[v.From()]; !ok {
+			provider.rates[v.From()] = make(map[Currency]*ExchangeRate)
+		}
+
+		provider.rates[v.From()][v.To()] = v
+	}
+
+	return provider
+}
+
+// Rate returns the rate to convert from a currency to another one
+func (p StaticExchangeRateProvider) Rate(from, to Currency) (*ExchangeRate, error) {
+	if from == to {
+		return NewIdentityExchangeRate(from), nil
+	}
+
+	if rate, ok := p.rates[from][to]; ok {
+		return rate, nil
+	}
+
+	return nil, NewErrDomain("currency", fmt.Sprintf("there is no exchange rate from '%s' to '%s'", from, to))
+}
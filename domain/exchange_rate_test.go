@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewExchangeRate(t *testing.T) {
+	tests := []struct {
+		name     string
+		rate     string
+		wantRate string
+		wantErr  error
+	}{
+		// fails
+		{
+			name:    "invalid rate",
+			rate:    "abc",
+			wantErr: NewErrDomain("exchange_rate", "'abc' must be a decimal number with at most 8 decimal places"),
+		},
+		{
+			name:    "too many decimal places",
+			rate:    "5.123456789",
+			wantErr: NewErrDomain("exchange_rate", "'5.123456789' must be a decimal number with at most 8 decimal places"),
+		},
+		{
+			name:    "zero rate",
+			rate:    "0",
+			wantErr: NewErrDomain("exchange_rate", "'0' must be greater than 0"),
+		},
+
+		// successes
+		{
+			name:     "valid rate",
+			rate:     "5.25",
+			wantRate: "5.25000000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewExchangeRate("USD", CurrencyBRL, tt.rate)
+
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("NewExchangeRate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.Rate() != tt.wantRate {
+				t.Errorf("Rate() = %v, want %v", got.Rate(), tt.wantRate)
+			}
+		})
+	}
+}
+
+func TestExchangeRate_Convert(t *testing.T) {
+	tests := []struct {
+		name   string
+		rate   string
+		amount Money
+		want   Money
+	}{
+		{
+			name:   "exact conversion",
+			rate:   "5.25",
+			amount: NewMoney(10000, "USD"),
+			want:   brl(52500),
+		},
+		{
+			name:   "rounds half up",
+			rate:   "0.5",
+			amount: NewMoney(1, "USD"),
+			want:   brl(1),
+		},
+		{
+			name:   "rounds down",
+			rate:   "0.33333333",
+			amount: NewMoney(100, "USD"),
+			want:   brl(33),
+		},
+		{
+			name:   "negative amounts round away from zero",
+			rate:   "0.5",
+			amount: NewMoney(-1, "USD"),
+			want:   brl(-1),
+		},
+		{
+			name:   "currency without decimal places",
+			rate:   "0.0365",
+			amount: NewMoney(1000, "JPY"),
+			want:   brl(3650),
+		},
+		{
+			name:   "currency with 3 decimal places",
+			rate:   "18.5",
+			amount: NewMoney(1234, "KWD"),
+			want:   brl(2283),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, _ := NewExchangeRate(tt.amount.Currency(), CurrencyBRL, tt.rate)
+
+			if got := rate.Convert(tt.amount); got != tt.want {
+				t.Errorf("Convert() = %v %v, want %v %v", got, got.Currency(), tt.want, tt.want.Currency())
+			}
+		})
+	}
+}
+
+func TestStaticExchangeRateProvider_Rate(t *testing.T) {
+	usdToBrl, _ := NewExchangeRate("USD", CurrencyBRL, "5.25")
+
+	provider := NewStaticExchangeRateProvider(usdToBrl)
+
+	if got, err := provider.Rate("USD", CurrencyBRL); err != nil || got != usdToBrl {
+		t.Errorf("Rate() = %v, %v, want %v", got, err, usdToBrl)
+	}
+
+	if got, err := provider.Rate(CurrencyBRL, CurrencyBRL); err != nil || got.Rate() != "1.00000000" {
+		t.Errorf("Rate() = %v, %v, want the identity rate", got, err)
+	}
+
+	wantErr := NewErrDomain("currency", "there is no exchange rate from 'EUR' to 'BRL'")
+	if _, err := provider.Rate("EUR", CurrencyBRL); !reflect.DeepEqual(err, wantErr) {
+		t.Errorf("Rate() error = %v, wantErr %v", err, wantErr)
+	}
+}
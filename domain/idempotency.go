@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+const maxIdempotencyKeyLength = 255
+
+// IdempotencyKeyStatus represents the processing status of a request identified by an idempotency key
+type IdempotencyKeyStatus string
+
+const (
+	// IdempotencyKeyProcessing means the first request with the key is still being processed
+	IdempotencyKeyProcessing IdempotencyKeyStatus = "processing"
+
+	// IdempotencyKeyCompleted means the first request with the key was processed and its response is stored
+	IdempotencyKeyCompleted IdempotencyKeyStatus = "completed"
+)
+
+// IdempotencyKey represents a client-generated key that identifies a request, so its retries are not processed again
+type IdempotencyKey struct {
+	key            string
+	fingerprint    string
+	status         IdempotencyKeyStatus
+	responseStatus int
+	responseBody   []byte
+	createdAt      time.Time
+}
+
+// NewIdempotencyKey builds a new IdempotencyKey struct in processing status
+func NewIdempotencyKey(key, fingerprint string) (*IdempotencyKey, error) {
+	if key == "" || len(key) > maxIdempotencyKeyLength {
+		description := fmt.Sprintf("must have between 1 and %d characters", maxIdempotencyKeyLength)
+
+		return nil, NewErrDomain("Idempotency-Key", description)
+	}
+
+	return &IdempotencyKey{key: key, fingerprint: fingerprint, status: IdempotencyKeyProcessing}, nil
+}
+
+// Check verifies whether a new request with the same key may be answered with the stored response
+func (i *IdempotencyKey) Check(fingerprint string) error {
+	if i.fingerprint != fingerprint {
+		return NewErrDomain("Idempotency-Key", fmt.Sprintf("'%s' was already used with a different request", i.key))
+	}
+
+	if !i.IsCompleted() {
+		return NewErrIdempotencyKeyInUse(i.key)
+	}
+
+	return nil
+}
+
+// IsAbandoned checks if the request is still in processing after the processing timeout, at the informed moment, as
+// when the instance processing it stops
+func (i *IdempotencyKey) IsAbandoned(at time.Time, timeout time.Duration) bool {
+	return !i.IsCompleted() && at.Sub(i.createdAt) > timeout
+}
+
+// Complete returns a new IdempotencyKey struct in completed status with the response of the request
+func (i *IdempotencyKey) Complete(status int, body []byte) *IdempotencyKey {
+	key := *i
+	key.status = IdempotencyKeyCompleted
+	key.responseStatus = status
+	key.responseBody = body
+
+	return &key
+}
+
+// Key returns the key value
+func (i *IdempotencyKey) Key() string {
+	return i.key
+}
+
+// Fingerprint returns the fingerprint of the request
+func (i *IdempotencyKey) Fingerprint() string {
+	return i.fingerprint
+}
+
+// Status returns the processing status
+func (i *IdempotencyKey) Status() IdempotencyKeyStatus {
+	return i.status
+}
+
+// IsCompleted checks if the request was already processed
+func (i *IdempotencyKey) IsCompleted() bool {
+	return i.status == IdempotencyKeyCompleted
+}
+
+// ResponseStatus returns the stored response status
+func (i *IdempotencyKey) ResponseStatus() int {
+	return i.responseStatus
+}
+
+// ResponseBody returns the stored response body
+func (i *IdempotencyKey) ResponseBody() []byte {
+	return i.responseBody
+}
+
+// CreatedAt returns the createdAt value
+func (i *IdempotencyKey) CreatedAt() time.Time {
+	return i.createdAt
+}
+
+// WithCreatedAt returns a new IdempotencyKey struct with the informed createdAt value
+func (i *IdempotencyKey) WithCreatedAt(t time.Time) *IdempotencyKey {
+	key := *i
+	key.createdAt = t
+
+	return &key
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// IdempotencyRepository represents the behaviour of the Idempotency Key Repository
+type IdempotencyRepository interface {
+	// Store stores a new key, returning false when the key was already stored
+	Store(context.Context, *IdempotencyKey) (bool, error)
+	FindByKey(context.Context, string) (*IdempotencyKey, error)
+	// TakeOver replaces a key still in processing created before the informed moment, returning false when the key
+	// was completed or taken over meanwhile
+	TakeOver(context.Context, *IdempotencyKey, time.Time) (bool, error)
+	Update(context.Context, *IdempotencyKey) error
+	Delete(context.Context, *IdempotencyKey) error
+	// DeleteCreatedBefore deletes the keys created before the informed moment, returning how many were deleted
+	DeleteCreatedBefore(context.Context, time.Time) (int64, error)
+}
+
+// IdempotencyRepositoryMock is a fake representation of an IdempotencyRepository, useful to create unit tests
+type IdempotencyRepositoryMock struct {
+	stored *IdempotencyKey
+	err    error
+}
+
+// NewIdempotencyRepositoryMock builds a new IdempotencyRepositoryMock struct with its mock results,
+// when stored is not nil, it represents a key already in the storage
+func NewIdempotencyRepositoryMock(stored *IdempotencyKey, err error) *IdempotencyRepositoryMock {
+	return &IdempotencyRepositoryMock{stored: stored, err: err}
+}
+
+// Store stores a key
+func (i IdempotencyRepositoryMock) Store(_ context.Context, _ *IdempotencyKey) (bool, error) {
+	if i.err != nil {
+		return false, i.err
+	}
+
+	return i.stored == nil, nil
+}
+
+// FindByKey finds a key
+func (i IdempotencyRepositoryMock) FindByKey(_ context.Context, _ string) (*IdempotencyKey, error) {
+	if i.err != nil {
+		return nil, i.err
+	}
+
+	return i.stored, nil
+}
+
+// TakeOver replaces the stored key when it is still in processing and was created before the informed moment
+func (i IdempotencyRepositoryMock) TakeOver(_ context.Context, _ *IdempotencyKey, createdBefore time.Time) (bool, error) {
+	if i.err != nil {
+		return false, i.err
+	}
+
+	return i.stored != nil && !i.stored.IsCompleted() && i.stored.CreatedAt().Before(createdBefore), nil
+}
+
+// Update updates a key
+func (i IdempotencyRepositoryMock) Update(_ context.Context, _ *IdempotencyKey) error {
+	return i.err
+}
+
+// Delete deletes a key
+func (i IdempotencyRepositoryMock) Delete(_ context.Context, _ *IdempotencyKey) error {
+	return i.err
+}
+
+// DeleteCreatedBefore deletes the stored key when it was created before the informed moment
+func (i IdempotencyRepositoryMock) DeleteCreatedBefore(_ context.Context, createdBefore time.Time) (int64, error) {
+	if i.err != nil {
+		return 0, i.err
+	}
+
+	if i.stored != nil && i.stored.CreatedAt().Before(createdBefore) {
+		return 1, nil
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewIdempotencyKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		wantErr error
+	}{
+		{
+			name:    "empty key",
+			key:     "",
+			wantErr: NewErrDomain("Idempotency-Key", "must have between 1 and 255 characters"),
+		},
+		{
+			name:    "key too long",
+			key:     strings.Repeat("a", 256),
+			wantErr: NewErrDomain("Idempotency-Key", "must have between 1 and 255 characters"),
+		},
+		{
+			name: "valid key",
+			key:  "a3b1c2d4-0000-4000-8000-000000000000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewIdempotencyKey(tt.key, "fingerprint")
+
+			if (err != nil) && !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("NewIdempotencyKey() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.Key() != tt.key || got.Fingerprint() != "fingerprint" {
+				t.Errorf("NewIdempotencyKey() got = %v", got)
+			}
+
+			if got.Status() != IdempotencyKeyProcessing {
+				t.Errorf("Status() = %v, want %v", got.Status(), IdempotencyKeyProcessing)
+			}
+		})
+	}
+}
+
+func TestIdempotencyKey_Check(t *testing.T) {
+	processing, _ := NewIdempotencyKey("key-1", "fingerprint-1")
+	completed := processing.Complete(201, []byte(`{"id":1}`))
+
+	tests := []struct {
+		name        string
+		key         *IdempotencyKey
+		fingerprint string
+		wantErr     error
+	}{
+		{
+			name:        "different request with the same key",
+			key:         completed,
+			fingerprint: "fingerprint-2",
+			wantErr:     NewErrDomain("Idempotency-Key", "'key-1' was already used with a different request"),
+		},
+		{
+			name:        "same request still being processed",
+			key:         processing,
+			fingerprint: "fingerprint-1",
+			wantErr:     NewErrIdempotencyKeyInUse("key-1"),
+		},
+		{
+			name:        "same request already processed",
+			key:         completed,
+			fingerprint: "fingerprint-1",
+			wantErr:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.key.Check(tt.fingerprint); !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIdempotencyKey_IsAbandoned(t *testing.T) {
+	var (
+		createdAt = time.Date(2020, 10, 25, 10, 0, 0, 0, time.UTC)
+		timeout   = 40 * time.Second
+		key, _    = NewIdempotencyKey("key-1", "fingerprint-1")
+	)
+
+	key = key.WithCreatedAt(createdAt)
+
+	tests := []struct {
+		name string
+		key  *IdempotencyKey
+		at   time.Time
+		want bool
+	}{
+		{
+			name: "request in processing within the timeout",
+			key:  key,
+			at:   createdAt.Add(timeout),
+			want: false,
+		},
+		{
+			name: "request in processing after the timeout",
+			key:  key,
+			at:   createdAt.Add(timeout + time.Second),
+			want: true,
+		},
+		{
+			name: "request processed",
+			key:  key.Complete(201, nil),
+			at:   createdAt.Add(timeout + time.Second),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.key.IsAbandoned(tt.at, timeout); got != tt.want {
+				t.Errorf("IsAbandoned() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIdempotencyKey_Complete(t *testing.T) {
+	key, _ := NewIdempotencyKey("key-1", "fingerprint-1")
+
+	got := key.Complete(201, []byte(`{"id":1}`))
+
+	if got == key {
+		t.Error("Complete() should return a new IdempotencyKey struct to assure immutability")
+	}
+
+	if !got.IsCompleted() || key.IsCompleted() {
+		t.Errorf("IsCompleted() = %v, want true", got.IsCompleted())
+	}
+
+	if got.ResponseStatus() != 201 || string(got.ResponseBody()) != `{"id":1}` {
+		t.Errorf("Complete() got status = %v, body = %s", got.ResponseStatus(), got.ResponseBody())
+	}
+}
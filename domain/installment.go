@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// MaxInstallments is the maximum number of installments of an installment purchase
+const MaxInstallments = 24
+
+// Installment represents one of the scheduled parts of an installment purchase
+type Installment struct {
+	number  int
+	amount  Money
+	dueDate time.Time
+}
+
+// NewInstallment builds a new Installment struct
+func NewInstallment(number int, amount Money, dueDate time.Time) *Installment {
+	return &Installment{number: number, amount: amount, dueDate: dueDate}
+}
+
+// Number returns the sequence number of the installment, starting from 1
+func (i *Installment) Number() int {
+	return i.number
+}
+
+// Amount returns the amount of the installment, with the same sign of the purchase
+func (i *Installment) Amount() Money {
+	return i.amount
+}
+
+// DueDate returns the date when the installment is due
+func (i *Installment) DueDate() time.Time {
+	return i.dueDate
+}
+
+// InstallmentPlan represents the schedule of the installments of a purchase
+type InstallmentPlan struct {
+	installments []*Installment
+}
+
+// NewInstallmentPlan splits the amount in count monthly installments, the first one due one month after purchasedAt.
+// The amount is split in minor units, the remaining units of the division are added to the first installment, so the sum of
+// the installments is always equal to the amount.
+func NewInstallmentPlan(amount Money, count int, purchasedAt time.Time) (*InstallmentPlan, error) {
+	if count < 1 || count > MaxInstallments {
+		return nil, NewErrDomain("installments", fmt.Sprintf("must be between 1 and %d", MaxInstallments))
+	}
+
+	var (
+		base      = amount.Units() / int64(count)
+		remainder = amount.Units() % int64(count)
+		plan      = &InstallmentPlan{installments: make([]*Installment, 0, count)}
+	)
+
+	for i := 1; i <= count; i++ {
+		installmentUnits := base
+		if i == 1 {
+			installmentUnits += remainder
+		}
+
+		plan.installments = append(plan.installments, NewInstallment(
+			i,
+			NewMoney(installmentUnits, amount.Currency()),
+			addMonths(purchasedAt, i),
+		))
+	}
+
+	return plan, nil
+}
+
+// LoadInstallmentPlan builds an InstallmentPlan struct with installments already scheduled
+func LoadInstallmentPlan(installments []*Installment) *InstallmentPlan {
+	return &InstallmentPlan{installments: installments}
+}
+
+// Installments returns the scheduled installments
+func (p *InstallmentPlan) Installments() []*Installment {
+	return p.installments
+}
+
+// Count returns the number of installments
+func (p *InstallmentPlan) Count() int {
+	return len(p.installments)
+}
+
+// addMonths adds months to a date, keeping the day of the month or using the last day of the month when it is shorter
+func addMonths(t time.Time, months int) time.Time {
+	var (
+		year, month, day = t.Date()
+		firstDay         = time.Date(year, month+time.Month(months), 1, 0, 0, 0, 0, t.Location())
+		lastDay          = firstDay.AddDate(0, 1, -1).Day()
+	)
+
+	if day > lastDay {
+		day = lastDay
+	}
+
+	return time.Date(firstDay.Year(), firstDay.Month(), day, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewInstallmentPlan(t *testing.T) {
+	var (
+		purchasedAt = time.Date(2020, 10, 4, 13, 0, 0, 0, time.UTC)
+		endOfMonth  = time.Date(2020, 1, 31, 13, 0, 0, 0, time.UTC)
+		date        = func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+	)
+
+	type args struct {
+		amount      Money
+		count       int
+		purchasedAt time.Time
+	}
+
+	tests := []struct {
+		name         string
+		args         args
+		wantAmounts  []Money
+		wantDueDates []time.Time
+		wantErr      error
+	}{
+		// fails
+		{
+			name:    "zero installments",
+			args:    args{amount: brl(-10000), count: 0, purchasedAt: purchasedAt},
+			wantErr: NewErrDomain("installments", "must be between 1 and 24"),
+		},
+		{
+			name:    "too many installments",
+			args:    args{amount: brl(-10000), count: 25, purchasedAt: purchasedAt},
+			wantErr: NewErrDomain("installments", "must be between 1 and 24"),
+		},
+
+		// successes
+		{
+			name:         "single installment",
+			args:         args{amount: brl(-10000), count: 1, purchasedAt: purchasedAt},
+			wantAmounts:  []Money{brl(-10000)},
+			wantDueDates: []time.Time{date(2020, 11, 4)},
+		},
+		{
+			name:         "exact division",
+			args:         args{amount: brl(-9000), count: 3, purchasedAt: purchasedAt},
+			wantAmounts:  []Money{brl(-3000), brl(-3000), brl(-3000)},
+			wantDueDates: []time.Time{date(2020, 11, 4), date(2020, 12, 4), date(2021, 1, 4)},
+		},
+		{
+			name:         "remaining cents added to the first installment",
+			args:         args{amount: brl(-10000), count: 3, purchasedAt: purchasedAt},
+			wantAmounts:  []Money{brl(-3334), brl(-3333), brl(-3333)},
+			wantDueDates: []time.Time{date(2020, 11, 4), date(2020, 12, 4), date(2021, 1, 4)},
+		},
+		{
+			name:         "amount with cents",
+			args:         args{amount: brl(-5), count: 2, purchasedAt: purchasedAt},
+			wantAmounts:  []Money{brl(-3), brl(-2)},
+			wantDueDates: []time.Time{date(2020, 11, 4), date(2020, 12, 4)},
+		},
+		{
+			name:         "due dates in shorter months",
+			args:         args{amount: brl(-3000), count: 3, purchasedAt: endOfMonth},
+			wantAmounts:  []Money{brl(-1000), brl(-1000), brl(-1000)},
+			wantDueDates: []time.Time{date(2020, 2, 29), date(2020, 3, 31), date(2020, 4, 30)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewInstallmentPlan(tt.args.amount, tt.args.count, tt.args.purchasedAt)
+
+			if (err != nil) && !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("NewInstallmentPlan() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			var (
+				gotAmounts  []Money
+				gotDueDates []time.Time
+				gotNumbers  []int
+				wantNumbers []int
+			)
+
+			for i, v := range got.Installments() {
+				gotAmounts = append(gotAmounts, v.Amount())
+				gotDueDates = append(gotDueDates, v.DueDate())
+				gotNumbers = append(gotNumbers, v.Number())
+				wantNumbers = append(wantNumbers, i+1)
+			}
+
+			if !reflect.DeepEqual(gotAmounts, tt.wantAmounts) {
+				t.Errorf("Installments() amounts = %v, want %v", gotAmounts, tt.wantAmounts)
+			}
+
+			if !reflect.DeepEqual(gotDueDates, tt.wantDueDates) {
+				t.Errorf("Installments() due dates = %v, want %v", gotDueDates, tt.wantDueDates)
+			}
+
+			if !reflect.DeepEqual(gotNumbers, wantNumbers) {
+				t.Errorf("Installments() numbers = %v, want %v", gotNumbers, wantNumbers)
+			}
+
+			if got.Count() != tt.args.count {
+				t.Errorf("Count() = %v, want %v", got.Count(), tt.args.count)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"regexp"
+	"time"
+)
+
+const (
+	interestRateDecimalPlaces = 4
+
+	// moraDaysPerMonth is the number of days the monthly mora percentage is prorated by
+	moraDaysPerMonth = 30
+)
+
+var interestRateRegex = regexp.MustCompile(`^[0-9]+(\.[0-9]{1,4})?$`)
+
+// InterestPolicy represents the charges posted daily on the overdue balance of an invoice: the rotativo interest, a
+// percentage per day, the late fee, a fixed amount charged once per invoice, and the mora, a percentage per month
+// prorated per day
+type InterestPolicy struct {
+	dailyInterest *big.Rat
+	lateFee       Money
+	monthlyMora   *big.Rat
+}
+
+// NewInterestPolicy builds a valid InterestPolicy given the percentages as decimals with at most 4 decimal places, as
+// "0.45" for 0.45%, and the late fee in the currency of the accounts
+func NewInterestPolicy(dailyInterest string, lateFee Money, monthlyMora string) (*InterestPolicy, error) {
+	interest, err := parseInterestRate("daily_interest", dailyInterest)
+	if err != nil {
+		return nil, err
+	}
+
+	mora, err := parseInterestRate("monthly_mora", monthlyMora)
+	if err != nil {
+		return nil, err
+	}
+
+	if lateFee.IsNegative() {
+		return nil, NewErrDomain("late_fee", "must be 0 or greater")
+	}
+
+	return &InterestPolicy{dailyInterest: interest, lateFee: lateFee, monthlyMora: mora}, nil
+}
+
+// parseInterestRate parses a percentage to the rate it represents, "1.5" is parsed to 0.015
+func parseInterestRate(field, v string) (*big.Rat, error) {
+	if !interestRateRegex.MatchString(v) {
+		description := fmt.Sprintf("'%s' must be a decimal percentage with at most %d decimal places", v, interestRateDecimalPlaces)
+
+		return nil, NewErrDomain(field, description)
+	}
+
+	rate, _ := new(big.Rat).SetString(v)
+
+	return rate.Quo(rate, big.NewRat(100, 1)), nil
+}
+
+// Accrual represents the charges of a day on the overdue balance of the last invoice of an account. Charges are
+// posted once per account and day, the late fee only once per invoice.
+type Accrual struct {
+	account        *Account
+	invoice        *Invoice
+	day            time.Time
+	overdue        Money
+	lateFeeCharged bool
+	accrued        map[uint64]bool
+}
+
+// NewAccrual builds a new Accrual struct of the invoice in the day of the informed moment, in UTC
+func NewAccrual(account *Account, invoice *Invoice, at time.Time) *Accrual {
+	at = at.UTC()
+
+	return &Accrual{
+		account: account,
+		invoice: invoice,
+		day:     time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC),
+		overdue: invoice.Total(),
+		accrued: make(map[uint64]bool),
+	}
+}
+
+// Assess returns a new Accrual struct with the overdue balance at the start of the day: the invoice total, minus the
+// credits and plus the charges registered since the invoice closing. Charges already posted in the day are kept, so
+// they are not posted again.
+func (a *Accrual) Assess(ctx context.Context, repo StatementRepositoryReader) (*Accrual, error) {
+	accrual := *a
+	accrual.accrued = make(map[uint64]bool)
+
+	var (
+		from = a.invoice.To().Add(time.Second)
+		to   = endOfDay(a.day)
+	)
+
+	err := repo.WalkByPeriod(ctx, a.account.ID(), from, to, func(t *Transaction) error {
+		operation := t.Operation()
+
+		if operation.ID().Value() == OperationMultaAtraso.ID().Value() {
+			accrual.lateFeeCharged = true
+		}
+
+		switch {
+		case operation.IsCharge() && !t.AccrualDate().Before(a.day):
+			accrual.accrued[operation.ID().Value()] = true
+		case operation.IsCharge():
+			accrual.overdue = accrual.overdue.Sub(t.Amount())
+		case operation.IsIncoming() && t.CreatedAt().Before(a.day):
+			accrual.overdue = accrual.overdue.Sub(t.Amount())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &accrual, nil
+}
+
+// IsOverdue checks if the invoice is overdue in the day: its due date has passed and there is a balance to be paid
+func (a *Accrual) IsOverdue() bool {
+	return a.day.After(a.invoice.DueDate()) && a.overdue.IsPositive()
+}
+
+// Charges returns the charges of the day to be posted, in the currency of the account: the late fee, when it was not
+// charged yet, the rotativo interest and the mora on the overdue balance. Charges already posted in the day and charges
+// rounded to zero are not returned, neither charges of invoices not overdue.
+func (a *Accrual) Charges(policy *InterestPolicy) ([]*Transaction, error) {
+	if !a.IsOverdue() {
+		return nil, nil
+	}
+
+	var (
+		currency = a.account.Currency()
+		mora     = new(big.Rat).Quo(policy.monthlyMora, big.NewRat(moraDaysPerMonth, 1))
+		amounts  = map[*Operation]Money{
+			OperationJurosRotativo: a.overdue.mul(policy.dailyInterest),
+			OperationJurosMora:     a.overdue.mul(mora),
+		}
+		charges []*Transaction
+	)
+
+	if !a.lateFeeCharged {
+		amounts[OperationMultaAtraso] = policy.lateFee
+	}
+
+	for _, operation := range []*Operation{OperationMultaAtraso, OperationJurosRotativo, OperationJurosMora} {
+		amount, ok := amounts[operation]
+		if !ok || !amount.IsPositive() || a.accrued[operation.ID().Value()] {
+			continue
+		}
+
+		charge, err := NewTransaction(a.account.ID(), operation, amount.WithCurrency(currency))
+		if err != nil {
+			return nil, err
+		}
+
+		charges = append(charges, charge.WithAccrualDate(a.day))
+	}
+
+	return charges, nil
+}
+
+// Day returns the day accrued, at the start of the day in UTC
+func (a *Accrual) Day() time.Time {
+	return a.day
+}
+
+// Invoice returns the invoice whose balance is overdue
+func (a *Accrual) Invoice() *Invoice {
+	return a.invoice
+}
+
+// Overdue returns the balance of the invoice not paid at the start of the day, in the invoice currency
+func (a *Accrual) Overdue() Money {
+	return a.overdue
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// InterestRepositoryReader represents the behaviour of the Interest Repository to read operations.
+// FindOverdueInvoices finds the last invoice of each account not closed, when it is due before the informed day.
+type InterestRepositoryReader interface {
+	FindOverdueInvoices(context.Context, time.Time) ([]*Invoice, error)
+}
+
+// InterestRepositoryMock is a fake representation of an Interest Repository, useful to create unit tests
+type InterestRepositoryMock struct {
+	invoices []*Invoice
+	err      error
+}
+
+// NewInterestRepositoryMock builds a new InterestRepositoryMock struct with its mock results
+func NewInterestRepositoryMock(invoices []*Invoice, err error) *InterestRepositoryMock {
+	return &InterestRepositoryMock{invoices: invoices, err: err}
+}
+
+// FindOverdueInvoices finds the invoices due before the informed day
+func (i InterestRepositoryMock) FindOverdueInvoices(_ context.Context, _ time.Time) ([]*Invoice, error) {
+	if i.err != nil {
+		return nil, i.err
+	}
+
+	return i.invoices, nil
+}
@@ -0,0 +1,198 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewInterestPolicy(t *testing.T) {
+	type args struct {
+		dailyInterest string
+		lateFee       Money
+		monthlyMora   string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		// fails
+		{
+			name:    "daily interest with more than 4 decimal places",
+			args:    args{dailyInterest: "0.00001", lateFee: brl(1000), monthlyMora: "1"},
+			wantErr: NewErrDomain("daily_interest", "'0.00001' must be a decimal percentage with at most 4 decimal places"),
+		},
+		{
+			name:    "negative monthly mora",
+			args:    args{dailyInterest: "0.4", lateFee: brl(1000), monthlyMora: "-1"},
+			wantErr: NewErrDomain("monthly_mora", "'-1' must be a decimal percentage with at most 4 decimal places"),
+		},
+		{
+			name:    "negative late fee",
+			args:    args{dailyInterest: "0.4", lateFee: brl(-1), monthlyMora: "1"},
+			wantErr: NewErrDomain("late_fee", "must be 0 or greater"),
+		},
+
+		// successes
+		{
+			name: "valid policy",
+			args: args{dailyInterest: "0.4", lateFee: brl(1000), monthlyMora: "1"},
+		},
+		{
+			name: "policy without charges",
+			args: args{dailyInterest: "0", lateFee: brl(0), monthlyMora: "0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewInterestPolicy(tt.args.dailyInterest, tt.args.lateFee, tt.args.monthlyMora)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("NewInterestPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAccrual_Charges(t *testing.T) {
+	var (
+		policy, _ = NewInterestPolicy("0.5", brl(1000), "1")
+		account   = new(Account).WithID(NewID(1)).WithCurrency(CurrencyBRL)
+		closing   = time.Date(2020, 10, 25, 23, 59, 59, 0, time.UTC)
+		dueDate   = time.Date(2020, 11, 5, 0, 0, 0, 0, time.UTC)
+		invoice   = LoadInvoice(NewID(1), NewID(1), closing.AddDate(0, -1, 0), closing, dueDate, brl(100000), nil, closing)
+		day       = time.Date(2020, 11, 6, 3, 0, 0, 0, time.UTC)
+		today     = time.Date(2020, 11, 6, 0, 0, 0, 0, time.UTC)
+		yesterday = time.Date(2020, 11, 5, 0, 0, 0, 0, time.UTC)
+	)
+
+	transaction := func(operation *Operation, cents int64, createdAt time.Time) *Transaction {
+		t, _ := NewTransaction(NewID(1), operation, brl(cents))
+
+		return t.WithCreatedAt(createdAt)
+	}
+
+	charge := func(operation *Operation, cents int64, day time.Time) *Transaction {
+		return transaction(operation, cents, day.Add(time.Hour)).WithAccrualDate(day)
+	}
+
+	type want struct {
+		operation *Operation
+		amount    Money
+	}
+
+	tests := []struct {
+		name         string
+		at           time.Time
+		transactions []*Transaction
+		repoErr      error
+		want         []want
+		wantErr      error
+	}{
+		// fails
+		{
+			name:    "error to walk the transactions",
+			at:      day,
+			repoErr: errors.New("database error"),
+			wantErr: errors.New("database error"),
+		},
+
+		// successes
+		{
+			name: "invoice not overdue in its due date",
+			at:   time.Date(2020, 11, 5, 23, 0, 0, 0, time.UTC),
+			want: nil,
+		},
+		{
+			name:         "invoice paid before its due date",
+			at:           day,
+			transactions: []*Transaction{transaction(OperationPagamento, 100000, yesterday)},
+			want:         nil,
+		},
+		{
+			name: "first day overdue",
+			at:   day,
+			want: []want{
+				{operation: OperationMultaAtraso, amount: brl(-1000)},
+				{operation: OperationJurosRotativo, amount: brl(-500)},
+				{operation: OperationJurosMora, amount: brl(-33)},
+			},
+		},
+		{
+			name: "invoice partially paid and a payment registered in the day",
+			at:   day,
+			transactions: []*Transaction{
+				transaction(OperationCompraAVista, 5000, yesterday),
+				transaction(OperationPagamento, 40000, yesterday),
+				transaction(OperationPagamento, 60000, today.Add(time.Minute)),
+			},
+			want: []want{
+				{operation: OperationMultaAtraso, amount: brl(-1000)},
+				{operation: OperationJurosRotativo, amount: brl(-300)},
+				{operation: OperationJurosMora, amount: brl(-20)},
+			},
+		},
+		{
+			name: "charges of the previous days added to the overdue balance",
+			at:   day,
+			transactions: []*Transaction{
+				charge(OperationMultaAtraso, 1000, yesterday),
+				charge(OperationJurosRotativo, 500, yesterday),
+			},
+			want: []want{
+				{operation: OperationJurosRotativo, amount: brl(-508)},
+				{operation: OperationJurosMora, amount: brl(-34)},
+			},
+		},
+		{
+			name: "charges already posted in the day",
+			at:   day,
+			transactions: []*Transaction{
+				charge(OperationMultaAtraso, 1000, today),
+				charge(OperationJurosRotativo, 500, today),
+			},
+			want: []want{
+				{operation: OperationJurosMora, amount: brl(-33)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accrual, err := NewAccrual(account, invoice, tt.at).Assess(context.Background(), NewStatementRepositoryMock(tt.transactions, tt.repoErr))
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Assess() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			got, err := accrual.Charges(policy)
+			if err != nil {
+				t.Errorf("Charges() error = %v", err)
+				return
+			}
+
+			if len(got) != len(tt.want) {
+				t.Errorf("Charges() = %v charges, want %v", len(got), len(tt.want))
+				return
+			}
+
+			for i, v := range got {
+				if v.Operation().ID().Value() != tt.want[i].operation.ID().Value() || v.Amount() != tt.want[i].amount {
+					t.Errorf("Charges()[%d] = %v %v, want %v %v", i, v.Operation().Description(), v.Amount(), tt.want[i].operation.Description(), tt.want[i].amount)
+				}
+
+				if !v.AccrualDate().Equal(today) {
+					t.Errorf("AccrualDate() = %v, want %v", v.AccrualDate(), today)
+				}
+			}
+		})
+	}
+}
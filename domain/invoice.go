@@ -0,0 +1,247 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// MinimumPaymentPercentage is the percentage of the invoice total required as the minimum payment
+const MinimumPaymentPercentage = 15
+
+// Invoice represents the bill of a closed billing cycle of an account. The total carries the total of the previous
+// invoice, plus the debits and minus the credits of the cycle: positive totals are owed by the customer.
+type Invoice struct {
+	id              *ID
+	accountID       *ID
+	from            time.Time
+	to              time.Time
+	dueDate         time.Time
+	previousBalance Money
+	items           []*InvoiceItem
+	total           Money
+	minimumPayment  Money
+	createdAt       time.Time
+}
+
+// InvoiceItem represents a transaction billed in an invoice, or an installment of an installment purchase
+type InvoiceItem struct {
+	transactionID *ID
+	description   string
+	amount        Money
+	date          time.Time
+	installment   *Installment
+	installments  int
+}
+
+// NewInvoice builds the invoice of the account billing cycle ending at closing, without items. The cycle starts right
+// after the previous invoice, or when the account was created when there is no previous invoice.
+func NewInvoice(account *Account, previous *Invoice, closing time.Time) *Invoice {
+	var (
+		from            = account.CreatedAt()
+		previousBalance = NewMoney(0, account.Currency())
+	)
+
+	if previous != nil {
+		from = previous.To().Add(time.Second)
+		previousBalance = previous.Total()
+	}
+
+	invoice := &Invoice{
+		id:              NewID(0),
+		accountID:       account.ID(),
+		from:            from,
+		to:              closing,
+		dueDate:         account.BillingCycle().DueDate(closing),
+		previousBalance: previousBalance,
+	}
+
+	return invoice.WithItems(nil)
+}
+
+// LoadInvoice builds an Invoice struct already closed, calculating its totals from the items
+func LoadInvoice(
+	id, accountID *ID,
+	from, to, dueDate time.Time,
+	previousBalance Money,
+	items []*InvoiceItem,
+	createdAt time.Time,
+) *Invoice {
+	invoice := &Invoice{
+		id:              id,
+		accountID:       accountID,
+		from:            from,
+		to:              to,
+		dueDate:         dueDate,
+		previousBalance: previousBalance,
+		createdAt:       createdAt,
+	}
+
+	return invoice.WithItems(items)
+}
+
+// Gather returns a new Invoice struct with the transactions created in the billing cycle and the installments due in
+// it. Installment purchases are billed by their installments, instead of the purchase amount.
+func (i *Invoice) Gather(ctx context.Context, statementRepo StatementRepositoryReader, invoiceRepo InvoiceRepositoryReader) (*Invoice, error) {
+	var items []*InvoiceItem
+
+	err := statementRepo.WalkByPeriod(ctx, i.accountID, i.from, i.to, func(t *Transaction) error {
+		if !t.Operation().IsInstallmentPurchase() {
+			items = append(items, NewInvoiceItem(t))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	installments, err := invoiceRepo.FindDueInstallments(ctx, i.accountID, i.from, i.to)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.WithItems(append(items, installments...)), nil
+}
+
+// Store stores an invoice given a repository
+func (i *Invoice) Store(ctx context.Context, repo InvoiceRepositoryWriter) (*Invoice, error) {
+	id, err := repo.Store(ctx, i)
+	if err != nil {
+		return nil, err
+	}
+
+	invoice := *i
+	invoice.id = id
+	invoice.createdAt = time.Now()
+
+	return &invoice, nil
+}
+
+// ID returns the id value
+func (i *Invoice) ID() *ID {
+	return i.id
+}
+
+// AccountID returns the id of the account owner of the invoice
+func (i *Invoice) AccountID() *ID {
+	return i.accountID
+}
+
+// From returns the start of the billing cycle
+func (i *Invoice) From() time.Time {
+	return i.from
+}
+
+// To returns the end of the billing cycle, the end of the closing day
+func (i *Invoice) To() time.Time {
+	return i.to
+}
+
+// DueDate returns the date when the invoice is due
+func (i *Invoice) DueDate() time.Time {
+	return i.dueDate
+}
+
+// PreviousBalance returns the total of the previous invoice
+func (i *Invoice) PreviousBalance() Money {
+	return i.previousBalance
+}
+
+// Items returns the transactions and installments billed in the invoice
+func (i *Invoice) Items() []*InvoiceItem {
+	return i.items
+}
+
+// Total returns the amount owed, negative when the customer has credit
+func (i *Invoice) Total() Money {
+	return i.total
+}
+
+// MinimumPayment returns the minimum amount to be paid until the due date, zero when nothing is owed
+func (i *Invoice) MinimumPayment() Money {
+	return i.minimumPayment
+}
+
+// CreatedAt returns the createdAt value
+func (i *Invoice) CreatedAt() time.Time {
+	return i.createdAt
+}
+
+// WithItems returns a new Invoice struct with the informed items and the totals calculated from them. The minimum
+// payment is rounded up to the minor unit of the currency.
+func (i *Invoice) WithItems(items []*InvoiceItem) *Invoice {
+	invoice := *i
+	invoice.items = items
+	invoice.total = i.previousBalance
+
+	for _, v := range items {
+		invoice.total = invoice.total.Sub(v.Amount())
+	}
+
+	invoice.minimumPayment = NewMoney(0, invoice.total.Currency())
+
+	if invoice.total.IsPositive() {
+		units := (invoice.total.Units()*MinimumPaymentPercentage + 99) / 100
+		invoice.minimumPayment = NewMoney(units, invoice.total.Currency())
+	}
+
+	return &invoice
+}
+
+// NewInvoiceItem builds a new InvoiceItem struct billing the transaction
+func NewInvoiceItem(t *Transaction) *InvoiceItem {
+	return &InvoiceItem{
+		transactionID: t.ID(),
+		description:   t.Operation().Description(),
+		amount:        t.Amount(),
+		date:          t.CreatedAt(),
+	}
+}
+
+// LoadInvoiceItem builds an InvoiceItem struct already billed, which is not an installment
+func LoadInvoiceItem(transactionID *ID, description string, amount Money, date time.Time) *InvoiceItem {
+	return &InvoiceItem{transactionID: transactionID, description: description, amount: amount, date: date}
+}
+
+// NewInstallmentInvoiceItem builds a new InvoiceItem struct billing an installment of the purchase, out of count
+// installments
+func NewInstallmentInvoiceItem(transactionID *ID, description string, installment *Installment, count int) *InvoiceItem {
+	return &InvoiceItem{
+		transactionID: transactionID,
+		description:   description,
+		amount:        installment.Amount(),
+		date:          installment.DueDate(),
+		installment:   installment,
+		installments:  count,
+	}
+}
+
+// TransactionID returns the id of the transaction billed
+func (i *InvoiceItem) TransactionID() *ID {
+	return i.transactionID
+}
+
+// Description returns the description of the operation of the transaction
+func (i *InvoiceItem) Description() string {
+	return i.description
+}
+
+// Amount returns the amount billed, with the sign of the transaction
+func (i *InvoiceItem) Amount() Money {
+	return i.amount
+}
+
+// Date returns when the transaction was created, or when the installment is due
+func (i *InvoiceItem) Date() time.Time {
+	return i.date
+}
+
+// Installment returns the installment billed, nil when the item is not an installment
+func (i *InvoiceItem) Installment() *Installment {
+	return i.installment
+}
+
+// Installments returns the number of installments of the purchase, zero when the item is not an installment
+func (i *InvoiceItem) Installments() int {
+	return i.installments
+}
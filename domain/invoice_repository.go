@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// InvoiceRepositoryReader represents the behaviour of the Invoice Repository to read operations.
+// FindLastByAccount returns nil when the account has no invoices, and FindAccountsToClose finds the accounts closing
+// in the informed day that have no invoice for the billing cycle ending at the informed closing.
+type InvoiceRepositoryReader interface {
+	FindOneByID(context.Context, *ID) (*Invoice, error)
+	FindByAccount(context.Context, *ID) ([]*Invoice, error)
+	FindLastByAccount(context.Context, *ID) (*Invoice, error)
+	FindDueInstallments(context.Context, *ID, time.Time, time.Time) ([]*InvoiceItem, error)
+	FindAccountsToClose(context.Context, int, time.Time) ([]*ID, error)
+}
+
+// InvoiceRepositoryWriter represents the behaviour of the Invoice Repository to write operations.
+// Only one invoice can be stored for each billing cycle of an account.
+type InvoiceRepositoryWriter interface {
+	Store(context.Context, *Invoice) (*ID, error)
+}
+
+// InvoiceRepositoryMock is a fake representation of an Invoice Repository, useful to create unit tests
+type InvoiceRepositoryMock struct {
+	id           *ID
+	invoices     []*Invoice
+	installments []*InvoiceItem
+	accountIDs   []*ID
+	err          error
+}
+
+// NewInvoiceRepositoryMock builds a new InvoiceRepositoryMock struct with its mock results
+func NewInvoiceRepositoryMock(id *ID, invoices []*Invoice, err error) *InvoiceRepositoryMock {
+	return &InvoiceRepositoryMock{id: id, invoices: invoices, err: err}
+}
+
+// WithInstallments returns a new InvoiceRepositoryMock struct with the informed due installments result
+func (i InvoiceRepositoryMock) WithInstallments(installments []*InvoiceItem) *InvoiceRepositoryMock {
+	mock := i
+	mock.installments = installments
+
+	return &mock
+}
+
+// WithAccountsToClose returns a new InvoiceRepositoryMock struct with the informed accounts to close result
+func (i InvoiceRepositoryMock) WithAccountsToClose(ids []*ID) *InvoiceRepositoryMock {
+	mock := i
+	mock.accountIDs = ids
+
+	return &mock
+}
+
+// FindOneByID finds an invoice by its id
+func (i InvoiceRepositoryMock) FindOneByID(_ context.Context, _ *ID) (*Invoice, error) {
+	if i.err != nil {
+		return nil, i.err
+	}
+
+	if len(i.invoices) == 0 {
+		return nil, nil
+	}
+
+	return i.invoices[0], nil
+}
+
+// FindByAccount finds the invoices of an account
+func (i InvoiceRepositoryMock) FindByAccount(_ context.Context, _ *ID) ([]*Invoice, error) {
+	if i.err != nil {
+		return nil, i.err
+	}
+
+	return i.invoices, nil
+}
+
+// FindLastByAccount finds the last invoice of an account
+func (i InvoiceRepositoryMock) FindLastByAccount(_ context.Context, _ *ID) (*Invoice, error) {
+	if i.err != nil {
+		return nil, i.err
+	}
+
+	if len(i.invoices) == 0 {
+		return nil, nil
+	}
+
+	return i.invoices[len(i.invoices)-1], nil
+}
+
+// FindDueInstallments finds the installments of an account due in the period
+func (i InvoiceRepositoryMock) FindDueInstallments(_ context.Context, _ *ID, _ time.Time, _ time.Time) ([]*InvoiceItem, error) {
+	if i.err != nil {
+		return nil, i.err
+	}
+
+	return i.installments, nil
+}
+
+// FindAccountsToClose finds the accounts with the billing cycle to close
+func (i InvoiceRepositoryMock) FindAccountsToClose(_ context.Context, closingDay int, _ time.Time) ([]*ID, error) {
+	if i.err != nil {
+		return nil, i.err
+	}
+
+	if closingDay != DefaultClosingDay {
+		return nil, nil
+	}
+
+	return i.accountIDs, nil
+}
+
+// Store stores an invoice
+func (i InvoiceRepositoryMock) Store(_ context.Context, _ *Invoice) (*ID, error) {
+	if i.err != nil {
+		return nil, i.err
+	}
+
+	return i.id, nil
+}
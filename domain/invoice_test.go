@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewInvoice(t *testing.T) {
+	var (
+		createdAt = time.Date(2020, 10, 3, 14, 0, 0, 0, time.UTC)
+		closing   = time.Date(2020, 10, 25, 23, 59, 59, 0, time.UTC)
+		account   = new(Account).WithID(NewID(1)).WithCurrency(CurrencyBRL).WithCreateAt(createdAt)
+		previous  = LoadInvoice(NewID(1), NewID(1), createdAt, closing, time.Time{}, brl(10000), nil, time.Time{})
+	)
+
+	tests := []struct {
+		name                string
+		previous            *Invoice
+		wantFrom            time.Time
+		wantPreviousBalance Money
+	}{
+		{
+			name:                "first invoice of the account",
+			previous:            nil,
+			wantFrom:            createdAt,
+			wantPreviousBalance: brl(0),
+		},
+		{
+			name:                "invoice after the previous one",
+			previous:            previous,
+			wantFrom:            time.Date(2020, 10, 26, 0, 0, 0, 0, time.UTC),
+			wantPreviousBalance: brl(10000),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewInvoice(account, tt.previous, closing.AddDate(0, 1, 0))
+
+			if !got.From().Equal(tt.wantFrom) {
+				t.Errorf("From() = %v, want %v", got.From(), tt.wantFrom)
+			}
+
+			if got.PreviousBalance() != tt.wantPreviousBalance || got.Total() != tt.wantPreviousBalance {
+				t.Errorf("PreviousBalance() = %v, Total() = %v, want %v", got.PreviousBalance(), got.Total(), tt.wantPreviousBalance)
+			}
+
+			if want := time.Date(2020, 12, 5, 0, 0, 0, 0, time.UTC); !got.DueDate().Equal(want) {
+				t.Errorf("DueDate() = %v, want %v", got.DueDate(), want)
+			}
+		})
+	}
+}
+
+func TestInvoice_Gather(t *testing.T) {
+	var (
+		account        = new(Account).WithID(NewID(1)).WithCurrency(CurrencyBRL)
+		closing        = time.Date(2020, 10, 25, 23, 59, 59, 0, time.UTC)
+		purchase, _    = NewTransaction(NewID(1), OperationCompraAVista, brl(5000))
+		installment, _ = NewTransaction(NewID(1), OperationCompraParcelada, brl(30000))
+		payment, _     = NewTransaction(NewID(1), OperationPagamento, brl(2000))
+		dueInstallment = NewInstallmentInvoiceItem(NewID(5), "COMPRA PARCELADA", NewInstallment(2, brl(-10000), closing), 3)
+	)
+
+	tests := []struct {
+		name               string
+		previousBalance    Money
+		statementRepo      *StatementRepositoryMock
+		invoiceRepo        *InvoiceRepositoryMock
+		wantItems          int
+		wantTotal          Money
+		wantMinimumPayment Money
+		wantErr            error
+	}{
+		// fails
+		{
+			name:          "error to walk the transactions",
+			statementRepo: NewStatementRepositoryMock(nil, errors.New("database error")),
+			invoiceRepo:   NewInvoiceRepositoryMock(nil, nil, nil),
+			wantErr:       errors.New("database error"),
+		},
+		{
+			name:          "error to find the due installments",
+			statementRepo: NewStatementRepositoryMock(nil, nil),
+			invoiceRepo:   NewInvoiceRepositoryMock(nil, nil, errors.New("database error")),
+			wantErr:       errors.New("database error"),
+		},
+
+		// successes
+		{
+			name:               "invoice without items",
+			statementRepo:      NewStatementRepositoryMock(nil, nil),
+			invoiceRepo:        NewInvoiceRepositoryMock(nil, nil, nil),
+			wantItems:          0,
+			wantTotal:          brl(0),
+			wantMinimumPayment: brl(0),
+		},
+		{
+			name:               "installment purchases billed by their installments",
+			statementRepo:      NewStatementRepositoryMock([]*Transaction{purchase, installment, payment}, nil),
+			invoiceRepo:        NewInvoiceRepositoryMock(nil, nil, nil).WithInstallments([]*InvoiceItem{dueInstallment}),
+			wantItems:          3,
+			wantTotal:          brl(13000),
+			wantMinimumPayment: brl(1950),
+		},
+		{
+			name:               "previous balance carried and minimum payment rounded up",
+			previousBalance:    brl(1),
+			statementRepo:      NewStatementRepositoryMock([]*Transaction{purchase}, nil),
+			invoiceRepo:        NewInvoiceRepositoryMock(nil, nil, nil),
+			wantItems:          1,
+			wantTotal:          brl(5001),
+			wantMinimumPayment: brl(751),
+		},
+		{
+			name:               "credit balance without minimum payment",
+			previousBalance:    brl(1000),
+			statementRepo:      NewStatementRepositoryMock([]*Transaction{payment}, nil),
+			invoiceRepo:        NewInvoiceRepositoryMock(nil, nil, nil),
+			wantItems:          1,
+			wantTotal:          brl(-1000),
+			wantMinimumPayment: brl(0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var previous *Invoice
+			if !tt.previousBalance.IsZero() {
+				previous = LoadInvoice(NewID(1), NewID(1), time.Time{}, closing.AddDate(0, -1, 0), time.Time{}, tt.previousBalance, nil, time.Time{})
+			}
+
+			got, err := NewInvoice(account, previous, closing).Gather(context.Background(), tt.statementRepo, tt.invoiceRepo)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Gather() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if len(got.Items()) != tt.wantItems {
+				t.Errorf("Items() = %v, want %v", len(got.Items()), tt.wantItems)
+			}
+
+			if got.Total() != tt.wantTotal {
+				t.Errorf("Total() = %v, want %v", got.Total(), tt.wantTotal)
+			}
+
+			if got.MinimumPayment() != tt.wantMinimumPayment {
+				t.Errorf("MinimumPayment() = %v, want %v", got.MinimumPayment(), tt.wantMinimumPayment)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"context"
+	"sync"
+)
+
+// Logger represents the behaviour of a structured and leveled logger. Each entry has a message and fields, and the
+// implementations add to it the request carried by the context, so all the entries of a request can be correlated.
+// With returns a Logger adding the informed fields to all its entries.
+type Logger interface {
+	Debug(ctx context.Context, msg string, fields ...LogField)
+	Info(ctx context.Context, msg string, fields ...LogField)
+	Warn(ctx context.Context, msg string, fields ...LogField)
+	Error(ctx context.Context, msg string, fields ...LogField)
+	With(fields ...LogField) Logger
+}
+
+// LogField is a key and value added to a log entry
+type LogField struct {
+	key   string
+	value interface{}
+}
+
+// NewLogField builds a new LogField struct
+func NewLogField(key string, value interface{}) LogField {
+	return LogField{key: key, value: value}
+}
+
+// NewErrLogField builds a new LogField struct with the message of the error, under the "error" key
+func NewErrLogField(err error) LogField {
+	if err == nil {
+		return LogField{key: "error"}
+	}
+
+	return LogField{key: "error", value: err.Error()}
+}
+
+// Key returns the key value
+func (f LogField) Key() string {
+	return f.key
+}
+
+// Value returns the value
+func (f LogField) Value() interface{} {
+	return f.value
+}
+
+// LogEntry is an entry written by the LoggerMock
+type LogEntry struct {
+	Level  string
+	Msg    string
+	Fields map[string]interface{}
+}
+
+// LoggerMock is a fake representation of a Logger, useful to create unit tests. It registers the entries written.
+type LoggerMock struct {
+	mu      *sync.Mutex
+	entries *[]LogEntry
+	fields  []LogField
+}
+
+// NewLoggerMock builds a new LoggerMock struct
+func NewLoggerMock() *LoggerMock {
+	return &LoggerMock{mu: new(sync.Mutex), entries: new([]LogEntry)}
+}
+
+// Debug writes a debug entry
+func (l LoggerMock) Debug(_ context.Context, msg string, fields ...LogField) {
+	l.write("debug", msg, fields)
+}
+
+// Info writes an info entry
+func (l LoggerMock) Info(_ context.Context, msg string, fields ...LogField) {
+	l.write("info", msg, fields)
+}
+
+// Warn writes a warn entry
+func (l LoggerMock) Warn(_ context.Context, msg string, fields ...LogField) {
+	l.write("warn", msg, fields)
+}
+
+// Error writes an error entry
+func (l LoggerMock) Error(_ context.Context, msg string, fields ...LogField) {
+	l.write("error", msg, fields)
+}
+
+// With returns a new LoggerMock struct adding the fields to its entries, registered with the entries of l
+func (l LoggerMock) With(fields ...LogField) Logger {
+	mock := l
+	mock.fields = append(append([]LogField(nil), l.fields...), fields...)
+
+	return &mock
+}
+
+// Entries returns the entries written
+func (l LoggerMock) Entries() []LogEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return append([]LogEntry(nil), *l.entries...)
+}
+
+func (l LoggerMock) write(level, msg string, fields []LogField) {
+	entry := LogEntry{Level: level, Msg: msg, Fields: make(map[string]interface{})}
+
+	for _, v := range append(append([]LogField(nil), l.fields...), fields...) {
+		entry.Fields[v.Key()] = v.Value()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	*l.entries = append(*l.entries, entry)
+}
@@ -0,0 +1,201 @@
+package domain
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// maxMoneyDigits is the greatest number of integer digits of an amount
+const maxMoneyDigits = 13
+
+var decimalRegex = regexp.MustCompile(`^(-)?([0-9]+)(\.([0-9]+))?$`)
+
+// Money represents an exact amount of money, stored in minor units of its currency, as the cents of the Brazilian Real
+type Money struct {
+	units    int64
+	currency Currency
+}
+
+// NewMoney builds a new Money value given its amount in minor units of the currency
+func NewMoney(units int64, currency Currency) Money {
+	return Money{units: units, currency: currency}
+}
+
+// ParseMoney builds a new Money value given a decimal representation, as "100", "-10.5" or "0.01".
+// Amounts with more significant decimal places than the minor units of the currency are rejected.
+func ParseMoney(v string, currency Currency) (Money, error) {
+	match := decimalRegex.FindStringSubmatch(strings.TrimSpace(v))
+	if match == nil {
+		return Money{}, NewErrDomain("amount", "must be a valid decimal number")
+	}
+
+	var (
+		negative   = match[1] == "-"
+		integer    = strings.TrimLeft(match[2], "0")
+		decimals   = strings.TrimRight(match[4], "0")
+		minorUnits = currency.MinorUnits()
+	)
+
+	if len(decimals) > minorUnits {
+		return Money{}, errDecimalPlaces(minorUnits)
+	}
+
+	if len(integer) > maxMoneyDigits {
+		return Money{}, NewErrDomain("amount", fmt.Sprintf("must have at most %d integer digits", maxMoneyDigits))
+	}
+
+	decimals += strings.Repeat("0", minorUnits-len(decimals))
+
+	units, err := strconv.ParseInt("0"+integer+decimals, 10, 64)
+	if err != nil {
+		return Money{}, NewErrDomain("amount", "must be a valid decimal number")
+	}
+
+	if negative {
+		units = -units
+	}
+
+	return NewMoney(units, currency), nil
+}
+
+// Units returns the amount in minor units of the currency
+func (m Money) Units() int64 {
+	return m.units
+}
+
+// Currency returns the currency
+func (m Money) Currency() Currency {
+	return m.currency
+}
+
+// In returns the same amount in the informed currency, as an amount informed before its currency is known. Amounts
+// with more significant decimal places than the minor units of the currency are rejected.
+func (m Money) In(currency Currency) (Money, error) {
+	amount := m.WithCurrency(currency)
+
+	if amount.WithCurrency(m.currency) != m {
+		return Money{}, errDecimalPlaces(currency.MinorUnits())
+	}
+
+	return amount, nil
+}
+
+// WithCurrency returns a new Money value with the same amount in the informed currency, rounding half away from zero
+// to its minor units
+func (m Money) WithCurrency(currency Currency) Money {
+	return m.rescale(currency, big.NewRat(1, 1))
+}
+
+// Add returns the sum of the amounts, which must be in the same currency
+func (m Money) Add(o Money) Money {
+	m.mustMatch(o)
+
+	return NewMoney(m.units+o.units, m.currency)
+}
+
+// Sub returns the subtraction of the amounts, which must be in the same currency
+func (m Money) Sub(o Money) Money {
+	m.mustMatch(o)
+
+	return NewMoney(m.units-o.units, m.currency)
+}
+
+// mustMatch panics when the amounts are in different currencies, as adding them is a bug, not an invalid input
+func (m Money) mustMatch(o Money) {
+	if m.currency != o.currency {
+		panic(fmt.Sprintf("money: amounts in '%s' and '%s' cannot be added or subtracted", m.currency, o.currency))
+	}
+}
+
+// mul returns the amount multiplied by the rate, keeping the currency and rounding half away from zero to the nearest
+// minor unit
+func (m Money) mul(rate *big.Rat) Money {
+	return m.rescale(m.currency, rate)
+}
+
+// rescale returns the amount multiplied by the rate in the informed currency, adjusting the minor units of the
+// currencies and rounding half away from zero to the nearest minor unit
+func (m Money) rescale(currency Currency, rate *big.Rat) Money {
+	var (
+		scale    = new(big.Rat).SetFrac(pow10(currency.MinorUnits()), pow10(m.currency.MinorUnits()))
+		product  = new(big.Rat).Mul(new(big.Rat).SetInt64(m.units), new(big.Rat).Mul(rate, scale))
+		num      = new(big.Int).Abs(product.Num())
+		quo, rem = new(big.Int).QuoRem(num, product.Denom(), new(big.Int))
+	)
+
+	if rem.Mul(rem, big.NewInt(2)).Cmp(product.Denom()) >= 0 {
+		quo.Add(quo, big.NewInt(1))
+	}
+
+	if product.Sign() < 0 {
+		quo.Neg(quo)
+	}
+
+	return NewMoney(quo.Int64(), currency)
+}
+
+// Neg returns the amount with the opposite sign
+func (m Money) Neg() Money {
+	return NewMoney(-m.units, m.currency)
+}
+
+// Abs returns the absolute amount
+func (m Money) Abs() Money {
+	if m.units < 0 {
+		return m.Neg()
+	}
+
+	return m
+}
+
+// IsZero checks if the amount is zero
+func (m Money) IsZero() bool {
+	return m.units == 0
+}
+
+// IsNegative checks if the amount is less than zero
+func (m Money) IsNegative() bool {
+	return m.units < 0
+}
+
+// IsPositive checks if the amount is greater than zero
+func (m Money) IsPositive() bool {
+	return m.units > 0
+}
+
+// String returns the decimal representation of the amount, always with the minor units of the currency
+func (m Money) String() string {
+	var (
+		sign       = ""
+		units      = m.units
+		minorUnits = m.currency.MinorUnits()
+	)
+
+	if units < 0 {
+		sign = "-"
+		units = -units
+	}
+
+	if minorUnits == 0 {
+		return fmt.Sprintf("%s%d", sign, units)
+	}
+
+	scale := pow10(minorUnits).Int64()
+
+	return fmt.Sprintf("%s%d.%0*d", sign, units/scale, minorUnits, units%scale)
+}
+
+func errDecimalPlaces(minorUnits int) error {
+	if minorUnits == 0 {
+		return NewErrDomain("amount", "must not have decimal places")
+	}
+
+	return NewErrDomain("amount", fmt.Sprintf("must have at most %d decimal places", minorUnits))
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
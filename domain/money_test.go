@@ -0,0 +1,299 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      string
+		wantUnits  int64
+		wantString string
+		wantErr    error
+	}{
+		// fails
+		{
+			name:    "empty value",
+			value:   "",
+			wantErr: NewErrDomain("amount", "must be a valid decimal number"),
+		},
+		{
+			name:    "alpha characters",
+			value:   "10,50",
+			wantErr: NewErrDomain("amount", "must be a valid decimal number"),
+		},
+		{
+			name:    "exponent notation",
+			value:   "1e2",
+			wantErr: NewErrDomain("amount", "must be a valid decimal number"),
+		},
+		{
+			name:    "more than 2 decimal places",
+			value:   "10.001",
+			wantErr: NewErrDomain("amount", "must have at most 2 decimal places"),
+		},
+		{
+			name:    "too big",
+			value:   "12345678901234",
+			wantErr: NewErrDomain("amount", "must have at most 13 integer digits"),
+		},
+
+		// successes
+		{
+			name:       "integer value",
+			value:      "100",
+			wantUnits:  10000,
+			wantString: "100.00",
+		},
+		{
+			name:       "one decimal place",
+			value:      "10.5",
+			wantUnits:  1050,
+			wantString: "10.50",
+		},
+		{
+			name:       "one cent",
+			value:      "0.01",
+			wantUnits:  1,
+			wantString: "0.01",
+		},
+		{
+			name:       "negative value",
+			value:      "-33.34",
+			wantUnits:  -3334,
+			wantString: "-33.34",
+		},
+		{
+			name:       "trailing zeros",
+			value:      "0.100",
+			wantUnits:  10,
+			wantString: "0.10",
+		},
+		{
+			name:       "floating point drift prone value",
+			value:      "0.29",
+			wantUnits:  29,
+			wantString: "0.29",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMoney(tt.value, CurrencyBRL)
+
+			if (err != nil) && !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("ParseMoney() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if (err == nil) && (tt.wantErr != nil) {
+				t.Errorf("ParseMoney() error = nil, wantErr %v", tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.Units() != tt.wantUnits {
+				t.Errorf("Units() = %v, want %v", got.Units(), tt.wantUnits)
+			}
+
+			if got.String() != tt.wantString {
+				t.Errorf("String() = %v, want %v", got.String(), tt.wantString)
+			}
+
+			if got.Currency() != CurrencyBRL {
+				t.Errorf("Currency() = %v, want %v", got.Currency(), CurrencyBRL)
+			}
+		})
+	}
+}
+
+func TestMoney_Operations(t *testing.T) {
+	var (
+		ten     = NewMoney(1000, CurrencyBRL)
+		cents29 = NewMoney(29, CurrencyBRL)
+	)
+
+	if got := ten.Add(cents29); got.Units() != 1029 {
+		t.Errorf("Add() = %v, want 10.29", got)
+	}
+
+	if got := ten.Sub(cents29); got.Units() != 971 {
+		t.Errorf("Sub() = %v, want 9.71", got)
+	}
+
+	if got := ten.Neg(); got.Units() != -1000 || !got.IsNegative() {
+		t.Errorf("Neg() = %v, want -10.00", got)
+	}
+
+	if got := ten.Neg().Abs(); got != ten {
+		t.Errorf("Abs() = %v, want 10.00", got)
+	}
+
+	if !NewMoney(0, CurrencyBRL).IsZero() || ten.IsZero() {
+		t.Error("IsZero() is invalid")
+	}
+
+	if !ten.IsPositive() || ten.Neg().IsPositive() {
+		t.Error("IsPositive() is invalid")
+	}
+
+	if got := NewMoney(-5, CurrencyBRL).String(); got != "-0.05" {
+		t.Errorf("String() = %v, want -0.05", got)
+	}
+}
+
+func TestParseMoney_MinorUnits(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      string
+		currency   Currency
+		wantUnits  int64
+		wantString string
+		wantErr    error
+	}{
+		// fails
+		{
+			name:     "decimal places in a currency without them",
+			value:    "1500.5",
+			currency: "JPY",
+			wantErr:  NewErrDomain("amount", "must not have decimal places"),
+		},
+		{
+			name:     "more than 3 decimal places",
+			value:    "1.2345",
+			currency: "KWD",
+			wantErr:  NewErrDomain("amount", "must have at most 3 decimal places"),
+		},
+
+		// successes
+		{
+			name:       "currency without decimal places",
+			value:      "1500.00",
+			currency:   "JPY",
+			wantUnits:  1500,
+			wantString: "1500",
+		},
+		{
+			name:       "zero in a currency without decimal places",
+			value:      "0",
+			currency:   "CLP",
+			wantUnits:  0,
+			wantString: "0",
+		},
+		{
+			name:       "currency with 3 decimal places",
+			value:      "-1.5",
+			currency:   "KWD",
+			wantUnits:  -1500,
+			wantString: "-1.500",
+		},
+		{
+			name:       "amount without currency",
+			value:      "10.0005",
+			wantUnits:  100005,
+			wantString: "10.0005",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMoney(tt.value, tt.currency)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("ParseMoney() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.Units() != tt.wantUnits || got.String() != tt.wantString {
+				t.Errorf("ParseMoney() = %v (%v units), want %v (%v units)", got, got.Units(), tt.wantString, tt.wantUnits)
+			}
+		})
+	}
+}
+
+func TestMoney_In(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   Money
+		currency Currency
+		want     Money
+		wantErr  error
+	}{
+		// fails
+		{
+			name:     "more decimal places than the currency",
+			amount:   NewMoney(100050, ""),
+			currency: CurrencyBRL,
+			wantErr:  NewErrDomain("amount", "must have at most 2 decimal places"),
+		},
+		{
+			name:     "decimal places in a currency without them",
+			amount:   NewMoney(15005000, ""),
+			currency: "JPY",
+			wantErr:  NewErrDomain("amount", "must not have decimal places"),
+		},
+
+		// successes
+		{
+			name:     "amount without currency",
+			amount:   NewMoney(105000, ""),
+			currency: CurrencyBRL,
+			want:     brl(1050),
+		},
+		{
+			name:     "amount without currency in a currency without decimal places",
+			amount:   NewMoney(15000000, ""),
+			currency: "JPY",
+			want:     NewMoney(1500, "JPY"),
+		},
+		{
+			name:     "amount already in the currency",
+			amount:   brl(1050),
+			currency: CurrencyBRL,
+			want:     brl(1050),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.amount.In(tt.currency)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("In() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("In() = %v %v, want %v %v", got, got.Currency(), tt.want, tt.want.Currency())
+			}
+		})
+	}
+}
+
+func TestMoney_CurrencyMismatch(t *testing.T) {
+	for name, fn := range map[string]func(){
+		"Add": func() { brl(1000).Add(NewMoney(1000, "USD")) },
+		"Sub": func() { brl(1000).Sub(NewMoney(1000, "")) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s() must panic when the currencies are different", name)
+				}
+			}()
+
+			fn()
+		})
+	}
+}
+
+func brl(cents int64) Money {
+	return NewMoney(cents, CurrencyBRL)
+}
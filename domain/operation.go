@@ -1,48 +1,226 @@
 package domain
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
 
+const (
+	// OperationDirectionDebit represents an operation decreasing the available credit limit of the account
+	OperationDirectionDebit OperationDirection = "DEBIT"
+
+	// OperationDirectionCredit represents an operation increasing the available credit limit of the account
+	OperationDirectionCredit OperationDirection = "CREDIT"
+)
+
+const (
+	// OperationKindStandard represents the purchases, withdrawals and any other debit or credit with no flow of its own
+	OperationKindStandard OperationKind = "STANDARD"
+
+	// OperationKindInstallmentPurchase represents a purchase billed by its installments
+	OperationKindInstallmentPurchase OperationKind = "INSTALLMENT_PURCHASE"
+
+	// OperationKindPayment represents a payment, which discharges the debits of the account
+	OperationKindPayment OperationKind = "PAYMENT"
+
+	// OperationKindTransfer represents the postings of a transfer
+	OperationKindTransfer OperationKind = "TRANSFER"
+
+	// OperationKindReversal represents the reversal of another transaction
+	OperationKindReversal OperationKind = "REVERSAL"
+
+	// OperationKindCharge represents the charges posted on overdue balances by the interest accrual
+	OperationKindCharge OperationKind = "CHARGE"
+)
+
+const operationDescriptionMaxLength = 50
+
+// The operations below are seeded by the migrations of the schema. The transfers, reversals and charges flows register
+// their transactions with them, the operations themselves are read through the Operation Repository.
 var (
 	// OperationCompraAVista representa uma operação de compra a vista
-	OperationCompraAVista = newOperation(uint64(1), "compra a vista")
+	OperationCompraAVista = newOperation(uint64(1), "compra a vista", OperationDirectionDebit, OperationKindStandard)
 
 	// OperationCompraParcelada representa uma operação de compra parcelada
-	OperationCompraParcelada = newOperation(uint64(2), "compra parcelada")
+	OperationCompraParcelada = newOperation(uint64(2), "compra parcelada", OperationDirectionDebit, OperationKindInstallmentPurchase)
 
 	// OperationSaque representa uma operação de saque
-	OperationSaque = newOperation(uint64(3), "saque")
+	OperationSaque = newOperation(uint64(3), "saque", OperationDirectionDebit, OperationKindStandard)
 
 	// OperationPagamento representa uma operação de pagamento
-	OperationPagamento = newOperation(uint64(4), "Pagamento")
+	OperationPagamento = newOperation(uint64(4), "Pagamento", OperationDirectionCredit, OperationKindPayment)
 
-	operations = map[uint64]*Operation{
-		OperationCompraAVista.id.Value():    OperationCompraAVista,
-		OperationCompraParcelada.id.Value(): OperationCompraParcelada,
-		OperationSaque.id.Value():           OperationSaque,
-		OperationPagamento.id.Value():       OperationPagamento,
-	}
+	// OperationTransferenciaEnviada representa o débito de uma transferência na conta de origem
+	OperationTransferenciaEnviada = newOperation(uint64(5), "transferencia enviada", OperationDirectionDebit, OperationKindTransfer)
+
+	// OperationTransferenciaRecebida representa o crédito de uma transferência na conta de destino
+	OperationTransferenciaRecebida = newOperation(uint64(6), "transferencia recebida", OperationDirectionCredit, OperationKindTransfer)
+
+	// OperationEstorno representa o estorno, total ou parcial, de uma transação
+	OperationEstorno = newOperation(uint64(7), "estorno", OperationDirectionCredit, OperationKindReversal)
+
+	// OperationJurosRotativo representa os juros diários do rotativo sobre o saldo em atraso da fatura
+	OperationJurosRotativo = newOperation(uint64(8), "juros rotativo", OperationDirectionDebit, OperationKindCharge)
+
+	// OperationMultaAtraso representa a multa cobrada uma única vez pelo atraso no pagamento da fatura
+	OperationMultaAtraso = newOperation(uint64(9), "multa por atraso", OperationDirectionDebit, OperationKindCharge)
+
+	// OperationJurosMora representa os juros de mora diários sobre o saldo em atraso da fatura
+	OperationJurosMora = newOperation(uint64(10), "juros de mora", OperationDirectionDebit, OperationKindCharge)
 )
 
+// OperationDirection represents how an operation affects the available credit limit of the account
+type OperationDirection string
+
+// NewOperationDirection builds a valid OperationDirection
+func NewOperationDirection(v string) (OperationDirection, error) {
+	direction := OperationDirection(strings.ToUpper(strings.TrimSpace(v)))
+
+	if direction != OperationDirectionDebit && direction != OperationDirectionCredit {
+		return "", NewErrDomain("direction", fmt.Sprintf("'%s' is not a valid operation direction", v))
+	}
+
+	return direction, nil
+}
+
+// String returns the direction value
+func (d OperationDirection) String() string {
+	return string(d)
+}
+
+// OperationKind represents the flow of the transactions of an operation
+type OperationKind string
+
+// NewOperationKind builds a valid OperationKind
+func NewOperationKind(v string) (OperationKind, error) {
+	kind := OperationKind(strings.ToUpper(strings.TrimSpace(v)))
+
+	switch kind {
+	case OperationKindStandard, OperationKindInstallmentPurchase, OperationKindPayment, OperationKindTransfer,
+		OperationKindReversal, OperationKindCharge:
+		return kind, nil
+	}
+
+	return "", NewErrDomain("kind", fmt.Sprintf("'%s' is not a valid operation kind", v))
+}
+
+// String returns the kind value
+func (k OperationKind) String() string {
+	return string(k)
+}
+
 // Operation contains all data to recognize the type of a transaction
 type Operation struct {
 	id          *ID
 	description string
+	direction   OperationDirection
+	kind        OperationKind
+	disabled    bool
+}
+
+// NewOperationType builds a new Operation struct to be registered, its id is assigned when it is stored. Transfers,
+// reversals and charges are registered only by their own flows, so no operation of these kinds can be registered.
+func NewOperationType(description string, direction OperationDirection, kind OperationKind) (*Operation, error) {
+	description = strings.TrimSpace(description)
+
+	if description == "" {
+		return nil, NewErrDomain("type", "is required")
+	}
+
+	if len(description) > operationDescriptionMaxLength {
+		return nil, NewErrDomain("type", fmt.Sprintf("must be at most %d characters in length", operationDescriptionMaxLength))
+	}
+
+	if _, err := NewOperationDirection(direction.String()); err != nil {
+		return nil, err
+	}
+
+	if _, err := NewOperationKind(kind.String()); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case kind == OperationKindTransfer || kind == OperationKindReversal || kind == OperationKindCharge:
+		return nil, NewErrDomain("kind", fmt.Sprintf("'%s' is registered only by its own flow", kind))
+	case kind == OperationKindPayment && direction != OperationDirectionCredit:
+		return nil, NewErrDomain("direction", fmt.Sprintf("must be '%s' for a payment", OperationDirectionCredit))
+	case kind == OperationKindInstallmentPurchase && direction != OperationDirectionDebit:
+		return nil, NewErrDomain("direction", fmt.Sprintf("must be '%s' for an installment purchase", OperationDirectionDebit))
+	}
+
+	return newOperation(0, description, direction, kind), nil
+}
+
+// LoadOperation loads an Operation struct already registered, of any kind
+func LoadOperation(id *ID, description string, direction OperationDirection, kind OperationKind, enabled bool) *Operation {
+	operation := newOperation(id.Value(), description, direction, kind)
+	operation.disabled = !enabled
+
+	return operation
+}
+
+// Store stores an operation given a repository
+func (o *Operation) Store(ctx context.Context, repo OperationRepositoryWriter) (*Operation, error) {
+	id, err := repo.Store(ctx, o)
+	if err != nil {
+		return nil, err
+	}
+
+	return o.WithID(id), nil
 }
 
-// IsIncoming checks if the operation is an incoming operation
+// IsIncoming checks if the operation is an incoming operation, increasing the available credit limit
 func (o Operation) IsIncoming() bool {
-	ids := []uint64{uint64(4)}
+	return o.direction == OperationDirectionCredit
+}
 
-	for _, v := range ids {
-		if v == o.id.Value() {
-			return true
-		}
+// IsPayment checks if the operation is a payment, which discharges the debits of the account
+func (o Operation) IsPayment() bool {
+	return o.kind == OperationKindPayment
+}
+
+// IsInstallmentPurchase checks if the operation is an installment purchase, billed by its installments
+func (o Operation) IsInstallmentPurchase() bool {
+	return o.kind == OperationKindInstallmentPurchase
+}
+
+// IsTransfer checks if the operation is one of the postings of a transfer
+func (o Operation) IsTransfer() bool {
+	return o.kind == OperationKindTransfer
+}
+
+// IsReversal checks if the operation is the reversal of another transaction, whose sign is the opposite of the
+// reversed transaction
+func (o Operation) IsReversal() bool {
+	return o.kind == OperationKindReversal
+}
+
+// IsCharge checks if the operation is one of the charges posted on overdue balances by the interest accrual
+func (o Operation) IsCharge() bool {
+	return o.kind == OperationKindCharge
+}
+
+// IsEnabled checks if new transactions can be registered with the operation
+func (o Operation) IsEnabled() bool {
+	return !o.disabled
+}
+
+// Disable returns a new Operation struct not accepting new transactions. Transfer postings, reversals and charges are
+// registered by their own flows and payments discharge the debits of the account, so none of them can be disabled.
+func (o *Operation) Disable() (*Operation, error) {
+	if o.IsPayment() || o.IsTransfer() || o.IsReversal() || o.IsCharge() {
+		description := fmt.Sprintf("'%d' is reserved and cannot be disabled", o.id.Value())
+
+		return nil, NewErrDomain("operation", description)
 	}
 
-	return false
+	return o.WithEnabled(false), nil
+}
+
+// Enable returns a new Operation struct accepting new transactions
+func (o *Operation) Enable() *Operation {
+	return o.WithEnabled(true)
 }
 
 // ID returns the id value
@@ -55,13 +233,65 @@ func (o Operation) Description() string {
 	return o.description
 }
 
-func newOperation(id uint64, description string) *Operation {
-	return &Operation{id: NewID(id), description: strings.ToUpper(description)}
+// Direction returns the direction value
+func (o Operation) Direction() OperationDirection {
+	return o.direction
+}
+
+// Kind returns the kind value
+func (o Operation) Kind() OperationKind {
+	return o.kind
+}
+
+// WithID returns a new Operation struct with the informed ID value
+func (o *Operation) WithID(id *ID) *Operation {
+	operation := *o
+	operation.id = id
+
+	return &operation
+}
+
+// WithEnabled returns a new Operation struct enabled or disabled as informed
+func (o *Operation) WithEnabled(enabled bool) *Operation {
+	operation := *o
+	operation.disabled = !enabled
+
+	return &operation
+}
+
+func newOperation(id uint64, description string, direction OperationDirection, kind OperationKind) *Operation {
+	return &Operation{id: NewID(id), description: strings.ToUpper(description), direction: direction, kind: kind}
+}
+
+// Operations contains the operations found in the Operation Repository by id
+type Operations struct {
+	byID map[uint64]*Operation
+}
+
+// NewOperations builds a new Operations struct with the informed operations
+func NewOperations(operations ...*Operation) *Operations {
+	byID := make(map[uint64]*Operation, len(operations))
+
+	for _, o := range operations {
+		byID[o.id.Value()] = o
+	}
+
+	return &Operations{byID: byID}
+}
+
+// FindOperations finds all the operations given a repository
+func FindOperations(ctx context.Context, repo OperationRepositoryReader) (*Operations, error) {
+	operations, err := repo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewOperations(operations...), nil
 }
 
-// NewOperation creates a valid Operation struct
-func NewOperation(id *ID) (*Operation, error) {
-	if v, ok := operations[id.Value()]; ok {
+// Find returns the operation of the informed id
+func (o *Operations) Find(id *ID) (*Operation, error) {
+	if v, ok := o.byID[id.Value()]; ok {
 		return v, nil
 	}
 
@@ -0,0 +1,63 @@
+package domain
+
+import "context"
+
+// OperationRepositoryReader represents the behaviour of the Operation Repository to read operation
+type OperationRepositoryReader interface {
+	FindAll(context.Context) ([]*Operation, error)
+	FindOneByID(context.Context, *ID) (*Operation, error)
+}
+
+// OperationRepositoryWriter represents the behaviour of the Operation Repository to write operation
+type OperationRepositoryWriter interface {
+	Store(context.Context, *Operation) (*ID, error)
+	UpdateEnabled(context.Context, *Operation) error
+}
+
+// OperationRepositoryMock is a fake representation of an Operation Repository, useful to create unit tests
+type OperationRepositoryMock struct {
+	id         *ID
+	operations []*Operation
+	err        error
+}
+
+// NewOperationRepositoryMock builds a new OperationRepositoryMock struct with its mock results
+func NewOperationRepositoryMock(id *ID, operations []*Operation, err error) *OperationRepositoryMock {
+	return &OperationRepositoryMock{id: id, operations: operations, err: err}
+}
+
+// FindAll returns all the operations
+func (o OperationRepositoryMock) FindAll(_ context.Context) ([]*Operation, error) {
+	if o.err != nil {
+		return nil, o.err
+	}
+
+	return o.operations, nil
+}
+
+// FindOneByID finds an operation by its id
+func (o OperationRepositoryMock) FindOneByID(_ context.Context, _ *ID) (*Operation, error) {
+	if o.err != nil {
+		return nil, o.err
+	}
+
+	if len(o.operations) == 0 {
+		return nil, nil
+	}
+
+	return o.operations[0], nil
+}
+
+// Store stores an operation
+func (o OperationRepositoryMock) Store(_ context.Context, _ *Operation) (*ID, error) {
+	if o.err != nil {
+		return nil, o.err
+	}
+
+	return o.id, nil
+}
+
+// UpdateEnabled updates whether the operation is enabled
+func (o OperationRepositoryMock) UpdateEnabled(_ context.Context, _ *Operation) error {
+	return o.err
+}
@@ -1,11 +1,16 @@
 package domain
 
 import (
+	"context"
+	"errors"
 	"reflect"
+	"strings"
 	"testing"
 )
 
-func TestOperation(t *testing.T) {
+func TestOperations_Find(t *testing.T) {
+	operations := NewOperations(OperationCompraAVista, OperationCompraParcelada, OperationSaque, OperationPagamento)
+
 	type fields struct {
 		id *ID
 	}
@@ -69,22 +74,12 @@ func TestOperation(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			o, err := NewOperation(tt.fields.id)
-
-			if (err != nil) && !reflect.DeepEqual(err, tt.wantErr) {
-				t.Errorf("NewOperation() error = %v, wantErr %v", err, tt.wantErr)
+			o, err := operations.Find(tt.fields.id)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Find() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 
-			if err != nil {
-				if v, ok := err.(*ErrDomain); ok {
-					if v.Error() != tt.wantErr.Error() {
-						t.Errorf("NewOperation() error.Error() = %v, wantErr %v", err.Error(), tt.wantErr.Error())
-						return
-					}
-				}
-			}
-
 			if err != nil {
 				return
 			}
@@ -96,9 +91,299 @@ func TestOperation(t *testing.T) {
 			if o.ID().Value() != tt.fields.id.Value() {
 				t.Errorf("Invalid ID")
 			}
+		})
+	}
+}
+
+func TestFindOperations(t *testing.T) {
+	cashback, _ := NewOperationType("cashback", OperationDirectionCredit, OperationKindStandard)
+	cashback = cashback.WithID(NewID(100))
+
+	if _, err := FindOperations(context.Background(), NewOperationRepositoryMock(nil, nil, errors.New("database error"))); err == nil {
+		t.Errorf("FindOperations() must fail when the repository fails")
+	}
+
+	operations, err := FindOperations(context.Background(), NewOperationRepositoryMock(nil, []*Operation{cashback}, nil))
+	if err != nil {
+		t.Fatalf("FindOperations() error = %v", err)
+	}
+
+	if got, err := operations.Find(NewID(100)); err != nil || !reflect.DeepEqual(got, cashback) {
+		t.Errorf("Find() got = %v, error = %v, want %v", got, err, cashback)
+	}
+
+	if _, err := operations.Find(OperationCompraAVista.ID()); err == nil {
+		t.Errorf("Find() must fail for the operations not found in the repository")
+	}
+}
+
+func TestNewOperationDirection(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    OperationDirection
+		wantErr error
+	}{
+		// fails
+		{
+			name:    "empty direction",
+			value:   "",
+			wantErr: NewErrDomain("direction", "'' is not a valid operation direction"),
+		},
+		{
+			name:    "unknown direction",
+			value:   "OUT",
+			wantErr: NewErrDomain("direction", "'OUT' is not a valid operation direction"),
+		},
+
+		// successes
+		{
+			name:  "debit direction",
+			value: "DEBIT",
+			want:  OperationDirectionDebit,
+		},
+		{
+			name:  "credit direction in lower case",
+			value: " credit ",
+			want:  OperationDirectionCredit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewOperationDirection(tt.value)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("NewOperationDirection() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("NewOperationDirection() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewOperationKind(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    OperationKind
+		wantErr error
+	}{
+		// fails
+		{
+			name:    "empty kind",
+			value:   "",
+			wantErr: NewErrDomain("kind", "'' is not a valid operation kind"),
+		},
+		{
+			name:    "unknown kind",
+			value:   "REFUND",
+			wantErr: NewErrDomain("kind", "'REFUND' is not a valid operation kind"),
+		},
+
+		// successes
+		{
+			name:  "standard kind",
+			value: "STANDARD",
+			want:  OperationKindStandard,
+		},
+		{
+			name:  "payment kind in lower case",
+			value: " payment ",
+			want:  OperationKindPayment,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewOperationKind(tt.value)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("NewOperationKind() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("NewOperationKind() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewOperationType(t *testing.T) {
+	type args struct {
+		description string
+		direction   OperationDirection
+		kind        OperationKind
+	}
+
+	tests := []struct {
+		name                      string
+		args                      args
+		wantIsIncoming            bool
+		wantIsPayment             bool
+		wantIsInstallmentPurchase bool
+		wantErr                   error
+	}{
+		// fails
+		{
+			name:    "empty description",
+			args:    args{description: " ", direction: OperationDirectionDebit, kind: OperationKindStandard},
+			wantErr: NewErrDomain("type", "is required"),
+		},
+		{
+			name:    "description too long",
+			args:    args{description: strings.Repeat("a", 51), direction: OperationDirectionDebit, kind: OperationKindStandard},
+			wantErr: NewErrDomain("type", "must be at most 50 characters in length"),
+		},
+		{
+			name:    "invalid direction",
+			args:    args{description: "cashback", direction: OperationDirection("OUT"), kind: OperationKindStandard},
+			wantErr: NewErrDomain("direction", "'OUT' is not a valid operation direction"),
+		},
+		{
+			name:    "invalid kind",
+			args:    args{description: "cashback", direction: OperationDirectionCredit, kind: OperationKind("REFUND")},
+			wantErr: NewErrDomain("kind", "'REFUND' is not a valid operation kind"),
+		},
+		{
+			name:    "transfer kind",
+			args:    args{description: "pix", direction: OperationDirectionDebit, kind: OperationKindTransfer},
+			wantErr: NewErrDomain("kind", "'TRANSFER' is registered only by its own flow"),
+		},
+		{
+			name:    "charge kind",
+			args:    args{description: "juros", direction: OperationDirectionDebit, kind: OperationKindCharge},
+			wantErr: NewErrDomain("kind", "'CHARGE' is registered only by its own flow"),
+		},
+		{
+			name:    "debit payment",
+			args:    args{description: "pagamento boleto", direction: OperationDirectionDebit, kind: OperationKindPayment},
+			wantErr: NewErrDomain("direction", "must be 'CREDIT' for a payment"),
+		},
+		{
+			name:    "credit installment purchase",
+			args:    args{description: "compra parcelada sem juros", direction: OperationDirectionCredit, kind: OperationKindInstallmentPurchase},
+			wantErr: NewErrDomain("direction", "must be 'DEBIT' for an installment purchase"),
+		},
+
+		// successes
+		{
+			name:           "debit operation",
+			args:           args{description: "tarifa", direction: OperationDirectionDebit, kind: OperationKindStandard},
+			wantIsIncoming: false,
+		},
+		{
+			name:           "credit operation",
+			args:           args{description: "cashback", direction: OperationDirectionCredit, kind: OperationKindStandard},
+			wantIsIncoming: true,
+		},
+		{
+			name:           "payment operation",
+			args:           args{description: "pagamento boleto", direction: OperationDirectionCredit, kind: OperationKindPayment},
+			wantIsIncoming: true,
+			wantIsPayment:  true,
+		},
+		{
+			name:                      "installment purchase operation",
+			args:                      args{description: "compra parcelada sem juros", direction: OperationDirectionDebit, kind: OperationKindInstallmentPurchase},
+			wantIsInstallmentPurchase: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewOperationType(tt.args.description, tt.args.direction, tt.args.kind)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("NewOperationType() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.Description() != strings.ToUpper(tt.args.description) {
+				t.Errorf("Description() = %v, want %v", got.Description(), strings.ToUpper(tt.args.description))
+			}
+
+			if got.IsIncoming() != tt.wantIsIncoming {
+				t.Errorf("IsIncoming() = %v, want %v", got.IsIncoming(), tt.wantIsIncoming)
+			}
+
+			if got.IsPayment() != tt.wantIsPayment {
+				t.Errorf("IsPayment() = %v, want %v", got.IsPayment(), tt.wantIsPayment)
+			}
+
+			if got.IsInstallmentPurchase() != tt.wantIsInstallmentPurchase {
+				t.Errorf("IsInstallmentPurchase() = %v, want %v", got.IsInstallmentPurchase(), tt.wantIsInstallmentPurchase)
+			}
+
+			if !got.IsEnabled() {
+				t.Errorf("IsEnabled() = false, want true")
+			}
+		})
+	}
+}
+
+func TestOperation_Disable(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation *Operation
+		wantErr   error
+	}{
+		// fails
+		{
+			name:      "payment operation",
+			operation: OperationPagamento,
+			wantErr:   NewErrDomain("operation", "'4' is reserved and cannot be disabled"),
+		},
+		{
+			name:      "transfer operation",
+			operation: OperationTransferenciaEnviada,
+			wantErr:   NewErrDomain("operation", "'5' is reserved and cannot be disabled"),
+		},
+		{
+			name:      "reversal operation",
+			operation: OperationEstorno,
+			wantErr:   NewErrDomain("operation", "'7' is reserved and cannot be disabled"),
+		},
+		{
+			name:      "charge operation",
+			operation: OperationMultaAtraso,
+			wantErr:   NewErrDomain("operation", "'9' is reserved and cannot be disabled"),
+		},
+
+		// successes
+		{
+			name:      "withdraw operation",
+			operation: OperationSaque,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.operation.Disable()
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Disable() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.IsEnabled() {
+				t.Errorf("IsEnabled() = true, want false")
+			}
+
+			if !tt.operation.IsEnabled() {
+				t.Errorf("Disable() must not change the original operation")
+			}
 
-			if o.Description() != (operations[o.ID().Value()]).Description() {
-				t.Errorf("Invalid Description")
+			if !got.Enable().IsEnabled() {
+				t.Errorf("Enable().IsEnabled() = false, want true")
 			}
 		})
 	}
@@ -0,0 +1,22 @@
+package domain
+
+// PaymentAllocation represents the part of a payment used to discharge the balance of a debit transaction
+type PaymentAllocation struct {
+	transactionID *ID
+	amount        Money
+}
+
+// NewPaymentAllocation builds a new PaymentAllocation struct
+func NewPaymentAllocation(transactionID *ID, amount Money) *PaymentAllocation {
+	return &PaymentAllocation{transactionID: transactionID, amount: amount}
+}
+
+// TransactionID returns the id of the discharged transaction
+func (p *PaymentAllocation) TransactionID() *ID {
+	return p.transactionID
+}
+
+// Amount returns the discharged amount, always positive
+func (p *PaymentAllocation) Amount() Money {
+	return p.amount
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Reversal represents the total or partial reversal of a transaction, registered as a compensating transaction with
+// the opposite sign, linked to the reversed transaction
+type Reversal struct {
+	original    *Transaction
+	amount      *Money
+	transaction *Transaction
+}
+
+// NewReversal builds a new Reversal struct of the informed transaction. When the amount is nil, the whole amount not
+// reversed yet is reversed, otherwise it must be positive.
+func NewReversal(transactionID *ID, amount *Money) (*Reversal, error) {
+	if amount != nil && !amount.IsPositive() {
+		return nil, NewErrDomain("amount", "must be greater than 0")
+	}
+
+	return &Reversal{
+		original: &Transaction{id: transactionID},
+		amount:   amount,
+	}, nil
+}
+
+// Store stores a reversal given a repository
+func (r *Reversal) Store(ctx context.Context, repo ReversalRepositoryWriter) (*Reversal, error) {
+	stored, err := repo.Store(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	reversal := *stored
+	reversal.transaction = stored.transaction.WithCreatedAt(time.Now())
+
+	return &reversal, nil
+}
+
+// Apply returns a new Reversal struct with the compensating transaction of the original transaction applied to its
+// account, and the original transaction updated with the reversed amount
+func (r *Reversal) Apply(original *Transaction, account *Account) (*Reversal, error) {
+	amount := original.ReversibleAmount()
+	if r.amount != nil {
+		amount = *r.amount
+	}
+
+	transaction, err := original.Reverse(amount)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err = account.ApplyTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	original = original.WithReversedAmount(original.ReversedAmount().Add(transaction.Amount().Abs()))
+
+	// the reversal settles the balance of the original transaction first, as they have opposite signs
+	if transaction.Amount().IsPositive() {
+		transaction, original, _ = settle(transaction, original)
+	} else {
+		original, transaction, _ = settle(original, transaction)
+	}
+
+	reversal := *r
+	reversal.original = original
+	reversal.transaction = transaction.WithAccount(account)
+
+	return &reversal, nil
+}
+
+// Original returns the reversed transaction
+func (r *Reversal) Original() *Transaction {
+	return r.original
+}
+
+// Transaction returns the compensating transaction, nil until the reversal is applied
+func (r *Reversal) Transaction() *Transaction {
+	return r.transaction
+}
+
+// WithID returns a new Reversal struct with the informed id of the compensating transaction
+func (r *Reversal) WithID(id *ID) *Reversal {
+	reversal := *r
+	reversal.transaction = r.transaction.WithID(id)
+
+	return &reversal
+}
@@ -0,0 +1,38 @@
+package domain
+
+import "context"
+
+// ReversalRepositoryWriter represents the behaviour of the Reversal Repository to write operations.
+// The reversal must be applied to the original transaction and its account and stored atomically, returning the applied
+// reversal with the id of the compensating transaction.
+type ReversalRepositoryWriter interface {
+	Store(context.Context, *Reversal) (*Reversal, error)
+}
+
+// ReversalRepositoryWriterMock is a fake representation of a ReversalRepositoryWriter, useful to create unit tests.
+// The reversal is applied to the informed original transaction and account.
+type ReversalRepositoryWriterMock struct {
+	id       *ID
+	original *Transaction
+	account  *Account
+	err      error
+}
+
+// NewReversalRepositoryMock builds a new ReversalRepositoryWriterMock struct with its mock results
+func NewReversalRepositoryMock(id *ID, original *Transaction, account *Account, err error) *ReversalRepositoryWriterMock {
+	return &ReversalRepositoryWriterMock{id: id, original: original, account: account, err: err}
+}
+
+// Store stores a reversal
+func (r ReversalRepositoryWriterMock) Store(_ context.Context, reversal *Reversal) (*Reversal, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	applied, err := reversal.Apply(r.original, r.account)
+	if err != nil {
+		return nil, err
+	}
+
+	return applied.WithID(r.id), nil
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewReversal(t *testing.T) {
+	var negative = brl(-100)
+
+	if _, err := NewReversal(NewID(1), &negative); !reflect.DeepEqual(err, NewErrDomain("amount", "must be greater than 0")) {
+		t.Errorf("NewReversal() error = %v, want the amount error", err)
+	}
+
+	if _, err := NewReversal(NewID(1), nil); err != nil {
+		t.Errorf("NewReversal() error = %v, want nil", err)
+	}
+}
+
+func TestReversal_Apply(t *testing.T) {
+	var (
+		purchase, _ = NewTransaction(NewID(1), OperationCompraAVista, brl(10000))
+		payment, _  = NewTransaction(NewID(1), OperationPagamento, brl(10000))
+		account     = new(Account).WithID(NewID(1)).WithCurrency(CurrencyBRL).WithCreditLimit(brl(50000))
+		amount      = func(m Money) *Money { return &m }
+	)
+
+	purchase = purchase.WithID(NewID(10))
+	payment = payment.WithID(NewID(11))
+
+	tests := []struct {
+		name              string
+		amount            *Money
+		original          *Transaction
+		account           *Account
+		wantAmount        Money
+		wantReversed      Money
+		wantAvailable     Money
+		wantFullyReversed bool
+		wantBalance       Money
+		wantErr           error
+	}{
+		// fails
+		{
+			name:     "original already fully reversed",
+			original: purchase.WithReversedAmount(brl(10000)),
+			account:  account,
+			wantErr:  NewErrDomain("transaction", "has already been fully reversed"),
+		},
+		{
+			name:     "reversal of a payment exceeds the available credit limit",
+			original: payment,
+			account:  account.WithAvailableCreditLimit(brl(5000)),
+			wantErr:  NewErrDomain("amount", "'100.00' exceeds the available credit limit '50.00'"),
+		},
+
+		// successes
+		{
+			name:              "remaining amount reversed when the amount is not informed",
+			original:          purchase.WithReversedAmount(brl(3000)),
+			account:           account,
+			wantAmount:        brl(7000),
+			wantReversed:      brl(10000),
+			wantAvailable:     brl(57000),
+			wantFullyReversed: true,
+			wantBalance:       brl(-3000),
+		},
+		{
+			name:          "partial reversal",
+			amount:        amount(brl(2000)),
+			original:      purchase,
+			account:       account,
+			wantAmount:    brl(2000),
+			wantReversed:  brl(2000),
+			wantAvailable: brl(52000),
+			wantBalance:   brl(-8000),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reversal, _ := NewReversal(tt.original.ID(), tt.amount)
+
+			got, err := reversal.Apply(tt.original, tt.account)
+
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Apply() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.Transaction().Amount() != tt.wantAmount {
+				t.Errorf("Transaction().Amount() = %v, want %v", got.Transaction().Amount(), tt.wantAmount)
+			}
+
+			if got.Original().ReversedAmount() != tt.wantReversed || got.Original().IsReversed() != tt.wantFullyReversed {
+				t.Errorf("Original() reversed = %v (%v), want %v (%v)", got.Original().ReversedAmount(), got.Original().IsReversed(), tt.wantReversed, tt.wantFullyReversed)
+			}
+
+			if got.Original().Balance() != tt.wantBalance || !got.Transaction().Balance().IsZero() {
+				t.Errorf("balances = %v and %v, want %v and 0.00", got.Original().Balance(), got.Transaction().Balance(), tt.wantBalance)
+			}
+
+			if got.Transaction().Account().AvailableCreditLimit() != tt.wantAvailable {
+				t.Errorf("Account().AvailableCreditLimit() = %v, want %v", got.Transaction().Account().AvailableCreditLimit(), tt.wantAvailable)
+			}
+		})
+	}
+}
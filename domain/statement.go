@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Statement represents the statement of an account in a period: the opening balance, every transaction with the
+// running balance after it, and the closing balance
+type Statement struct {
+	account        *Account
+	from           time.Time
+	to             time.Time
+	openingBalance Money
+	closingBalance Money
+}
+
+// StatementLine represents a transaction of a statement, with the running balance of the account after it
+type StatementLine struct {
+	transaction *Transaction
+	balance     Money
+}
+
+// StatementWriter represents the behaviour of a statement output, receiving the statement before its lines and
+// once again, with the closing balance, after them
+type StatementWriter interface {
+	Begin(*Statement) error
+	Line(*StatementLine) error
+	End(*Statement) error
+}
+
+// NewStatement builds a new Statement struct of the account in the informed period
+func NewStatement(account *Account, from, to time.Time) (*Statement, error) {
+	if from.After(to) {
+		return nil, NewErrDomain("from", "must be before to")
+	}
+
+	zero := NewMoney(0, account.Currency())
+
+	return &Statement{account: account, from: from, to: to, openingBalance: zero, closingBalance: zero}, nil
+}
+
+// Open returns a new Statement struct with the opening balance, the balance of the account right before the period
+func (s *Statement) Open(ctx context.Context, repo AccountRepositoryReader) (*Statement, error) {
+	balance, err := s.account.Balance(ctx, repo, s.from.Truncate(time.Second).Add(-time.Second))
+	if err != nil {
+		return nil, err
+	}
+
+	statement := *s
+	statement.openingBalance = balance.Amount()
+	statement.closingBalance = balance.Amount()
+
+	return &statement, nil
+}
+
+// Write writes the statement line by line as the transactions are read from the repository, from the oldest to the
+// newest, so the whole period does not have to be loaded at once
+func (s *Statement) Write(ctx context.Context, repo StatementRepositoryReader, w StatementWriter) error {
+	if err := w.Begin(s); err != nil {
+		return err
+	}
+
+	balance := s.openingBalance
+
+	err := repo.WalkByPeriod(ctx, s.account.ID(), s.from, s.to, func(t *Transaction) error {
+		balance = balance.Add(t.Amount())
+
+		return w.Line(&StatementLine{transaction: t, balance: balance})
+	})
+	if err != nil {
+		return err
+	}
+
+	statement := *s
+	statement.closingBalance = balance
+
+	return w.End(&statement)
+}
+
+// Account returns the account owner of the statement
+func (s *Statement) Account() *Account {
+	return s.account
+}
+
+// From returns the start of the period
+func (s *Statement) From() time.Time {
+	return s.from
+}
+
+// To returns the end of the period
+func (s *Statement) To() time.Time {
+	return s.to
+}
+
+// OpeningBalance returns the balance of the account right before the period
+func (s *Statement) OpeningBalance() Money {
+	return s.openingBalance
+}
+
+// ClosingBalance returns the balance of the account at the end of the period, known after all lines are written
+func (s *Statement) ClosingBalance() Money {
+	return s.closingBalance
+}
+
+// Transaction returns the transaction of the line
+func (l *StatementLine) Transaction() *Transaction {
+	return l.transaction
+}
+
+// Balance returns the running balance of the account after the transaction
+func (l *StatementLine) Balance() Money {
+	return l.balance
+}
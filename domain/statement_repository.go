@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// StatementRepositoryReader represents the behaviour of the Statement Repository to read operations.
+// The transactions of the account created in the period must be walked from the oldest to the newest, stopping at
+// the first error returned by the informed function.
+type StatementRepositoryReader interface {
+	WalkByPeriod(context.Context, *ID, time.Time, time.Time, func(*Transaction) error) error
+}
+
+// StatementRepositoryMock is a fake representation of a StatementRepositoryReader, useful to create unit tests
+type StatementRepositoryMock struct {
+	transactions []*Transaction
+	err          error
+}
+
+// NewStatementRepositoryMock builds a new StatementRepositoryMock struct with its mock results
+func NewStatementRepositoryMock(transactions []*Transaction, err error) *StatementRepositoryMock {
+	return &StatementRepositoryMock{transactions: transactions, err: err}
+}
+
+// WalkByPeriod walks the transactions of the account
+func (s StatementRepositoryMock) WalkByPeriod(_ context.Context, _ *ID, _ time.Time, _ time.Time, fn func(*Transaction) error) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	for _, t := range s.transactions {
+		if err := fn(t); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,194 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewStatement(t *testing.T) {
+	var (
+		account = new(Account).WithID(NewID(1)).WithCurrency(CurrencyBRL)
+		from    = time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+		to      = time.Date(2020, 10, 31, 23, 59, 59, 0, time.UTC)
+	)
+
+	tests := []struct {
+		name    string
+		from    time.Time
+		to      time.Time
+		wantErr error
+	}{
+		// fails
+		{
+			name:    "period starting after its end",
+			from:    to,
+			to:      from,
+			wantErr: NewErrDomain("from", "must be before to"),
+		},
+
+		// successes
+		{
+			name: "valid period",
+			from: from,
+			to:   to,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewStatement(account, tt.from, tt.to)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("NewStatement() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if !got.OpeningBalance().IsZero() || got.OpeningBalance().Currency() != CurrencyBRL {
+				t.Errorf("OpeningBalance() = %v, want 0.00 BRL", got.OpeningBalance())
+			}
+		})
+	}
+}
+
+func TestStatement_Write(t *testing.T) {
+	var (
+		account     = new(Account).WithID(NewID(1)).WithCurrency(CurrencyBRL)
+		from        = time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+		to          = time.Date(2020, 10, 31, 23, 59, 59, 0, time.UTC)
+		purchase, _ = NewTransaction(NewID(1), OperationCompraAVista, brl(5000))
+		payment, _  = NewTransaction(NewID(1), OperationPagamento, brl(2000))
+	)
+
+	type fields struct {
+		accountRepo   *AccountRepositoryMock
+		statementRepo *StatementRepositoryMock
+		writer        *statementWriterMock
+	}
+	tests := []struct {
+		name         string
+		fields       fields
+		wantBalances []Money
+		wantOpening  Money
+		wantClosing  Money
+		wantErr      error
+	}{
+		// fails
+		{
+			name: "error to load the opening balance",
+			fields: fields{
+				accountRepo:   NewAccountRepositoryMock(nil, account, errors.New("database error")),
+				statementRepo: NewStatementRepositoryMock(nil, nil),
+				writer:        &statementWriterMock{},
+			},
+			wantErr: errors.New("database error"),
+		},
+		{
+			name: "error to walk the transactions",
+			fields: fields{
+				accountRepo:   NewAccountRepositoryMock(nil, account, nil).WithBalance(brl(10000)),
+				statementRepo: NewStatementRepositoryMock(nil, errors.New("database error")),
+				writer:        &statementWriterMock{},
+			},
+			wantErr: errors.New("database error"),
+		},
+		{
+			name: "error to write a line",
+			fields: fields{
+				accountRepo:   NewAccountRepositoryMock(nil, account, nil).WithBalance(brl(10000)),
+				statementRepo: NewStatementRepositoryMock([]*Transaction{purchase, payment}, nil),
+				writer:        &statementWriterMock{err: errors.New("broken pipe")},
+			},
+			wantErr: errors.New("broken pipe"),
+		},
+
+		// successes
+		{
+			name: "statement without transactions",
+			fields: fields{
+				accountRepo:   NewAccountRepositoryMock(nil, account, nil).WithBalance(brl(10000)),
+				statementRepo: NewStatementRepositoryMock(nil, nil),
+				writer:        &statementWriterMock{},
+			},
+			wantOpening: brl(10000),
+			wantClosing: brl(10000),
+		},
+		{
+			name: "statement with running balances",
+			fields: fields{
+				accountRepo:   NewAccountRepositoryMock(nil, account, nil).WithBalance(brl(10000)),
+				statementRepo: NewStatementRepositoryMock([]*Transaction{purchase, payment}, nil),
+				writer:        &statementWriterMock{},
+			},
+			wantBalances: []Money{brl(5000), brl(7000)},
+			wantOpening:  brl(10000),
+			wantClosing:  brl(7000),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statement, _ := NewStatement(account, from, to)
+
+			statement, err := statement.Open(context.Background(), tt.fields.accountRepo)
+			if err == nil {
+				err = statement.Write(context.Background(), tt.fields.statementRepo, tt.fields.writer)
+			}
+
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Write() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(tt.fields.writer.balances, tt.wantBalances) {
+				t.Errorf("Write() balances = %v, want %v", tt.fields.writer.balances, tt.wantBalances)
+			}
+
+			if got := tt.fields.writer.begin.OpeningBalance(); got != tt.wantOpening {
+				t.Errorf("OpeningBalance() = %v, want %v", got, tt.wantOpening)
+			}
+
+			if got := tt.fields.writer.end.ClosingBalance(); got != tt.wantClosing {
+				t.Errorf("ClosingBalance() = %v, want %v", got, tt.wantClosing)
+			}
+		})
+	}
+}
+
+type statementWriterMock struct {
+	begin    *Statement
+	end      *Statement
+	balances []Money
+	err      error
+}
+
+func (s *statementWriterMock) Begin(statement *Statement) error {
+	s.begin = statement
+
+	return nil
+}
+
+func (s *statementWriterMock) Line(line *StatementLine) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	s.balances = append(s.balances, line.Balance())
+
+	return nil
+}
+
+func (s *statementWriterMock) End(statement *Statement) error {
+	s.end = statement
+
+	return nil
+}
@@ -1,55 +1,221 @@
 package domain
 
 import (
+	"context"
+	"fmt"
 	"time"
 )
 
 // Transaction represents a Transaction in the Domain
 type Transaction struct {
-	id        *ID
-	account   *Account
-	operation *Operation
-	amount    float64
-	createdAt time.Time
+	id              *ID
+	account         *Account
+	operation       *Operation
+	amount          Money
+	originalAmount  Money
+	exchangeRate    *ExchangeRate
+	installmentPlan *InstallmentPlan
+	transferID      *ID
+	reversalOf      *ID
+	reversedAmount  Money
+	balance         Money
+	allocations     []*PaymentAllocation
+	accrualDate     time.Time
+	createdAt       time.Time
 }
 
 // NewTransaction builds a new Transaction struct
-func NewTransaction(accountID *ID, operationID *ID, amount float64) (*Transaction, error) {
-	operation, err := NewOperation(operationID)
-	if err != nil {
-		return nil, err
+func NewTransaction(accountID *ID, operation *Operation, amount Money) (*Transaction, error) {
+	if !amount.IsPositive() {
+		return nil, NewErrDomain("amount", "must be greater than 0")
 	}
 
 	account := &Account{id: accountID}
 
 	if !operation.IsIncoming() {
-		amount = -amount
+		amount = amount.Neg()
 	}
 
 	return &Transaction{
-		id:        NewID(0),
-		account:   account,
-		operation: operation,
-		amount:    amount,
+		id:             NewID(0),
+		account:        account,
+		operation:      operation,
+		amount:         amount,
+		originalAmount: amount,
+		exchangeRate:   NewIdentityExchangeRate(amount.Currency()),
+		balance:        amount,
 	}, nil
 }
 
 // Store stores a transaction given a repository
-func (t *Transaction) Store(repo TransactionRepositoryWriter) (*Transaction, error) {
-	id, err := repo.Store(t)
+func (t *Transaction) Store(ctx context.Context, repo TransactionRepositoryWriter) (*Transaction, error) {
+	stored, err := repo.Store(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction := *stored
+	transaction.createdAt = time.Now()
+
+	return &transaction, nil
+}
+
+// ConvertTo returns a new Transaction struct with its amount converted to the informed currency, usually the account
+// currency, keeping the original amount and the exchange rate used in the conversion
+func (t *Transaction) ConvertTo(currency Currency, provider ExchangeRateProvider) (*Transaction, error) {
+	from := t.OriginalAmount().Currency()
+
+	rate := NewIdentityExchangeRate(currency)
+	if from != currency {
+		var err error
+		if rate, err = provider.Rate(from, currency); err != nil {
+			return nil, err
+		}
+	}
+
+	amount := rate.Convert(t.OriginalAmount())
+	if amount.IsZero() {
+		return nil, NewErrDomain("amount", fmt.Sprintf("must be greater than 0 when converted to '%s'", currency))
+	}
+
+	return t.WithConversion(amount, t.OriginalAmount(), rate), nil
+}
+
+// WithInstallments returns a new Transaction struct with a plan of count monthly installments, starting one month after
+// purchasedAt. Only installment purchases accept installments, when count is zero they are paid in a single installment
+// and the other operations remain without installments.
+func (t *Transaction) WithInstallments(count int, purchasedAt time.Time) (*Transaction, error) {
+	if !t.Operation().IsInstallmentPurchase() {
+		if count == 0 {
+			return t, nil
+		}
+
+		description := fmt.Sprintf("are allowed only for the operation '%s'", OperationCompraParcelada.Description())
+
+		return nil, NewErrDomain("installments", description)
+	}
+
+	if count == 0 {
+		count = 1
+	}
+
+	plan, err := NewInstallmentPlan(t.Amount(), count, purchasedAt)
 	if err != nil {
 		return nil, err
 	}
 
+	return t.WithInstallmentPlan(plan), nil
+}
+
+// Reverse returns the compensating transaction of a reversal of the informed amount, with the opposite sign of the
+// transaction. Transactions can be partially reversed many times, as long as the reversed amounts do not exceed the
+// transaction amount. Reversals and transfer postings cannot be reversed.
+func (t *Transaction) Reverse(amount Money) (*Transaction, error) {
+	if t.Operation().IsReversal() || t.Operation().IsTransfer() {
+		description := fmt.Sprintf("'%s' transactions cannot be reversed", t.Operation().Description())
+
+		return nil, NewErrDomain("operation", description)
+	}
+
+	if amount.Currency() == "" {
+		var err error
+
+		if amount, err = amount.In(t.Amount().Currency()); err != nil {
+			return nil, err
+		}
+	}
+
+	if amount.Currency() != t.Amount().Currency() {
+		description := fmt.Sprintf("'%s' does not match the transaction currency '%s'", amount.Currency(), t.Amount().Currency())
+
+		return nil, NewErrDomain("currency", description)
+	}
+
+	if t.IsReversed() {
+		return nil, NewErrDomain("transaction", "has already been fully reversed")
+	}
+
+	if !amount.IsPositive() {
+		return nil, NewErrDomain("amount", "must be greater than 0")
+	}
+
+	if reversible := t.ReversibleAmount(); amount.Sub(reversible).IsPositive() {
+		description := fmt.Sprintf("'%s' exceeds the amount available to reverse '%s'", amount, reversible)
+
+		return nil, NewErrDomain("amount", description)
+	}
+
+	if t.Amount().IsPositive() {
+		amount = amount.Neg()
+	}
+
 	return &Transaction{
-		id:        id,
-		account:   t.Account(),
-		operation: t.Operation(),
-		amount:    t.Amount(),
-		createdAt: time.Now(),
+		id:             NewID(0),
+		account:        t.account,
+		operation:      OperationEstorno,
+		amount:         amount,
+		originalAmount: amount,
+		exchangeRate:   NewIdentityExchangeRate(amount.Currency()),
+		reversalOf:     t.id,
+		balance:        amount,
 	}, nil
 }
 
+// Discharge returns a new Transaction struct of a payment allocated to the informed debits, which must be in
+// chronological order, and the debits with their balances discharged. Each debit is fully discharged before moving to
+// the next one, and the leftover remains as the balance of the payment. Other operations are returned unchanged.
+func (t *Transaction) Discharge(debits []*Transaction) (*Transaction, []*Transaction) {
+	if !t.Operation().IsPayment() {
+		return t, nil
+	}
+
+	var (
+		payment    = t
+		discharged []*Transaction
+	)
+
+	for _, debit := range debits {
+		if !payment.Balance().IsPositive() {
+			break
+		}
+
+		var amount Money
+		if payment, debit, amount = settle(payment, debit); amount.IsZero() {
+			continue
+		}
+
+		payment = payment.WithAllocations(append(payment.Allocations(), NewPaymentAllocation(debit.ID(), amount)))
+		discharged = append(discharged, debit)
+	}
+
+	return payment, discharged
+}
+
+// settle offsets the positive balance of the credit against the negative balance of the debit, returning both
+// transactions updated and the settled amount, always positive or zero
+func settle(credit, debit *Transaction) (*Transaction, *Transaction, Money) {
+	if !credit.Balance().IsPositive() || !debit.Balance().IsNegative() {
+		return credit, debit, NewMoney(0, credit.Balance().Currency())
+	}
+
+	amount := credit.Balance()
+	if owed := debit.Balance().Neg(); owed.Sub(amount).IsNegative() {
+		amount = owed
+	}
+
+	return credit.WithBalance(credit.Balance().Sub(amount)), debit.WithBalance(debit.Balance().Add(amount)), amount
+}
+
+// ReversibleAmount returns the amount of the transaction not reversed yet, always positive or zero
+func (t *Transaction) ReversibleAmount() Money {
+	return t.Amount().Abs().Sub(t.ReversedAmount())
+}
+
+// IsReversed checks if the whole amount of the transaction has been reversed
+func (t *Transaction) IsReversed() bool {
+	return !t.ReversedAmount().IsZero() && t.ReversibleAmount().IsZero()
+}
+
 // ID returns the transaction's id
 func (t *Transaction) ID() *ID {
 	return t.id
@@ -65,11 +231,58 @@ func (t *Transaction) Operation() *Operation {
 	return t.operation
 }
 
-// Amount returns the amount
-func (t *Transaction) Amount() float64 {
+// Amount returns the amount in the account currency
+func (t *Transaction) Amount() Money {
 	return t.amount
 }
 
+// OriginalAmount returns the amount in the currency informed when the transaction was created
+func (t *Transaction) OriginalAmount() Money {
+	return t.originalAmount
+}
+
+// ExchangeRate returns the rate used to convert the original amount to the amount
+func (t *Transaction) ExchangeRate() *ExchangeRate {
+	return t.exchangeRate
+}
+
+// InstallmentPlan returns the installment plan, nil when the transaction has no installments
+func (t *Transaction) InstallmentPlan() *InstallmentPlan {
+	return t.installmentPlan
+}
+
+// TransferID returns the id of the transfer which the transaction is a posting of, nil for other transactions
+func (t *Transaction) TransferID() *ID {
+	return t.transferID
+}
+
+// ReversalOf returns the id of the transaction reversed by this one, nil for other transactions
+func (t *Transaction) ReversalOf() *ID {
+	return t.reversalOf
+}
+
+// ReversedAmount returns the total amount already reversed of the transaction, always positive or zero
+func (t *Transaction) ReversedAmount() Money {
+	return t.reversedAmount.WithCurrency(t.amount.Currency())
+}
+
+// Balance returns the amount of the transaction not settled yet. Debits are discharged by payments until their balance
+// is zero, and payments keep their leftover as a positive balance.
+func (t *Transaction) Balance() Money {
+	return t.balance
+}
+
+// Allocations returns how a payment was allocated to discharge the debits of the account
+func (t *Transaction) Allocations() []*PaymentAllocation {
+	return t.allocations
+}
+
+// AccrualDate returns the day accrued by a charge, zero for other transactions. Each charge is posted only once per
+// account and day.
+func (t *Transaction) AccrualDate() time.Time {
+	return t.accrualDate
+}
+
 // CreatedAt returns the createdAt value
 func (t *Transaction) CreatedAt() time.Time {
 	return t.createdAt
@@ -77,22 +290,92 @@ func (t *Transaction) CreatedAt() time.Time {
 
 // WithAccount returns a new Transaction struct with the informed account
 func (t *Transaction) WithAccount(a *Account) *Transaction {
-	return &Transaction{
-		account:   a,
-		id:        t.ID(),
-		operation: t.Operation(),
-		amount:    t.Amount(),
-		createdAt: t.CreatedAt(),
-	}
+	transaction := *t
+	transaction.account = a
+
+	return &transaction
 }
 
-// WithID returns a new Transaction struct with the informed account
+// WithID returns a new Transaction struct with the informed id
 func (t *Transaction) WithID(id *ID) *Transaction {
-	return &Transaction{
-		id:        id,
-		account:   t.Account(),
-		operation: t.Operation(),
-		amount:    t.Amount(),
-		createdAt: t.CreatedAt(),
-	}
+	transaction := *t
+	transaction.id = id
+
+	return &transaction
+}
+
+// WithCreatedAt returns a new Transaction struct with the informed createdAt value
+func (t *Transaction) WithCreatedAt(createdAt time.Time) *Transaction {
+	transaction := *t
+	transaction.createdAt = createdAt
+
+	return &transaction
+}
+
+// WithConversion returns a new Transaction struct with the informed amount, also as its balance, original amount and
+// exchange rate
+func (t *Transaction) WithConversion(amount, originalAmount Money, rate *ExchangeRate) *Transaction {
+	transaction := *t
+	transaction.amount = amount
+	transaction.balance = amount
+	transaction.originalAmount = originalAmount
+	transaction.exchangeRate = rate
+
+	return &transaction
+}
+
+// WithInstallmentPlan returns a new Transaction struct with the informed installment plan
+func (t *Transaction) WithInstallmentPlan(plan *InstallmentPlan) *Transaction {
+	transaction := *t
+	transaction.installmentPlan = plan
+
+	return &transaction
+}
+
+// WithTransferID returns a new Transaction struct as a posting of the informed transfer
+func (t *Transaction) WithTransferID(id *ID) *Transaction {
+	transaction := *t
+	transaction.transferID = id
+
+	return &transaction
+}
+
+// WithReversalOf returns a new Transaction struct as the reversal of the informed transaction
+func (t *Transaction) WithReversalOf(id *ID) *Transaction {
+	transaction := *t
+	transaction.reversalOf = id
+
+	return &transaction
+}
+
+// WithReversedAmount returns a new Transaction struct with the informed total reversed amount
+func (t *Transaction) WithReversedAmount(amount Money) *Transaction {
+	transaction := *t
+	transaction.reversedAmount = amount
+
+	return &transaction
+}
+
+// WithBalance returns a new Transaction struct with the informed balance
+func (t *Transaction) WithBalance(balance Money) *Transaction {
+	transaction := *t
+	transaction.balance = balance
+
+	return &transaction
+}
+
+// WithAccrualDate returns a new Transaction struct as the charge accrued in the informed day
+func (t *Transaction) WithAccrualDate(day time.Time) *Transaction {
+	transaction := *t
+	transaction.accrualDate = day
+
+	return &transaction
+}
+
+// WithAllocations returns a new Transaction struct with the informed payment allocations
+func (t *Transaction) WithAllocations(allocations []*PaymentAllocation) *Transaction {
+	transaction := *t
+	transaction.allocations = allocations
+
+	return &transaction
 }
@@ -0,0 +1,188 @@
+package domain
+
+import "time"
+
+const (
+	// DefaultTransactionPageSize is the page size used when it is not informed
+	DefaultTransactionPageSize = 20
+
+	// MaxTransactionPageSize is the biggest page size allowed
+	MaxTransactionPageSize = 100
+)
+
+// TransactionFilter contains the criteria to list the transactions of an account
+type TransactionFilter struct {
+	accountID  *ID
+	operations []*ID
+	from       *time.Time
+	to         *time.Time
+	minAmount  *Money
+	maxAmount  *Money
+	cursor     *Cursor
+	limit      int
+}
+
+// NewTransactionFilter builds a new TransactionFilter struct, the transactions are sorted from the newest to the oldest
+func NewTransactionFilter(accountID *ID, limit int) (*TransactionFilter, error) {
+	if limit == 0 {
+		limit = DefaultTransactionPageSize
+	}
+
+	if limit < 0 || limit > MaxTransactionPageSize {
+		return nil, NewErrDomain("limit", "must be between 1 and 100")
+	}
+
+	return &TransactionFilter{accountID: accountID, limit: limit}, nil
+}
+
+// AccountID returns the account id to filter
+func (f *TransactionFilter) AccountID() *ID {
+	return f.accountID
+}
+
+// Operations returns the operations to filter
+func (f *TransactionFilter) Operations() []*ID {
+	return f.operations
+}
+
+// From returns the start of the period to filter
+func (f *TransactionFilter) From() *time.Time {
+	return f.from
+}
+
+// To returns the end of the period to filter
+func (f *TransactionFilter) To() *time.Time {
+	return f.to
+}
+
+// MinAmount returns the minimum absolute amount to filter
+func (f *TransactionFilter) MinAmount() *Money {
+	return f.minAmount
+}
+
+// MaxAmount returns the maximum absolute amount to filter
+func (f *TransactionFilter) MaxAmount() *Money {
+	return f.maxAmount
+}
+
+// Cursor returns the cursor where the page starts
+func (f *TransactionFilter) Cursor() *Cursor {
+	return f.cursor
+}
+
+// Limit returns the page size
+func (f *TransactionFilter) Limit() int {
+	return f.limit
+}
+
+// WithOperations returns a new TransactionFilter struct filtering by the informed operations
+func (f *TransactionFilter) WithOperations(ids ...*ID) *TransactionFilter {
+	filter := *f
+	filter.operations = ids
+
+	return &filter
+}
+
+// WithPeriod returns a new TransactionFilter struct filtering by the informed period, both limits are optional
+func (f *TransactionFilter) WithPeriod(from, to *time.Time) (*TransactionFilter, error) {
+	if from != nil && to != nil && from.After(*to) {
+		return nil, NewErrDomain("from", "must be before to")
+	}
+
+	filter := *f
+	filter.from = from
+	filter.to = to
+
+	return &filter, nil
+}
+
+// WithAmountRange returns a new TransactionFilter struct filtering by the absolute amount, both limits are optional
+func (f *TransactionFilter) WithAmountRange(min, max *Money) (*TransactionFilter, error) {
+	if (min != nil && min.IsNegative()) || (max != nil && max.IsNegative()) {
+		return nil, NewErrDomain("amount", "range must not be negative")
+	}
+
+	if min != nil && max != nil && min.Units() > max.Units() {
+		return nil, NewErrDomain("min_amount", "must be less than or equal to max_amount")
+	}
+
+	filter := *f
+	filter.minAmount = min
+	filter.maxAmount = max
+
+	return &filter, nil
+}
+
+// WithCursor returns a new TransactionFilter struct starting the page from the informed cursor
+func (f *TransactionFilter) WithCursor(c *Cursor) *TransactionFilter {
+	filter := *f
+	filter.cursor = c
+
+	return &filter
+}
+
+// TransactionPage represents a page of transactions with the cursors to navigate to the adjacent pages
+type TransactionPage struct {
+	transactions []*Transaction
+	next         *Cursor
+	prev         *Cursor
+}
+
+// NewTransactionPage builds a page given the transactions loaded using the filter.
+// The transactions must be sorted according to the cursor direction and may contain one extra register,
+// used only to identify whether there is another page in the same direction.
+func NewTransactionPage(transactions []*Transaction, filter *TransactionFilter) *TransactionPage {
+	var (
+		cursor    = filter.Cursor()
+		backwards = cursor != nil && cursor.Direction() == CursorPrev
+		hasMore   = len(transactions) > filter.Limit()
+	)
+
+	if hasMore {
+		transactions = transactions[:filter.Limit()]
+	}
+
+	if backwards {
+		reversed := make([]*Transaction, len(transactions))
+		for i, t := range transactions {
+			reversed[len(transactions)-1-i] = t
+		}
+		transactions = reversed
+	}
+
+	page := &TransactionPage{transactions: transactions}
+
+	if len(transactions) == 0 {
+		return page
+	}
+
+	var (
+		hasNext = (!backwards && hasMore) || backwards
+		hasPrev = (backwards && hasMore) || (!backwards && cursor != nil)
+	)
+
+	if hasNext {
+		page.next = NewCursor(transactions[len(transactions)-1].ID(), CursorNext)
+	}
+
+	if hasPrev {
+		page.prev = NewCursor(transactions[0].ID(), CursorPrev)
+	}
+
+	return page
+}
+
+// Transactions returns the transactions of the page
+func (p *TransactionPage) Transactions() []*Transaction {
+	return p.transactions
+}
+
+// Next returns the cursor to the next page, nil if there is no next page
+func (p *TransactionPage) Next() *Cursor {
+	return p.next
+}
+
+// Prev returns the cursor to the previous page, nil if there is no previous page
+func (p *TransactionPage) Prev() *Cursor {
+	return p.prev
+}
@@ -0,0 +1,204 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewTransactionFilter(t *testing.T) {
+	var (
+		yesterday = time.Now().Add(-24 * time.Hour)
+		today     = time.Now()
+		ten       = brl(1000)
+		hundred   = brl(10000)
+		negative  = brl(-100)
+	)
+
+	tests := []struct {
+		name      string
+		build     func() (*TransactionFilter, error)
+		wantLimit int
+		wantErr   error
+	}{
+		// fails
+		{
+			name: "limit greater than the max page size",
+			build: func() (*TransactionFilter, error) {
+				return NewTransactionFilter(NewID(1), 101)
+			},
+			wantErr: NewErrDomain("limit", "must be between 1 and 100"),
+		},
+		{
+			name: "negative limit",
+			build: func() (*TransactionFilter, error) {
+				return NewTransactionFilter(NewID(1), -1)
+			},
+			wantErr: NewErrDomain("limit", "must be between 1 and 100"),
+		},
+		{
+			name: "invalid period",
+			build: func() (*TransactionFilter, error) {
+				f, _ := NewTransactionFilter(NewID(1), 10)
+				return f.WithPeriod(&today, &yesterday)
+			},
+			wantErr: NewErrDomain("from", "must be before to"),
+		},
+		{
+			name: "invalid amount range",
+			build: func() (*TransactionFilter, error) {
+				f, _ := NewTransactionFilter(NewID(1), 10)
+				return f.WithAmountRange(&hundred, &ten)
+			},
+			wantErr: NewErrDomain("min_amount", "must be less than or equal to max_amount"),
+		},
+		{
+			name: "negative amount range",
+			build: func() (*TransactionFilter, error) {
+				f, _ := NewTransactionFilter(NewID(1), 10)
+				return f.WithAmountRange(&negative, nil)
+			},
+			wantErr: NewErrDomain("amount", "range must not be negative"),
+		},
+
+		// successes
+		{
+			name: "default limit",
+			build: func() (*TransactionFilter, error) {
+				return NewTransactionFilter(NewID(1), 0)
+			},
+			wantLimit: DefaultTransactionPageSize,
+		},
+		{
+			name: "all filters",
+			build: func() (*TransactionFilter, error) {
+				f, _ := NewTransactionFilter(NewID(1), 50)
+				f = f.WithOperations(NewID(1), NewID(4))
+				f, _ = f.WithPeriod(&yesterday, &today)
+				return f.WithAmountRange(&ten, &hundred)
+			},
+			wantLimit: 50,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.build()
+
+			if (err != nil) && !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("NewTransactionFilter() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if (err == nil) && (tt.wantErr != nil) {
+				t.Errorf("NewTransactionFilter() error = nil, wantErr %v", tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.Limit() != tt.wantLimit {
+				t.Errorf("Limit() = %v, want %v", got.Limit(), tt.wantLimit)
+			}
+		})
+	}
+}
+
+func TestNewTransactionPage(t *testing.T) {
+	transactions := func(ids ...uint64) []*Transaction {
+		var list []*Transaction
+		for _, id := range ids {
+			list = append(list, (&Transaction{}).WithID(NewID(id)))
+		}
+		return list
+	}
+
+	filter := func(c *Cursor) *TransactionFilter {
+		f, _ := NewTransactionFilter(NewID(1), 3)
+		return f.WithCursor(c)
+	}
+
+	tests := []struct {
+		name         string
+		transactions []*Transaction
+		filter       *TransactionFilter
+		wantIDs      []uint64
+		wantNext     *Cursor
+		wantPrev     *Cursor
+	}{
+		{
+			name:         "empty page",
+			transactions: nil,
+			filter:       filter(nil),
+			wantIDs:      nil,
+		},
+		{
+			name:         "first and only page",
+			transactions: transactions(10, 9),
+			filter:       filter(nil),
+			wantIDs:      []uint64{10, 9},
+		},
+		{
+			name:         "first page with a next page",
+			transactions: transactions(10, 9, 8, 7),
+			filter:       filter(nil),
+			wantIDs:      []uint64{10, 9, 8},
+			wantNext:     NewCursor(NewID(8), CursorNext),
+		},
+		{
+			name:         "middle page navigating forward",
+			transactions: transactions(7, 6, 5, 4),
+			filter:       filter(NewCursor(NewID(8), CursorNext)),
+			wantIDs:      []uint64{7, 6, 5},
+			wantNext:     NewCursor(NewID(5), CursorNext),
+			wantPrev:     NewCursor(NewID(7), CursorPrev),
+		},
+		{
+			name:         "last page navigating forward",
+			transactions: transactions(4, 3),
+			filter:       filter(NewCursor(NewID(5), CursorNext)),
+			wantIDs:      []uint64{4, 3},
+			wantPrev:     NewCursor(NewID(4), CursorPrev),
+		},
+		{
+			name:         "middle page navigating backwards",
+			transactions: transactions(5, 6, 7, 8),
+			filter:       filter(NewCursor(NewID(4), CursorPrev)),
+			wantIDs:      []uint64{7, 6, 5},
+			wantNext:     NewCursor(NewID(5), CursorNext),
+			wantPrev:     NewCursor(NewID(7), CursorPrev),
+		},
+		{
+			name:         "first page navigating backwards",
+			transactions: transactions(8, 9, 10),
+			filter:       filter(NewCursor(NewID(7), CursorPrev)),
+			wantIDs:      []uint64{10, 9, 8},
+			wantNext:     NewCursor(NewID(8), CursorNext),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewTransactionPage(tt.transactions, tt.filter)
+
+			var gotIDs []uint64
+			for _, v := range got.Transactions() {
+				gotIDs = append(gotIDs, v.ID().Value())
+			}
+
+			if !reflect.DeepEqual(gotIDs, tt.wantIDs) {
+				t.Errorf("Transactions() = %v, want %v", gotIDs, tt.wantIDs)
+			}
+
+			if !reflect.DeepEqual(got.Next(), tt.wantNext) {
+				t.Errorf("Next() = %v, want %v", got.Next(), tt.wantNext)
+			}
+
+			if !reflect.DeepEqual(got.Prev(), tt.wantPrev) {
+				t.Errorf("Prev() = %v, want %v", got.Prev(), tt.wantPrev)
+			}
+		})
+	}
+}
@@ -1,8 +1,12 @@
 package domain
 
-// TransactionRepositoryWriter represents the behaviour of the Transaction Repository
+import "context"
+
+// TransactionRepositoryWriter represents the behaviour of the Transaction Repository to write operations.
+// Payments must be discharged against the open debits of the account when stored, returning the stored transaction
+// with its id and allocations.
 type TransactionRepositoryWriter interface {
-	Store(*Transaction) (*ID, error)
+	Store(context.Context, *Transaction) (*Transaction, error)
 }
 
 // TransactionRepositoryWriterMock is a fake representation of a TransactionRepositoryWriter, useful to create unit tests
@@ -17,10 +21,35 @@ func NewTransactionRepositoryMock(id *ID, err error) *TransactionRepositoryWrite
 }
 
 // Store stores a transaction
-func (t TransactionRepositoryWriterMock) Store(_ *Transaction) (*ID, error) {
+func (t TransactionRepositoryWriterMock) Store(_ context.Context, transaction *Transaction) (*Transaction, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+
+	return transaction.WithID(t.id), nil
+}
+
+// TransactionRepositoryReader represents the behaviour of the Transaction Repository to read operations
+type TransactionRepositoryReader interface {
+	FindByFilter(context.Context, *TransactionFilter) (*TransactionPage, error)
+}
+
+// TransactionRepositoryReaderMock is a fake representation of a TransactionRepositoryReader, useful to create unit tests
+type TransactionRepositoryReaderMock struct {
+	page *TransactionPage
+	err  error
+}
+
+// NewTransactionRepositoryReaderMock builds a new TransactionRepositoryReaderMock struct with its mock results
+func NewTransactionRepositoryReaderMock(page *TransactionPage, err error) *TransactionRepositoryReaderMock {
+	return &TransactionRepositoryReaderMock{page: page, err: err}
+}
+
+// FindByFilter finds the transactions matching the filter
+func (t TransactionRepositoryReaderMock) FindByFilter(_ context.Context, _ *TransactionFilter) (*TransactionPage, error) {
 	if t.err != nil {
 		return nil, t.err
 	}
 
-	return t.id, nil
+	return t.page, nil
 }
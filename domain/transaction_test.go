@@ -1,16 +1,18 @@
 package domain
 
 import (
+	"context"
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestNewTransaction(t *testing.T) {
 	type args struct {
-		accountID   *ID
-		operationID *ID
-		amount      float64
+		accountID *ID
+		operation *Operation
+		amount    Money
 	}
 
 	tests := []struct {
@@ -21,33 +23,23 @@ func TestNewTransaction(t *testing.T) {
 	}{
 		// fails
 		{
-			name: "returns error when the operation 0 is invalid",
+			name: "returns error when the amount is zero",
 			args: args{
-				accountID:   NewID(100),
-				operationID: NewID(0),
-				amount:      201,
-			},
-			want:    nil,
-			wantErr: NewErrDomain("operation", "'0' is not a valid operation id"),
-		},
-		{
-			name: "returns error when the operation 10 is invalid",
-			args: args{
-				accountID:   NewID(100),
-				operationID: NewID(10),
-				amount:      201,
+				accountID: NewID(100),
+				operation: OperationCompraAVista,
+				amount:    brl(0),
 			},
 			want:    nil,
-			wantErr: NewErrDomain("operation", "'10' is not a valid operation id"),
+			wantErr: NewErrDomain("amount", "must be greater than 0"),
 		},
 
 		// successes
 		{
 			name: "valid transaction with OperationCompraAVista",
 			args: args{
-				accountID:   NewID(100),
-				operationID: NewID(1),
-				amount:      201,
+				accountID: NewID(100),
+				operation: OperationCompraAVista,
+				amount:    brl(20100),
 			},
 			want: &Transaction{
 				id: NewID(0),
@@ -55,16 +47,16 @@ func TestNewTransaction(t *testing.T) {
 					id: NewID(100),
 				},
 				operation: OperationCompraAVista,
-				amount:    -201,
+				amount:    brl(-20100),
 			},
 			wantErr: nil,
 		},
 		{
 			name: "valid transaction with OperationCompraParcelada",
 			args: args{
-				accountID:   NewID(100),
-				operationID: NewID(2),
-				amount:      201,
+				accountID: NewID(100),
+				operation: OperationCompraParcelada,
+				amount:    brl(20100),
 			},
 			want: &Transaction{
 				id: NewID(0),
@@ -72,16 +64,16 @@ func TestNewTransaction(t *testing.T) {
 					id: NewID(100),
 				},
 				operation: OperationCompraParcelada,
-				amount:    -201,
+				amount:    brl(-20100),
 			},
 			wantErr: nil,
 		},
 		{
 			name: "valid transaction with OperationSaque",
 			args: args{
-				accountID:   NewID(100),
-				operationID: NewID(3),
-				amount:      201,
+				accountID: NewID(100),
+				operation: OperationSaque,
+				amount:    brl(20100),
 			},
 			want: &Transaction{
 				id: NewID(0),
@@ -89,16 +81,16 @@ func TestNewTransaction(t *testing.T) {
 					id: NewID(100),
 				},
 				operation: OperationSaque,
-				amount:    -201,
+				amount:    brl(-20100),
 			},
 			wantErr: nil,
 		},
 		{
 			name: "valid transaction with OperationPagamento",
 			args: args{
-				accountID:   NewID(100),
-				operationID: NewID(4),
-				amount:      201,
+				accountID: NewID(100),
+				operation: OperationPagamento,
+				amount:    brl(20100),
 			},
 			want: &Transaction{
 				id: NewID(0),
@@ -106,7 +98,7 @@ func TestNewTransaction(t *testing.T) {
 					id: NewID(100),
 				},
 				operation: OperationPagamento,
-				amount:    201,
+				amount:    brl(20100),
 			},
 			wantErr: nil,
 		},
@@ -114,7 +106,7 @@ func TestNewTransaction(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := NewTransaction(tt.args.accountID, tt.args.operationID, tt.args.amount)
+			got, err := NewTransaction(tt.args.accountID, tt.args.operation, tt.args.amount)
 
 			if (err != nil) && !reflect.DeepEqual(err, tt.wantErr) {
 				t.Errorf("NewTransaction() error = %v, wantErr %v", err, tt.wantErr)
@@ -149,7 +141,7 @@ func TestNewTransaction(t *testing.T) {
 }
 
 func TestTransaction_Store(t *testing.T) {
-	transaction, _ := NewTransaction(NewID(uint64(1)), NewID(uint64(1)), 100)
+	transaction, _ := NewTransaction(NewID(uint64(1)), OperationCompraAVista, brl(10000))
 
 	type args struct {
 		repo TransactionRepositoryWriter
@@ -180,7 +172,7 @@ func TestTransaction_Store(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := transaction.Store(tt.args.repo)
+			got, err := transaction.Store(context.Background(), tt.args.repo)
 
 			if (err != nil) && !reflect.DeepEqual(err, tt.wantErr) {
 				t.Errorf("Store() error = %v, wantErr %v", err, tt.wantErr)
@@ -278,3 +270,354 @@ func TestTransaction_WithID(t1 *testing.T) {
 		})
 	}
 }
+
+func TestTransaction_WithInstallments(t *testing.T) {
+	var (
+		purchasedAt            = time.Date(2020, 10, 4, 13, 0, 0, 0, time.UTC)
+		installmentPurchase, _ = NewTransaction(NewID(1), OperationCompraParcelada, brl(10000))
+		payment, _             = NewTransaction(NewID(1), OperationPagamento, brl(10000))
+	)
+
+	type args struct {
+		count int
+	}
+
+	tests := []struct {
+		name         string
+		transaction  *Transaction
+		args         args
+		wantPlanSize int
+		wantErr      error
+	}{
+		// fails
+		{
+			name:        "installments for a payment",
+			transaction: payment,
+			args:        args{count: 3},
+			wantErr:     NewErrDomain("installments", "are allowed only for the operation 'COMPRA PARCELADA'"),
+		},
+		{
+			name:        "too many installments",
+			transaction: installmentPurchase,
+			args:        args{count: 100},
+			wantErr:     NewErrDomain("installments", "must be between 1 and 24"),
+		},
+
+		// successes
+		{
+			name:         "payment without installments",
+			transaction:  payment,
+			args:         args{count: 0},
+			wantPlanSize: 0,
+		},
+		{
+			name:         "installment purchase without the number of installments",
+			transaction:  installmentPurchase,
+			args:         args{count: 0},
+			wantPlanSize: 1,
+		},
+		{
+			name:         "installment purchase in 3 installments",
+			transaction:  installmentPurchase,
+			args:         args{count: 3},
+			wantPlanSize: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.transaction.WithInstallments(tt.args.count, purchasedAt)
+
+			if (err != nil) && !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("WithInstallments() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if tt.wantPlanSize == 0 {
+				if got.InstallmentPlan() != nil {
+					t.Errorf("InstallmentPlan() = %v, want nil", got.InstallmentPlan())
+				}
+				return
+			}
+
+			if got.InstallmentPlan().Count() != tt.wantPlanSize {
+				t.Errorf("InstallmentPlan().Count() = %v, want %v", got.InstallmentPlan().Count(), tt.wantPlanSize)
+			}
+
+			sum := brl(0)
+			for _, v := range got.InstallmentPlan().Installments() {
+				sum = sum.Add(v.Amount())
+			}
+
+			if sum != got.Amount() {
+				t.Errorf("sum of the installments = %v, want %v", sum, got.Amount())
+			}
+		})
+	}
+}
+
+func TestTransaction_ConvertTo(t *testing.T) {
+	var (
+		usdToBrl, _ = NewExchangeRate("USD", CurrencyBRL, "5.25")
+		usdToGbp, _ = NewExchangeRate("USD", "GBP", "0.001")
+		provider    = NewStaticExchangeRateProvider(usdToBrl, usdToGbp)
+		usdPurchase = func(cents int64) *Transaction {
+			transaction, _ := NewTransaction(NewID(1), OperationCompraAVista, NewMoney(cents, "USD"))
+			return transaction
+		}
+		brlPurchase, _ = NewTransaction(NewID(1), OperationCompraAVista, brl(10000))
+	)
+
+	type args struct {
+		currency Currency
+	}
+
+	tests := []struct {
+		name         string
+		transaction  *Transaction
+		args         args
+		wantAmount   Money
+		wantOriginal Money
+		wantRate     string
+		wantErr      error
+	}{
+		// fails
+		{
+			name:        "no exchange rate available",
+			transaction: usdPurchase(10000),
+			args:        args{currency: "EUR"},
+			wantErr:     NewErrDomain("currency", "there is no exchange rate from 'USD' to 'EUR'"),
+		},
+		{
+			name:        "converted amount rounded to zero",
+			transaction: usdPurchase(1),
+			args:        args{currency: "GBP"},
+			wantErr:     NewErrDomain("amount", "must be greater than 0 when converted to 'GBP'"),
+		},
+
+		// successes
+		{
+			name:         "foreign currency purchase",
+			transaction:  usdPurchase(10000),
+			args:         args{currency: CurrencyBRL},
+			wantAmount:   brl(-52500),
+			wantOriginal: NewMoney(-10000, "USD"),
+			wantRate:     "5.25000000",
+		},
+		{
+			name:         "same currency purchase",
+			transaction:  brlPurchase,
+			args:         args{currency: CurrencyBRL},
+			wantAmount:   brl(-10000),
+			wantOriginal: brl(-10000),
+			wantRate:     "1.00000000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.transaction.ConvertTo(tt.args.currency, provider)
+
+			if (err != nil) && !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("ConvertTo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.Amount() != tt.wantAmount {
+				t.Errorf("Amount() = %v, want %v", got.Amount(), tt.wantAmount)
+			}
+
+			if got.OriginalAmount() != tt.wantOriginal {
+				t.Errorf("OriginalAmount() = %v, want %v", got.OriginalAmount(), tt.wantOriginal)
+			}
+
+			if got.ExchangeRate().Rate() != tt.wantRate {
+				t.Errorf("ExchangeRate() = %v, want %v", got.ExchangeRate().Rate(), tt.wantRate)
+			}
+		})
+	}
+}
+
+func TestTransaction_Reverse(t *testing.T) {
+	var (
+		purchase, _ = NewTransaction(NewID(1), OperationCompraAVista, brl(10000))
+		payment, _  = NewTransaction(NewID(1), OperationPagamento, brl(10000))
+		reversal, _ = purchase.WithID(NewID(1)).Reverse(brl(10000))
+		transfer, _ = NewTransfer(NewID(1), NewID(2), brl(10000))
+	)
+
+	tests := []struct {
+		name        string
+		transaction *Transaction
+		amount      Money
+		wantAmount  Money
+		wantErr     error
+	}{
+		// fails
+		{
+			name:        "reversal of a reversal",
+			transaction: reversal,
+			amount:      brl(10000),
+			wantErr:     NewErrDomain("operation", "'ESTORNO' transactions cannot be reversed"),
+		},
+		{
+			name:        "reversal of a transfer posting",
+			transaction: transfer.Debit(),
+			amount:      brl(10000),
+			wantErr:     NewErrDomain("operation", "'TRANSFERENCIA ENVIADA' transactions cannot be reversed"),
+		},
+		{
+			name:        "amount in another currency",
+			transaction: purchase,
+			amount:      NewMoney(10000, "USD"),
+			wantErr:     NewErrDomain("currency", "'USD' does not match the transaction currency 'BRL'"),
+		},
+		{
+			name:        "amount not positive",
+			transaction: purchase,
+			amount:      brl(0),
+			wantErr:     NewErrDomain("amount", "must be greater than 0"),
+		},
+		{
+			name:        "transaction already fully reversed",
+			transaction: purchase.WithReversedAmount(brl(10000)),
+			amount:      brl(100),
+			wantErr:     NewErrDomain("transaction", "has already been fully reversed"),
+		},
+		{
+			name:        "amount exceeds the amount not reversed yet",
+			transaction: purchase.WithReversedAmount(brl(6000)),
+			amount:      brl(5000),
+			wantErr:     NewErrDomain("amount", "'50.00' exceeds the amount available to reverse '40.00'"),
+		},
+
+		// successes
+		{
+			name:        "total reversal of a purchase",
+			transaction: purchase,
+			amount:      brl(10000),
+			wantAmount:  brl(10000),
+		},
+		{
+			name:        "partial reversal of a purchase without currency",
+			transaction: purchase.WithReversedAmount(brl(6000)),
+			amount:      NewMoney(400000, ""),
+			wantAmount:  brl(4000),
+		},
+		{
+			name:        "reversal of a payment",
+			transaction: payment,
+			amount:      brl(2500),
+			wantAmount:  brl(-2500),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.transaction.WithID(NewID(1)).Reverse(tt.amount)
+
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Reverse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.Amount() != tt.wantAmount {
+				t.Errorf("Reverse() Amount() = %v %v, want %v", got.Amount(), got.Amount().Currency(), tt.wantAmount)
+			}
+
+			if got.Operation() != OperationEstorno || got.ReversalOf().Value() != 1 {
+				t.Errorf("Reverse() = operation %v reversal of %v", got.Operation().Description(), got.ReversalOf())
+			}
+		})
+	}
+}
+
+func TestTransaction_Discharge(t *testing.T) {
+	var (
+		debit = func(id uint64, cents int64) *Transaction {
+			purchase, _ := NewTransaction(NewID(1), OperationCompraAVista, brl(cents))
+			return purchase.WithID(NewID(id))
+		}
+		payment = func(cents int64) *Transaction {
+			transaction, _ := NewTransaction(NewID(1), OperationPagamento, brl(cents))
+			return transaction
+		}
+	)
+
+	tests := []struct {
+		name            string
+		transaction     *Transaction
+		debits          []*Transaction
+		wantBalance     Money
+		wantAllocations []*PaymentAllocation
+		wantBalances    []Money
+	}{
+		{
+			name:        "operations other than payments are not discharged",
+			transaction: debit(3, 5000),
+			debits:      []*Transaction{debit(1, 10000)},
+			wantBalance: brl(-5000),
+		},
+		{
+			name:        "payment without open debits keeps its amount as balance",
+			transaction: payment(5000),
+			wantBalance: brl(5000),
+		},
+		{
+			name:            "payment partially discharges the oldest debit",
+			transaction:     payment(5000),
+			debits:          []*Transaction{debit(1, 10000), debit(2, 10000)},
+			wantBalance:     brl(0),
+			wantAllocations: []*PaymentAllocation{NewPaymentAllocation(NewID(1), brl(5000))},
+			wantBalances:    []Money{brl(-5000)},
+		},
+		{
+			name:        "payment discharges the debits in order, keeping the leftover",
+			transaction: payment(25000),
+			debits:      []*Transaction{debit(1, 10000).WithBalance(brl(-4000)), debit(2, 10000)},
+			wantBalance: brl(11000),
+			wantAllocations: []*PaymentAllocation{
+				NewPaymentAllocation(NewID(1), brl(4000)),
+				NewPaymentAllocation(NewID(2), brl(10000)),
+			},
+			wantBalances: []Money{brl(0), brl(0)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, discharged := tt.transaction.Discharge(tt.debits)
+
+			if got.Balance() != tt.wantBalance {
+				t.Errorf("Discharge() Balance() = %v, want %v", got.Balance(), tt.wantBalance)
+			}
+
+			if !reflect.DeepEqual(got.Allocations(), tt.wantAllocations) {
+				t.Errorf("Discharge() Allocations() = %v, want %v", got.Allocations(), tt.wantAllocations)
+			}
+
+			if len(discharged) != len(tt.wantBalances) {
+				t.Errorf("Discharge() discharged %d debits, want %d", len(discharged), len(tt.wantBalances))
+				return
+			}
+
+			for i, v := range discharged {
+				if v.Balance() != tt.wantBalances[i] {
+					t.Errorf("Discharge() debit %v Balance() = %v, want %v", v.ID(), v.Balance(), tt.wantBalances[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Transfer represents a movement of money between two accounts of the same currency, registered as a debit posting on
+// the source account and a credit posting on the destination account, both sharing the transfer id
+type Transfer struct {
+	id          *ID
+	source      *Account
+	destination *Account
+	amount      Money
+	createdAt   time.Time
+}
+
+// NewTransfer builds a new Transfer struct. When the amount has no currency, it is in the source account currency.
+func NewTransfer(sourceID, destinationID *ID, amount Money) (*Transfer, error) {
+	if sourceID.Value() == destinationID.Value() {
+		return nil, NewErrDomain("destination_account_id", "must be different from the source account")
+	}
+
+	if !amount.IsPositive() {
+		return nil, NewErrDomain("amount", "must be greater than 0")
+	}
+
+	return &Transfer{
+		id:          NewID(0),
+		source:      &Account{id: sourceID},
+		destination: &Account{id: destinationID},
+		amount:      amount,
+	}, nil
+}
+
+// Store stores a transfer given a repository
+func (t *Transfer) Store(ctx context.Context, repo TransferRepositoryWriter) (*Transfer, error) {
+	stored, err := repo.Store(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer := *stored
+	transfer.createdAt = time.Now()
+
+	return &transfer, nil
+}
+
+// Apply returns a new Transfer struct with its postings applied to the source and destination accounts.
+// Both accounts must have the same currency, and the debit is rejected when it exceeds the available credit limit of the
+// source account.
+func (t *Transfer) Apply(source, destination *Account) (*Transfer, error) {
+	if destination.Currency() != source.Currency() {
+		description := fmt.Sprintf("must have the same currency of the source account '%s'", source.Currency())
+
+		return nil, NewErrDomain("destination_account_id", description)
+	}
+
+	transfer := *t
+	transfer.source = source
+	transfer.destination = destination
+
+	var err error
+
+	if transfer.amount.Currency() == "" {
+		if transfer.amount, err = transfer.amount.In(source.Currency()); err != nil {
+			return nil, err
+		}
+	}
+
+	if transfer.source, err = source.ApplyTransaction(transfer.Debit()); err != nil {
+		return nil, err
+	}
+
+	if transfer.destination, err = destination.ApplyTransaction(transfer.Credit()); err != nil {
+		return nil, err
+	}
+
+	return &transfer, nil
+}
+
+// Debit returns the posting of the transfer on the source account
+func (t *Transfer) Debit() *Transaction {
+	return t.posting(t.source, OperationTransferenciaEnviada)
+}
+
+// Credit returns the posting of the transfer on the destination account
+func (t *Transfer) Credit() *Transaction {
+	return t.posting(t.destination, OperationTransferenciaRecebida)
+}
+
+func (t *Transfer) posting(account *Account, operation *Operation) *Transaction {
+	var amount = t.amount
+	if !operation.IsIncoming() {
+		amount = amount.Neg()
+	}
+
+	return &Transaction{
+		id:             NewID(0),
+		account:        account,
+		operation:      operation,
+		amount:         amount,
+		originalAmount: amount,
+		exchangeRate:   NewIdentityExchangeRate(amount.Currency()),
+		transferID:     t.id,
+		balance:        amount,
+		createdAt:      t.createdAt,
+	}
+}
+
+// ID returns the transfer's id
+func (t *Transfer) ID() *ID {
+	return t.id
+}
+
+// Source returns the account where the money comes from
+func (t *Transfer) Source() *Account {
+	return t.source
+}
+
+// Destination returns the account where the money goes to
+func (t *Transfer) Destination() *Account {
+	return t.destination
+}
+
+// Amount returns the transferred amount, always positive
+func (t *Transfer) Amount() Money {
+	return t.amount
+}
+
+// CreatedAt returns the createdAt value
+func (t *Transfer) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+// WithID returns a new Transfer struct with the informed id
+func (t *Transfer) WithID(id *ID) *Transfer {
+	transfer := *t
+	transfer.id = id
+
+	return &transfer
+}
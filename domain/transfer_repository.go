@@ -0,0 +1,29 @@
+package domain
+
+import "context"
+
+// TransferRepositoryWriter represents the behaviour of the Transfer Repository to write operations.
+// The transfer must be applied to its accounts and stored atomically, returning the applied transfer with its id.
+type TransferRepositoryWriter interface {
+	Store(context.Context, *Transfer) (*Transfer, error)
+}
+
+// TransferRepositoryWriterMock is a fake representation of a TransferRepositoryWriter, useful to create unit tests
+type TransferRepositoryWriterMock struct {
+	id  *ID
+	err error
+}
+
+// NewTransferRepositoryMock builds a new TransferRepositoryWriterMock struct with its mock results
+func NewTransferRepositoryMock(id *ID, err error) *TransferRepositoryWriterMock {
+	return &TransferRepositoryWriterMock{id: id, err: err}
+}
+
+// Store stores a transfer
+func (t TransferRepositoryWriterMock) Store(_ context.Context, transfer *Transfer) (*Transfer, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+
+	return transfer.WithID(t.id), nil
+}
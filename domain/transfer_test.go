@@ -0,0 +1,172 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNewTransfer(t *testing.T) {
+	type args struct {
+		sourceID      *ID
+		destinationID *ID
+		amount        Money
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		// fails
+		{
+			name:    "transfer to the same account",
+			args:    args{sourceID: NewID(1), destinationID: NewID(1), amount: brl(1000)},
+			wantErr: NewErrDomain("destination_account_id", "must be different from the source account"),
+		},
+		{
+			name:    "transfer without amount",
+			args:    args{sourceID: NewID(1), destinationID: NewID(2), amount: brl(0)},
+			wantErr: NewErrDomain("amount", "must be greater than 0"),
+		},
+
+		// successes
+		{
+			name: "valid transfer",
+			args: args{sourceID: NewID(1), destinationID: NewID(2), amount: brl(1000)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewTransfer(tt.args.sourceID, tt.args.destinationID, tt.args.amount)
+
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("NewTransfer() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.Source().ID() != tt.args.sourceID || got.Destination().ID() != tt.args.destinationID {
+				t.Errorf("NewTransfer() accounts = %v, %v", got.Source().ID(), got.Destination().ID())
+			}
+
+			if got.Amount() != tt.args.amount {
+				t.Errorf("NewTransfer() Amount() = %v, want %v", got.Amount(), tt.args.amount)
+			}
+		})
+	}
+}
+
+func TestTransfer_Apply(t *testing.T) {
+	var (
+		transfer, _ = NewTransfer(NewID(1), NewID(2), NewMoney(3000000, ""))
+		account     = func(id uint64, currency Currency, available int64) *Account {
+			return (&Account{id: NewID(id), currency: currency}).WithCreditLimit(NewMoney(available, currency))
+		}
+	)
+
+	type args struct {
+		source      *Account
+		destination *Account
+	}
+
+	tests := []struct {
+		name                     string
+		args                     args
+		wantSourceAvailable      Money
+		wantDestinationAvailable Money
+		wantErr                  error
+	}{
+		// fails
+		{
+			name:    "accounts with different currencies",
+			args:    args{source: account(1, CurrencyBRL, 100000), destination: account(2, "USD", 0)},
+			wantErr: NewErrDomain("destination_account_id", "must have the same currency of the source account 'BRL'"),
+		},
+		{
+			name:    "amount exceeds the available credit limit of the source account",
+			args:    args{source: account(1, CurrencyBRL, 10000), destination: account(2, CurrencyBRL, 0)},
+			wantErr: NewErrDomain("amount", "'300.00' exceeds the available credit limit '100.00'"),
+		},
+
+		// successes
+		{
+			name:                     "postings applied to both accounts",
+			args:                     args{source: account(1, CurrencyBRL, 100000), destination: account(2, CurrencyBRL, 0)},
+			wantSourceAvailable:      brl(70000),
+			wantDestinationAvailable: brl(30000),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := transfer.Apply(tt.args.source, tt.args.destination)
+
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Apply() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.Amount() != brl(30000) {
+				t.Errorf("Amount() = %v %v, want 300.00 BRL", got.Amount(), got.Amount().Currency())
+			}
+
+			if got.Source().AvailableCreditLimit() != tt.wantSourceAvailable {
+				t.Errorf("Source().AvailableCreditLimit() = %v, want %v", got.Source().AvailableCreditLimit(), tt.wantSourceAvailable)
+			}
+
+			if got.Destination().AvailableCreditLimit() != tt.wantDestinationAvailable {
+				t.Errorf("Destination().AvailableCreditLimit() = %v, want %v", got.Destination().AvailableCreditLimit(), tt.wantDestinationAvailable)
+			}
+		})
+	}
+}
+
+func TestTransfer_Postings(t *testing.T) {
+	transfer, _ := NewTransfer(NewID(1), NewID(2), brl(5000))
+	transfer = transfer.WithID(NewID(10))
+
+	var (
+		debit  = transfer.Debit()
+		credit = transfer.Credit()
+	)
+
+	if debit.Amount() != brl(-5000) || debit.Account().ID().Value() != 1 || debit.Operation() != OperationTransferenciaEnviada {
+		t.Errorf("Debit() = %v on account %v with operation %v", debit.Amount(), debit.Account().ID(), debit.Operation())
+	}
+
+	if credit.Amount() != brl(5000) || credit.Account().ID().Value() != 2 || credit.Operation() != OperationTransferenciaRecebida {
+		t.Errorf("Credit() = %v on account %v with operation %v", credit.Amount(), credit.Account().ID(), credit.Operation())
+	}
+
+	if debit.TransferID() != transfer.ID() || credit.TransferID() != transfer.ID() {
+		t.Errorf("postings must share the transfer id, got %v and %v", debit.TransferID(), credit.TransferID())
+	}
+}
+
+func TestTransfer_Store(t *testing.T) {
+	transfer, _ := NewTransfer(NewID(1), NewID(2), brl(5000))
+
+	if _, err := transfer.Store(context.Background(), NewTransferRepositoryMock(nil, errors.New("repository error"))); err == nil {
+		t.Error("Store() error = nil, want the repository error")
+	}
+
+	got, err := transfer.Store(context.Background(), NewTransferRepositoryMock(NewID(10), nil))
+	if err != nil {
+		t.Errorf("Store() error = %v", err)
+		return
+	}
+
+	if got.ID().Value() != 10 || got.CreatedAt().IsZero() {
+		t.Errorf("Store() = id %v created at %v", got.ID(), got.CreatedAt())
+	}
+}
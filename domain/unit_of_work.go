@@ -0,0 +1,29 @@
+package domain
+
+import "context"
+
+// UnitOfWork represents the behaviour to run a function atomically: the repositories called with the context received
+// by the function share a single database transaction, committed when the function succeeds and rolled back otherwise
+type UnitOfWork interface {
+	Do(context.Context, func(context.Context) error) error
+}
+
+// UnitOfWorkMock is an in-memory representation of a UnitOfWork, running the function right away, useful to create
+// unit tests
+type UnitOfWorkMock struct {
+	err error
+}
+
+// NewUnitOfWorkMock builds a new UnitOfWorkMock struct, failing to begin the unit of work when err is informed
+func NewUnitOfWorkMock(err error) *UnitOfWorkMock {
+	return &UnitOfWorkMock{err: err}
+}
+
+// Do runs the function
+func (u UnitOfWorkMock) Do(ctx context.Context, fn func(context.Context) error) error {
+	if u.err != nil {
+		return u.err
+	}
+
+	return fn(ctx)
+}
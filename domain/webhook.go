@@ -0,0 +1,326 @@
+package domain
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	webhookSecretLength = 32
+
+	// maxWebhookRetryDelay is the longest delay between two attempts of a delivery
+	maxWebhookRetryDelay = 24 * time.Hour
+
+	maxWebhookErrorLength = 255
+)
+
+// Webhook represents the subscription of a partner to events, delivered to its URL signed with the webhook secret
+type Webhook struct {
+	id         *ID
+	url        string
+	eventTypes []EventType
+	secret     string
+	createdAt  time.Time
+}
+
+// NewWebhook builds a valid Webhook struct subscribed to the informed event types, with a new random secret
+func NewWebhook(rawURL string, eventTypes []string) (*Webhook, error) {
+	if u, err := url.Parse(rawURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, NewErrDomain("url", fmt.Sprintf("'%s' must be an absolute http or https url", rawURL))
+	}
+
+	if len(eventTypes) == 0 {
+		return nil, NewErrDomain("event_types", "must have at least one event type")
+	}
+
+	types := make([]EventType, 0, len(eventTypes))
+
+	for _, v := range eventTypes {
+		eventType := EventType(v)
+		if eventType != EventAccountCreated && eventType != EventTransactionCreated {
+			return nil, NewErrDomain("event_types", fmt.Sprintf("'%s' is not a valid event type", v))
+		}
+
+		types = append(types, eventType)
+	}
+
+	secret := make([]byte, webhookSecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+
+	return &Webhook{url: rawURL, eventTypes: types, secret: hex.EncodeToString(secret)}, nil
+}
+
+// LoadWebhook builds a Webhook struct with the data loaded from the storage
+func LoadWebhook(id *ID, url string, eventTypes []EventType, secret string, createdAt time.Time) *Webhook {
+	return &Webhook{id: id, url: url, eventTypes: eventTypes, secret: secret, createdAt: createdAt}
+}
+
+// Store stores a webhook given a repository
+func (w *Webhook) Store(ctx context.Context, repo WebhookRepositoryWriter) (*Webhook, error) {
+	id, err := repo.Store(ctx, w)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook := *w
+	webhook.id = id
+	webhook.createdAt = time.Now()
+
+	return &webhook, nil
+}
+
+// Subscribes checks if the webhook is subscribed to the event type
+func (w *Webhook) Subscribes(eventType EventType) bool {
+	for _, v := range w.eventTypes {
+		if v == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Sign signs the body of a delivery sent at the informed moment: the HMAC-SHA256 of "<unix timestamp>.<body>", using
+// the webhook secret as key, in hexadecimal. The timestamp allows the partner to reject replayed deliveries.
+func (w *Webhook) Sign(at time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(w.secret))
+	mac.Write([]byte(strconv.FormatInt(at.Unix(), 10) + "."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ID returns the id value
+func (w *Webhook) ID() *ID {
+	return w.id
+}
+
+// URL returns the url the events are delivered to
+func (w *Webhook) URL() string {
+	return w.url
+}
+
+// EventTypes returns the event types the webhook is subscribed to
+func (w *Webhook) EventTypes() []EventType {
+	return w.eventTypes
+}
+
+// Secret returns the key used to sign the deliveries
+func (w *Webhook) Secret() string {
+	return w.secret
+}
+
+// CreatedAt returns the created at value
+func (w *Webhook) CreatedAt() time.Time {
+	return w.createdAt
+}
+
+// WebhookRetryPolicy represents how the failed deliveries are retried: with an exponential backoff, doubling the delay
+// at each attempt, up to a maximum of attempts, when the delivery is moved to the dead letters
+type WebhookRetryPolicy struct {
+	maxAttempts int
+	baseDelay   time.Duration
+}
+
+// NewWebhookRetryPolicy builds a valid WebhookRetryPolicy struct, baseDelay is the delay after the first attempt
+func NewWebhookRetryPolicy(maxAttempts int, baseDelay time.Duration) (*WebhookRetryPolicy, error) {
+	if maxAttempts < 1 {
+		return nil, NewErrDomain("max_attempts", "must be 1 or greater")
+	}
+
+	if baseDelay <= 0 {
+		return nil, NewErrDomain("base_delay", "must be greater than zero")
+	}
+
+	return &WebhookRetryPolicy{maxAttempts: maxAttempts, baseDelay: baseDelay}, nil
+}
+
+// Backoff returns the delay after the informed attempt, the first one is attempt 1
+func (p *WebhookRetryPolicy) Backoff(attempt int) time.Duration {
+	delay := p.baseDelay
+
+	for i := 1; i < attempt && delay < maxWebhookRetryDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > maxWebhookRetryDelay {
+		return maxWebhookRetryDelay
+	}
+
+	return delay
+}
+
+// WebhookDeliveryStatus represents the status of the delivery of an event to a webhook
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryPending means the delivery is waiting for its next attempt
+	WebhookDeliveryPending WebhookDeliveryStatus = "pending"
+
+	// WebhookDeliveryDelivered means the partner acknowledged the delivery
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+
+	// WebhookDeliveryFailed means all the attempts failed and the delivery was moved to the dead letters
+	WebhookDeliveryFailed WebhookDeliveryStatus = "failed"
+)
+
+// String returns the status as string
+func (s WebhookDeliveryStatus) String() string {
+	return string(s)
+}
+
+// WebhookDelivery represents the delivery of an event to a webhook
+type WebhookDelivery struct {
+	id            *ID
+	webhook       *Webhook
+	event         *Event
+	status        WebhookDeliveryStatus
+	attempts      int
+	nextAttemptAt time.Time
+	lastAttemptAt time.Time
+	lastError     string
+}
+
+// NewWebhookDelivery builds a new WebhookDelivery struct of the event to the webhook, attempted right away
+func NewWebhookDelivery(webhook *Webhook, event *Event, at time.Time) *WebhookDelivery {
+	return &WebhookDelivery{webhook: webhook, event: event, status: WebhookDeliveryPending, nextAttemptAt: at}
+}
+
+// LoadWebhookDelivery builds a WebhookDelivery struct with the data loaded from the storage
+func LoadWebhookDelivery(
+	id *ID,
+	webhook *Webhook,
+	event *Event,
+	status WebhookDeliveryStatus,
+	attempts int,
+	nextAttemptAt time.Time,
+	lastAttemptAt time.Time,
+	lastError string,
+) *WebhookDelivery {
+	return &WebhookDelivery{
+		id:            id,
+		webhook:       webhook,
+		event:         event,
+		status:        status,
+		attempts:      attempts,
+		nextAttemptAt: nextAttemptAt,
+		lastAttemptAt: lastAttemptAt,
+		lastError:     lastError,
+	}
+}
+
+// Store stores a delivery given a repository
+func (d *WebhookDelivery) Store(ctx context.Context, repo WebhookDeliveryRepositoryWriter) (*WebhookDelivery, error) {
+	id, err := repo.Store(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery := *d
+	delivery.id = id
+
+	return &delivery, nil
+}
+
+// Succeed returns a new WebhookDelivery struct acknowledged by the partner at the informed moment
+func (d *WebhookDelivery) Succeed(at time.Time) *WebhookDelivery {
+	delivery := *d
+	delivery.status = WebhookDeliveryDelivered
+	delivery.attempts++
+	delivery.lastAttemptAt = at
+	delivery.lastError = ""
+
+	return &delivery
+}
+
+// Fail returns a new WebhookDelivery struct with the failed attempt registered: rescheduled according to the retry
+// policy, or failed when there are no attempts left
+func (d *WebhookDelivery) Fail(reason string, at time.Time, policy *WebhookRetryPolicy) *WebhookDelivery {
+	if len(reason) > maxWebhookErrorLength {
+		reason = reason[:maxWebhookErrorLength]
+	}
+
+	delivery := *d
+	delivery.attempts++
+	delivery.lastAttemptAt = at
+	delivery.lastError = reason
+
+	if delivery.attempts >= policy.maxAttempts {
+		delivery.status = WebhookDeliveryFailed
+		return &delivery
+	}
+
+	delivery.nextAttemptAt = at.Add(policy.Backoff(delivery.attempts))
+
+	return &delivery
+}
+
+// Replay returns a new WebhookDelivery struct of a failed delivery, pending again with all its attempts, attempted right
+// away
+func (d *WebhookDelivery) Replay(at time.Time) (*WebhookDelivery, error) {
+	if !d.IsFailed() {
+		return nil, NewErrDomain("status", fmt.Sprintf("'%s' deliveries cannot be replayed, only failed ones", d.status))
+	}
+
+	delivery := *d
+	delivery.status = WebhookDeliveryPending
+	delivery.attempts = 0
+	delivery.nextAttemptAt = at
+	delivery.lastError = ""
+
+	return &delivery, nil
+}
+
+// IsFailed checks if all the attempts of the delivery failed
+func (d *WebhookDelivery) IsFailed() bool {
+	return d.status == WebhookDeliveryFailed
+}
+
+// ID returns the id value
+func (d *WebhookDelivery) ID() *ID {
+	return d.id
+}
+
+// Webhook returns the webhook the event is delivered to
+func (d *WebhookDelivery) Webhook() *Webhook {
+	return d.webhook
+}
+
+// Event returns the event delivered
+func (d *WebhookDelivery) Event() *Event {
+	return d.event
+}
+
+// Status returns the status value
+func (d *WebhookDelivery) Status() WebhookDeliveryStatus {
+	return d.status
+}
+
+// Attempts returns how many times the delivery was attempted
+func (d *WebhookDelivery) Attempts() int {
+	return d.attempts
+}
+
+// NextAttemptAt returns when the delivery is attempted again
+func (d *WebhookDelivery) NextAttemptAt() time.Time {
+	return d.nextAttemptAt
+}
+
+// LastAttemptAt returns when the delivery was last attempted
+func (d *WebhookDelivery) LastAttemptAt() time.Time {
+	return d.lastAttemptAt
+}
+
+// LastError returns why the last attempt failed
+func (d *WebhookDelivery) LastError() string {
+	return d.lastError
+}
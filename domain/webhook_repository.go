@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// WebhookRepositoryWriter represents the behaviour of the Webhook Repository to write operations
+type WebhookRepositoryWriter interface {
+	Store(context.Context, *Webhook) (*ID, error)
+}
+
+// WebhookRepositoryReader represents the behaviour of the Webhook Repository to read operations.
+// FindByEventType finds the webhooks subscribed to the informed event type.
+type WebhookRepositoryReader interface {
+	FindByEventType(context.Context, EventType) ([]*Webhook, error)
+}
+
+// WebhookRepositoryMock is a fake representation of the Webhook Repository, useful to create unit tests
+type WebhookRepositoryMock struct {
+	id       *ID
+	webhooks []*Webhook
+	err      error
+}
+
+// NewWebhookRepositoryMock builds a new WebhookRepositoryMock struct with its mock results
+func NewWebhookRepositoryMock(id *ID, webhooks []*Webhook, err error) *WebhookRepositoryMock {
+	return &WebhookRepositoryMock{id: id, webhooks: webhooks, err: err}
+}
+
+// Store stores a webhook
+func (w WebhookRepositoryMock) Store(_ context.Context, _ *Webhook) (*ID, error) {
+	if w.err != nil {
+		return nil, w.err
+	}
+
+	return w.id, nil
+}
+
+// FindByEventType finds the webhooks subscribed to the event type
+func (w WebhookRepositoryMock) FindByEventType(_ context.Context, eventType EventType) ([]*Webhook, error) {
+	if w.err != nil {
+		return nil, w.err
+	}
+
+	var webhooks []*Webhook
+
+	for _, v := range w.webhooks {
+		if v.Subscribes(eventType) {
+			webhooks = append(webhooks, v)
+		}
+	}
+
+	return webhooks, nil
+}
+
+// WebhookDeliveryRepositoryWriter represents the behaviour of the Webhook Delivery Repository to write operations.
+// Only one delivery can be stored for each webhook and event, storing it again returns the id of the stored one.
+// MoveToDeadLetter moves a failed delivery to the dead letters, and Replay moves it back to the deliveries, pending.
+type WebhookDeliveryRepositoryWriter interface {
+	Store(context.Context, *WebhookDelivery) (*ID, error)
+	Update(context.Context, *WebhookDelivery) error
+	MoveToDeadLetter(context.Context, *WebhookDelivery) error
+	Replay(context.Context, *WebhookDelivery) error
+}
+
+// WebhookDeliveryRepositoryReader represents the behaviour of the Webhook Delivery Repository to read operations.
+// FindDue finds the pending deliveries whose next attempt is due at the informed moment, from the oldest to the newest,
+// up to the informed limit. FindDeadLetterByID returns an error when the delivery is not in the dead letters.
+type WebhookDeliveryRepositoryReader interface {
+	FindDue(context.Context, time.Time, int) ([]*WebhookDelivery, error)
+	FindDeadLetters(context.Context) ([]*WebhookDelivery, error)
+	FindDeadLetterByID(context.Context, *ID) (*WebhookDelivery, error)
+}
+
+// WebhookDeliveryRepositoryMock is a fake representation of the Webhook Delivery Repository, useful to create unit
+// tests. It registers the deliveries written, by operation.
+type WebhookDeliveryRepositoryMock struct {
+	id         *ID
+	deliveries []*WebhookDelivery
+	err        error
+	writeErr   error
+	written    map[string][]*WebhookDelivery
+}
+
+// NewWebhookDeliveryRepositoryMock builds a new WebhookDeliveryRepositoryMock struct with its mock results
+func NewWebhookDeliveryRepositoryMock(id *ID, deliveries []*WebhookDelivery, err error) *WebhookDeliveryRepositoryMock {
+	return &WebhookDeliveryRepositoryMock{
+		id:         id,
+		deliveries: deliveries,
+		err:        err,
+		written:    make(map[string][]*WebhookDelivery),
+	}
+}
+
+// WithWriteErr returns a new WebhookDeliveryRepositoryMock struct failing the write operations with err
+func (w WebhookDeliveryRepositoryMock) WithWriteErr(err error) *WebhookDeliveryRepositoryMock {
+	mock := w
+	mock.writeErr = err
+
+	return &mock
+}
+
+// Written returns the deliveries written by the informed operation, as "Store" or "Update"
+func (w WebhookDeliveryRepositoryMock) Written(operation string) []*WebhookDelivery {
+	return w.written[operation]
+}
+
+func (w WebhookDeliveryRepositoryMock) write(operation string, delivery *WebhookDelivery) error {
+	if w.err != nil {
+		return w.err
+	}
+
+	if w.writeErr != nil {
+		return w.writeErr
+	}
+
+	w.written[operation] = append(w.written[operation], delivery)
+
+	return nil
+}
+
+// Store stores a delivery
+func (w WebhookDeliveryRepositoryMock) Store(_ context.Context, delivery *WebhookDelivery) (*ID, error) {
+	if err := w.write("Store", delivery); err != nil {
+		return nil, err
+	}
+
+	return w.id, nil
+}
+
+// Update updates the attempts of a delivery
+func (w WebhookDeliveryRepositoryMock) Update(_ context.Context, delivery *WebhookDelivery) error {
+	return w.write("Update", delivery)
+}
+
+// MoveToDeadLetter moves a failed delivery to the dead letters
+func (w WebhookDeliveryRepositoryMock) MoveToDeadLetter(_ context.Context, delivery *WebhookDelivery) error {
+	return w.write("MoveToDeadLetter", delivery)
+}
+
+// Replay moves a failed delivery back to the deliveries
+func (w WebhookDeliveryRepositoryMock) Replay(_ context.Context, delivery *WebhookDelivery) error {
+	return w.write("Replay", delivery)
+}
+
+// FindDue finds the deliveries to be attempted
+func (w WebhookDeliveryRepositoryMock) FindDue(_ context.Context, _ time.Time, _ int) ([]*WebhookDelivery, error) {
+	if w.err != nil {
+		return nil, w.err
+	}
+
+	return w.deliveries, nil
+}
+
+// FindDeadLetters finds the failed deliveries
+func (w WebhookDeliveryRepositoryMock) FindDeadLetters(_ context.Context) ([]*WebhookDelivery, error) {
+	if w.err != nil {
+		return nil, w.err
+	}
+
+	return w.deliveries, nil
+}
+
+// FindDeadLetterByID finds a failed delivery by its id
+func (w WebhookDeliveryRepositoryMock) FindDeadLetterByID(_ context.Context, _ *ID) (*WebhookDelivery, error) {
+	if w.err != nil {
+		return nil, w.err
+	}
+
+	if len(w.deliveries) == 0 {
+		return nil, nil
+	}
+
+	return w.deliveries[0], nil
+}
@@ -0,0 +1,37 @@
+package domain
+
+import "context"
+
+// WebhookSender represents the behaviour to send a delivery to the url of its webhook, signed with the webhook secret.
+// It fails when the partner does not acknowledge the delivery.
+type WebhookSender interface {
+	Send(context.Context, *WebhookDelivery) error
+}
+
+// WebhookSenderMock is a fake representation of a WebhookSender, useful to create unit tests. It fails to send the
+// deliveries of the informed webhooks.
+type WebhookSenderMock struct {
+	sent     *[]*WebhookDelivery
+	failures map[uint64]error
+}
+
+// NewWebhookSenderMock builds a new WebhookSenderMock struct, failing the deliveries by webhook id
+func NewWebhookSenderMock(failures map[uint64]error) *WebhookSenderMock {
+	return &WebhookSenderMock{sent: new([]*WebhookDelivery), failures: failures}
+}
+
+// Send sends a delivery
+func (w WebhookSenderMock) Send(_ context.Context, delivery *WebhookDelivery) error {
+	if err, ok := w.failures[delivery.Webhook().ID().Value()]; ok {
+		return err
+	}
+
+	*w.sent = append(*w.sent, delivery)
+
+	return nil
+}
+
+// Sent returns the deliveries sent
+func (w WebhookSenderMock) Sent() []*WebhookDelivery {
+	return *w.sent
+}
@@ -0,0 +1,201 @@
+package domain
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewWebhook(t *testing.T) {
+	type args struct {
+		url        string
+		eventTypes []string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		// fails
+		{
+			name:    "relative url",
+			args:    args{url: "/events", eventTypes: []string{"account.created"}},
+			wantErr: NewErrDomain("url", "'/events' must be an absolute http or https url"),
+		},
+		{
+			name:    "url with invalid scheme",
+			args:    args{url: "ftp://partner.com/events", eventTypes: []string{"account.created"}},
+			wantErr: NewErrDomain("url", "'ftp://partner.com/events' must be an absolute http or https url"),
+		},
+		{
+			name:    "without event types",
+			args:    args{url: "https://partner.com/events"},
+			wantErr: NewErrDomain("event_types", "must have at least one event type"),
+		},
+		{
+			name:    "unknown event type",
+			args:    args{url: "https://partner.com/events", eventTypes: []string{"account.created", "account.deleted"}},
+			wantErr: NewErrDomain("event_types", "'account.deleted' is not a valid event type"),
+		},
+
+		// successes
+		{
+			name: "webhook subscribed to one event type",
+			args: args{url: "http://partner.com/events", eventTypes: []string{"transaction.created"}},
+		},
+		{
+			name: "webhook subscribed to all the event types",
+			args: args{url: "https://partner.com/events", eventTypes: []string{"account.created", "transaction.created"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewWebhook(tt.args.url, tt.args.eventTypes)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("NewWebhook() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if len(got.Secret()) != 2*webhookSecretLength {
+				t.Errorf("NewWebhook() secret = %v, want %d hexadecimal chars", got.Secret(), 2*webhookSecretLength)
+			}
+
+			for _, v := range tt.args.eventTypes {
+				if !got.Subscribes(EventType(v)) {
+					t.Errorf("NewWebhook() must subscribe to %v", v)
+				}
+			}
+		})
+	}
+}
+
+func TestWebhook_Sign(t *testing.T) {
+	var (
+		webhook = LoadWebhook(NewID(1), "https://partner.com/events", nil, "secret", time.Time{})
+		at      = time.Unix(1603620000, 0)
+		body    = []byte(`{"id":1}`)
+	)
+
+	// echo -n '1603620000.{"id":1}' | openssl dgst -sha256 -hmac secret
+	want := "24ef916f26d23c92e3bb42ad1565b3daa9696649cfdbdaf098a162adf6083afb"
+
+	got := webhook.Sign(at, body)
+	if got != want {
+		t.Errorf("Sign() = %v, want %v", got, want)
+	}
+
+	if got == webhook.Sign(at.Add(time.Second), body) {
+		t.Errorf("Sign() must depend on the timestamp")
+	}
+
+	other := LoadWebhook(NewID(2), "https://partner.com/events", nil, "other", time.Time{})
+	if got == other.Sign(at, body) {
+		t.Errorf("Sign() must depend on the secret")
+	}
+}
+
+func TestWebhookRetryPolicy_Backoff(t *testing.T) {
+	policy, _ := NewWebhookRetryPolicy(5, 30*time.Second)
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: 30 * time.Second},
+		{attempt: 2, want: time.Minute},
+		{attempt: 3, want: 2 * time.Minute},
+		{attempt: 5, want: 8 * time.Minute},
+		{attempt: 20, want: maxWebhookRetryDelay},
+	}
+
+	for _, tt := range tests {
+		if got := policy.Backoff(tt.attempt); got != tt.want {
+			t.Errorf("Backoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestWebhookDelivery_Fail(t *testing.T) {
+	var (
+		policy, _ = NewWebhookRetryPolicy(3, time.Minute)
+		webhook   = LoadWebhook(NewID(1), "https://partner.com/events", []EventType{EventAccountCreated}, "secret", time.Time{})
+		event     = LoadEvent(NewID(1), EventAccountCreated, NewID(1), []byte(`{"id":1}`), time.Time{})
+		at        = time.Date(2020, 10, 25, 10, 0, 0, 0, time.UTC)
+	)
+
+	delivery := NewWebhookDelivery(webhook, event, at)
+
+	delivery = delivery.Fail("status 500", at, policy)
+	if delivery.IsFailed() || delivery.Attempts() != 1 || !delivery.NextAttemptAt().Equal(at.Add(time.Minute)) {
+		t.Errorf("Fail() = %v %v %v", delivery.Status(), delivery.Attempts(), delivery.NextAttemptAt())
+	}
+
+	delivery = delivery.Fail("status 500", at.Add(time.Minute), policy)
+	if delivery.IsFailed() || delivery.Attempts() != 2 || !delivery.NextAttemptAt().Equal(at.Add(3*time.Minute)) {
+		t.Errorf("Fail() = %v %v %v", delivery.Status(), delivery.Attempts(), delivery.NextAttemptAt())
+	}
+
+	delivery = delivery.Fail(strings.Repeat("x", 300), at.Add(3*time.Minute), policy)
+	if !delivery.IsFailed() || delivery.Attempts() != 3 || len(delivery.LastError()) != maxWebhookErrorLength {
+		t.Errorf("Fail() = %v %v %v", delivery.Status(), delivery.Attempts(), delivery.LastError())
+	}
+}
+
+func TestWebhookDelivery_Replay(t *testing.T) {
+	var (
+		webhook = LoadWebhook(NewID(1), "https://partner.com/events", []EventType{EventAccountCreated}, "secret", time.Time{})
+		event   = LoadEvent(NewID(1), EventAccountCreated, NewID(1), []byte(`{"id":1}`), time.Time{})
+		at      = time.Date(2020, 10, 25, 10, 0, 0, 0, time.UTC)
+	)
+
+	tests := []struct {
+		name     string
+		delivery *WebhookDelivery
+		wantErr  error
+	}{
+		// fails
+		{
+			name:     "pending delivery",
+			delivery: LoadWebhookDelivery(NewID(1), webhook, event, WebhookDeliveryPending, 1, at, at, "status 500"),
+			wantErr:  NewErrDomain("status", "'pending' deliveries cannot be replayed, only failed ones"),
+		},
+		{
+			name:     "delivered delivery",
+			delivery: LoadWebhookDelivery(NewID(1), webhook, event, WebhookDeliveryDelivered, 1, at, at, ""),
+			wantErr:  NewErrDomain("status", "'delivered' deliveries cannot be replayed, only failed ones"),
+		},
+
+		// successes
+		{
+			name:     "failed delivery",
+			delivery: LoadWebhookDelivery(NewID(1), webhook, event, WebhookDeliveryFailed, 8, at, at, "status 500"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replayAt := at.Add(time.Hour)
+
+			got, err := tt.delivery.Replay(replayAt)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Replay() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.Status() != WebhookDeliveryPending || got.Attempts() != 0 || !got.NextAttemptAt().Equal(replayAt) {
+				t.Errorf("Replay() = %v %v %v", got.Status(), got.Attempts(), got.NextAttemptAt())
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// Level is the severity of a log entry
+type Level int
+
+// Levels of the log entries, from the least to the most severe
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+var levels = map[Level]string{
+	LevelDebug: "debug",
+	LevelInfo:  "info",
+	LevelWarn:  "warn",
+	LevelError: "error",
+}
+
+// ParseLevel parses the name of a level, as "debug", "info", "warn" or "error"
+func ParseLevel(v string) (Level, error) {
+	for level, name := range levels {
+		if strings.EqualFold(strings.TrimSpace(v), name) {
+			return level, nil
+		}
+	}
+
+	return LevelInfo, fmt.Errorf("invalid log level '%s'", v)
+}
+
+// String returns the name of the level
+func (l Level) String() string {
+	return levels[l]
+}
+
+// JSON writes each log entry as a JSON object in a line, with the moment, the level, the message, the request id
+// carried by the context and the fields of the entry. Entries less severe than its level are discarded.
+type JSON struct {
+	mu     *sync.Mutex
+	w      io.Writer
+	level  Level
+	fields []domain.LogField
+	now    func() time.Time
+}
+
+// NewJSON builds a new JSON struct writing the entries from the informed level to w
+func NewJSON(w io.Writer, level Level, now func() time.Time) *JSON {
+	return &JSON{mu: new(sync.Mutex), w: w, level: level, now: now}
+}
+
+// Debug writes a debug entry
+func (j JSON) Debug(ctx context.Context, msg string, fields ...domain.LogField) {
+	j.write(ctx, LevelDebug, msg, fields)
+}
+
+// Info writes an info entry
+func (j JSON) Info(ctx context.Context, msg string, fields ...domain.LogField) {
+	j.write(ctx, LevelInfo, msg, fields)
+}
+
+// Warn writes a warn entry
+func (j JSON) Warn(ctx context.Context, msg string, fields ...domain.LogField) {
+	j.write(ctx, LevelWarn, msg, fields)
+}
+
+// Error writes an error entry
+func (j JSON) Error(ctx context.Context, msg string, fields ...domain.LogField) {
+	j.write(ctx, LevelError, msg, fields)
+}
+
+// With returns a new JSON struct adding the fields to all its entries, written to the same writer
+func (j JSON) With(fields ...domain.LogField) domain.Logger {
+	logger := j
+	logger.fields = append(append([]domain.LogField(nil), j.fields...), fields...)
+
+	return &logger
+}
+
+func (j JSON) write(ctx context.Context, level Level, msg string, fields []domain.LogField) {
+	if level < j.level {
+		return
+	}
+
+	entry := make(map[string]interface{}, len(j.fields)+len(fields)+4)
+
+	for _, v := range append(append([]domain.LogField(nil), j.fields...), fields...) {
+		entry[v.Key()] = value(v.Value())
+	}
+
+	// the fields of the entry never override its main keys
+	entry["time"] = j.now().UTC().Format(time.RFC3339Nano)
+	entry["level"] = level.String()
+	entry["msg"] = msg
+
+	if id := RequestID(ctx); id != "" {
+		entry["request_id"] = id
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		line, _ = json.Marshal(map[string]interface{}{
+			"time":  entry["time"],
+			"level": entry["level"],
+			"msg":   msg,
+			"error": fmt.Sprintf("invalid log fields: %s", err),
+		})
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	_, _ = j.w.Write(append(line, '\n'))
+}
+
+// value adapts a field value to be encoded: errors have no exported fields, and the values with a text representation,
+// as money and ids, are written as it
+func value(v interface{}) interface{} {
+	switch t := v.(type) {
+	case error:
+		return t.Error()
+	case json.Marshaler:
+		return v
+	case fmt.Stringer:
+		return t.String()
+	default:
+		return v
+	}
+}
@@ -0,0 +1,123 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+func TestJSON(t *testing.T) {
+	var (
+		now = func() time.Time { return time.Date(2021, 3, 10, 12, 30, 0, 0, time.UTC) }
+		ctx = WithRequestID(context.Background(), "abc-123")
+	)
+
+	tests := []struct {
+		name  string
+		level Level
+		write func(domain.Logger)
+		want  string
+	}{
+		{
+			name:  "entry less severe than the level",
+			level: LevelWarn,
+			write: func(l domain.Logger) { l.Info(ctx, "account found") },
+			want:  "",
+		},
+		{
+			name:  "entry without request",
+			level: LevelDebug,
+			write: func(l domain.Logger) { l.Debug(context.Background(), "starting app") },
+			want:  `{"level":"debug","msg":"starting app","time":"2021-03-10T12:30:00Z"}` + "\n",
+		},
+		{
+			name:  "entry with the request id and fields",
+			level: LevelInfo,
+			write: func(l domain.Logger) {
+				l.Error(ctx, "unable to create account", domain.NewErrLogField(errors.New("database error")), domain.NewLogField("account_id", 1))
+			},
+			want: `{"account_id":1,"error":"database error","level":"error","msg":"unable to create account","request_id":"abc-123","time":"2021-03-10T12:30:00Z"}` + "\n",
+		},
+		{
+			name:  "entry with the fields of the logger",
+			level: LevelInfo,
+			write: func(l domain.Logger) {
+				l.With(domain.NewLogField("job", "billing")).Warn(ctx, "invoices generated", domain.NewLogField("count", 2))
+			},
+			want: `{"count":2,"job":"billing","level":"warn","msg":"invoices generated","request_id":"abc-123","time":"2021-03-10T12:30:00Z"}` + "\n",
+		},
+		{
+			name:  "fields never override the main keys",
+			level: LevelInfo,
+			write: func(l domain.Logger) { l.Info(ctx, "response", domain.NewLogField("msg", "other")) },
+			want:  `{"level":"info","msg":"response","request_id":"abc-123","time":"2021-03-10T12:30:00Z"}` + "\n",
+		},
+		{
+			name:  "values with a text representation",
+			level: LevelInfo,
+			write: func(l domain.Logger) {
+				l.Info(ctx, "transaction created", domain.NewLogField("amount", domain.NewMoney(-1050, domain.CurrencyBRL)))
+			},
+			want: `{"amount":"-10.50","level":"info","msg":"transaction created","request_id":"abc-123","time":"2021-03-10T12:30:00Z"}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+
+			tt.write(NewJSON(out, tt.level, now))
+
+			if got := out.String(); got != tt.want {
+				t.Errorf("JSON wrote %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    Level
+		wantErr bool
+	}{
+		// fails
+		{
+			name:    "unknown level",
+			value:   "verbose",
+			want:    LevelInfo,
+			wantErr: true,
+		},
+
+		// successes
+		{
+			name:  "debug",
+			value: "debug",
+			want:  LevelDebug,
+		},
+		{
+			name:  "warn in upper case",
+			value: " WARN ",
+			want:  LevelWarn,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLevel(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseLevel() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseLevel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package logger
+
+import "context"
+
+// requestIDContextKey identifies the request id in the context
+type requestIDContextKey struct{}
+
+// WithRequestID returns a copy of the context carrying the request id, added to the log entries written with it
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, id)
+}
+
+// RequestID returns the request id carried by the context, empty when there is none
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	id, _ := ctx.Value(requestIDContextKey{}).(string)
+
+	return id
+}
@@ -0,0 +1,29 @@
+package publisher
+
+import (
+	"context"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// FanOut publishes the events to several publishers, in the informed order
+type FanOut struct {
+	publishers []domain.EventPublisher
+}
+
+// NewFanOut builds a new FanOut struct with its dependencies
+func NewFanOut(publishers ...domain.EventPublisher) *FanOut {
+	return &FanOut{publishers: publishers}
+}
+
+// Publish publishes the event to all the publishers, stopping at the first that fails. The event is published again
+// to all of them in the next attempt, so the publishers must accept repeated events.
+func (f FanOut) Publish(ctx context.Context, event *domain.Event) error {
+	for _, p := range f.publishers {
+		if err := p.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
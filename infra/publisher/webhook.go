@@ -0,0 +1,68 @@
+package publisher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// Webhook publishes the events to an HTTP endpoint, one request per event
+type Webhook struct {
+	client *http.Client
+	url    string
+}
+
+// NewWebhook builds a new Webhook struct with its dependencies
+func NewWebhook(client *http.Client, url string) *Webhook {
+	return &Webhook{client: client, url: url}
+}
+
+// Publish posts the event to the endpoint, which must answer with a 2xx status code to acknowledge it
+func (w Webhook) Publish(ctx context.Context, event *domain.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "error to encode the event")
+	}
+
+	return post(ctx, w.client, w.url, event, body, nil)
+}
+
+// post posts the encoded event to the url with the informed extra headers, failing when the endpoint does not answer
+// with a 2xx status code
+func post(ctx context.Context, client *http.Client, url string, event *domain.Event, body []byte, headers http.Header) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "error to build the webhook request")
+	}
+
+	for key := range headers {
+		req.Header.Set(key, headers.Get(key))
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-ID", strconv.FormatUint(event.ID().Value(), 10))
+	req.Header.Set("X-Event-Type", event.Type().String())
+
+	res, err := client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "webhook request error")
+	}
+	defer res.Body.Close()
+
+	// the body is drained so the connection can be reused
+	io.Copy(ioutil.Discard, res.Body)
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook answered with the status code %d", res.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+const (
+	// SignatureHeader carries the signature of the delivery, as "sha256=<hex>"
+	SignatureHeader = "X-Signature"
+
+	// SignatureTimestampHeader carries the unix timestamp the delivery was signed at
+	SignatureTimestampHeader = "X-Signature-Timestamp"
+)
+
+// WebhookSender sends the deliveries to the url of their webhooks, signed with the webhook secret. The partners verify
+// a delivery computing the HMAC-SHA256 of "<X-Signature-Timestamp>.<body>" with their secret.
+type WebhookSender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewWebhookSender builds a new WebhookSender struct with its dependencies
+func NewWebhookSender(client *http.Client, now func() time.Time) *WebhookSender {
+	return &WebhookSender{client: client, now: now}
+}
+
+// Send posts the event of the delivery to its webhook, which must answer with a 2xx status code to acknowledge it
+func (w WebhookSender) Send(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	body, err := json.Marshal(delivery.Event())
+	if err != nil {
+		return errors.Wrap(err, "error to encode the event")
+	}
+
+	var (
+		webhook = delivery.Webhook()
+		at      = w.now()
+		headers = http.Header{}
+	)
+
+	headers.Set(SignatureHeader, "sha256="+webhook.Sign(at, body))
+	headers.Set(SignatureTimestampHeader, strconv.FormatInt(at.Unix(), 10))
+
+	return post(ctx, w.client, webhook.URL(), delivery.Event(), body, headers)
+}
@@ -0,0 +1,82 @@
+package publisher
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+func TestWebhookSender_Send(t *testing.T) {
+	var (
+		now   = time.Date(2020, 10, 25, 10, 0, 0, 0, time.UTC)
+		event = domain.LoadEvent(domain.NewID(1), domain.EventAccountCreated, domain.NewID(10), []byte(`{"id":10}`), now)
+		body  = `{"id":1,"type":"account.created","aggregate_id":10,"occurred_at":"2020-10-25T10:00:00Z","payload":{"id":10}}`
+	)
+
+	tests := []struct {
+		name       string
+		statusCode int
+		wantErr    bool
+	}{
+		// fails
+		{name: "delivery not acknowledged", statusCode: http.StatusInternalServerError, wantErr: true},
+		{name: "delivery redirected", statusCode: http.StatusMovedPermanently, wantErr: true},
+
+		// successes
+		{name: "delivery acknowledged", statusCode: http.StatusOK},
+		{name: "delivery accepted", statusCode: http.StatusAccepted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received *http.Request
+			var receivedBody []byte
+
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				received = req
+				receivedBody, _ = ioutil.ReadAll(req.Body)
+				rw.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+
+			var (
+				webhook  = domain.LoadWebhook(domain.NewID(1), server.URL, nil, "secret", now)
+				delivery = domain.NewWebhookDelivery(webhook, event, now)
+				sender   = NewWebhookSender(server.Client(), func() time.Time { return now })
+			)
+
+			err := sender.Send(context.Background(), delivery)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Send() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if string(receivedBody) != body {
+				t.Errorf("Send() body = %s, want %s", receivedBody, body)
+			}
+
+			mac := hmac.New(sha256.New, []byte("secret"))
+			mac.Write([]byte("1603620000." + body))
+
+			if want := "sha256=" + hex.EncodeToString(mac.Sum(nil)); received.Header.Get(SignatureHeader) != want {
+				t.Errorf("Send() signature = %v, want %v", received.Header.Get(SignatureHeader), want)
+			}
+
+			if got := received.Header.Get(SignatureTimestampHeader); got != "1603620000" {
+				t.Errorf("Send() timestamp = %v, want 1603620000", got)
+			}
+
+			if received.Header.Get("X-Event-ID") != "1" || received.Header.Get("X-Event-Type") != "account.created" {
+				t.Errorf("Send() event headers = %v", received.Header)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// Writer publishes the events as JSON lines to a writer, as the standard output or a file, useful to run locally
+type Writer struct {
+	mu *sync.Mutex
+	w  io.Writer
+}
+
+// NewWriter builds a new Writer struct with its dependencies
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{mu: &sync.Mutex{}, w: w}
+}
+
+// Publish writes the event in a single line
+func (w Writer) Publish(_ context.Context, event *domain.Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "error to encode the event")
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, err := w.w.Write(append(line, '\n')); err != nil {
+		return errors.Wrap(err, "error to write the event")
+	}
+
+	return nil
+}
@@ -1,32 +1,69 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"strconv"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/tonytcb/bank-transactions-go/domain"
 )
 
+const (
+	timestampLayout = "2006-01-02 15:04:05"
+	dateLayout      = "2006-01-02"
+)
+
 // AccountReader exposes account read database operations
 type AccountReader struct {
-	conn *sql.DB
+	conn    *sql.DB
+	dialect dialect
 }
 
 // NewAccountReader build a new AccountReader struct with its dependencies
 func NewAccountReader(conn *sql.DB) *AccountReader {
-	return &AccountReader{conn: conn}
+	return &AccountReader{conn: conn, dialect: dialectOf(conn)}
 }
 
 // FindOneByID finds and return one account based in the informed ID
-func (a AccountReader) FindOneByID(id *domain.ID) (*domain.Account, error) {
+func (a AccountReader) FindOneByID(ctx context.Context, id *domain.ID) (*domain.Account, error) {
 	var (
-		documentNumber     string
-		createdAtTimestamp []uint8
-		query              = `SELECT document_number, created_at FROM accounts WHERE id = ?`
+		documentType         string
+		documentNumber       string
+		currency             string
+		creditLimit          decimal
+		availableCreditLimit decimal
+		closingDay           int
+		dueDay               int
+		status               string
+		statusReason         sql.NullString
+		statusChangedAt      timestamp
+		createdAt            timestamp
+		query                = `
+			SELECT document_type, document_number, currency, credit_limit, available_credit_limit, closing_day, due_day,
+				status, status_reason, status_changed_at, created_at
+			FROM accounts
+			WHERE id = ?
+		`
 	)
 
-	if err := a.conn.QueryRow(query, id.Value()).Scan(&documentNumber, &createdAtTimestamp); err != nil {
+	row := executorOf(ctx, a.conn).QueryRowContext(ctx, a.dialect.query(query), id.Value())
+
+	err := row.Scan(
+		&documentType,
+		&documentNumber,
+		&currency,
+		&creditLimit,
+		&availableCreditLimit,
+		&closingDay,
+		&dueDay,
+		&status,
+		&statusReason,
+		&statusChangedAt,
+		&createdAt,
+	)
+	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, NewErrRegisterNotFound("id", strconv.FormatUint(id.Value(), 10))
 		}
@@ -34,24 +71,71 @@ func (a AccountReader) FindOneByID(id *domain.ID) (*domain.Account, error) {
 		return nil, err
 	}
 
-	createdAt, err := timestampToTime(createdAtTimestamp)
+	account, err := domain.NewAccount(domain.DocumentNumber(documentNumber))
+	if err != nil || account.Document().Type() != domain.DocumentType(documentType) {
+		return nil, NewErrLoadInvalidData("accounts")
+	}
+
+	limit, err := decimalToMoney(string(creditLimit), domain.Currency(currency))
 	if err != nil {
-		createdAt = time.Time{}
+		return nil, NewErrLoadInvalidData("accounts")
 	}
 
-	account, err := domain.NewAccount(domain.DocumentNumber(documentNumber))
+	available, err := decimalToMoney(string(availableCreditLimit), domain.Currency(currency))
 	if err != nil {
 		return nil, NewErrLoadInvalidData("accounts")
 	}
 
-	return account.WithID(id).WithCreateAt(createdAt), nil
+	cycle, err := domain.NewBillingCycle(closingDay, dueDay)
+	if err != nil {
+		return nil, NewErrLoadInvalidData("accounts")
+	}
+
+	account = account.
+		WithID(id).
+		WithCreateAt(createdAt.Time).
+		WithCurrency(domain.Currency(currency)).
+		WithCreditLimit(limit).
+		WithAvailableCreditLimit(available).
+		WithBillingCycle(cycle).
+		WithStatus(domain.AccountStatus(status), domain.StatusReason(statusReason.String), statusChangedAt.Time)
+
+	return account, nil
 }
 
-func timestampToTime(t []uint8) (time.Time, error) {
-	parsedTime, err := time.Parse("2006-01-02 15:04:05", string(t))
+// BalanceByID sums all transactions of the account created until the informed moment, in the account currency
+func (a AccountReader) BalanceByID(ctx context.Context, id *domain.ID, at time.Time) (domain.Money, error) {
+	var (
+		balance  decimal
+		currency string
+		query    = `
+			SELECT COALESCE(SUM(t.amount), 0), a.currency
+			FROM accounts a
+			LEFT JOIN transactions t ON t.account_id = a.id AND t.created_at <= ?
+			WHERE a.id = ?
+			GROUP BY a.currency
+		`
+	)
+
+	row := executorOf(ctx, a.conn).QueryRowContext(ctx, a.dialect.query(query), at.UTC().Format(timestampLayout), id.Value())
+
+	if err := row.Scan(&balance, &currency); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.Money{}, NewErrRegisterNotFound("id", strconv.FormatUint(id.Value(), 10))
+		}
+
+		return domain.Money{}, errors.Wrap(err, "database error")
+	}
+
+	amount, err := decimalToMoney(string(balance), domain.Currency(currency))
 	if err != nil {
-		return time.Time{}, err
+		return domain.Money{}, NewErrLoadInvalidData("transactions")
 	}
 
-	return parsedTime, nil
+	return amount, nil
+}
+
+// decimalToMoney converts a DECIMAL column, read as string to keep its exact value, to a domain.Money value
+func decimalToMoney(v string, currency domain.Currency) (domain.Money, error) {
+	return domain.ParseMoney(v, currency)
 }
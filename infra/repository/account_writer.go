@@ -1,48 +1,113 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
 
-	"github.com/go-sql-driver/mysql"
 	"github.com/pkg/errors"
 	"github.com/tonytcb/bank-transactions-go/domain"
 )
 
 // AccountWriter exposes account write database operations
 type AccountWriter struct {
-	conn *sql.DB
+	conn    *sql.DB
+	dialect dialect
 }
 
 // NewAccountWriter build a new AccountWriter struct with its dependencies
 func NewAccountWriter(conn *sql.DB) *AccountWriter {
-	return &AccountWriter{conn: conn}
+	return &AccountWriter{conn: conn, dialect: dialectOf(conn)}
 }
 
 // Store stores an account in the storage
-func (a AccountWriter) Store(acc *domain.Account) (*domain.ID, error) {
+func (a AccountWriter) Store(ctx context.Context, acc *domain.Account) (*domain.ID, error) {
 	var query = `
-		INSERT INTO accounts (document_number)
-		VALUES (?)
+		INSERT INTO accounts
+			(document_type, document_number, currency, credit_limit, available_credit_limit, closing_day, due_day)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
 	`
 
-	stmt, err := a.conn.Prepare(query)
+	id, err := a.dialect.insert(
+		ctx,
+		executorOf(ctx, a.conn),
+		query,
+		acc.Document().Type().String(),
+		acc.Document().Number().String(),
+		acc.Currency().String(),
+		acc.CreditLimit().String(),
+		acc.AvailableCreditLimit().String(),
+		acc.BillingCycle().ClosingDay(),
+		acc.BillingCycle().DueDay(),
+	)
 	if err != nil {
-		return nil, errors.Wrap(err, "prepare statement error")
+		return nil, err
 	}
 
-	result, err := stmt.Exec(acc.Document().Number().String())
+	return domain.NewID(id), nil
+}
+
+// StoreStatusChange updates the account status and registers the change in its history, in the same database
+// transaction. The update only succeeds when the account still has the status it was changed from, so concurrent
+// changes cannot override each other.
+func (a AccountWriter) StoreStatusChange(ctx context.Context, change *domain.AccountStatusChange) error {
+	tx, err := beginTx(ctx, a.conn)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var (
+		changedAt   = change.ChangedAt().UTC().Format(timestampLayout)
+		updateQuery = `
+			UPDATE accounts SET status = ?, status_reason = ?, status_changed_at = ?
+			WHERE id = ? AND status = ?
+		`
+	)
+
+	result, err := tx.ExecContext(
+		ctx,
+		a.dialect.query(updateQuery),
+		change.To().String(),
+		change.Reason().String(),
+		changedAt,
+		change.AccountID().Value(),
+		change.From().String(),
+	)
+	if err != nil {
+		return errors.Wrap(err, "error to update the account status")
+	}
+
+	affected, err := result.RowsAffected()
 	if err != nil {
-		if v, ok := err.(*mysql.MySQLError); ok {
-			return nil, translateMySQLErrors(v)
-		}
+		return errors.Wrap(err, "error to read the affected rows")
+	}
 
-		return nil, errors.Wrap(err, "database error")
+	if affected == 0 {
+		return domain.NewErrDomain("status", fmt.Sprintf("'%s' is no longer the status of the account", change.From()))
 	}
 
-	id, err := result.LastInsertId()
+	var insertQuery = `
+		INSERT INTO account_status_changes (account_id, from_status, to_status, reason, changed_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	_, err = tx.ExecContext(
+		ctx,
+		a.dialect.query(insertQuery),
+		change.AccountID().Value(),
+		change.From().String(),
+		change.To().String(),
+		change.Reason().String(),
+		changedAt,
+	)
 	if err != nil {
-		return nil, errors.Wrap(err, "error to read the last inserted id")
+		return a.dialect.translateErrors(err, "error to store the account status change")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "commit transaction error")
 	}
 
-	return domain.NewID(uint64(id)), nil
+	return nil
 }
@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+	"github.com/pkg/errors"
+)
+
+// dialect represents the differences between the database engines supported. The queries are written for MySQL,
+// with "?" placeholders, and adapted to the engine of the connection.
+type dialect struct {
+	// numbered rewrites the "?" placeholders to "$1", "$2", ...
+	numbered bool
+
+	// lock is the clause locking the selected rows until the end of the database transaction
+	lock string
+
+	// returning means the inserted id is read with a RETURNING clause, as the driver has no LastInsertId
+	returning bool
+
+	// translate translates the errors of the engine to the errors of the repository, returning nil for unknown errors
+	translate func(error) error
+
+	// ignoreDuplicate is the clause of an INSERT query skipping the row when it duplicates the unique columns, instead
+	// of failing, which would abort the PostgreSQL transaction
+	ignoreDuplicate func(columns ...string) string
+
+	// concat is the expression concatenating the values
+	concat func(values ...string) string
+}
+
+var (
+	dialectMySQL = dialect{
+		lock: "FOR UPDATE",
+		translate: func(err error) error {
+			if v, ok := err.(*mysql.MySQLError); ok {
+				return translateMySQLErrors(v)
+			}
+
+			return nil
+		},
+		ignoreDuplicate: func(columns ...string) string {
+			return fmt.Sprintf("ON DUPLICATE KEY UPDATE %s = %s", columns[0], columns[0])
+		},
+		concat: func(values ...string) string {
+			return "CONCAT(" + strings.Join(values, ", ") + ")"
+		},
+	}
+
+	dialectPostgres = dialect{
+		numbered:  true,
+		lock:      "FOR UPDATE",
+		returning: true,
+		translate: func(err error) error {
+			if v, ok := err.(*pq.Error); ok {
+				return translatePostgresErrors(v)
+			}
+
+			return nil
+		},
+		ignoreDuplicate: onConflictDoNothing,
+		concat:          concatOperator,
+	}
+
+	// SQLite has no row locks, the connection must begin the transactions as IMMEDIATE, locking the database for
+	// writes instead
+	dialectSQLite = dialect{translate: translateSQLiteErrors, ignoreDuplicate: onConflictDoNothing, concat: concatOperator}
+)
+
+func onConflictDoNothing(columns ...string) string {
+	return "ON CONFLICT (" + strings.Join(columns, ", ") + ") DO NOTHING"
+}
+
+func concatOperator(values ...string) string {
+	return strings.Join(values, " || ")
+}
+
+// dialectOf returns the dialect of the database engine of the connection
+func dialectOf(conn *sql.DB) dialect {
+	switch conn.Driver().(type) {
+	case *pq.Driver:
+		return dialectPostgres
+	case *sqlite3.SQLiteDriver:
+		return dialectSQLite
+	default:
+		return dialectMySQL
+	}
+}
+
+// query adapts the placeholders of a query to the engine
+func (d dialect) query(query string) string {
+	if !d.numbered {
+		return query
+	}
+
+	var (
+		b strings.Builder
+		n int
+	)
+
+	for _, r := range query {
+		if r != '?' {
+			b.WriteRune(r)
+			continue
+		}
+
+		n++
+		b.WriteString("$" + strconv.Itoa(n))
+	}
+
+	return b.String()
+}
+
+// insert runs an INSERT query, returning the id of the inserted row
+func (d dialect) insert(ctx context.Context, tx executor, query string, args ...interface{}) (uint64, error) {
+	if d.returning {
+		var id uint64
+
+		if err := tx.QueryRowContext(ctx, d.query(query)+" RETURNING id", args...).Scan(&id); err != nil {
+			return 0, d.translateErrors(err, "database error")
+		}
+
+		return id, nil
+	}
+
+	result, err := tx.ExecContext(ctx, d.query(query), args...)
+	if err != nil {
+		return 0, d.translateErrors(err, "database error")
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, errors.Wrap(err, "error to read the last inserted id")
+	}
+
+	return uint64(id), nil
+}
+
+// translateErrors translates the error of the engine, or wraps it with the informed message when it is unknown
+func (d dialect) translateErrors(err error, message string) error {
+	if translated := d.translate(err); translated != nil {
+		return translated
+	}
+
+	return errors.Wrap(err, message)
+}
+
+// timestamp scans a DATETIME, TIMESTAMP or DATE column, read as text by the MySQL driver and as time.Time by the
+// PostgreSQL and SQLite drivers. NULL is scanned as the zero time.
+type timestamp struct {
+	time.Time
+}
+
+// Scan implements the sql.Scanner interface
+func (t *timestamp) Scan(v interface{}) error {
+	switch v := v.(type) {
+	case nil:
+		t.Time = time.Time{}
+	case time.Time:
+		t.Time = v.UTC()
+	case []byte:
+		return t.parse(string(v))
+	case string:
+		return t.parse(v)
+	default:
+		return fmt.Errorf("unsupported timestamp value %T", v)
+	}
+
+	return nil
+}
+
+func (t *timestamp) parse(v string) error {
+	for _, layout := range []string{timestampLayout, dateLayout} {
+		if parsed, err := time.Parse(layout, v); err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid timestamp '%s'", v)
+}
+
+// decimalScale is the largest scale of the DECIMAL columns
+const decimalScale = 8
+
+// decimal scans a DECIMAL column as a string, keeping its exact value. SQLite stores it as an INTEGER or a REAL, which
+// are formatted with the largest scale of the columns, the extra zeros are discarded when parsing the value.
+type decimal string
+
+// Scan implements the sql.Scanner interface
+func (d *decimal) Scan(v interface{}) error {
+	switch v := v.(type) {
+	case []byte:
+		*d = decimal(v)
+	case string:
+		*d = decimal(v)
+	case int64:
+		*d = decimal(strconv.FormatInt(v, 10))
+	case float64:
+		*d = decimal(strconv.FormatFloat(v, 'f', decimalScale, 64))
+	default:
+		return fmt.Errorf("unsupported decimal value %T", v)
+	}
+
+	return nil
+}
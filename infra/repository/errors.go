@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
 )
 
 // ErrDuplicateEntry represents a duplicate entry error
@@ -113,7 +114,7 @@ func translateMySQLErrors(err *mysql.MySQLError) error {
 	)
 
 	if err.Number == duplicateEntryErrorCode {
-		duplicatedErrorRegex := regexp.MustCompile(`Duplicate entry '(\w+)' for key '(\w+)'`)
+		duplicatedErrorRegex := regexp.MustCompile(`Duplicate entry '(.*)' for key '([\w.]+)'`)
 
 		if match := duplicatedErrorRegex.FindAllStringSubmatch(err.Message, -1); len(match) > 0 {
 			return NewErrDuplicatedEntry(match[0][2], match[0][1])
@@ -136,3 +137,46 @@ func translateMySQLErrors(err *mysql.MySQLError) error {
 
 	return err
 }
+
+func translatePostgresErrors(err *pq.Error) error {
+	const (
+		uniqueViolationErrorCode     = "23505"
+		foreignKeyViolationErrorCode = "23503"
+	)
+
+	if err.Code == uniqueViolationErrorCode {
+		duplicatedErrorRegex := regexp.MustCompile(`Key \((.+)\)=\((.*)\) already exists`)
+
+		if match := duplicatedErrorRegex.FindStringSubmatch(err.Detail); len(match) > 0 {
+			return NewErrDuplicatedEntry(match[1], match[2])
+		}
+	}
+
+	if err.Code == foreignKeyViolationErrorCode {
+		foreignKeyErrorRegex := regexp.MustCompile(`Key \((.+)\)=\((.*)\) is not present in table "(.+)"`)
+
+		if match := foreignKeyErrorRegex.FindStringSubmatch(err.Detail); len(match) > 0 {
+			return NewErrForeignKeyConstraint(err.Table, err.Constraint, match[1], match[3])
+		}
+	}
+
+	return err
+}
+
+// translateSQLiteErrors translates the SQLite errors by their messages, as the error type of the driver is only
+// available when it is compiled with cgo. SQLite does not report which foreign key failed.
+func translateSQLiteErrors(err error) error {
+	const foreignKeyErrorMessage = "FOREIGN KEY constraint failed"
+
+	duplicatedErrorRegex := regexp.MustCompile(`UNIQUE constraint failed: ([\w-]+)\.(\w+)`)
+
+	if match := duplicatedErrorRegex.FindStringSubmatch(err.Error()); len(match) > 0 {
+		return NewErrDuplicatedEntry(match[2], "")
+	}
+
+	if strings.Contains(err.Error(), foreignKeyErrorMessage) {
+		return NewErrForeignKeyConstraint("", "", "", "")
+	}
+
+	return nil
+}
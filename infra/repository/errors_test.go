@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+func TestTranslatePostgresErrors(t *testing.T) {
+	unknown := &pq.Error{Code: "23502", Message: `null value in column "currency" violates not-null constraint`}
+
+	tests := []struct {
+		name string
+		err  *pq.Error
+		want error
+	}{
+		{
+			name: "unknown error",
+			err:  unknown,
+			want: unknown,
+		},
+		{
+			name: "unique violation",
+			err: &pq.Error{
+				Code:   "23505",
+				Detail: "Key (document_number)=(00000000191) already exists.",
+			},
+			want: NewErrDuplicatedEntry("document_number", "00000000191"),
+		},
+		{
+			name: "foreign key violation",
+			err: &pq.Error{
+				Code:       "23503",
+				Detail:     `Key (operation_id)=(99) is not present in table "operations".`,
+				Table:      "transactions",
+				Constraint: "transactions_operation_id_fkey",
+			},
+			want: NewErrForeignKeyConstraint("transactions", "transactions_operation_id_fkey", "operation_id", "operations"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := translatePostgresErrors(tt.err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("translatePostgresErrors() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTranslateSQLiteErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{
+			name: "unknown error",
+			err:  errors.New("database is locked"),
+			want: nil,
+		},
+		{
+			name: "unique violation",
+			err:  errors.New("UNIQUE constraint failed: accounts.document_number"),
+			want: NewErrDuplicatedEntry("document_number", ""),
+		},
+		{
+			name: "foreign key violation",
+			err:  errors.New("FOREIGN KEY constraint failed"),
+			want: NewErrForeignKeyConstraint("", "", "", ""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := translateSQLiteErrors(tt.err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("translateSQLiteErrors() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
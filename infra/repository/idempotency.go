@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// Idempotency exposes idempotency keys database operations
+type Idempotency struct {
+	conn    *sql.DB
+	dialect dialect
+}
+
+// NewIdempotency build a new Idempotency struct with its dependencies
+func NewIdempotency(conn *sql.DB) *Idempotency {
+	return &Idempotency{conn: conn, dialect: dialectOf(conn)}
+}
+
+// Store stores a new key, returning false when the key was already stored.
+// The primary key guarantees that only one of concurrent requests with the same key is able to store it.
+func (i Idempotency) Store(ctx context.Context, key *domain.IdempotencyKey) (bool, error) {
+	var query = `
+		INSERT INTO idempotency_keys (idempotency_key, fingerprint, status, created_at)
+		VALUES (?, ?, ?, ?)
+		` + i.dialect.ignoreDuplicate("idempotency_key") + `
+	`
+
+	result, err := executorOf(ctx, i.conn).ExecContext(
+		ctx,
+		i.dialect.query(query),
+		key.Key(),
+		key.Fingerprint(),
+		string(key.Status()),
+		key.CreatedAt().UTC().Format(timestampLayout),
+	)
+	if err != nil {
+		return false, errors.Wrap(err, "database error")
+	}
+
+	stored, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "error to read the affected rows")
+	}
+
+	return stored > 0, nil
+}
+
+// FindByKey finds a stored key
+func (i Idempotency) FindByKey(ctx context.Context, key string) (*domain.IdempotencyKey, error) {
+	var (
+		fingerprint    string
+		status         string
+		responseStatus sql.NullInt64
+		responseBody   []byte
+		createdAt      timestamp
+		query          = `
+			SELECT fingerprint, status, response_status, response_body, created_at
+			FROM idempotency_keys
+			WHERE idempotency_key = ?
+		`
+	)
+
+	row := executorOf(ctx, i.conn).QueryRowContext(ctx, i.dialect.query(query), key)
+
+	if err := row.Scan(&fingerprint, &status, &responseStatus, &responseBody, &createdAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, NewErrRegisterNotFound("idempotency_key", key)
+		}
+
+		return nil, errors.Wrap(err, "database error")
+	}
+
+	idempotencyKey, err := domain.NewIdempotencyKey(key, fingerprint)
+	if err != nil {
+		return nil, NewErrLoadInvalidData("idempotency_keys")
+	}
+
+	if domain.IdempotencyKeyStatus(status) == domain.IdempotencyKeyCompleted {
+		idempotencyKey = idempotencyKey.Complete(int(responseStatus.Int64), responseBody)
+	}
+
+	return idempotencyKey.WithCreatedAt(createdAt.Time), nil
+}
+
+// TakeOver replaces a key still in processing created before the informed moment, returning false when the key was
+// completed or taken over meanwhile. Only one of concurrent retries matches the condition of the update.
+func (i Idempotency) TakeOver(ctx context.Context, key *domain.IdempotencyKey, createdBefore time.Time) (bool, error) {
+	var query = `
+		UPDATE idempotency_keys
+		SET fingerprint = ?, created_at = ?
+		WHERE idempotency_key = ? AND status = ? AND created_at < ?
+	`
+
+	result, err := executorOf(ctx, i.conn).ExecContext(
+		ctx,
+		i.dialect.query(query),
+		key.Fingerprint(),
+		key.CreatedAt().UTC().Format(timestampLayout),
+		key.Key(),
+		string(domain.IdempotencyKeyProcessing),
+		createdBefore.UTC().Format(timestampLayout),
+	)
+	if err != nil {
+		return false, errors.Wrap(err, "database error")
+	}
+
+	takenOver, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "error to read the affected rows")
+	}
+
+	return takenOver > 0, nil
+}
+
+// Update updates the status and the response of a key
+func (i Idempotency) Update(ctx context.Context, key *domain.IdempotencyKey) error {
+	var query = `
+		UPDATE idempotency_keys
+		SET status = ?, response_status = ?, response_body = ?
+		WHERE idempotency_key = ?
+	`
+
+	_, err := executorOf(ctx, i.conn).ExecContext(ctx, i.dialect.query(query), string(key.Status()), key.ResponseStatus(), key.ResponseBody(), key.Key())
+	if err != nil {
+		return errors.Wrap(err, "database error")
+	}
+
+	return nil
+}
+
+// Delete deletes a key still in processing
+func (i Idempotency) Delete(ctx context.Context, key *domain.IdempotencyKey) error {
+	var query = `DELETE FROM idempotency_keys WHERE idempotency_key = ? AND status = ?`
+
+	if _, err := executorOf(ctx, i.conn).ExecContext(ctx, i.dialect.query(query), key.Key(), string(domain.IdempotencyKeyProcessing)); err != nil {
+		return errors.Wrap(err, "database error")
+	}
+
+	return nil
+}
+
+// DeleteCreatedBefore deletes the keys created before the informed moment, returning how many were deleted
+func (i Idempotency) DeleteCreatedBefore(ctx context.Context, createdBefore time.Time) (int64, error) {
+	var query = `DELETE FROM idempotency_keys WHERE created_at < ?`
+
+	result, err := executorOf(ctx, i.conn).ExecContext(ctx, i.dialect.query(query), createdBefore.UTC().Format(timestampLayout))
+	if err != nil {
+		return 0, errors.Wrap(err, "database error")
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "error to read the affected rows")
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,438 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// invoiceColumns are the columns loaded by scanInvoice, in the same order
+const invoiceColumns = `id, account_id, period_start, period_end, due_date, previous_balance, currency, created_at`
+
+// Invoice exposes invoice database operations
+type Invoice struct {
+	conn       *sql.DB
+	dialect    dialect
+	operations *operationCache
+}
+
+// NewInvoice build a new Invoice struct with its dependencies
+func NewInvoice(conn *sql.DB) *Invoice {
+	return &Invoice{conn: conn, dialect: dialectOf(conn), operations: newOperationCache()}
+}
+
+// FindOneByID finds and return one invoice, with its items, based in the informed ID
+func (i Invoice) FindOneByID(ctx context.Context, id *domain.ID) (*domain.Invoice, error) {
+	var query = `SELECT ` + invoiceColumns + ` FROM invoices WHERE id = ?`
+
+	invoices, err := i.findInvoices(ctx, query, id.Value())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(invoices) == 0 {
+		return nil, NewErrRegisterNotFound("id", strconv.FormatUint(id.Value(), 10))
+	}
+
+	return invoices[0], nil
+}
+
+// FindByAccount finds the invoices of the account, from the newest to the oldest
+func (i Invoice) FindByAccount(ctx context.Context, accountID *domain.ID) ([]*domain.Invoice, error) {
+	var query = `
+		SELECT ` + invoiceColumns + `
+		FROM invoices
+		WHERE account_id = ?
+		ORDER BY period_end DESC
+	`
+
+	return i.findInvoices(ctx, query, accountID.Value())
+}
+
+// FindLastByAccount finds the invoice of the last billing cycle closed of the account, nil when there is none
+func (i Invoice) FindLastByAccount(ctx context.Context, accountID *domain.ID) (*domain.Invoice, error) {
+	var query = `
+		SELECT ` + invoiceColumns + `
+		FROM invoices
+		WHERE account_id = ?
+		ORDER BY period_end DESC
+		LIMIT 1
+	`
+
+	invoices, err := i.findInvoices(ctx, query, accountID.Value())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(invoices) == 0 {
+		return nil, nil
+	}
+
+	return invoices[0], nil
+}
+
+// FindOverdueInvoices finds the last invoice of each account not closed, when it is due before the informed day
+func (i Invoice) FindOverdueInvoices(ctx context.Context, at time.Time) ([]*domain.Invoice, error) {
+	var query = `
+		SELECT ` + invoiceColumns + `
+		FROM invoices
+		WHERE due_date < ?
+			AND account_id IN (SELECT id FROM accounts WHERE status <> ?)
+			AND period_end = (SELECT MAX(l.period_end) FROM invoices l WHERE l.account_id = invoices.account_id)
+		ORDER BY account_id
+	`
+
+	return i.findInvoices(ctx, query, at.UTC().Format(dateLayout), domain.AccountStatusClosed.String())
+}
+
+// FindDueInstallments finds the installments of the account purchases due in the period
+func (i Invoice) FindDueInstallments(ctx context.Context, accountID *domain.ID, from, to time.Time) ([]*domain.InvoiceItem, error) {
+	var query = `
+		SELECT i.transaction_id, t.operation_id, i.number, i.amount, t.currency, i.due_date,
+			(SELECT COUNT(*) FROM installments c WHERE c.transaction_id = i.transaction_id)
+		FROM installments i
+		INNER JOIN transactions t ON t.id = i.transaction_id
+		WHERE t.account_id = ? AND i.due_date BETWEEN ? AND ?
+		ORDER BY i.due_date, i.transaction_id
+	`
+
+	var items []*domain.InvoiceItem
+
+	err := i.operations.with(ctx, executorOf(ctx, i.conn), func(operations *domain.Operations) error {
+		rows, err := executorOf(ctx, i.conn).QueryContext(
+			ctx,
+			i.dialect.query(query),
+			accountID.Value(),
+			from.UTC().Format(dateLayout),
+			to.UTC().Format(dateLayout),
+		)
+		if err != nil {
+			return errors.Wrap(err, "database error")
+		}
+		defer rows.Close()
+
+		items, err = scanInstallments(rows, operations)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// scanInstallments scans the installments loaded by FindDueInstallments as invoice items
+func scanInstallments(rows *sql.Rows, operations *domain.Operations) ([]*domain.InvoiceItem, error) {
+	var items []*domain.InvoiceItem
+
+	for rows.Next() {
+		var (
+			transactionID uint64
+			operationID   uint64
+			number        int
+			amount        decimal
+			currency      string
+			dueDate       timestamp
+			count         int
+		)
+
+		if err := rows.Scan(&transactionID, &operationID, &number, &amount, &currency, &dueDate, &count); err != nil {
+			return nil, errors.Wrap(err, "error to scan the installment")
+		}
+
+		money, err := decimalToMoney(string(amount), domain.Currency(currency))
+		if err != nil {
+			return nil, NewErrLoadInvalidData("installments")
+		}
+
+		operation, err := operations.Find(domain.NewID(operationID))
+		if err != nil {
+			return nil, errUnknownOperation
+		}
+
+		items = append(items, domain.NewInstallmentInvoiceItem(
+			domain.NewID(transactionID),
+			operation.Description(),
+			domain.NewInstallment(number, money, dueDate.Time),
+			count,
+		))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "error to read the installments")
+	}
+
+	return items, nil
+}
+
+// FindAccountsToClose finds the accounts closing in the informed day, created until the closing, without an invoice
+// for the billing cycle ending at the closing
+func (i Invoice) FindAccountsToClose(ctx context.Context, closingDay int, closing time.Time) ([]*domain.ID, error) {
+	var (
+		end   = closing.UTC().Format(timestampLayout)
+		query = `
+			SELECT a.id
+			FROM accounts a
+			LEFT JOIN invoices i ON i.account_id = a.id AND i.period_end = ?
+			WHERE a.closing_day = ? AND a.created_at <= ? AND i.id IS NULL
+			ORDER BY a.id
+		`
+	)
+
+	rows, err := executorOf(ctx, i.conn).QueryContext(ctx, i.dialect.query(query), end, closingDay, end)
+	if err != nil {
+		return nil, errors.Wrap(err, "database error")
+	}
+	defer rows.Close()
+
+	var ids []*domain.ID
+
+	for rows.Next() {
+		var id uint64
+
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.Wrap(err, "error to scan the account id")
+		}
+
+		ids = append(ids, domain.NewID(id))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "error to read the accounts")
+	}
+
+	return ids, nil
+}
+
+// Store stores an invoice and its items in the same database transaction
+func (i Invoice) Store(ctx context.Context, invoice *domain.Invoice) (*domain.ID, error) {
+	tx, err := beginTx(ctx, i.conn)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var query = `
+		INSERT INTO invoices (account_id, period_start, period_end, due_date, previous_balance, currency)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	id, err := i.dialect.insert(
+		ctx,
+		tx,
+		query,
+		invoice.AccountID().Value(),
+		invoice.From().UTC().Format(timestampLayout),
+		invoice.To().UTC().Format(timestampLayout),
+		invoice.DueDate().Format(dateLayout),
+		invoice.PreviousBalance().String(),
+		invoice.PreviousBalance().Currency().String(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.storeItems(ctx, tx, id, invoice.Items()); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit transaction error")
+	}
+
+	return domain.NewID(id), nil
+}
+
+// storeItems stores the transactions and installments billed in the invoice
+func (i Invoice) storeItems(ctx context.Context, tx executor, invoiceID uint64, items []*domain.InvoiceItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	var query = `
+		INSERT INTO invoice_items (invoice_id, transaction_id, description, amount, date, installment, installments)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	stmt, err := tx.PrepareContext(ctx, i.dialect.query(query))
+	if err != nil {
+		return errors.Wrap(err, "prepare statement error")
+	}
+	defer stmt.Close()
+
+	for _, v := range items {
+		var (
+			installment  interface{}
+			installments interface{}
+		)
+
+		if v.Installment() != nil {
+			installment = v.Installment().Number()
+			installments = v.Installments()
+		}
+
+		_, err := stmt.ExecContext(
+			ctx,
+			invoiceID,
+			v.TransactionID().Value(),
+			v.Description(),
+			v.Amount().String(),
+			v.Date().UTC().Format(timestampLayout),
+			installment,
+			installments,
+		)
+		if err != nil {
+			return errors.Wrap(err, "error to store the invoice items")
+		}
+	}
+
+	return nil
+}
+
+// findInvoices finds the invoices loaded by the query, with their items
+func (i Invoice) findInvoices(ctx context.Context, query string, args ...interface{}) ([]*domain.Invoice, error) {
+	rows, err := executorOf(ctx, i.conn).QueryContext(ctx, i.dialect.query(query), args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "database error")
+	}
+	defer rows.Close()
+
+	var invoices []*domain.Invoice
+
+	for rows.Next() {
+		invoice, err := scanInvoice(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		invoices = append(invoices, invoice)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "error to read the invoices")
+	}
+
+	return i.loadItems(ctx, invoices)
+}
+
+// loadItems loads the items of the invoices using a single query, recalculating their totals
+func (i Invoice) loadItems(ctx context.Context, invoices []*domain.Invoice) ([]*domain.Invoice, error) {
+	if len(invoices) == 0 {
+		return invoices, nil
+	}
+
+	var (
+		placeholders = make([]string, len(invoices))
+		args         = make([]interface{}, len(invoices))
+		items        = make(map[uint64][]*domain.InvoiceItem)
+	)
+
+	for k, v := range invoices {
+		placeholders[k] = "?"
+		args[k] = v.ID().Value()
+	}
+
+	query := `
+		SELECT ii.invoice_id, ii.transaction_id, ii.description, ii.amount, i.currency, ii.date, ii.installment,
+			ii.installments
+		FROM invoice_items ii
+		INNER JOIN invoices i ON i.id = ii.invoice_id
+		WHERE ii.invoice_id IN (` + strings.Join(placeholders, ", ") + `)
+		ORDER BY ii.invoice_id, ii.date, ii.id
+	`
+
+	rows, err := executorOf(ctx, i.conn).QueryContext(ctx, i.dialect.query(query), args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "database error")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			invoiceID     uint64
+			transactionID uint64
+			description   string
+			amount        decimal
+			currency      string
+			date          timestamp
+			installment   sql.NullInt64
+			installments  sql.NullInt64
+		)
+
+		err := rows.Scan(&invoiceID, &transactionID, &description, &amount, &currency, &date, &installment, &installments)
+		if err != nil {
+			return nil, errors.Wrap(err, "error to scan the invoice item")
+		}
+
+		money, err := decimalToMoney(string(amount), domain.Currency(currency))
+		if err != nil {
+			return nil, NewErrLoadInvalidData("invoice_items")
+		}
+
+		var item *domain.InvoiceItem
+
+		if installment.Valid {
+			item = domain.NewInstallmentInvoiceItem(
+				domain.NewID(transactionID),
+				description,
+				domain.NewInstallment(int(installment.Int64), money, date.Time),
+				int(installments.Int64),
+			)
+		} else {
+			item = domain.LoadInvoiceItem(domain.NewID(transactionID), description, money, date.Time)
+		}
+
+		items[invoiceID] = append(items[invoiceID], item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "error to read the invoice items")
+	}
+
+	for k, v := range invoices {
+		invoices[k] = v.WithItems(items[v.ID().Value()])
+	}
+
+	return invoices, nil
+}
+
+// scanInvoice scans an invoice loaded with the invoiceColumns, without its items
+func scanInvoice(row rowScanner) (*domain.Invoice, error) {
+	var (
+		id              uint64
+		accountID       uint64
+		periodStart     timestamp
+		periodEnd       timestamp
+		dueDate         timestamp
+		previousBalance decimal
+		currency        string
+		createdAt       timestamp
+	)
+
+	err := row.Scan(&id, &accountID, &periodStart, &periodEnd, &dueDate, &previousBalance, &currency, &createdAt)
+	if err != nil {
+		return nil, errors.Wrap(err, "error to scan the invoice")
+	}
+
+	previous, err := decimalToMoney(string(previousBalance), domain.Currency(currency))
+	if err != nil {
+		return nil, NewErrLoadInvalidData("invoices")
+	}
+
+	return domain.LoadInvoice(
+		domain.NewID(id),
+		domain.NewID(accountID),
+		periodStart.Time,
+		periodEnd.Time,
+		dueDate.Time,
+		previous,
+		nil,
+		createdAt.Time,
+	), nil
+}
@@ -0,0 +1,124 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+)
+
+// Account exposes account in memory operations
+type Account struct {
+	store *Store
+}
+
+// NewAccount build a new Account struct with its dependencies
+func NewAccount(store *Store) *Account {
+	return &Account{store: store}
+}
+
+// Store stores an account, rejecting a document number already stored
+func (a Account) Store(ctx context.Context, acc *domain.Account) (*domain.ID, error) {
+	var id uint64
+
+	err := a.store.write(ctx, func(t *tx) error {
+		for _, v := range t.accounts() {
+			if v.Document().Number() == acc.Document().Number() {
+				return repository.NewErrDuplicatedEntry("document_number", acc.Document().Number().String())
+			}
+		}
+
+		id = t.nextID("accounts")
+
+		t.accounts()[id] = acc.
+			WithID(domain.NewID(id)).
+			WithStatus(acc.Status(), "", time.Time{}).
+			WithCreateAt(a.store.now().UTC())
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewID(id), nil
+}
+
+// FindOneByID finds and return one account based in the informed ID
+func (a Account) FindOneByID(ctx context.Context, id *domain.ID) (*domain.Account, error) {
+	var account *domain.Account
+
+	err := a.store.read(ctx, func(t *tables) error {
+		v, ok := t.accounts[id.Value()]
+		if !ok {
+			return repository.NewErrRegisterNotFound("id", strconv.FormatUint(id.Value(), 10))
+		}
+
+		account = v
+
+		return nil
+	})
+
+	return account, err
+}
+
+// BalanceByID sums all transactions of the account created until the informed moment, in the account currency
+func (a Account) BalanceByID(ctx context.Context, id *domain.ID, at time.Time) (domain.Money, error) {
+	var balance domain.Money
+
+	err := a.store.read(ctx, func(t *tables) error {
+		account, ok := t.accounts[id.Value()]
+		if !ok {
+			return repository.NewErrRegisterNotFound("id", strconv.FormatUint(id.Value(), 10))
+		}
+
+		balance = domain.NewMoney(0, account.Currency())
+
+		for _, v := range t.transactions {
+			if v.Account().ID().Value() == id.Value() && !v.CreatedAt().After(at) {
+				balance = balance.Add(v.Amount())
+			}
+		}
+
+		return nil
+	})
+
+	return balance, err
+}
+
+// StoreStatusChange updates the account status and registers the change in its history. The update only succeeds
+// when the account still has the status it was changed from, so concurrent changes cannot override each other.
+func (a Account) StoreStatusChange(ctx context.Context, change *domain.AccountStatusChange) error {
+	return a.store.write(ctx, func(t *tx) error {
+		account, ok := t.accounts()[change.AccountID().Value()]
+		if !ok || account.Status() != change.From() {
+			return domain.NewErrDomain("status", fmt.Sprintf("'%s' is no longer the status of the account", change.From()))
+		}
+
+		t.accounts()[account.ID().Value()] = account.WithStatus(change.To(), change.Reason(), change.ChangedAt())
+		t.addStatusChange(change)
+
+		return nil
+	})
+}
+
+// lockAccount loads an account to be updated. A missing account is reported as a foreign key error of the table and
+// column referencing it.
+func lockAccount(t *tx, id *domain.ID, table, foreignKey string) (*domain.Account, error) {
+	account, ok := t.accounts()[id.Value()]
+	if !ok {
+		return nil, repository.NewErrForeignKeyConstraint(table, "", foreignKey, "id")
+	}
+
+	return account, nil
+}
+
+// updateAvailableCreditLimit stores the available credit limit of an account
+func updateAvailableCreditLimit(t *tx, account *domain.Account) {
+	if stored, ok := t.accounts()[account.ID().Value()]; ok {
+		t.accounts()[account.ID().Value()] = stored.WithAvailableCreditLimit(account.AvailableCreditLimit())
+	}
+}
@@ -0,0 +1,117 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+)
+
+// Idempotency exposes idempotency keys in memory operations
+type Idempotency struct {
+	store *Store
+}
+
+// NewIdempotency build a new Idempotency struct with its dependencies
+func NewIdempotency(store *Store) *Idempotency {
+	return &Idempotency{store: store}
+}
+
+// Store stores a new key, returning false when the key was already stored
+func (i Idempotency) Store(ctx context.Context, key *domain.IdempotencyKey) (bool, error) {
+	var stored bool
+
+	err := i.store.write(ctx, func(t *tx) error {
+		if _, ok := t.idempotencyKeys()[key.Key()]; ok {
+			return nil
+		}
+
+		t.idempotencyKeys()[key.Key()] = key.WithCreatedAt(key.CreatedAt().UTC())
+		stored = true
+
+		return nil
+	})
+
+	return stored, err
+}
+
+// FindByKey finds a stored key
+func (i Idempotency) FindByKey(ctx context.Context, key string) (*domain.IdempotencyKey, error) {
+	var idempotencyKey *domain.IdempotencyKey
+
+	err := i.store.read(ctx, func(t *tables) error {
+		v, ok := t.idempotencyKeys[key]
+		if !ok {
+			return repository.NewErrRegisterNotFound("idempotency_key", key)
+		}
+
+		idempotencyKey = v
+
+		return nil
+	})
+
+	return idempotencyKey, err
+}
+
+// TakeOver replaces a key still in processing created before the informed moment, returning false when the key was
+// completed or taken over meanwhile
+func (i Idempotency) TakeOver(ctx context.Context, key *domain.IdempotencyKey, createdBefore time.Time) (bool, error) {
+	var takenOver bool
+
+	err := i.store.write(ctx, func(t *tx) error {
+		stored, ok := t.idempotencyKeys()[key.Key()]
+		if !ok || stored.IsCompleted() || !stored.CreatedAt().Before(createdBefore) {
+			return nil
+		}
+
+		t.idempotencyKeys()[key.Key()] = key.WithCreatedAt(key.CreatedAt().UTC())
+		takenOver = true
+
+		return nil
+	})
+
+	return takenOver, err
+}
+
+// Update updates the status and the response of a key
+func (i Idempotency) Update(ctx context.Context, key *domain.IdempotencyKey) error {
+	return i.store.write(ctx, func(t *tx) error {
+		if stored, ok := t.idempotencyKeys()[key.Key()]; ok {
+			t.idempotencyKeys()[key.Key()] = key.WithCreatedAt(stored.CreatedAt())
+		}
+
+		return nil
+	})
+}
+
+// Delete deletes a key still in processing
+func (i Idempotency) Delete(ctx context.Context, key *domain.IdempotencyKey) error {
+	return i.store.write(ctx, func(t *tx) error {
+		if stored, ok := t.idempotencyKeys()[key.Key()]; ok && stored.Status() == domain.IdempotencyKeyProcessing {
+			delete(t.idempotencyKeys(), key.Key())
+		}
+
+		return nil
+	})
+}
+
+// DeleteCreatedBefore deletes the keys created before the informed moment, returning how many were deleted
+func (i Idempotency) DeleteCreatedBefore(ctx context.Context, createdBefore time.Time) (int64, error) {
+	var deleted int64
+
+	err := i.store.write(ctx, func(t *tx) error {
+		for k, v := range t.idempotencyKeys() {
+			if !v.CreatedAt().Before(createdBefore) {
+				continue
+			}
+
+			delete(t.idempotencyKeys(), k)
+			deleted++
+		}
+
+		return nil
+	})
+
+	return deleted, err
+}
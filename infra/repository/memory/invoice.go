@@ -0,0 +1,231 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+)
+
+// Invoice exposes invoice in memory operations
+type Invoice struct {
+	store *Store
+}
+
+// NewInvoice build a new Invoice struct with its dependencies
+func NewInvoice(store *Store) *Invoice {
+	return &Invoice{store: store}
+}
+
+// FindOneByID finds and return one invoice, with its items, based in the informed ID
+func (i Invoice) FindOneByID(ctx context.Context, id *domain.ID) (*domain.Invoice, error) {
+	var invoice *domain.Invoice
+
+	err := i.store.read(ctx, func(t *tables) error {
+		v, ok := t.invoices[id.Value()]
+		if !ok {
+			return repository.NewErrRegisterNotFound("id", strconv.FormatUint(id.Value(), 10))
+		}
+
+		invoice = v
+
+		return nil
+	})
+
+	return invoice, err
+}
+
+// FindByAccount finds the invoices of the account, from the newest to the oldest
+func (i Invoice) FindByAccount(ctx context.Context, accountID *domain.ID) ([]*domain.Invoice, error) {
+	var invoices []*domain.Invoice
+
+	err := i.store.read(ctx, func(t *tables) error {
+		invoices = invoicesOf(t.invoices, accountID)
+
+		return nil
+	})
+
+	return invoices, err
+}
+
+// FindLastByAccount finds the invoice of the last billing cycle closed of the account, nil when there is none
+func (i Invoice) FindLastByAccount(ctx context.Context, accountID *domain.ID) (*domain.Invoice, error) {
+	var invoice *domain.Invoice
+
+	err := i.store.read(ctx, func(t *tables) error {
+		if invoices := invoicesOf(t.invoices, accountID); len(invoices) > 0 {
+			invoice = invoices[0]
+		}
+
+		return nil
+	})
+
+	return invoice, err
+}
+
+// FindOverdueInvoices finds the last invoice of each account not closed, when it is due before the informed day
+func (i Invoice) FindOverdueInvoices(ctx context.Context, at time.Time) ([]*domain.Invoice, error) {
+	var invoices []*domain.Invoice
+
+	err := i.store.read(ctx, func(t *tables) error {
+		for _, id := range accountIDs(t) {
+			if t.accounts[id].Status() == domain.AccountStatusClosed {
+				continue
+			}
+
+			last := invoicesOf(t.invoices, domain.NewID(id))
+			if len(last) > 0 && last[0].DueDate().Before(day(at)) {
+				invoices = append(invoices, last[0])
+			}
+		}
+
+		return nil
+	})
+
+	return invoices, err
+}
+
+// FindDueInstallments finds the installments of the account purchases due in the period
+func (i Invoice) FindDueInstallments(ctx context.Context, accountID *domain.ID, from, to time.Time) ([]*domain.InvoiceItem, error) {
+	var items []*domain.InvoiceItem
+
+	err := i.store.read(ctx, func(t *tables) error {
+		for transactionID, plan := range t.installments {
+			transaction := t.transactions[transactionID]
+			if transaction.Account().ID().Value() != accountID.Value() {
+				continue
+			}
+
+			for _, v := range plan.Installments() {
+				if v.DueDate().Before(day(from)) || v.DueDate().After(day(to)) {
+					continue
+				}
+
+				items = append(items, domain.NewInstallmentInvoiceItem(
+					transaction.ID(),
+					transaction.Operation().Description(),
+					v,
+					plan.Count(),
+				))
+			}
+		}
+
+		return nil
+	})
+
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].Date().Equal(items[j].Date()) {
+			return items[i].Date().Before(items[j].Date())
+		}
+
+		return items[i].TransactionID().Value() < items[j].TransactionID().Value()
+	})
+
+	return items, err
+}
+
+// FindAccountsToClose finds the accounts closing in the informed day, created until the closing, without an invoice
+// for the billing cycle ending at the closing
+func (i Invoice) FindAccountsToClose(ctx context.Context, closingDay int, closing time.Time) ([]*domain.ID, error) {
+	var ids []*domain.ID
+
+	err := i.store.read(ctx, func(t *tables) error {
+		for _, id := range accountIDs(t) {
+			account := t.accounts[id]
+
+			if account.BillingCycle().ClosingDay() != closingDay || account.CreatedAt().After(closing) {
+				continue
+			}
+
+			if last := invoicesOf(t.invoices, account.ID()); len(last) > 0 && closedAt(last, closing) {
+				continue
+			}
+
+			ids = append(ids, account.ID())
+		}
+
+		return nil
+	})
+
+	return ids, err
+}
+
+// Store stores an invoice and its items, rejecting a second invoice of the account for the same billing cycle
+func (i Invoice) Store(ctx context.Context, invoice *domain.Invoice) (*domain.ID, error) {
+	var id uint64
+
+	err := i.store.write(ctx, func(t *tx) error {
+		if _, ok := t.accounts()[invoice.AccountID().Value()]; !ok {
+			return repository.NewErrForeignKeyConstraint("invoices", "", "account_id", "id")
+		}
+
+		if closedAt(invoicesOf(t.invoices(), invoice.AccountID()), invoice.To()) {
+			value := fmt.Sprintf("%d-%s", invoice.AccountID().Value(), invoice.To().UTC().Format("2006-01-02 15:04:05"))
+
+			return repository.NewErrDuplicatedEntry("invoices_account_id_period_end", value)
+		}
+
+		id = t.nextID("invoices")
+
+		t.invoices()[id] = domain.LoadInvoice(
+			domain.NewID(id),
+			invoice.AccountID(),
+			invoice.From(),
+			invoice.To(),
+			invoice.DueDate(),
+			invoice.PreviousBalance(),
+			invoice.Items(),
+			i.store.now().UTC(),
+		)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewID(id), nil
+}
+
+// invoicesOf returns the invoices of the account, from the newest to the oldest
+func invoicesOf(all map[uint64]*domain.Invoice, accountID *domain.ID) []*domain.Invoice {
+	var invoices []*domain.Invoice
+
+	for _, v := range all {
+		if v.AccountID().Value() == accountID.Value() {
+			invoices = append(invoices, v)
+		}
+	}
+
+	sort.Slice(invoices, func(i, j int) bool {
+		return invoices[i].To().After(invoices[j].To())
+	})
+
+	return invoices
+}
+
+// closedAt checks if one of the invoices is of the billing cycle ending at the closing
+func closedAt(invoices []*domain.Invoice, closing time.Time) bool {
+	for _, v := range invoices {
+		if v.To().Equal(closing) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// accountIDs returns the ids of the accounts in ascending order
+func accountIDs(t *tables) []uint64 {
+	ids := make([]uint64, 0, len(t.accounts))
+
+	for id := range t.accounts {
+		ids = append(ids, id)
+	}
+
+	return sortedIDs(ids)
+}
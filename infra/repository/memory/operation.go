@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+)
+
+// Operation exposes operation in memory operations
+type Operation struct {
+	store *Store
+}
+
+// NewOperation build a new Operation struct with its dependencies
+func NewOperation(store *Store) *Operation {
+	return &Operation{store: store}
+}
+
+// FindAll finds and returns all the operations, enabled or not, ordered by id
+func (o Operation) FindAll(ctx context.Context) ([]*domain.Operation, error) {
+	var operations []*domain.Operation
+
+	err := o.store.read(ctx, func(t *tables) error {
+		for _, v := range t.operations {
+			operations = append(operations, v)
+		}
+
+		return nil
+	})
+
+	sort.Slice(operations, func(i, j int) bool {
+		return operations[i].ID().Value() < operations[j].ID().Value()
+	})
+
+	return operations, err
+}
+
+// FindOneByID finds and returns one operation based in the informed ID
+func (o Operation) FindOneByID(ctx context.Context, id *domain.ID) (*domain.Operation, error) {
+	var operation *domain.Operation
+
+	err := o.store.read(ctx, func(t *tables) error {
+		v, ok := t.operations[id.Value()]
+		if !ok {
+			return repository.NewErrRegisterNotFound("id", strconv.FormatUint(id.Value(), 10))
+		}
+
+		operation = v
+
+		return nil
+	})
+
+	return operation, err
+}
+
+// Store stores an operation, rejecting a description already stored regardless of its case
+func (o Operation) Store(ctx context.Context, operation *domain.Operation) (*domain.ID, error) {
+	var id uint64
+
+	err := o.store.write(ctx, func(t *tx) error {
+		for _, v := range t.operations() {
+			if strings.EqualFold(v.Description(), operation.Description()) {
+				return repository.NewErrDuplicatedEntry("description", operation.Description())
+			}
+		}
+
+		id = t.nextID("operations")
+		t.operations()[id] = operation.WithID(domain.NewID(id))
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewID(id), nil
+}
+
+// UpdateEnabled updates whether the operation accepts new transactions
+func (o Operation) UpdateEnabled(ctx context.Context, operation *domain.Operation) error {
+	return o.store.write(ctx, func(t *tx) error {
+		if stored, ok := t.operations()[operation.ID().Value()]; ok {
+			t.operations()[operation.ID().Value()] = stored.WithEnabled(operation.IsEnabled())
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,105 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// Outbox exposes the events in memory operations. Events are stored with the entity that raised them, and read from
+// there to be published.
+type Outbox struct {
+	store *Store
+}
+
+// NewOutbox build a new Outbox struct with its dependencies
+func NewOutbox(store *Store) *Outbox {
+	return &Outbox{store: store}
+}
+
+// Store stores an event not published yet, in the unit of work carried by the context when there is one
+func (o Outbox) Store(ctx context.Context, event *domain.Event) (*domain.ID, error) {
+	var id uint64
+
+	err := o.store.write(ctx, func(t *tx) error {
+		id = t.nextID("outbox")
+
+		t.events()[id] = domain.LoadEvent(
+			domain.NewID(id),
+			event.Type(),
+			event.AggregateID(),
+			event.Payload(),
+			event.OccurredAt().UTC(),
+		)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewID(id), nil
+}
+
+// MarkPublished registers the moment an event was published
+func (o Outbox) MarkPublished(ctx context.Context, event *domain.Event, at time.Time) error {
+	return o.store.write(ctx, func(t *tx) error {
+		if _, ok := t.events()[event.ID().Value()]; ok {
+			t.publishedAt()[event.ID().Value()] = at.UTC()
+		}
+
+		return nil
+	})
+}
+
+// ClaimUnpublished claims the events not published yet, from the oldest to the newest, until the informed moment
+func (o Outbox) ClaimUnpublished(ctx context.Context, limit int, until time.Time) ([]*domain.Event, error) {
+	var (
+		events []*domain.Event
+		now    = time.Now()
+	)
+
+	err := o.store.write(ctx, func(t *tx) error {
+		ids := make([]uint64, 0, len(t.events()))
+
+		for id := range t.events() {
+			if _, published := t.publishedAt()[id]; published {
+				continue
+			}
+
+			if claimedUntil, claimed := t.claimedUntil()[id]; claimed && !claimedUntil.Before(now) {
+				continue
+			}
+
+			ids = append(ids, id)
+		}
+
+		for _, id := range sortedIDs(ids) {
+			if len(events) == limit {
+				break
+			}
+
+			t.claimedUntil()[id] = until.UTC()
+			events = append(events, t.events()[id])
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// ReleaseClaims releases the claims of the events, so they can be claimed right away
+func (o Outbox) ReleaseClaims(ctx context.Context, events []*domain.Event) error {
+	return o.store.write(ctx, func(t *tx) error {
+		for _, event := range events {
+			delete(t.claimedUntil(), event.ID().Value())
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+)
+
+// Reversal exposes reversal in memory operations
+type Reversal struct {
+	store *Store
+}
+
+// NewReversal build a new Reversal struct with its dependencies
+func NewReversal(store *Store) *Reversal {
+	return &Reversal{store: store}
+}
+
+// Store stores the compensating transaction of a reversal, updating the reversed amount of the original transaction
+// and the available credit limit of its account
+func (r Reversal) Store(ctx context.Context, reversal *domain.Reversal) (*domain.Reversal, error) {
+	err := r.store.write(ctx, func(t *tx) error {
+		id := reversal.Original().ID()
+
+		original, ok := t.transactions()[id.Value()]
+		if !ok {
+			return repository.NewErrRegisterNotFound("id", strconv.FormatUint(id.Value(), 10))
+		}
+
+		account, err := lockAccount(t, original.Account().ID(), "transactions", "account_id")
+		if err != nil {
+			return err
+		}
+
+		reversal, err = reversal.Apply(original, account)
+		if err != nil {
+			return err
+		}
+
+		updateAvailableCreditLimit(t, reversal.Transaction().Account())
+
+		updated := reversal.Original()
+		t.transactions()[id.Value()] = original.WithReversedAmount(updated.ReversedAmount()).WithBalance(updated.Balance())
+
+		transactionID, err := insertTransaction(t, reversal.Transaction(), r.store.now())
+		if err != nil {
+			return err
+		}
+
+		reversal = reversal.WithID(domain.NewID(transactionID))
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reversal, nil
+}
@@ -0,0 +1,440 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+)
+
+// txContextKey identifies the tables of a unit of work in the context
+type txContextKey struct{}
+
+// Store keeps the data of all the repositories in memory, as a development and test backend with no database.
+// Each write runs on a copy of the tables, which replaces them only when the write succeeds, so a failed write leaves
+// no partial changes, as a database transaction would. Only the tables changed by the write are copied.
+type Store struct {
+	mu     sync.RWMutex
+	tables *tables
+	now    func() time.Time
+}
+
+// NewStore builds a new Store struct with the built-in operations. The moment each register is created is read from now.
+func NewStore(now func() time.Time) *Store {
+	t := newTables()
+
+	for _, v := range []*domain.Operation{
+		domain.OperationCompraAVista,
+		domain.OperationCompraParcelada,
+		domain.OperationSaque,
+		domain.OperationPagamento,
+		domain.OperationTransferenciaEnviada,
+		domain.OperationTransferenciaRecebida,
+		domain.OperationEstorno,
+		domain.OperationJurosRotativo,
+		domain.OperationMultaAtraso,
+		domain.OperationJurosMora,
+	} {
+		t.operations[v.ID().Value()] = v
+		t.sequences["operations"] = v.ID().Value()
+	}
+
+	return &Store{tables: t, now: now}
+}
+
+// Repositories builds the repositories of the store
+func (s *Store) Repositories() *repository.Repositories {
+	var (
+		account         = NewAccount(s)
+		transaction     = NewTransaction(s)
+		operation       = NewOperation(s)
+		invoice         = NewInvoice(s)
+		outbox          = NewOutbox(s)
+		webhook         = NewWebhook(s)
+		webhookDelivery = NewWebhookDelivery(s)
+	)
+
+	return &repository.Repositories{
+		AccountReader:         account,
+		AccountWriter:         account,
+		AccountStatusWriter:   account,
+		TransactionReader:     transaction,
+		TransactionWriter:     transaction,
+		StatementReader:       transaction,
+		TransferWriter:        NewTransfer(s),
+		ReversalWriter:        NewReversal(s),
+		OperationReader:       operation,
+		OperationWriter:       operation,
+		InvoiceReader:         invoice,
+		InvoiceWriter:         invoice,
+		InterestReader:        invoice,
+		EventWriter:           outbox,
+		Idempotency:           NewIdempotency(s),
+		WebhookReader:         webhook,
+		WebhookWriter:         webhook,
+		WebhookDeliveryReader: webhookDelivery,
+		WebhookDeliveryWriter: webhookDelivery,
+		UnitOfWork:            NewUnitOfWork(s),
+	}
+}
+
+// read runs fn with the tables, the ones of the unit of work carried by the context when there is one
+func (s *Store) read(ctx context.Context, fn func(*tables) error) error {
+	if t, ok := ctx.Value(txContextKey{}).(*tx); ok {
+		return fn(t.tables)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return fn(s.tables)
+}
+
+// write runs fn with a copy of the tables, replacing them when fn succeeds. Inside a unit of work, fn runs with its
+// copy, replaced or discarded by the unit of work.
+func (s *Store) write(ctx context.Context, fn func(*tx) error) error {
+	if t, ok := ctx.Value(txContextKey{}).(*tx); ok {
+		return fn(t)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := &tx{tables: s.tables.fork()}
+
+	if err := fn(t); err != nil {
+		return err
+	}
+
+	s.tables = t.tables
+
+	return nil
+}
+
+// UnitOfWork runs functions with a copy of the tables, shared by all repositories through the context
+type UnitOfWork struct {
+	store *Store
+}
+
+// NewUnitOfWork builds a new UnitOfWork struct with its dependencies
+func NewUnitOfWork(store *Store) *UnitOfWork {
+	return &UnitOfWork{store: store}
+}
+
+// Do runs fn with a copy of the tables, which replaces them when fn succeeds and is discarded otherwise. The store is
+// locked until fn returns, so units of work never interleave. When the context already carries a unit of work, fn
+// joins it.
+func (u UnitOfWork) Do(ctx context.Context, fn func(context.Context) error) error {
+	return u.store.write(ctx, func(t *tx) error {
+		return fn(context.WithValue(ctx, txContextKey{}, t))
+	})
+}
+
+// allocation is a payment allocation, with the id of the payment it belongs to
+type allocation struct {
+	paymentID  uint64
+	allocation *domain.PaymentAllocation
+}
+
+// tables contains the registers of each repository by id. The registers are never changed, they are replaced by
+// their updated copies.
+type tables struct {
+	accounts        map[uint64]*domain.Account
+	statusChanges   []*domain.AccountStatusChange
+	operations      map[uint64]*domain.Operation
+	transfers       map[uint64]*domain.Transfer
+	transactions    map[uint64]*domain.Transaction
+	installments    map[uint64]*domain.InstallmentPlan
+	allocations     []allocation
+	invoices        map[uint64]*domain.Invoice
+	idempotencyKeys map[string]*domain.IdempotencyKey
+	events          map[uint64]*domain.Event
+	publishedAt     map[uint64]time.Time
+	claimedUntil    map[uint64]time.Time
+	webhooks        map[uint64]*domain.Webhook
+	deliveries      map[uint64]*domain.WebhookDelivery
+	deadLetters     map[uint64]*domain.WebhookDelivery
+	sequences       map[string]uint64
+}
+
+func newTables() *tables {
+	return &tables{
+		accounts:        make(map[uint64]*domain.Account),
+		operations:      make(map[uint64]*domain.Operation),
+		transfers:       make(map[uint64]*domain.Transfer),
+		transactions:    make(map[uint64]*domain.Transaction),
+		installments:    make(map[uint64]*domain.InstallmentPlan),
+		invoices:        make(map[uint64]*domain.Invoice),
+		idempotencyKeys: make(map[string]*domain.IdempotencyKey),
+		events:          make(map[uint64]*domain.Event),
+		publishedAt:     make(map[uint64]time.Time),
+		claimedUntil:    make(map[uint64]time.Time),
+		webhooks:        make(map[uint64]*domain.Webhook),
+		deliveries:      make(map[uint64]*domain.WebhookDelivery),
+		deadLetters:     make(map[uint64]*domain.WebhookDelivery),
+		sequences:       make(map[string]uint64),
+	}
+}
+
+// fork returns a copy of the tables sharing all of them, which are copied by the write before their first use
+func (t *tables) fork() *tables {
+	c := *t
+
+	return &c
+}
+
+// tx is a write on a fork of the tables. The tables are only reached through the accessors below, each one copying
+// its table before its first use, so the tables of the store are never changed and the ones not used are never
+// copied. The registers are shared, as they are never changed.
+type tx struct {
+	tables *tables
+	copied struct {
+		accounts, statusChanges, operations, transfers, transactions, installments, allocations, invoices,
+		idempotencyKeys, events, publishedAt, claimedUntil, webhooks, deliveries, deadLetters, sequences bool
+	}
+}
+
+// accounts returns the accounts of the write, copied before its first use
+func (t *tx) accounts() map[uint64]*domain.Account {
+	if !t.copied.accounts {
+		c := make(map[uint64]*domain.Account, len(t.tables.accounts))
+		for k, v := range t.tables.accounts {
+			c[k] = v
+		}
+
+		t.tables.accounts, t.copied.accounts = c, true
+	}
+
+	return t.tables.accounts
+}
+
+// operations returns the operations of the write, copied before its first use
+func (t *tx) operations() map[uint64]*domain.Operation {
+	if !t.copied.operations {
+		c := make(map[uint64]*domain.Operation, len(t.tables.operations))
+		for k, v := range t.tables.operations {
+			c[k] = v
+		}
+
+		t.tables.operations, t.copied.operations = c, true
+	}
+
+	return t.tables.operations
+}
+
+// transfers returns the transfers of the write, copied before its first use
+func (t *tx) transfers() map[uint64]*domain.Transfer {
+	if !t.copied.transfers {
+		c := make(map[uint64]*domain.Transfer, len(t.tables.transfers))
+		for k, v := range t.tables.transfers {
+			c[k] = v
+		}
+
+		t.tables.transfers, t.copied.transfers = c, true
+	}
+
+	return t.tables.transfers
+}
+
+// transactions returns the transactions of the write, copied before its first use
+func (t *tx) transactions() map[uint64]*domain.Transaction {
+	if !t.copied.transactions {
+		c := make(map[uint64]*domain.Transaction, len(t.tables.transactions))
+		for k, v := range t.tables.transactions {
+			c[k] = v
+		}
+
+		t.tables.transactions, t.copied.transactions = c, true
+	}
+
+	return t.tables.transactions
+}
+
+// installments returns the installment plans of the write, copied before its first use
+func (t *tx) installments() map[uint64]*domain.InstallmentPlan {
+	if !t.copied.installments {
+		c := make(map[uint64]*domain.InstallmentPlan, len(t.tables.installments))
+		for k, v := range t.tables.installments {
+			c[k] = v
+		}
+
+		t.tables.installments, t.copied.installments = c, true
+	}
+
+	return t.tables.installments
+}
+
+// invoices returns the invoices of the write, copied before its first use
+func (t *tx) invoices() map[uint64]*domain.Invoice {
+	if !t.copied.invoices {
+		c := make(map[uint64]*domain.Invoice, len(t.tables.invoices))
+		for k, v := range t.tables.invoices {
+			c[k] = v
+		}
+
+		t.tables.invoices, t.copied.invoices = c, true
+	}
+
+	return t.tables.invoices
+}
+
+// idempotencyKeys returns the idempotency keys of the write, copied before its first use
+func (t *tx) idempotencyKeys() map[string]*domain.IdempotencyKey {
+	if !t.copied.idempotencyKeys {
+		c := make(map[string]*domain.IdempotencyKey, len(t.tables.idempotencyKeys))
+		for k, v := range t.tables.idempotencyKeys {
+			c[k] = v
+		}
+
+		t.tables.idempotencyKeys, t.copied.idempotencyKeys = c, true
+	}
+
+	return t.tables.idempotencyKeys
+}
+
+// events returns the events of the write, copied before its first use
+func (t *tx) events() map[uint64]*domain.Event {
+	if !t.copied.events {
+		c := make(map[uint64]*domain.Event, len(t.tables.events))
+		for k, v := range t.tables.events {
+			c[k] = v
+		}
+
+		t.tables.events, t.copied.events = c, true
+	}
+
+	return t.tables.events
+}
+
+// publishedAt returns the moments the events were published of the write, copied before its first use
+func (t *tx) publishedAt() map[uint64]time.Time {
+	if !t.copied.publishedAt {
+		c := make(map[uint64]time.Time, len(t.tables.publishedAt))
+		for k, v := range t.tables.publishedAt {
+			c[k] = v
+		}
+
+		t.tables.publishedAt, t.copied.publishedAt = c, true
+	}
+
+	return t.tables.publishedAt
+}
+
+// claimedUntil returns the moments the claims of the events expire of the write, copied before its first use
+func (t *tx) claimedUntil() map[uint64]time.Time {
+	if !t.copied.claimedUntil {
+		c := make(map[uint64]time.Time, len(t.tables.claimedUntil))
+		for k, v := range t.tables.claimedUntil {
+			c[k] = v
+		}
+
+		t.tables.claimedUntil, t.copied.claimedUntil = c, true
+	}
+
+	return t.tables.claimedUntil
+}
+
+// webhooks returns the webhooks of the write, copied before its first use
+func (t *tx) webhooks() map[uint64]*domain.Webhook {
+	if !t.copied.webhooks {
+		c := make(map[uint64]*domain.Webhook, len(t.tables.webhooks))
+		for k, v := range t.tables.webhooks {
+			c[k] = v
+		}
+
+		t.tables.webhooks, t.copied.webhooks = c, true
+	}
+
+	return t.tables.webhooks
+}
+
+// deliveries returns the deliveries of the write, copied before its first use
+func (t *tx) deliveries() map[uint64]*domain.WebhookDelivery {
+	if !t.copied.deliveries {
+		c := make(map[uint64]*domain.WebhookDelivery, len(t.tables.deliveries))
+		for k, v := range t.tables.deliveries {
+			c[k] = v
+		}
+
+		t.tables.deliveries, t.copied.deliveries = c, true
+	}
+
+	return t.tables.deliveries
+}
+
+// deadLetters returns the dead letters of the write, copied before its first use
+func (t *tx) deadLetters() map[uint64]*domain.WebhookDelivery {
+	if !t.copied.deadLetters {
+		c := make(map[uint64]*domain.WebhookDelivery, len(t.tables.deadLetters))
+		for k, v := range t.tables.deadLetters {
+			c[k] = v
+		}
+
+		t.tables.deadLetters, t.copied.deadLetters = c, true
+	}
+
+	return t.tables.deadLetters
+}
+
+// sequences returns the last ids of the tables of the write, copied before its first use
+func (t *tx) sequences() map[string]uint64 {
+	if !t.copied.sequences {
+		c := make(map[string]uint64, len(t.tables.sequences))
+		for k, v := range t.tables.sequences {
+			c[k] = v
+		}
+
+		t.tables.sequences, t.copied.sequences = c, true
+	}
+
+	return t.tables.sequences
+}
+
+// addStatusChange appends a change to a copy of the account status changes
+func (t *tx) addStatusChange(change *domain.AccountStatusChange) {
+	if !t.copied.statusChanges {
+		changes := make([]*domain.AccountStatusChange, len(t.tables.statusChanges), len(t.tables.statusChanges)+1)
+		copy(changes, t.tables.statusChanges)
+
+		t.tables.statusChanges, t.copied.statusChanges = changes, true
+	}
+
+	t.tables.statusChanges = append(t.tables.statusChanges, change)
+}
+
+// addAllocation appends an allocation to a copy of the payment allocations
+func (t *tx) addAllocation(v allocation) {
+	if !t.copied.allocations {
+		allocations := make([]allocation, len(t.tables.allocations), len(t.tables.allocations)+1)
+		copy(allocations, t.tables.allocations)
+
+		t.tables.allocations, t.copied.allocations = allocations, true
+	}
+
+	t.tables.allocations = append(t.tables.allocations, v)
+}
+
+// nextID returns the next id of the table, as an auto increment column
+func (t *tx) nextID(table string) uint64 {
+	sequences := t.sequences()
+	sequences[table]++
+
+	return sequences[table]
+}
+
+// sortedIDs returns the ids of a table in ascending order
+func sortedIDs(ids []uint64) []uint64 {
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return ids
+}
+
+// day returns the day of the moment, as a DATE column
+func day(t time.Time) time.Time {
+	t = t.UTC()
+
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
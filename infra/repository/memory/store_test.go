@@ -0,0 +1,283 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+)
+
+func newAccount(t *testing.T, documentNumber string, creditLimit int64) *domain.Account {
+	account, err := domain.NewAccount(domain.DocumentNumber(documentNumber))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	limit := domain.NewMoney(creditLimit, domain.CurrencyBRL)
+
+	return account.
+		WithCurrency(domain.CurrencyBRL).
+		WithCreditLimit(limit).
+		WithAvailableCreditLimit(limit).
+		WithBillingCycle(domain.DefaultBillingCycle())
+}
+
+func newTransaction(t *testing.T, accountID uint64, operation *domain.Operation, cents int64) *domain.Transaction {
+	transaction, err := domain.NewTransaction(domain.NewID(accountID), operation, domain.NewMoney(cents, domain.CurrencyBRL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return transaction
+}
+
+func TestAccount_Store(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		account = NewAccount(NewStore(time.Now))
+	)
+
+	id, err := account.Store(ctx, newAccount(t, "00000000191", 150050))
+	if err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+
+	got, err := account.FindOneByID(ctx, id)
+	if err != nil {
+		t.Fatalf("FindOneByID() error = %v", err)
+	}
+
+	if got.ID().Value() != 1 || got.Document().Number() != "00000000191" || got.CreditLimit().String() != "1500.50" ||
+		got.Status() != domain.AccountStatusActive || got.CreatedAt().IsZero() {
+		t.Errorf("FindOneByID() = %v %v %v %v %v", got.ID(), got.Document().Number(), got.CreditLimit(),
+			got.Status(), got.CreatedAt())
+	}
+
+	_, err = account.Store(ctx, newAccount(t, "00000000191", 0))
+	if wantErr := repository.NewErrDuplicatedEntry("document_number", "00000000191"); !reflect.DeepEqual(err, wantErr) {
+		t.Errorf("Store() error = %v, wantErr %v", err, wantErr)
+	}
+
+	_, err = account.FindOneByID(ctx, domain.NewID(2))
+	if wantErr := repository.NewErrRegisterNotFound("id", "2"); !reflect.DeepEqual(err, wantErr) {
+		t.Errorf("FindOneByID() error = %v, wantErr %v", err, wantErr)
+	}
+}
+
+func TestTransaction_Store(t *testing.T) {
+	var (
+		ctx         = context.Background()
+		store       = NewStore(time.Now)
+		transaction = NewTransaction(store)
+		account     = NewAccount(store)
+	)
+
+	accountID, err := account.Store(ctx, newAccount(t, "52998224725", 100000))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		transaction   *domain.Transaction
+		wantErr       error
+		wantAvailable string
+		wantBalance   string
+	}{
+		// fails
+		{
+			name:        "unknown account",
+			transaction: newTransaction(t, 99, domain.OperationCompraAVista, 1000),
+			wantErr:     repository.NewErrForeignKeyConstraint("transactions", "", "account_id", "id"),
+		},
+		{
+			name:        "amount greater than the available credit limit",
+			transaction: newTransaction(t, accountID.Value(), domain.OperationCompraAVista, 100001),
+			wantErr:     domain.NewErrDomain("amount", "'1000.01' exceeds the available credit limit '1000.00'"),
+		},
+
+		// successes
+		{
+			name:          "purchase",
+			transaction:   newTransaction(t, accountID.Value(), domain.OperationCompraAVista, 30025),
+			wantAvailable: "699.75",
+			wantBalance:   "-300.25",
+		},
+		{
+			name:          "withdraw",
+			transaction:   newTransaction(t, accountID.Value(), domain.OperationSaque, 10000),
+			wantAvailable: "599.75",
+			wantBalance:   "-400.25",
+		},
+		{
+			name:          "payment discharging the purchase",
+			transaction:   newTransaction(t, accountID.Value(), domain.OperationPagamento, 35025),
+			wantAvailable: "950.00",
+			wantBalance:   "-50.00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := transaction.Store(ctx, tt.transaction)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Store() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.ID().Value() == 0 {
+				t.Errorf("Store() must set the transaction id")
+			}
+
+			acc, err := account.FindOneByID(ctx, accountID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if acc.AvailableCreditLimit().String() != tt.wantAvailable {
+				t.Errorf("AvailableCreditLimit() = %v, want %v", acc.AvailableCreditLimit(), tt.wantAvailable)
+			}
+
+			balance, err := account.BalanceByID(ctx, accountID, time.Now().Add(time.Hour))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if balance.String() != tt.wantBalance {
+				t.Errorf("BalanceByID() = %v, want %v", balance, tt.wantBalance)
+			}
+		})
+	}
+}
+
+func TestTransaction_Store_Concurrently(t *testing.T) {
+	var (
+		ctx         = context.Background()
+		store       = NewStore(time.Now)
+		transaction = NewTransaction(store)
+		account     = NewAccount(store)
+		wg          sync.WaitGroup
+		mu          sync.Mutex
+		stored      int
+	)
+
+	accountID, err := account.Store(ctx, newAccount(t, "52998224725", 50000))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func(purchase *domain.Transaction) {
+			defer wg.Done()
+
+			if _, err := transaction.Store(ctx, purchase); err == nil {
+				mu.Lock()
+				stored++
+				mu.Unlock()
+			}
+		}(newTransaction(t, accountID.Value(), domain.OperationCompraAVista, 10000))
+	}
+
+	wg.Wait()
+
+	acc, err := account.FindOneByID(ctx, accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stored != 5 || acc.AvailableCreditLimit().String() != "0.00" {
+		t.Errorf("Store() stored %d transactions, available credit limit %v", stored, acc.AvailableCreditLimit())
+	}
+}
+
+func TestUnitOfWork_Do(t *testing.T) {
+	var errRollback = errors.New("rollback")
+
+	tests := []struct {
+		name      string
+		err       error
+		wantFound bool
+	}{
+		// fails
+		{
+			name: "registers discarded",
+			err:  errRollback,
+		},
+
+		// successes
+		{
+			name:      "registers stored",
+			wantFound: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				ctx     = context.Background()
+				store   = NewStore(time.Now)
+				account = NewAccount(store)
+			)
+
+			err := NewUnitOfWork(store).Do(ctx, func(ctx context.Context) error {
+				if _, err := account.Store(ctx, newAccount(t, "00000000191", 0)); err != nil {
+					return err
+				}
+
+				return tt.err
+			})
+			if err != tt.err {
+				t.Errorf("Do() error = %v, wantErr %v", err, tt.err)
+			}
+
+			_, err = account.FindOneByID(ctx, domain.NewID(1))
+			if found := err == nil; found != tt.wantFound {
+				t.Errorf("FindOneByID() found = %v, want %v", found, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestStore_Write(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		store      = NewStore(time.Now)
+		accounts   = store.tables.accounts
+		operations = store.tables.operations
+	)
+
+	if _, err := NewAccount(store).Store(ctx, newAccount(t, "00000000191", 0)); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+
+	if len(accounts) != 0 || len(store.tables.accounts) != 1 {
+		t.Errorf("write() must change a copy of the accounts, got %v in the original and %v in the copy",
+			len(accounts), len(store.tables.accounts))
+	}
+
+	if reflect.ValueOf(store.tables.operations).Pointer() != reflect.ValueOf(operations).Pointer() {
+		t.Errorf("write() must not copy the operations, which were not used")
+	}
+
+	accounts = store.tables.accounts
+
+	err := store.write(ctx, func(w *tx) error {
+		delete(w.accounts(), 1)
+
+		return errors.New("failed")
+	})
+	if err == nil || len(accounts) != 1 || len(store.tables.accounts) != 1 {
+		t.Errorf("write() must discard the copy of the accounts when it fails, got %v in the store", len(store.tables.accounts))
+	}
+}
@@ -0,0 +1,279 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+)
+
+// Transaction exposes transaction in memory operations
+type Transaction struct {
+	store *Store
+}
+
+// NewTransaction build a new Transaction struct with its dependencies
+func NewTransaction(store *Store) *Transaction {
+	return &Transaction{store: store}
+}
+
+// Store stores a transaction, updating the available credit limit of its account. Payments are discharged against
+// the open debits of the account, from the oldest to the newest.
+func (tr Transaction) Store(ctx context.Context, transaction *domain.Transaction) (*domain.Transaction, error) {
+	err := tr.store.write(ctx, func(t *tx) error {
+		account, err := lockAccount(t, transaction.Account().ID(), "transactions", "account_id")
+		if err != nil {
+			return err
+		}
+
+		account, err = account.ApplyTransaction(transaction)
+		if err != nil {
+			return err
+		}
+
+		updateAvailableCreditLimit(t, account)
+
+		var discharged []*domain.Transaction
+
+		if transaction.Operation().IsPayment() {
+			transaction, discharged = transaction.Discharge(findOpenDebits(t, account.ID()))
+		}
+
+		id, err := insertTransaction(t, transaction, tr.store.now())
+		if err != nil {
+			return err
+		}
+
+		transaction = transaction.WithID(domain.NewID(id))
+
+		for _, debit := range discharged {
+			updateBalance(t, debit)
+		}
+
+		for _, v := range transaction.Allocations() {
+			t.addAllocation(allocation{paymentID: id, allocation: v})
+		}
+
+		if plan := transaction.InstallmentPlan(); plan != nil {
+			t.installments()[id] = plan
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// FindByFilter finds a page of transactions matching the filter, using the transaction id as the pagination key
+func (tr Transaction) FindByFilter(ctx context.Context, filter *domain.TransactionFilter) (*domain.TransactionPage, error) {
+	var transactions []*domain.Transaction
+
+	err := tr.store.read(ctx, func(t *tables) error {
+		for _, v := range t.transactions {
+			if matches(v, filter) {
+				transactions = append(transactions, v)
+			}
+		}
+
+		prev := filter.Cursor() != nil && filter.Cursor().Direction() == domain.CursorPrev
+
+		sort.Slice(transactions, func(i, j int) bool {
+			if prev {
+				return transactions[i].ID().Value() < transactions[j].ID().Value()
+			}
+
+			return transactions[i].ID().Value() > transactions[j].ID().Value()
+		})
+
+		// keeps one extra register to identify whether there is another page
+		if len(transactions) > filter.Limit()+1 {
+			transactions = transactions[:filter.Limit()+1]
+		}
+
+		for i, v := range transactions {
+			if plan, ok := t.installments[v.ID().Value()]; ok {
+				transactions[i] = v.WithInstallmentPlan(plan)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewTransactionPage(transactions, filter), nil
+}
+
+// WalkByPeriod calls fn with each transaction of the account created in the period, from the oldest to the newest.
+// The store is not locked while fn runs.
+func (tr Transaction) WalkByPeriod(
+	ctx context.Context,
+	accountID *domain.ID,
+	from, to time.Time,
+	fn func(*domain.Transaction) error,
+) error {
+	var transactions []*domain.Transaction
+
+	err := tr.store.read(ctx, func(t *tables) error {
+		for _, v := range t.transactions {
+			if v.Account().ID().Value() == accountID.Value() && !v.CreatedAt().Before(from) && !v.CreatedAt().After(to) {
+				transactions = append(transactions, v)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(transactions, func(i, j int) bool {
+		return transactions[i].ID().Value() < transactions[j].ID().Value()
+	})
+
+	for _, transaction := range transactions {
+		if err := fn(transaction); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// matches checks if the transaction matches the filter
+func matches(transaction *domain.Transaction, filter *domain.TransactionFilter) bool {
+	if transaction.Account().ID().Value() != filter.AccountID().Value() {
+		return false
+	}
+
+	if ops := filter.Operations(); len(ops) > 0 {
+		var found bool
+
+		for _, op := range ops {
+			found = found || op.Value() == transaction.Operation().ID().Value()
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	if v := filter.From(); v != nil && transaction.CreatedAt().Before(*v) {
+		return false
+	}
+
+	if v := filter.To(); v != nil && transaction.CreatedAt().After(*v) {
+		return false
+	}
+
+	if v := filter.MinAmount(); v != nil && transaction.Amount().Abs().Sub(v.WithCurrency(transaction.Amount().Currency())).IsNegative() {
+		return false
+	}
+
+	if v := filter.MaxAmount(); v != nil && transaction.Amount().Abs().Sub(v.WithCurrency(transaction.Amount().Currency())).IsPositive() {
+		return false
+	}
+
+	if c := filter.Cursor(); c != nil {
+		if c.Direction() == domain.CursorPrev {
+			return transaction.ID().Value() > c.ID().Value()
+		}
+
+		return transaction.ID().Value() < c.ID().Value()
+	}
+
+	return true
+}
+
+// findOpenDebits finds the transactions of the account with a negative balance, from the oldest to the newest
+func findOpenDebits(t *tx, accountID *domain.ID) []*domain.Transaction {
+	var debits []*domain.Transaction
+
+	for _, v := range t.transactions() {
+		if v.Account().ID().Value() == accountID.Value() && v.Balance().IsNegative() {
+			debits = append(debits, v)
+		}
+	}
+
+	sort.Slice(debits, func(i, j int) bool {
+		if !debits[i].CreatedAt().Equal(debits[j].CreatedAt()) {
+			return debits[i].CreatedAt().Before(debits[j].CreatedAt())
+		}
+
+		return debits[i].ID().Value() < debits[j].ID().Value()
+	})
+
+	return debits
+}
+
+// updateBalance stores the balance of a transaction
+func updateBalance(t *tx, transaction *domain.Transaction) {
+	if stored, ok := t.transactions()[transaction.ID().Value()]; ok {
+		t.transactions()[transaction.ID().Value()] = stored.WithBalance(transaction.Balance())
+	}
+}
+
+// insertTransaction inserts a transaction created at the informed moment, returning its generated id. The transaction
+// is stored as its columns, without its account data, allocations and installments.
+func insertTransaction(t *tx, transaction *domain.Transaction, at time.Time) (uint64, error) {
+	var (
+		accountID   = transaction.Account().ID()
+		operationID = transaction.Operation().ID()
+	)
+
+	if _, ok := t.accounts()[accountID.Value()]; !ok {
+		return 0, repository.NewErrForeignKeyConstraint("transactions", "", "account_id", "id")
+	}
+
+	if _, ok := t.operations()[operationID.Value()]; !ok {
+		return 0, repository.NewErrForeignKeyConstraint("transactions", "", "operation_id", "id")
+	}
+
+	if v := transaction.TransferID(); v != nil {
+		if _, ok := t.transfers()[v.Value()]; !ok {
+			return 0, repository.NewErrForeignKeyConstraint("transactions", "", "transfer_id", "id")
+		}
+	}
+
+	if v := transaction.ReversalOf(); v != nil {
+		if _, ok := t.transactions()[v.Value()]; !ok {
+			return 0, repository.NewErrForeignKeyConstraint("transactions", "", "reversal_of", "id")
+		}
+	}
+
+	if accrual := transaction.AccrualDate(); !accrual.IsZero() {
+		for _, v := range t.transactions() {
+			if v.Account().ID().Value() == accountID.Value() &&
+				v.Operation().ID().Value() == operationID.Value() &&
+				v.AccrualDate().Equal(day(accrual)) {
+				value := fmt.Sprintf("%d-%d-%s", accountID.Value(), operationID.Value(), day(accrual).Format("2006-01-02"))
+
+				return 0, repository.NewErrDuplicatedEntry("transactions_account_id_operation_id_accrual_date", value)
+			}
+		}
+	}
+
+	id := t.nextID("transactions")
+
+	stored := transaction.
+		WithID(domain.NewID(id)).
+		WithAccount(new(domain.Account).WithID(accountID)).
+		WithAllocations(nil).
+		WithInstallmentPlan(nil).
+		WithCreatedAt(at.UTC())
+
+	if accrual := transaction.AccrualDate(); !accrual.IsZero() {
+		stored = stored.WithAccrualDate(day(accrual))
+	}
+
+	t.transactions()[id] = stored
+
+	return id, nil
+}
@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// Transfer exposes transfer in memory operations
+type Transfer struct {
+	store *Store
+}
+
+// NewTransfer build a new Transfer struct with its dependencies
+func NewTransfer(store *Store) *Transfer {
+	return &Transfer{store: store}
+}
+
+// Store stores a transfer and its debit and credit postings, updating the available credit limit of both accounts.
+// The accounts are loaded in id order, so a missing account is reported the same way as by the database.
+func (tr Transfer) Store(ctx context.Context, transfer *domain.Transfer) (*domain.Transfer, error) {
+	err := tr.store.write(ctx, func(t *tx) error {
+		source, destination, err := lockAccounts(t, transfer)
+		if err != nil {
+			return err
+		}
+
+		transfer, err = transfer.Apply(source, destination)
+		if err != nil {
+			return err
+		}
+
+		for _, account := range []*domain.Account{transfer.Source(), transfer.Destination()} {
+			updateAvailableCreditLimit(t, account)
+		}
+
+		id := t.nextID("transfers")
+
+		transfer = transfer.WithID(domain.NewID(id))
+		t.transfers()[id] = transfer
+
+		for _, posting := range []*domain.Transaction{transfer.Debit(), transfer.Credit()} {
+			if _, err := insertTransaction(t, posting, tr.store.now()); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+// lockAccounts loads the source and destination accounts of the transfer, always in the same order
+func lockAccounts(t *tx, transfer *domain.Transfer) (*domain.Account, *domain.Account, error) {
+	var (
+		source      *domain.Account
+		destination *domain.Account
+		err         error
+	)
+
+	lockSource := func() error {
+		source, err = lockAccount(t, transfer.Source().ID(), "transfers", "source_account_id")
+		return err
+	}
+
+	lockDestination := func() error {
+		destination, err = lockAccount(t, transfer.Destination().ID(), "transfers", "destination_account_id")
+		return err
+	}
+
+	locks := []func() error{lockSource, lockDestination}
+	if transfer.Destination().ID().Value() < transfer.Source().ID().Value() {
+		locks = []func() error{lockDestination, lockSource}
+	}
+
+	for _, lock := range locks {
+		if err := lock(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return source, destination, nil
+}
@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// Webhook exposes webhook in memory operations
+type Webhook struct {
+	store *Store
+}
+
+// NewWebhook build a new Webhook struct with its dependencies
+func NewWebhook(store *Store) *Webhook {
+	return &Webhook{store: store}
+}
+
+// Store stores a webhook
+func (w Webhook) Store(ctx context.Context, webhook *domain.Webhook) (*domain.ID, error) {
+	var id uint64
+
+	err := w.store.write(ctx, func(t *tx) error {
+		id = t.nextID("webhooks")
+
+		t.webhooks()[id] = domain.LoadWebhook(
+			domain.NewID(id),
+			webhook.URL(),
+			webhook.EventTypes(),
+			webhook.Secret(),
+			w.store.now().UTC(),
+		)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewID(id), nil
+}
+
+// FindByEventType finds the webhooks subscribed to the informed event type
+func (w Webhook) FindByEventType(ctx context.Context, eventType domain.EventType) ([]*domain.Webhook, error) {
+	var webhooks []*domain.Webhook
+
+	err := w.store.read(ctx, func(t *tables) error {
+		ids := make([]uint64, 0, len(t.webhooks))
+
+		for id := range t.webhooks {
+			ids = append(ids, id)
+		}
+
+		for _, id := range sortedIDs(ids) {
+			if t.webhooks[id].Subscribes(eventType) {
+				webhooks = append(webhooks, t.webhooks[id])
+			}
+		}
+
+		return nil
+	})
+
+	return webhooks, err
+}
@@ -0,0 +1,204 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+)
+
+// WebhookDelivery exposes webhook delivery in memory operations
+type WebhookDelivery struct {
+	store *Store
+}
+
+// NewWebhookDelivery build a new WebhookDelivery struct with its dependencies
+func NewWebhookDelivery(store *Store) *WebhookDelivery {
+	return &WebhookDelivery{store: store}
+}
+
+// Store stores a delivery, returning the id of the delivery already stored for the same webhook and event
+func (w WebhookDelivery) Store(ctx context.Context, delivery *domain.WebhookDelivery) (*domain.ID, error) {
+	var id uint64
+
+	err := w.store.write(ctx, func(t *tx) error {
+		webhook, ok := t.webhooks()[delivery.Webhook().ID().Value()]
+		if !ok {
+			return repository.NewErrForeignKeyConstraint("webhook_deliveries", "", "webhook_id", "id")
+		}
+
+		event, ok := t.events()[delivery.Event().ID().Value()]
+		if !ok {
+			return repository.NewErrForeignKeyConstraint("webhook_deliveries", "", "event_id", "id")
+		}
+
+		for k, v := range t.deliveries() {
+			if v.Webhook().ID().Value() == webhook.ID().Value() && v.Event().ID().Value() == event.ID().Value() {
+				id = k
+				return nil
+			}
+		}
+
+		id = t.nextID("webhook_deliveries")
+
+		t.deliveries()[id] = domain.LoadWebhookDelivery(
+			domain.NewID(id),
+			webhook,
+			event,
+			domain.WebhookDeliveryPending,
+			0,
+			delivery.NextAttemptAt().UTC(),
+			time.Time{},
+			"",
+		)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewID(id), nil
+}
+
+// Update updates the attempts of a delivery, registering when it was delivered
+func (w WebhookDelivery) Update(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	return w.store.write(ctx, func(t *tx) error {
+		if stored, ok := t.deliveries()[delivery.ID().Value()]; ok {
+			t.deliveries()[delivery.ID().Value()] = domain.LoadWebhookDelivery(
+				stored.ID(),
+				stored.Webhook(),
+				stored.Event(),
+				delivery.Status(),
+				delivery.Attempts(),
+				delivery.NextAttemptAt().UTC(),
+				delivery.LastAttemptAt().UTC(),
+				delivery.LastError(),
+			)
+		}
+
+		return nil
+	})
+}
+
+// MoveToDeadLetter moves a failed delivery to the dead letters, keeping its id
+func (w WebhookDelivery) MoveToDeadLetter(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	return w.store.write(ctx, func(t *tx) error {
+		id := delivery.ID().Value()
+
+		t.deadLetters()[id] = domain.LoadWebhookDelivery(
+			delivery.ID(),
+			delivery.Webhook(),
+			delivery.Event(),
+			domain.WebhookDeliveryFailed,
+			delivery.Attempts(),
+			delivery.LastAttemptAt().UTC(),
+			delivery.LastAttemptAt().UTC(),
+			delivery.LastError(),
+		)
+
+		delete(t.deliveries(), id)
+
+		return nil
+	})
+}
+
+// Replay moves a dead letter back to the deliveries, pending
+func (w WebhookDelivery) Replay(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	return w.store.write(ctx, func(t *tx) error {
+		id := delivery.ID().Value()
+
+		if _, ok := t.deliveries()[id]; ok {
+			return repository.NewErrDuplicatedEntry("PRIMARY", strconv.FormatUint(id, 10))
+		}
+
+		t.deliveries()[id] = domain.LoadWebhookDelivery(
+			delivery.ID(),
+			delivery.Webhook(),
+			delivery.Event(),
+			domain.WebhookDeliveryPending,
+			delivery.Attempts(),
+			delivery.NextAttemptAt().UTC(),
+			delivery.LastAttemptAt().UTC(),
+			"",
+		)
+
+		delete(t.deadLetters(), id)
+
+		return nil
+	})
+}
+
+// FindDue finds the pending deliveries whose next attempt is due at the informed moment, from the oldest to the newest,
+// up to the informed limit
+func (w WebhookDelivery) FindDue(ctx context.Context, at time.Time, limit int) ([]*domain.WebhookDelivery, error) {
+	var deliveries []*domain.WebhookDelivery
+
+	err := w.store.read(ctx, func(t *tables) error {
+		for _, v := range t.deliveries {
+			if v.Status() != domain.WebhookDeliveryDelivered && !v.NextAttemptAt().After(at) {
+				deliveries = append(deliveries, v)
+			}
+		}
+
+		return nil
+	})
+
+	sort.Slice(deliveries, func(i, j int) bool {
+		if !deliveries[i].NextAttemptAt().Equal(deliveries[j].NextAttemptAt()) {
+			return deliveries[i].NextAttemptAt().Before(deliveries[j].NextAttemptAt())
+		}
+
+		return deliveries[i].ID().Value() < deliveries[j].ID().Value()
+	})
+
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+
+	return deliveries, err
+}
+
+// FindDeadLetters finds the deliveries moved to the dead letters, from the last attempted to the first
+func (w WebhookDelivery) FindDeadLetters(ctx context.Context) ([]*domain.WebhookDelivery, error) {
+	var deliveries []*domain.WebhookDelivery
+
+	err := w.store.read(ctx, func(t *tables) error {
+		for _, v := range t.deadLetters {
+			deliveries = append(deliveries, v)
+		}
+
+		return nil
+	})
+
+	sort.Slice(deliveries, func(i, j int) bool {
+		if !deliveries[i].LastAttemptAt().Equal(deliveries[j].LastAttemptAt()) {
+			return deliveries[i].LastAttemptAt().After(deliveries[j].LastAttemptAt())
+		}
+
+		return deliveries[i].ID().Value() > deliveries[j].ID().Value()
+	})
+
+	return deliveries, err
+}
+
+// FindDeadLetterByID finds one delivery in the dead letters based in the informed ID
+func (w WebhookDelivery) FindDeadLetterByID(ctx context.Context, id *domain.ID) (*domain.WebhookDelivery, error) {
+	var delivery *domain.WebhookDelivery
+
+	err := w.store.read(ctx, func(t *tables) error {
+		v, ok := t.deadLetters[id.Value()]
+		if !ok {
+			return repository.NewErrRegisterNotFound("id", strconv.FormatUint(id.Value(), 10))
+		}
+
+		delivery = v
+
+		return nil
+	})
+
+	return delivery, err
+}
@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+const operationColumns = `id, description, direction, kind, enabled`
+
+// operationCacheTTL is how long the operations loaded are used, so the changes made by other instances are seen after
+// it at most
+const operationCacheTTL = 30 * time.Second
+
+// errUnknownOperation is returned when a register scanned refers to an operation not loaded
+var errUnknownOperation = errors.New("unknown operation")
+
+// Operation exposes operation database operations
+type Operation struct {
+	conn       *sql.DB
+	dialect    dialect
+	operations *operationCache
+}
+
+// NewOperation build a new Operation struct with its dependencies
+func NewOperation(conn *sql.DB) *Operation {
+	return &Operation{conn: conn, dialect: dialectOf(conn), operations: newOperationCache()}
+}
+
+// FindAll finds and returns all the operations, enabled or not, ordered by id
+func (o Operation) FindAll(ctx context.Context) ([]*domain.Operation, error) {
+	operations, _, err := o.operations.load(ctx, executorOf(ctx, o.conn), false)
+
+	return operations, err
+}
+
+// FindOneByID finds and returns one operation based in the informed ID
+func (o Operation) FindOneByID(ctx context.Context, id *domain.ID) (*domain.Operation, error) {
+	var query = `SELECT ` + operationColumns + ` FROM operations WHERE id = ?`
+
+	operation, err := scanOperation(executorOf(ctx, o.conn).QueryRowContext(ctx, o.dialect.query(query), id.Value()))
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, NewErrRegisterNotFound("id", strconv.FormatUint(id.Value(), 10))
+		}
+
+		return nil, err
+	}
+
+	return operation, nil
+}
+
+// Store stores an operation in the storage
+func (o Operation) Store(ctx context.Context, operation *domain.Operation) (*domain.ID, error) {
+	var query = `INSERT INTO operations (description, direction, kind, enabled) VALUES (?, ?, ?, ?)`
+
+	id, err := o.dialect.insert(
+		ctx,
+		executorOf(ctx, o.conn),
+		query,
+		operation.Description(),
+		operation.Direction().String(),
+		operation.Kind().String(),
+		operation.IsEnabled(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	o.operations.invalidate()
+
+	return domain.NewID(id), nil
+}
+
+// UpdateEnabled updates whether the operation accepts new transactions
+func (o Operation) UpdateEnabled(ctx context.Context, operation *domain.Operation) error {
+	var query = `UPDATE operations SET enabled = ? WHERE id = ?`
+
+	if _, err := executorOf(ctx, o.conn).ExecContext(ctx, o.dialect.query(query), operation.IsEnabled(), operation.ID().Value()); err != nil {
+		return errors.Wrap(err, "database error")
+	}
+
+	o.operations.invalidate()
+
+	return nil
+}
+
+// findOperations finds all the operations, ordered by id
+func findOperations(ctx context.Context, tx executor) ([]*domain.Operation, error) {
+	var query = `SELECT ` + operationColumns + ` FROM operations ORDER BY id`
+
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(err, "database error")
+	}
+	defer rows.Close()
+
+	var operations []*domain.Operation
+
+	for rows.Next() {
+		operation, err := scanOperation(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		operations = append(operations, operation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "database error")
+	}
+
+	return operations, nil
+}
+
+// operationCache keeps the operations loaded by a repository, instead of loading them on every query. They are loaded
+// again once the operationCacheTTL is over, when a register refers to an operation not loaded, as one created by
+// another instance, and when the repository changes them.
+type operationCache struct {
+	mu         sync.Mutex
+	all        []*domain.Operation
+	operations *domain.Operations
+	loadedAt   time.Time
+}
+
+func newOperationCache() *operationCache {
+	return &operationCache{}
+}
+
+// load returns the operations cached, loading them when they are not loaded, are expired or reload is informed. The
+// lock is not held while loading them, so a database transaction using the cache never waits for another one.
+func (c *operationCache) load(ctx context.Context, tx executor, reload bool) ([]*domain.Operation, *domain.Operations, error) {
+	c.mu.Lock()
+	all, operations, loadedAt := c.all, c.operations, c.loadedAt
+	c.mu.Unlock()
+
+	if !reload && operations != nil && time.Since(loadedAt) < operationCacheTTL {
+		return all, operations, nil
+	}
+
+	loadedAt = time.Now()
+
+	all, err := findOperations(ctx, tx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	operations = domain.NewOperations(all...)
+
+	c.mu.Lock()
+	if c.loadedAt.Before(loadedAt) {
+		c.all, c.operations, c.loadedAt = all, operations, loadedAt
+	}
+	c.mu.Unlock()
+
+	return all, operations, nil
+}
+
+// with calls fn with the operations cached, calling it again with the operations reloaded when fn finds a register
+// referring to an operation not loaded
+func (c *operationCache) with(ctx context.Context, tx executor, fn func(*domain.Operations) error) error {
+	_, operations, err := c.load(ctx, tx, false)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(operations); errors.Cause(err) != errUnknownOperation {
+		return err
+	}
+
+	if _, operations, err = c.load(ctx, tx, true); err != nil {
+		return err
+	}
+
+	if err := fn(operations); errors.Cause(err) != errUnknownOperation {
+		return err
+	}
+
+	return NewErrLoadInvalidData("operation_id")
+}
+
+// invalidate makes the operations be loaded again on the next use, discarding the ones being loaded at the moment
+func (c *operationCache) invalidate() {
+	c.mu.Lock()
+	c.all, c.operations, c.loadedAt = nil, nil, time.Now()
+	c.mu.Unlock()
+}
+
+func scanOperation(row rowScanner) (*domain.Operation, error) {
+	var (
+		id          uint64
+		description string
+		direction   string
+		kind        string
+		enabled     bool
+	)
+
+	if err := row.Scan(&id, &description, &direction, &kind, &enabled); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+
+		return nil, errors.Wrap(err, "database error")
+	}
+
+	operationDirection, err := domain.NewOperationDirection(direction)
+	if err != nil {
+		return nil, NewErrLoadInvalidData("direction")
+	}
+
+	operationKind, err := domain.NewOperationKind(kind)
+	if err != nil {
+		return nil, NewErrLoadInvalidData("kind")
+	}
+
+	return domain.LoadOperation(domain.NewID(id), description, operationDirection, operationKind, enabled), nil
+}
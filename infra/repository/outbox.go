@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// Outbox exposes the events database operations. Events are stored in the outbox table in the same database
+// transaction of the entity that raised them, and read from there to be published.
+type Outbox struct {
+	conn    *sql.DB
+	dialect dialect
+}
+
+// NewOutbox build a new Outbox struct with its dependencies
+func NewOutbox(conn *sql.DB) *Outbox {
+	return &Outbox{conn: conn, dialect: dialectOf(conn)}
+}
+
+// Store stores an event not published yet, in the database transaction carried by the context when there is one
+func (o Outbox) Store(ctx context.Context, event *domain.Event) (*domain.ID, error) {
+	var query = `
+		INSERT INTO outbox (event_type, aggregate_id, payload, occurred_at)
+		VALUES (?, ?, ?, ?)
+	`
+
+	id, err := o.dialect.insert(
+		ctx,
+		executorOf(ctx, o.conn),
+		query,
+		event.Type().String(),
+		event.AggregateID().Value(),
+		string(event.Payload()),
+		event.OccurredAt().UTC().Format(timestampLayout),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewID(id), nil
+}
+
+// MarkPublished registers the moment an event was published
+func (o Outbox) MarkPublished(ctx context.Context, event *domain.Event, at time.Time) error {
+	var query = `UPDATE outbox SET published_at = ? WHERE id = ?`
+
+	_, err := executorOf(ctx, o.conn).ExecContext(ctx, o.dialect.query(query), at.UTC().Format(timestampLayout), event.ID().Value())
+	if err != nil {
+		return errors.Wrap(err, "error to mark the event as published")
+	}
+
+	return nil
+}
+
+// ClaimUnpublished claims the events not published yet, from the oldest to the newest, until the informed moment.
+// The events are locked while claimed, so a concurrent claim waits for it and skips them.
+func (o Outbox) ClaimUnpublished(ctx context.Context, limit int, until time.Time) ([]*domain.Event, error) {
+	var query = `
+		SELECT id, event_type, aggregate_id, payload, occurred_at
+		FROM outbox
+		WHERE published_at IS NULL AND (claimed_until IS NULL OR claimed_until < ?)
+		ORDER BY id ASC
+		LIMIT ?
+	` + o.dialect.lock
+
+	tx, err := beginTx(ctx, o.conn)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	events, err := o.findEvents(ctx, tx, o.dialect.query(query), time.Now().UTC().Format(timestampLayout), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := o.claim(ctx, tx, events, &until); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit transaction error")
+	}
+
+	return events, nil
+}
+
+// ReleaseClaims releases the claims of the events, so they can be claimed right away
+func (o Outbox) ReleaseClaims(ctx context.Context, events []*domain.Event) error {
+	return o.claim(ctx, executorOf(ctx, o.conn), events, nil)
+}
+
+// claim registers the moment the events are claimed until, releasing their claims when until is nil
+func (o Outbox) claim(ctx context.Context, tx executor, events []*domain.Event, until *time.Time) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	var (
+		placeholders = make([]string, len(events))
+		args         = make([]interface{}, 0, len(events)+1)
+	)
+
+	if until != nil {
+		args = append(args, until.UTC().Format(timestampLayout))
+	} else {
+		args = append(args, nil)
+	}
+
+	for i, event := range events {
+		placeholders[i] = "?"
+		args = append(args, event.ID().Value())
+	}
+
+	var query = `UPDATE outbox SET claimed_until = ? WHERE id IN (` + strings.Join(placeholders, ", ") + `)`
+
+	if _, err := tx.ExecContext(ctx, o.dialect.query(query), args...); err != nil {
+		return errors.Wrap(err, "error to claim the events")
+	}
+
+	return nil
+}
+
+// findEvents finds the events of the query
+func (o Outbox) findEvents(ctx context.Context, tx executor, query string, args ...interface{}) ([]*domain.Event, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "database error")
+	}
+	defer rows.Close()
+
+	var events []*domain.Event
+
+	for rows.Next() {
+		var (
+			id          uint64
+			eventType   string
+			aggregateID uint64
+			payload     string
+			occurredAt  timestamp
+		)
+
+		if err := rows.Scan(&id, &eventType, &aggregateID, &payload, &occurredAt); err != nil {
+			return nil, errors.Wrap(err, "error to read the event")
+		}
+
+		events = append(events, domain.LoadEvent(
+			domain.NewID(id),
+			domain.EventType(eventType),
+			domain.NewID(aggregateID),
+			[]byte(payload),
+			occurredAt.Time,
+		))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "error to read the events")
+	}
+
+	return events, nil
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// Repositories groups the implementations of the domain repositories, so the servers are built the same way whatever
+// the storage is
+type Repositories struct {
+	AccountReader         domain.AccountRepositoryReader
+	AccountWriter         domain.AccountRepositoryWriter
+	AccountStatusWriter   domain.AccountStatusRepositoryWriter
+	TransactionReader     domain.TransactionRepositoryReader
+	TransactionWriter     domain.TransactionRepositoryWriter
+	StatementReader       domain.StatementRepositoryReader
+	TransferWriter        domain.TransferRepositoryWriter
+	ReversalWriter        domain.ReversalRepositoryWriter
+	OperationReader       domain.OperationRepositoryReader
+	OperationWriter       domain.OperationRepositoryWriter
+	InvoiceReader         domain.InvoiceRepositoryReader
+	InvoiceWriter         domain.InvoiceRepositoryWriter
+	InterestReader        domain.InterestRepositoryReader
+	EventWriter           domain.EventRepositoryWriter
+	Idempotency           domain.IdempotencyRepository
+	WebhookReader         domain.WebhookRepositoryReader
+	WebhookWriter         domain.WebhookRepositoryWriter
+	WebhookDeliveryReader domain.WebhookDeliveryRepositoryReader
+	WebhookDeliveryWriter domain.WebhookDeliveryRepositoryWriter
+	UnitOfWork            domain.UnitOfWork
+}
+
+// NewRepositories builds the repositories of the database connection
+func NewRepositories(conn *sql.DB) *Repositories {
+	var (
+		transactionReader = NewTransactionReader(conn)
+		operation         = NewOperation(conn)
+		invoice           = NewInvoice(conn)
+		outbox            = NewOutbox(conn)
+		webhook           = NewWebhook(conn)
+		webhookDelivery   = NewWebhookDelivery(conn)
+	)
+
+	return &Repositories{
+		AccountReader:         NewAccountReader(conn),
+		AccountWriter:         NewAccountWriter(conn),
+		AccountStatusWriter:   NewAccountWriter(conn),
+		TransactionReader:     transactionReader,
+		TransactionWriter:     NewTransaction(conn),
+		StatementReader:       transactionReader,
+		TransferWriter:        NewTransfer(conn),
+		ReversalWriter:        NewReversal(conn),
+		OperationReader:       operation,
+		OperationWriter:       operation,
+		InvoiceReader:         invoice,
+		InvoiceWriter:         invoice,
+		InterestReader:        invoice,
+		EventWriter:           outbox,
+		Idempotency:           NewIdempotency(conn),
+		WebhookReader:         webhook,
+		WebhookWriter:         webhook,
+		WebhookDeliveryReader: webhookDelivery,
+		WebhookDeliveryWriter: webhookDelivery,
+		UnitOfWork:            NewUnitOfWork(conn),
+	}
+}
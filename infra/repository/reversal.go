@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// Reversal exposes reversal database operations
+type Reversal struct {
+	conn       *sql.DB
+	dialect    dialect
+	operations *operationCache
+}
+
+// NewReversal build a new Reversal struct with its dependencies
+func NewReversal(conn *sql.DB) *Reversal {
+	return &Reversal{conn: conn, dialect: dialectOf(conn), operations: newOperationCache()}
+}
+
+// Store stores the compensating transaction of a reversal in a single database transaction, updating the reversed
+// amount of the original transaction and the available credit limit of its account. The account is locked before the
+// original transaction row, in the same order as the other writes of the account, and both are locked until the end,
+// so concurrent reversals cannot exceed its amount.
+func (r Reversal) Store(ctx context.Context, reversal *domain.Reversal) (*domain.Reversal, error) {
+	tx, err := beginTx(ctx, r.conn)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	accountID, err := r.findAccountID(ctx, tx, reversal.Original().ID())
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := lockAccount(ctx, tx, r.dialect, accountID, "transactions", "account_id")
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := r.lockTransaction(ctx, tx, reversal.Original().ID())
+	if err != nil {
+		return nil, err
+	}
+
+	reversal, err = reversal.Apply(original, account)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := updateAvailableCreditLimit(ctx, tx, r.dialect, reversal.Transaction().Account()); err != nil {
+		return nil, err
+	}
+
+	var (
+		updated     = reversal.Original()
+		updateQuery = `UPDATE transactions SET reversed_amount = ?, balance = ? WHERE id = ?`
+	)
+
+	_, err = tx.ExecContext(ctx, r.dialect.query(updateQuery), updated.ReversedAmount().String(), updated.Balance().String(), updated.ID().Value())
+	if err != nil {
+		return nil, errors.Wrap(err, "error to update the reversed amount")
+	}
+
+	id, err := insertTransaction(ctx, tx, r.dialect, reversal.Transaction())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit transaction error")
+	}
+
+	return reversal.WithID(domain.NewID(id)), nil
+}
+
+// findAccountID finds the account of a transaction, which never changes, without locking the transaction row
+func (r Reversal) findAccountID(ctx context.Context, tx executor, id *domain.ID) (*domain.ID, error) {
+	var (
+		accountID uint64
+		query     = `SELECT account_id FROM transactions WHERE id = ?`
+	)
+
+	if err := tx.QueryRowContext(ctx, r.dialect.query(query), id.Value()).Scan(&accountID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, NewErrRegisterNotFound("id", strconv.FormatUint(id.Value(), 10))
+		}
+
+		return nil, errors.Wrap(err, "database error")
+	}
+
+	return domain.NewID(accountID), nil
+}
+
+// lockTransaction loads a transaction, locking its row until the end of the database transaction
+func (r Reversal) lockTransaction(ctx context.Context, tx executor, id *domain.ID) (*domain.Transaction, error) {
+	var query = `SELECT ` + transactionColumns + ` FROM transactions WHERE id = ? ` + r.dialect.lock
+
+	var transaction *domain.Transaction
+
+	err := r.operations.with(ctx, tx, func(operations *domain.Operations) error {
+		var err error
+
+		transaction, err = scanTransaction(tx.QueryRowContext(ctx, r.dialect.query(query), id.Value()), operations)
+
+		return err
+	})
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, NewErrRegisterNotFound("id", strconv.FormatUint(id.Value(), 10))
+		}
+
+		return nil, err
+	}
+
+	return transaction, nil
+}
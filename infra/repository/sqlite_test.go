@@ -0,0 +1,551 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+	"github.com/tonytcb/bank-transactions-go/infra/storage"
+	"github.com/tonytcb/bank-transactions-go/infra/storage/migration"
+	"github.com/tonytcb/bank-transactions-go/usecase"
+)
+
+// newSQLiteStorage creates a SQLite database in a temporary file with the schema migrated
+func newSQLiteStorage(t *testing.T) *sql.DB {
+	dir, err := ioutil.TempDir("", "bank-transactions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	conn, err := storage.NewSQLiteConnection(filepath.Join(dir, "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	if _, err := migration.NewMigrator(conn).Up(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	return conn
+}
+
+func newSQLiteAccount(t *testing.T, documentNumber string, creditLimit int64) *domain.Account {
+	account, err := domain.NewAccount(domain.DocumentNumber(documentNumber))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	limit := domain.NewMoney(creditLimit, domain.CurrencyBRL)
+
+	return account.
+		WithCurrency(domain.CurrencyBRL).
+		WithCreditLimit(limit).
+		WithAvailableCreditLimit(limit).
+		WithBillingCycle(domain.DefaultBillingCycle())
+}
+
+func TestSQLite_AccountWriter_Store(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		conn   = newSQLiteStorage(t)
+		writer = NewAccountWriter(conn)
+		reader = NewAccountReader(conn)
+	)
+
+	id, err := writer.Store(ctx, newSQLiteAccount(t, "00000000191", 150050))
+	if err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+
+	got, err := reader.FindOneByID(ctx, id)
+	if err != nil {
+		t.Fatalf("FindOneByID() error = %v", err)
+	}
+
+	if got.Document().Number() != "00000000191" || got.CreditLimit().String() != "1500.50" ||
+		got.AvailableCreditLimit().String() != "1500.50" || got.Status() != domain.AccountStatusActive ||
+		got.CreatedAt().IsZero() {
+		t.Errorf("FindOneByID() = %v %v %v %v %v", got.Document().Number(), got.CreditLimit(),
+			got.AvailableCreditLimit(), got.Status(), got.CreatedAt())
+	}
+
+	_, err = writer.Store(ctx, newSQLiteAccount(t, "00000000191", 0))
+	if wantErr := NewErrDuplicatedEntry("document_number", ""); !reflect.DeepEqual(err, wantErr) {
+		t.Errorf("Store() error = %v, wantErr %v", err, wantErr)
+	}
+
+	_, err = reader.FindOneByID(ctx, domain.NewID(2))
+	if wantErr := NewErrRegisterNotFound("id", "2"); !reflect.DeepEqual(err, wantErr) {
+		t.Errorf("FindOneByID() error = %v, wantErr %v", err, wantErr)
+	}
+}
+
+func TestSQLite_Transaction_Store(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		conn       = newSQLiteStorage(t)
+		repository = NewTransaction(conn)
+		reader     = NewAccountReader(conn)
+	)
+
+	accountID, err := NewAccountWriter(conn).Store(ctx, newSQLiteAccount(t, "52998224725", 100000))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newTransaction := func(accountID uint64, operation *domain.Operation, cents int64) *domain.Transaction {
+		transaction, err := domain.NewTransaction(domain.NewID(accountID), operation, domain.NewMoney(cents, domain.CurrencyBRL))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return transaction
+	}
+
+	tests := []struct {
+		name          string
+		transaction   *domain.Transaction
+		wantErr       error
+		wantAvailable string
+		wantBalance   string
+	}{
+		// fails
+		{
+			name:        "unknown account",
+			transaction: newTransaction(99, domain.OperationCompraAVista, 1000),
+			wantErr:     NewErrForeignKeyConstraint("transactions", "", "account_id", "id"),
+		},
+		{
+			name:        "amount greater than the available credit limit",
+			transaction: newTransaction(accountID.Value(), domain.OperationCompraAVista, 100001),
+			wantErr:     domain.NewErrDomain("amount", "'1000.01' exceeds the available credit limit '1000.00'"),
+		},
+
+		// successes
+		{
+			name:          "purchase",
+			transaction:   newTransaction(accountID.Value(), domain.OperationCompraAVista, 30025),
+			wantAvailable: "699.75",
+			wantBalance:   "-300.25",
+		},
+		{
+			name:          "withdraw",
+			transaction:   newTransaction(accountID.Value(), domain.OperationSaque, 10000),
+			wantAvailable: "599.75",
+			wantBalance:   "-400.25",
+		},
+		{
+			name:          "payment discharging the purchase",
+			transaction:   newTransaction(accountID.Value(), domain.OperationPagamento, 35025),
+			wantAvailable: "950.00",
+			wantBalance:   "-50.00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repository.Store(ctx, tt.transaction)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Store() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.ID().Value() == 0 {
+				t.Errorf("Store() must set the transaction id")
+			}
+
+			account, err := reader.FindOneByID(ctx, accountID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if account.AvailableCreditLimit().String() != tt.wantAvailable {
+				t.Errorf("AvailableCreditLimit() = %v, want %v", account.AvailableCreditLimit(), tt.wantAvailable)
+			}
+
+			balance, err := reader.BalanceByID(ctx, accountID, time.Now().Add(time.Hour))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if balance.String() != tt.wantBalance {
+				t.Errorf("BalanceByID() = %v, want %v", balance, tt.wantBalance)
+			}
+		})
+	}
+}
+
+func TestSQLite_Transaction_MinorUnits(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		conn       = newSQLiteStorage(t)
+		repository = NewTransaction(conn)
+		reader     = NewAccountReader(conn)
+	)
+
+	for _, tt := range []struct {
+		document      string
+		currency      domain.Currency
+		limit         int64
+		amount        int64
+		wantAvailable string
+	}{
+		{document: "00000000191", currency: "KWD", limit: 1000500, amount: 1234, wantAvailable: "999.266"},
+		{document: "52998224725", currency: "JPY", limit: 150000, amount: 1500, wantAvailable: "148500"},
+	} {
+		limit := domain.NewMoney(tt.limit, tt.currency)
+		account := newSQLiteAccount(t, tt.document, 0).
+			WithCurrency(tt.currency).
+			WithCreditLimit(limit).
+			WithAvailableCreditLimit(limit)
+
+		accountID, err := NewAccountWriter(conn).Store(ctx, account)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		purchase, err := domain.NewTransaction(accountID, domain.OperationCompraAVista, domain.NewMoney(tt.amount, tt.currency))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := repository.Store(ctx, purchase); err != nil {
+			t.Fatalf("Store() in %s error = %v", tt.currency, err)
+		}
+
+		got, err := reader.FindOneByID(ctx, accountID)
+		if err != nil || got.AvailableCreditLimit().String() != tt.wantAvailable {
+			t.Errorf("FindOneByID() in %s = %v, error = %v, want %s available", tt.currency, got, err, tt.wantAvailable)
+		}
+	}
+}
+
+func TestSQLite_Operation_Cache(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		conn       = newSQLiteStorage(t)
+		repository = NewOperation(conn)
+		other      = NewOperation(conn)
+		reader     = NewTransactionReader(conn)
+	)
+
+	accountID, err := NewAccountWriter(conn).Store(ctx, newSQLiteAccount(t, "52998224725", 100000))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filter, err := domain.NewTransactionFilter(accountID, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// loads the operations before another instance creates one
+	if _, err := reader.FindByFilter(ctx, filter); err != nil {
+		t.Fatalf("FindByFilter() error = %v", err)
+	}
+
+	boleto, err := domain.NewOperationType("pagamento boleto", domain.OperationDirectionCredit, domain.OperationKindPayment)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if boleto, err = boleto.Store(ctx, other); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+
+	payment, err := domain.NewTransaction(accountID, boleto, domain.NewMoney(1000, domain.CurrencyBRL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewTransaction(conn).Store(ctx, payment); err != nil {
+		t.Fatalf("Transaction.Store() error = %v", err)
+	}
+
+	page, err := reader.FindByFilter(ctx, filter)
+	if err != nil || len(page.Transactions()) != 1 || !page.Transactions()[0].Operation().IsPayment() {
+		t.Fatalf("FindByFilter() = %v, error = %v, want the payment of the operation created", page, err)
+	}
+
+	operations, err := repository.FindAll(ctx)
+	if err != nil || len(operations) != 11 {
+		t.Fatalf("FindAll() = %v, error = %v, want 11 operations", operations, err)
+	}
+
+	disabled := domain.OperationSaque.WithEnabled(false)
+
+	if err := other.UpdateEnabled(ctx, disabled); err != nil {
+		t.Fatal(err)
+	}
+
+	if operations, _ := repository.FindAll(ctx); !operations[2].IsEnabled() {
+		t.Errorf("FindAll() must use the operations cached until they expire")
+	}
+
+	if err := repository.UpdateEnabled(ctx, disabled); err != nil {
+		t.Fatal(err)
+	}
+
+	if operations, _ := repository.FindAll(ctx); operations[2].IsEnabled() {
+		t.Errorf("FindAll() must load the operations again once the repository changes them")
+	}
+}
+
+func TestSQLite_Outbox_ClaimUnpublished(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		conn  = newSQLiteStorage(t)
+		first = NewOutbox(conn)
+		other = NewOutbox(conn)
+		until = time.Now().Add(time.Minute)
+	)
+
+	for i := uint64(1); i <= 3; i++ {
+		event := domain.LoadEvent(nil, domain.EventAccountCreated, domain.NewID(i), []byte(`{}`), time.Now())
+
+		if _, err := first.Store(ctx, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ids := func(events []*domain.Event) []uint64 {
+		var ids []uint64
+
+		for _, event := range events {
+			ids = append(ids, event.ID().Value())
+		}
+
+		return ids
+	}
+
+	claimed, err := first.ClaimUnpublished(ctx, 2, until)
+	if err != nil || !reflect.DeepEqual(ids(claimed), []uint64{1, 2}) {
+		t.Fatalf("ClaimUnpublished() = %v, error = %v, want events 1 and 2", ids(claimed), err)
+	}
+
+	got, err := other.ClaimUnpublished(ctx, 2, until)
+	if err != nil || !reflect.DeepEqual(ids(got), []uint64{3}) {
+		t.Errorf("ClaimUnpublished() = %v, error = %v, want the event not claimed yet", ids(got), err)
+	}
+
+	if err := first.MarkPublished(ctx, claimed[0], time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := first.ReleaseClaims(ctx, claimed[1:]); err != nil {
+		t.Fatalf("ReleaseClaims() error = %v", err)
+	}
+
+	got, err = other.ClaimUnpublished(ctx, 2, until)
+	if err != nil || !reflect.DeepEqual(ids(got), []uint64{2}) {
+		t.Errorf("ClaimUnpublished() = %v, error = %v, want the released event", ids(got), err)
+	}
+
+	got, err = other.ClaimUnpublished(ctx, 2, time.Now().Add(time.Hour))
+	if err != nil || len(got) != 0 {
+		t.Errorf("ClaimUnpublished() = %v, error = %v, want no event", ids(got), err)
+	}
+}
+
+func TestSQLite_Idempotency_TakeOver(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		repository = NewIdempotency(newSQLiteStorage(t))
+		now        = time.Now()
+		key, _     = domain.NewIdempotencyKey("key-1", "fingerprint-1")
+	)
+
+	if _, err := repository.Store(ctx, key.WithCreatedAt(now.Add(-time.Hour))); err != nil {
+		t.Fatal(err)
+	}
+
+	createdBefore := now.Add(-time.Minute)
+
+	for _, want := range []bool{true, false} {
+		takenOver, err := repository.TakeOver(ctx, key.WithCreatedAt(now), createdBefore)
+		if err != nil || takenOver != want {
+			t.Errorf("TakeOver() = %v, error = %v, want %v", takenOver, err, want)
+		}
+	}
+
+	stored, err := repository.FindByKey(ctx, "key-1")
+	if err != nil || stored.CreatedAt().Unix() != now.Unix() {
+		t.Errorf("FindByKey() = %v, error = %v, want the key created at %v", stored, err, now)
+	}
+}
+
+func TestSQLite_Idempotency_DeleteCreatedBefore(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		repository = NewIdempotency(newSQLiteStorage(t))
+		now        = time.Now()
+		expired, _ = domain.NewIdempotencyKey("key-1", "fingerprint-1")
+		current, _ = domain.NewIdempotencyKey("key-2", "fingerprint-2")
+	)
+
+	for _, key := range []*domain.IdempotencyKey{expired.WithCreatedAt(now.Add(-2 * time.Hour)), current.WithCreatedAt(now)} {
+		if _, err := repository.Store(ctx, key); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deleted, err := repository.DeleteCreatedBefore(ctx, now.Add(-time.Hour))
+	if err != nil || deleted != 1 {
+		t.Errorf("DeleteCreatedBefore() = %v, error = %v, want 1", deleted, err)
+	}
+
+	if _, err := repository.FindByKey(ctx, "key-2"); err != nil {
+		t.Errorf("FindByKey() error = %v, want the key within the ttl", err)
+	}
+}
+
+// TestSQLite_UseCases runs the use cases over the SQLite repositories, as the app does with DB_DRIVER=sqlite
+func TestSQLite_UseCases(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		repos = NewRepositories(newSQLiteStorage(t))
+		brl   = func(cents int64) domain.Money { return domain.NewMoney(cents, domain.CurrencyBRL) }
+	)
+
+	createAccount := usecase.NewCreateAccount(repos.AccountWriter, repos.EventWriter, repos.UnitOfWork)
+
+	source, err := createAccount.Create(ctx, "00000000191", domain.CurrencyBRL, brl(100000), domain.BillingCycle{})
+	if err != nil {
+		t.Fatalf("CreateAccount.Create() error = %v", err)
+	}
+
+	destination, err := createAccount.Create(ctx, "52998224725", domain.CurrencyBRL, brl(0), domain.BillingCycle{})
+	if err != nil {
+		t.Fatalf("CreateAccount.Create() error = %v", err)
+	}
+
+	createTransaction := usecase.NewCreateTransaction(
+		repos.TransactionWriter,
+		repos.AccountReader,
+		repos.OperationReader,
+		repos.EventWriter,
+		repos.UnitOfWork,
+		domain.NewStaticExchangeRateProvider(),
+	)
+
+	purchase, err := createTransaction.Create(ctx, source.ID(), domain.NewID(1), brl(30000), 0)
+	if err != nil {
+		t.Fatalf("CreateTransaction.Create() error = %v", err)
+	}
+
+	if _, err := createTransaction.Create(ctx, source.ID(), domain.NewID(2), brl(30000), 3); err != nil {
+		t.Fatalf("CreateTransaction.Create() installments error = %v", err)
+	}
+
+	if _, err := usecase.NewCreateTransfer(repos.TransferWriter).Create(ctx, source.ID(), destination.ID(), brl(5000)); err != nil {
+		t.Fatalf("CreateTransfer.Create() error = %v", err)
+	}
+
+	if _, err := usecase.NewReverseTransaction(repos.ReversalWriter).Reverse(ctx, purchase.ID(), nil); err != nil {
+		t.Fatalf("ReverseTransaction.Reverse() error = %v", err)
+	}
+
+	balance, err := usecase.NewFindAccountBalance(repos.AccountReader).Find(ctx, source.ID(), time.Now().Add(time.Hour))
+	if err != nil || balance.Amount().String() != "-350.00" {
+		t.Errorf("FindAccountBalance.Find() = %v, error = %v, want -350.00", balance, err)
+	}
+
+	account, err := usecase.NewFindAccount(repos.AccountReader).Find(ctx, source.ID())
+	if err != nil || account.AvailableCreditLimit().String() != "650.00" {
+		t.Errorf("FindAccount.Find() = %v, error = %v, want 650.00 available", account, err)
+	}
+
+	filter, err := domain.NewTransactionFilter(source.ID(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	page, err := usecase.NewListTransactions(repos.AccountReader, repos.OperationReader, repos.TransactionReader).List(ctx, filter)
+	if err != nil || len(page.Transactions()) != 4 || page.Transactions()[2].InstallmentPlan() == nil {
+		t.Errorf("ListTransactions.List() = %v, error = %v, want 4 transactions", page, err)
+	}
+
+	if _, err := usecase.NewCreateWebhook(repos.WebhookWriter).Create(ctx, "https://partner.com/events", []string{"transaction.created"}); err != nil {
+		t.Fatalf("CreateWebhook.Create() error = %v", err)
+	}
+
+	schedule := usecase.NewScheduleWebhookDeliveries(repos.WebhookReader, repos.WebhookDeliveryWriter, time.Now)
+
+	relayed, err := usecase.NewRelayEvents(repos.EventWriter, schedule, 10).Relay(ctx)
+	if err != nil || relayed != 4 {
+		t.Errorf("RelayEvents.Relay() = %v, error = %v, want 4 events", relayed, err)
+	}
+
+	policy, err := domain.NewWebhookRetryPolicy(1, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		sender  = domain.NewWebhookSenderMock(map[uint64]error{1: errors.New("partner unavailable")})
+		deliver = usecase.NewDeliverWebhooks(repos.WebhookDeliveryReader, repos.WebhookDeliveryWriter, sender, policy, 10, time.Now)
+	)
+
+	if _, err := deliver.Deliver(ctx); err != nil {
+		t.Errorf("DeliverWebhooks.Deliver() error = %v", err)
+	}
+
+	failed, err := usecase.NewListFailedWebhookDeliveries(repos.WebhookDeliveryReader).List(ctx)
+	if err != nil || len(failed) != 2 {
+		t.Fatalf("ListFailedWebhookDeliveries.List() = %v, error = %v, want 2 deliveries", failed, err)
+	}
+
+	replay := usecase.NewReplayWebhookDelivery(repos.WebhookDeliveryReader, repos.WebhookDeliveryWriter, time.Now)
+	if _, err := replay.Replay(ctx, failed[0].ID()); err != nil {
+		t.Errorf("ReplayWebhookDelivery.Replay() error = %v", err)
+	}
+
+	var (
+		now     = time.Now().UTC()
+		closing = time.Date(now.Year(), now.Month()+1, 25, 23, 59, 59, 0, time.UTC)
+	)
+
+	closed, err := usecase.NewCloseBillingCycles(
+		repos.AccountReader,
+		repos.StatementReader,
+		repos.InvoiceReader,
+		repos.InvoiceWriter,
+	).Close(ctx, closing.Add(time.Hour))
+	if err != nil || closed != 2 {
+		t.Fatalf("CloseBillingCycles.Close() = %v, error = %v, want 2 invoices", closed, err)
+	}
+
+	invoices, err := usecase.NewListInvoices(repos.AccountReader, repos.InvoiceReader).List(ctx, source.ID())
+	if err != nil || len(invoices) != 1 || len(invoices[0].Items()) == 0 {
+		t.Errorf("ListInvoices.List() = %v, error = %v, want 1 invoice with items", invoices, err)
+	}
+
+	idempotency := usecase.NewIdempotency(repos.Idempotency, time.Now, time.Minute, time.Hour)
+
+	key, replayed, err := idempotency.Start(ctx, "key-1", "fingerprint")
+	if err != nil || replayed {
+		t.Fatalf("Idempotency.Start() replay = %v, error = %v", replayed, err)
+	}
+
+	if err := idempotency.Finish(ctx, key, 201, []byte(`{"id":1}`)); err != nil {
+		t.Errorf("Idempotency.Finish() error = %v", err)
+	}
+
+	key, replayed, err = idempotency.Start(ctx, "key-1", "fingerprint")
+	if err != nil || !replayed || string(key.ResponseBody()) != `{"id":1}` {
+		t.Errorf("Idempotency.Start() = %v, replay = %v, error = %v, want the stored response", key, replayed, err)
+	}
+}
@@ -1,48 +1,277 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"time"
 
-	"github.com/go-sql-driver/mysql"
 	"github.com/pkg/errors"
 	"github.com/tonytcb/bank-transactions-go/domain"
 )
 
 // Transaction exposes transaction database operations
 type Transaction struct {
-	conn *sql.DB
+	conn       *sql.DB
+	dialect    dialect
+	operations *operationCache
 }
 
 // NewTransaction build a new Transaction struct with its dependencies
 func NewTransaction(conn *sql.DB) *Transaction {
-	return &Transaction{conn: conn}
+	return &Transaction{conn: conn, dialect: dialectOf(conn), operations: newOperationCache()}
 }
 
-// Store stores a transaction in the storage
-func (t Transaction) Store(transaction *domain.Transaction) (*domain.ID, error) {
+// Store stores a transaction in the storage, updating the available credit limit of its account in the same
+// database transaction. The account row is locked until the end, so concurrent transactions cannot overspend.
+// Payments are discharged against the open debits of the account, from the oldest to the newest.
+func (t Transaction) Store(ctx context.Context, transaction *domain.Transaction) (*domain.Transaction, error) {
+	tx, err := beginTx(ctx, t.conn)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	account, err := lockAccount(ctx, tx, t.dialect, transaction.Account().ID(), "transactions", "account_id")
+	if err != nil {
+		return nil, err
+	}
+
+	account, err = account.ApplyTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := updateAvailableCreditLimit(ctx, tx, t.dialect, account); err != nil {
+		return nil, err
+	}
+
+	var discharged []*domain.Transaction
+
+	if transaction.Operation().IsPayment() {
+		debits, err := t.findOpenDebits(ctx, tx, account.ID())
+		if err != nil {
+			return nil, err
+		}
+
+		transaction, discharged = transaction.Discharge(debits)
+	}
+
+	id, err := insertTransaction(ctx, tx, t.dialect, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction = transaction.WithID(domain.NewID(id))
+
+	for _, debit := range discharged {
+		if err := updateBalance(ctx, tx, t.dialect, debit); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := t.storeAllocations(ctx, tx, id, transaction.Allocations()); err != nil {
+		return nil, err
+	}
+
+	if plan := transaction.InstallmentPlan(); plan != nil {
+		if err := t.storeInstallments(ctx, tx, id, plan); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit transaction error")
+	}
+
+	return transaction, nil
+}
+
+// findOpenDebits finds the transactions of the account with a negative balance, from the oldest to the newest
+func (t Transaction) findOpenDebits(ctx context.Context, tx executor, accountID *domain.ID) ([]*domain.Transaction, error) {
 	var query = `
-		INSERT INTO transactions (account_id, operation_id, amount)
-		VALUES (?, ?, ?)
+		SELECT ` + transactionColumns + `
+		FROM transactions
+		WHERE account_id = ? AND balance < 0
+		ORDER BY created_at, id
+		` + t.dialect.lock + `
 	`
 
-	stmt, err := t.conn.Prepare(query)
+	var debits []*domain.Transaction
+
+	err := t.operations.with(ctx, tx, func(operations *domain.Operations) error {
+		rows, err := tx.QueryContext(ctx, t.dialect.query(query), accountID.Value())
+		if err != nil {
+			return errors.Wrap(err, "database error")
+		}
+		defer rows.Close()
+
+		debits, err = scanTransactions(rows, operations)
+
+		return err
+	})
 	if err != nil {
-		return nil, errors.Wrap(err, "prepare statement error")
+		return nil, err
 	}
 
-	result, err := stmt.Exec(transaction.Account().ID().Value(), transaction.Operation().ID().Value(), transaction.Amount())
+	return debits, nil
+}
+
+// storeAllocations stores how the payment was allocated to discharge the debits of the account
+func (t Transaction) storeAllocations(ctx context.Context, tx executor, paymentID uint64, allocations []*domain.PaymentAllocation) error {
+	if len(allocations) == 0 {
+		return nil
+	}
+
+	var query = `
+		INSERT INTO payment_allocations (payment_id, transaction_id, amount)
+		VALUES (?, ?, ?)
+	`
+
+	stmt, err := tx.PrepareContext(ctx, t.dialect.query(query))
 	if err != nil {
-		if v, ok := err.(*mysql.MySQLError); ok {
-			return nil, translateMySQLErrors(v)
+		return errors.Wrap(err, "prepare statement error")
+	}
+	defer stmt.Close()
+
+	for _, v := range allocations {
+		if _, err := stmt.ExecContext(ctx, paymentID, v.TransactionID().Value(), v.Amount().String()); err != nil {
+			return errors.Wrap(err, "error to store the payment allocations")
 		}
+	}
+
+	return nil
+}
+
+// lockAccount loads the credit limits of an account, locking its row until the end of the database transaction.
+// A missing account is reported as a foreign key error of the table and column referencing it.
+func lockAccount(ctx context.Context, tx executor, d dialect, id *domain.ID, table, foreignKey string) (*domain.Account, error) {
+	var (
+		currency             string
+		creditLimit          decimal
+		availableCreditLimit decimal
+		status               string
+		query                = `
+			SELECT currency, credit_limit, available_credit_limit, status
+			FROM accounts
+			WHERE id = ?
+			` + d.lock + `
+		`
+	)
+
+	row := tx.QueryRowContext(ctx, d.query(query), id.Value())
+
+	if err := row.Scan(&currency, &creditLimit, &availableCreditLimit, &status); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, NewErrForeignKeyConstraint(table, "", foreignKey, "id")
+		}
+
+		return nil, errors.Wrap(err, "error to lock the account")
+	}
+
+	limit, err := decimalToMoney(string(creditLimit), domain.Currency(currency))
+	if err != nil {
+		return nil, NewErrLoadInvalidData("accounts")
+	}
+
+	available, err := decimalToMoney(string(availableCreditLimit), domain.Currency(currency))
+	if err != nil {
+		return nil, NewErrLoadInvalidData("accounts")
+	}
+
+	account := new(domain.Account).
+		WithID(id).
+		WithCurrency(domain.Currency(currency)).
+		WithCreditLimit(limit).
+		WithAvailableCreditLimit(available).
+		WithStatus(domain.AccountStatus(status), "", time.Time{})
+
+	return account, nil
+}
+
+// updateAvailableCreditLimit stores the available credit limit of an account
+func updateAvailableCreditLimit(ctx context.Context, tx executor, d dialect, account *domain.Account) error {
+	var query = `UPDATE accounts SET available_credit_limit = ? WHERE id = ?`
 
-		return nil, errors.Wrap(err, "unknown database error")
+	if _, err := tx.ExecContext(ctx, d.query(query), account.AvailableCreditLimit().String(), account.ID().Value()); err != nil {
+		return errors.Wrap(err, "error to update the available credit limit")
 	}
 
-	id, err := result.LastInsertId()
+	return nil
+}
+
+// updateBalance stores the balance of a transaction
+func updateBalance(ctx context.Context, tx executor, d dialect, transaction *domain.Transaction) error {
+	var query = `UPDATE transactions SET balance = ? WHERE id = ?`
+
+	if _, err := tx.ExecContext(ctx, d.query(query), transaction.Balance().String(), transaction.ID().Value()); err != nil {
+		return errors.Wrap(err, "error to update the transaction balance")
+	}
+
+	return nil
+}
+
+// insertTransaction inserts a transaction, returning its generated id
+func insertTransaction(ctx context.Context, tx executor, d dialect, transaction *domain.Transaction) (uint64, error) {
+	var (
+		transferID  interface{}
+		reversalOf  interface{}
+		accrualDate interface{}
+		query       = `
+			INSERT INTO transactions
+				(account_id, operation_id, amount, currency, original_amount, original_currency, exchange_rate, transfer_id, reversal_of, balance,
+				accrual_date)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`
+	)
+
+	if v := transaction.TransferID(); v != nil {
+		transferID = v.Value()
+	}
+
+	if v := transaction.ReversalOf(); v != nil {
+		reversalOf = v.Value()
+	}
+
+	if v := transaction.AccrualDate(); !v.IsZero() {
+		accrualDate = v.Format(dateLayout)
+	}
+
+	return d.insert(
+		ctx,
+		tx,
+		query,
+		transaction.Account().ID().Value(),
+		transaction.Operation().ID().Value(),
+		transaction.Amount().String(),
+		transaction.Amount().Currency().String(),
+		transaction.OriginalAmount().String(),
+		transaction.OriginalAmount().Currency().String(),
+		transaction.ExchangeRate().Rate(),
+		transferID,
+		reversalOf,
+		transaction.Balance().String(),
+		accrualDate,
+	)
+}
+
+// storeInstallments stores the scheduled installments of the transaction
+func (t Transaction) storeInstallments(ctx context.Context, tx executor, transactionID uint64, plan *domain.InstallmentPlan) error {
+	var query = `
+		INSERT INTO installments (transaction_id, number, amount, due_date)
+		VALUES (?, ?, ?, ?)
+	`
+
+	stmt, err := tx.PrepareContext(ctx, t.dialect.query(query))
 	if err != nil {
-		return nil, errors.Wrap(err, "error to read the last inserted id")
+		return errors.Wrap(err, "prepare statement error")
+	}
+	defer stmt.Close()
+
+	for _, v := range plan.Installments() {
+		if _, err := stmt.ExecContext(ctx, transactionID, v.Number(), v.Amount().String(), v.DueDate().Format(dateLayout)); err != nil {
+			return errors.Wrap(err, "error to store the installments")
+		}
 	}
 
-	return domain.NewID(uint64(id)), nil
+	return nil
 }
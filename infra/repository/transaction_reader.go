@@ -0,0 +1,360 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// TransactionReader exposes transaction read database operations
+type TransactionReader struct {
+	conn       *sql.DB
+	dialect    dialect
+	operations *operationCache
+}
+
+// transactionColumns are the columns loaded by scanTransaction, in the same order
+const transactionColumns = `id, account_id, operation_id, amount, currency, original_amount, original_currency, exchange_rate,
+	transfer_id, reversal_of, reversed_amount, balance, accrual_date, created_at`
+
+// rowScanner is implemented by both sql.Row and sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// NewTransactionReader build a new TransactionReader struct with its dependencies
+func NewTransactionReader(conn *sql.DB) *TransactionReader {
+	return &TransactionReader{conn: conn, dialect: dialectOf(conn), operations: newOperationCache()}
+}
+
+// FindByFilter finds a page of transactions matching the filter, using the transaction id as the pagination key
+func (t TransactionReader) FindByFilter(ctx context.Context, filter *domain.TransactionFilter) (*domain.TransactionPage, error) {
+	var (
+		conditions = []string{"account_id = ?"}
+		args       = []interface{}{filter.AccountID().Value()}
+		order      = "DESC"
+	)
+
+	if ops := filter.Operations(); len(ops) > 0 {
+		placeholders := make([]string, len(ops))
+		for i, op := range ops {
+			placeholders[i] = "?"
+			args = append(args, op.Value())
+		}
+
+		conditions = append(conditions, "operation_id IN ("+strings.Join(placeholders, ", ")+")")
+	}
+
+	if v := filter.From(); v != nil {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, v.UTC().Format(timestampLayout))
+	}
+
+	if v := filter.To(); v != nil {
+		conditions = append(conditions, "created_at <= ?")
+		args = append(args, v.UTC().Format(timestampLayout))
+	}
+
+	if v := filter.MinAmount(); v != nil {
+		conditions = append(conditions, "ABS(amount) >= ?")
+		args = append(args, v.String())
+	}
+
+	if v := filter.MaxAmount(); v != nil {
+		conditions = append(conditions, "ABS(amount) <= ?")
+		args = append(args, v.String())
+	}
+
+	if c := filter.Cursor(); c != nil {
+		if c.Direction() == domain.CursorPrev {
+			conditions = append(conditions, "id > ?")
+			order = "ASC"
+		} else {
+			conditions = append(conditions, "id < ?")
+		}
+
+		args = append(args, c.ID().Value())
+	}
+
+	// loads one extra register to identify whether there is another page
+	args = append(args, filter.Limit()+1)
+
+	query := `
+		SELECT ` + transactionColumns + `
+		FROM transactions
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY id ` + order + `
+		LIMIT ?
+	`
+
+	var transactions []*domain.Transaction
+
+	err := t.operations.with(ctx, executorOf(ctx, t.conn), func(operations *domain.Operations) error {
+		rows, err := executorOf(ctx, t.conn).QueryContext(ctx, t.dialect.query(query), args...)
+		if err != nil {
+			return errors.Wrap(err, "database error")
+		}
+		defer rows.Close()
+
+		transactions, err = scanTransactions(rows, operations)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err = t.loadInstallmentPlans(ctx, transactions)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewTransactionPage(transactions, filter), nil
+}
+
+// WalkByPeriod calls fn with each transaction of the account created in the period, from the oldest to the newest.
+// The transactions are loaded in pages, so a long period is never loaded at once, and the connection is not held
+// while fn runs.
+func (t TransactionReader) WalkByPeriod(
+	ctx context.Context,
+	accountID *domain.ID,
+	from, to time.Time,
+	fn func(*domain.Transaction) error,
+) error {
+	const pageSize = 500
+
+	var (
+		lastID uint64
+		query  = `
+			SELECT ` + transactionColumns + `
+			FROM transactions
+			WHERE account_id = ? AND created_at >= ? AND created_at <= ? AND id > ?
+			ORDER BY id ASC
+			LIMIT ?
+		`
+	)
+
+	for {
+		var transactions []*domain.Transaction
+
+		err := t.operations.with(ctx, executorOf(ctx, t.conn), func(operations *domain.Operations) error {
+			rows, err := executorOf(ctx, t.conn).QueryContext(
+				ctx,
+				t.dialect.query(query),
+				accountID.Value(),
+				from.UTC().Format(timestampLayout),
+				to.UTC().Format(timestampLayout),
+				lastID,
+				pageSize,
+			)
+			if err != nil {
+				return errors.Wrap(err, "database error")
+			}
+			defer rows.Close()
+
+			transactions, err = scanTransactions(rows, operations)
+
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, transaction := range transactions {
+			if err := fn(transaction); err != nil {
+				return err
+			}
+		}
+
+		if len(transactions) < pageSize {
+			return nil
+		}
+
+		lastID = transactions[len(transactions)-1].ID().Value()
+	}
+}
+
+// loadInstallmentPlans loads the installment plans of the transactions using a single query
+func (t TransactionReader) loadInstallmentPlans(ctx context.Context, transactions []*domain.Transaction) ([]*domain.Transaction, error) {
+	if len(transactions) == 0 {
+		return transactions, nil
+	}
+
+	var (
+		placeholders = make([]string, len(transactions))
+		args         = make([]interface{}, len(transactions))
+		installments = make(map[uint64][]*domain.Installment)
+	)
+
+	for i, v := range transactions {
+		placeholders[i] = "?"
+		args[i] = v.ID().Value()
+	}
+
+	query := `
+		SELECT i.transaction_id, i.number, i.amount, t.currency, i.due_date
+		FROM installments i
+		INNER JOIN transactions t ON t.id = i.transaction_id
+		WHERE i.transaction_id IN (` + strings.Join(placeholders, ", ") + `)
+		ORDER BY i.transaction_id, i.number
+	`
+
+	rows, err := executorOf(ctx, t.conn).QueryContext(ctx, t.dialect.query(query), args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "database error")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			transactionID uint64
+			number        int
+			amount        decimal
+			currency      string
+			dueDate       timestamp
+		)
+
+		if err := rows.Scan(&transactionID, &number, &amount, &currency, &dueDate); err != nil {
+			return nil, errors.Wrap(err, "error to scan the installment")
+		}
+
+		money, err := decimalToMoney(string(amount), domain.Currency(currency))
+		if err != nil {
+			return nil, NewErrLoadInvalidData("installments")
+		}
+
+		installments[transactionID] = append(installments[transactionID], domain.NewInstallment(number, money, dueDate.Time))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "error to read the installments")
+	}
+
+	for i, v := range transactions {
+		if list, ok := installments[v.ID().Value()]; ok {
+			transactions[i] = v.WithInstallmentPlan(domain.LoadInstallmentPlan(list))
+		}
+	}
+
+	return transactions, nil
+}
+
+// scanTransactions scans all the transactions of the rows, loaded with the transactionColumns
+func scanTransactions(rows *sql.Rows, operations *domain.Operations) ([]*domain.Transaction, error) {
+	var transactions []*domain.Transaction
+
+	for rows.Next() {
+		transaction, err := scanTransaction(rows, operations)
+		if err != nil {
+			return nil, err
+		}
+
+		transactions = append(transactions, transaction)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "error to read the transactions")
+	}
+
+	return transactions, nil
+}
+
+// scanTransaction scans a transaction loaded with the transactionColumns, finding its operation in the operations
+func scanTransaction(row rowScanner, operations *domain.Operations) (*domain.Transaction, error) {
+	var (
+		id               uint64
+		accountID        uint64
+		operationID      uint64
+		amount           decimal
+		currency         string
+		originalAmount   decimal
+		originalCurrency string
+		exchangeRate     decimal
+		transferID       sql.NullInt64
+		reversalOf       sql.NullInt64
+		reversedAmount   decimal
+		balance          decimal
+		accrualDate      timestamp
+		createdAt        timestamp
+	)
+
+	err := row.Scan(
+		&id,
+		&accountID,
+		&operationID,
+		&amount,
+		&currency,
+		&originalAmount,
+		&originalCurrency,
+		&exchangeRate,
+		&transferID,
+		&reversalOf,
+		&reversedAmount,
+		&balance,
+		&accrualDate,
+		&createdAt,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "error to scan the transaction")
+	}
+
+	money, err := decimalToMoney(string(amount), domain.Currency(currency))
+	if err != nil {
+		return nil, NewErrLoadInvalidData("transactions")
+	}
+
+	original, err := decimalToMoney(string(originalAmount), domain.Currency(originalCurrency))
+	if err != nil {
+		return nil, NewErrLoadInvalidData("transactions")
+	}
+
+	rate, err := domain.NewExchangeRate(original.Currency(), money.Currency(), string(exchangeRate))
+	if err != nil {
+		return nil, NewErrLoadInvalidData("transactions")
+	}
+
+	reversed, err := decimalToMoney(string(reversedAmount), domain.Currency(currency))
+	if err != nil {
+		return nil, NewErrLoadInvalidData("transactions")
+	}
+
+	remaining, err := decimalToMoney(string(balance), domain.Currency(currency))
+	if err != nil {
+		return nil, NewErrLoadInvalidData("transactions")
+	}
+
+	operation, err := operations.Find(domain.NewID(operationID))
+	if err != nil {
+		return nil, errUnknownOperation
+	}
+
+	transaction, err := domain.NewTransaction(domain.NewID(accountID), operation, money.Abs())
+	if err != nil {
+		return nil, NewErrLoadInvalidData("transactions")
+	}
+
+	transaction = transaction.
+		WithID(domain.NewID(id)).
+		WithCreatedAt(createdAt.Time).
+		WithConversion(money, original, rate).
+		WithReversedAmount(reversed).
+		WithBalance(remaining)
+
+	if transferID.Valid {
+		transaction = transaction.WithTransferID(domain.NewID(uint64(transferID.Int64)))
+	}
+
+	if reversalOf.Valid {
+		transaction = transaction.WithReversalOf(domain.NewID(uint64(reversalOf.Int64)))
+	}
+
+	if !accrualDate.IsZero() {
+		transaction = transaction.WithAccrualDate(accrualDate.Time)
+	}
+
+	return transaction, nil
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// Transfer exposes transfer database operations
+type Transfer struct {
+	conn    *sql.DB
+	dialect dialect
+}
+
+// NewTransfer build a new Transfer struct with its dependencies
+func NewTransfer(conn *sql.DB) *Transfer {
+	return &Transfer{conn: conn, dialect: dialectOf(conn)}
+}
+
+// Store stores a transfer and its debit and credit postings in a single database transaction, updating the available
+// credit limit of both accounts. The account rows are locked in id order, so concurrent transfers cannot deadlock.
+func (t Transfer) Store(ctx context.Context, transfer *domain.Transfer) (*domain.Transfer, error) {
+	tx, err := beginTx(ctx, t.conn)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	source, destination, err := t.lockAccounts(ctx, tx, transfer)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer, err = transfer.Apply(source, destination)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, account := range []*domain.Account{transfer.Source(), transfer.Destination()} {
+		if err := updateAvailableCreditLimit(ctx, tx, t.dialect, account); err != nil {
+			return nil, err
+		}
+	}
+
+	var query = `
+		INSERT INTO transfers (source_account_id, destination_account_id, amount, currency)
+		VALUES (?, ?, ?, ?)
+	`
+
+	id, err := t.dialect.insert(
+		ctx,
+		tx,
+		query,
+		transfer.Source().ID().Value(),
+		transfer.Destination().ID().Value(),
+		transfer.Amount().String(),
+		transfer.Amount().Currency().String(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer = transfer.WithID(domain.NewID(id))
+
+	for _, posting := range []*domain.Transaction{transfer.Debit(), transfer.Credit()} {
+		if _, err := insertTransaction(ctx, tx, t.dialect, posting); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit transaction error")
+	}
+
+	return transfer, nil
+}
+
+// lockAccounts locks the source and destination accounts of the transfer, always in the same order
+func (t Transfer) lockAccounts(ctx context.Context, tx executor, transfer *domain.Transfer) (*domain.Account, *domain.Account, error) {
+	var (
+		source      *domain.Account
+		destination *domain.Account
+		err         error
+	)
+
+	lockSource := func() error {
+		source, err = lockAccount(ctx, tx, t.dialect, transfer.Source().ID(), "transfers", "source_account_id")
+		return err
+	}
+
+	lockDestination := func() error {
+		destination, err = lockAccount(ctx, tx, t.dialect, transfer.Destination().ID(), "transfers", "destination_account_id")
+		return err
+	}
+
+	locks := []func() error{lockSource, lockDestination}
+	if transfer.Destination().ID().Value() < transfer.Source().ID().Value() {
+		locks = []func() error{lockDestination, lockSource}
+	}
+
+	for _, lock := range locks {
+		if err := lock(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return source, destination, nil
+}
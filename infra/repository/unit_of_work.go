@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+)
+
+// txContextKey identifies the database transaction of a unit of work in the context
+type txContextKey struct{}
+
+// executor represents the operations shared by a database connection and a database transaction
+type executor interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+	PrepareContext(context.Context, string) (*sql.Stmt, error)
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}
+
+// UnitOfWork runs functions inside a single database transaction, shared by all repositories through the context
+type UnitOfWork struct {
+	conn *sql.DB
+}
+
+// NewUnitOfWork builds a new UnitOfWork struct with its dependencies
+func NewUnitOfWork(conn *sql.DB) *UnitOfWork {
+	return &UnitOfWork{conn: conn}
+}
+
+// Do runs fn inside a database transaction, committed when fn succeeds and rolled back otherwise. The repositories
+// called with the context received by fn run their queries in this transaction. When the context already carries a
+// transaction, fn joins it, and it is committed by the outermost unit of work.
+func (u UnitOfWork) Do(ctx context.Context, fn func(context.Context) error) error {
+	tx, err := beginTx(ctx, u.conn)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, txContextKey{}, tx.Tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "commit transaction error")
+	}
+
+	return nil
+}
+
+// dbTx is a database transaction begun by a repository. When the context already carries the transaction of a unit of
+// work, the repository joins it, and committing or rolling it back is left to the unit of work.
+type dbTx struct {
+	*sql.Tx
+	joined bool
+}
+
+// beginTx begins a database transaction, or joins the transaction carried by the context
+func beginTx(ctx context.Context, conn *sql.DB) (*dbTx, error) {
+	if tx, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return &dbTx{Tx: tx, joined: true}, nil
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "begin transaction error")
+	}
+
+	return &dbTx{Tx: tx}, nil
+}
+
+// Commit commits the transaction, unless it was joined
+func (t dbTx) Commit() error {
+	if t.joined {
+		return nil
+	}
+
+	return t.Tx.Commit()
+}
+
+// Rollback rolls the transaction back, unless it was joined
+func (t dbTx) Rollback() error {
+	if t.joined {
+		return nil
+	}
+
+	return t.Tx.Rollback()
+}
+
+// executorOf returns the transaction carried by the context, or the connection when there is none
+func executorOf(ctx context.Context, conn *sql.DB) executor {
+	if tx, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return tx
+	}
+
+	return conn
+}
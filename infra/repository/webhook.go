@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// Webhook exposes the webhooks database operations. The event types of a webhook are stored comma separated.
+type Webhook struct {
+	conn    *sql.DB
+	dialect dialect
+}
+
+// NewWebhook build a new Webhook struct with its dependencies
+func NewWebhook(conn *sql.DB) *Webhook {
+	return &Webhook{conn: conn, dialect: dialectOf(conn)}
+}
+
+// Store stores a webhook
+func (w Webhook) Store(ctx context.Context, webhook *domain.Webhook) (*domain.ID, error) {
+	var query = `INSERT INTO webhooks (url, event_types, secret) VALUES (?, ?, ?)`
+
+	id, err := w.dialect.insert(
+		ctx,
+		executorOf(ctx, w.conn),
+		query,
+		webhook.URL(),
+		eventTypesToColumn(webhook.EventTypes()),
+		webhook.Secret(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewID(id), nil
+}
+
+// FindByEventType finds the webhooks subscribed to the event type
+func (w Webhook) FindByEventType(ctx context.Context, eventType domain.EventType) ([]*domain.Webhook, error) {
+	var query = `
+		SELECT id, url, event_types, secret, created_at
+		FROM webhooks
+		WHERE ` + w.dialect.concat("','", "event_types", "','") + ` LIKE ?
+		ORDER BY id ASC
+	`
+
+	rows, err := executorOf(ctx, w.conn).QueryContext(ctx, w.dialect.query(query), "%,"+eventType.String()+",%")
+	if err != nil {
+		return nil, errors.Wrap(err, "database error")
+	}
+	defer rows.Close()
+
+	var webhooks []*domain.Webhook
+
+	for rows.Next() {
+		var (
+			id         uint64
+			url        string
+			eventTypes string
+			secret     string
+			createdAt  timestamp
+		)
+
+		if err := rows.Scan(&id, &url, &eventTypes, &secret, &createdAt); err != nil {
+			return nil, errors.Wrap(err, "error to read the webhook")
+		}
+
+		webhooks = append(webhooks, domain.LoadWebhook(
+			domain.NewID(id),
+			url,
+			columnToEventTypes(eventTypes),
+			secret,
+			createdAt.Time,
+		))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "error to read the webhooks")
+	}
+
+	return webhooks, nil
+}
+
+func eventTypesToColumn(eventTypes []domain.EventType) string {
+	values := make([]string, len(eventTypes))
+
+	for i, v := range eventTypes {
+		values[i] = v.String()
+	}
+
+	return strings.Join(values, ",")
+}
+
+func columnToEventTypes(v string) []domain.EventType {
+	values := strings.Split(v, ",")
+	eventTypes := make([]domain.EventType, len(values))
+
+	for i, v := range values {
+		eventTypes[i] = domain.EventType(v)
+	}
+
+	return eventTypes
+}
@@ -0,0 +1,319 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// WebhookDelivery exposes the webhook deliveries database operations. The deliveries whose attempts all failed are
+// moved from the webhook_deliveries table to the webhook_dead_letters table, keeping their ids.
+type WebhookDelivery struct {
+	conn    *sql.DB
+	dialect dialect
+}
+
+// NewWebhookDelivery build a new WebhookDelivery struct with its dependencies
+func NewWebhookDelivery(conn *sql.DB) *WebhookDelivery {
+	return &WebhookDelivery{conn: conn, dialect: dialectOf(conn)}
+}
+
+// Store stores a delivery, returning the id of the delivery already stored for the same webhook and event
+func (w WebhookDelivery) Store(ctx context.Context, delivery *domain.WebhookDelivery) (*domain.ID, error) {
+	var (
+		tx     = executorOf(ctx, w.conn)
+		insert = `
+			INSERT INTO webhook_deliveries (webhook_id, event_id, next_attempt_at)
+			VALUES (?, ?, ?)
+			` + w.dialect.ignoreDuplicate("webhook_id", "event_id") + `
+		`
+	)
+
+	_, err := tx.ExecContext(
+		ctx,
+		w.dialect.query(insert),
+		delivery.Webhook().ID().Value(),
+		delivery.Event().ID().Value(),
+		delivery.NextAttemptAt().UTC().Format(timestampLayout),
+	)
+	if err != nil {
+		return nil, w.dialect.translateErrors(err, "error to store the webhook delivery")
+	}
+
+	var (
+		id    uint64
+		query = `SELECT id FROM webhook_deliveries WHERE webhook_id = ? AND event_id = ?`
+	)
+
+	err = tx.QueryRowContext(ctx, w.dialect.query(query), delivery.Webhook().ID().Value(), delivery.Event().ID().Value()).
+		Scan(&id)
+	if err != nil {
+		return nil, errors.Wrap(err, "error to get the webhook delivery id")
+	}
+
+	return domain.NewID(id), nil
+}
+
+// Update updates the attempts of a delivery, registering when it was delivered
+func (w WebhookDelivery) Update(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	var (
+		deliveredAt interface{}
+		query       = `
+			UPDATE webhook_deliveries
+			SET attempts = ?, next_attempt_at = ?, last_attempt_at = ?, last_error = ?, delivered_at = ?
+			WHERE id = ?
+		`
+	)
+
+	if delivery.Status() == domain.WebhookDeliveryDelivered {
+		deliveredAt = delivery.LastAttemptAt().UTC().Format(timestampLayout)
+	}
+
+	_, err := executorOf(ctx, w.conn).ExecContext(
+		ctx,
+		w.dialect.query(query),
+		delivery.Attempts(),
+		delivery.NextAttemptAt().UTC().Format(timestampLayout),
+		nullableTimestamp(delivery.LastAttemptAt()),
+		delivery.LastError(),
+		deliveredAt,
+		delivery.ID().Value(),
+	)
+	if err != nil {
+		return errors.Wrap(err, "error to update the webhook delivery")
+	}
+
+	return nil
+}
+
+// MoveToDeadLetter moves a failed delivery to the dead letters
+func (w WebhookDelivery) MoveToDeadLetter(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	tx, err := beginTx(ctx, w.conn)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var insert = `
+		INSERT INTO webhook_dead_letters (id, webhook_id, event_id, attempts, last_attempt_at, last_error)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	_, err = tx.ExecContext(
+		ctx,
+		w.dialect.query(insert),
+		delivery.ID().Value(),
+		delivery.Webhook().ID().Value(),
+		delivery.Event().ID().Value(),
+		delivery.Attempts(),
+		delivery.LastAttemptAt().UTC().Format(timestampLayout),
+		delivery.LastError(),
+	)
+	if err != nil {
+		return errors.Wrap(err, "error to store the webhook dead letter")
+	}
+
+	if _, err := tx.ExecContext(ctx, w.dialect.query(`DELETE FROM webhook_deliveries WHERE id = ?`), delivery.ID().Value()); err != nil {
+		return errors.Wrap(err, "error to delete the webhook delivery")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "commit transaction error")
+	}
+
+	return nil
+}
+
+// Replay moves a failed delivery back to the deliveries, keeping its id
+func (w WebhookDelivery) Replay(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	tx, err := beginTx(ctx, w.conn)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var insert = `
+		INSERT INTO webhook_deliveries (id, webhook_id, event_id, attempts, next_attempt_at, last_attempt_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	_, err = tx.ExecContext(
+		ctx,
+		w.dialect.query(insert),
+		delivery.ID().Value(),
+		delivery.Webhook().ID().Value(),
+		delivery.Event().ID().Value(),
+		delivery.Attempts(),
+		delivery.NextAttemptAt().UTC().Format(timestampLayout),
+		nullableTimestamp(delivery.LastAttemptAt()),
+	)
+	if err != nil {
+		return w.dialect.translateErrors(err, "error to replay the webhook delivery")
+	}
+
+	if _, err := tx.ExecContext(ctx, w.dialect.query(`DELETE FROM webhook_dead_letters WHERE id = ?`), delivery.ID().Value()); err != nil {
+		return errors.Wrap(err, "error to delete the webhook dead letter")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "commit transaction error")
+	}
+
+	return nil
+}
+
+// FindDue finds the pending deliveries whose next attempt is due, from the oldest to the newest
+func (w WebhookDelivery) FindDue(ctx context.Context, at time.Time, limit int) ([]*domain.WebhookDelivery, error) {
+	var query = `
+		SELECT d.id, d.attempts, d.next_attempt_at, d.last_attempt_at, COALESCE(d.last_error, ''),
+			w.id, w.url, w.event_types, w.secret, w.created_at,
+			o.id, o.event_type, o.aggregate_id, o.payload, o.occurred_at
+		FROM webhook_deliveries d
+		INNER JOIN webhooks w ON w.id = d.webhook_id
+		INNER JOIN outbox o ON o.id = d.event_id
+		WHERE d.delivered_at IS NULL AND d.next_attempt_at <= ?
+		ORDER BY d.next_attempt_at ASC, d.id ASC
+		LIMIT ?
+	`
+
+	return w.findDeliveries(ctx, domain.WebhookDeliveryPending, query, at.UTC().Format(timestampLayout), limit)
+}
+
+// FindDeadLetters finds the failed deliveries, from the newest to the oldest
+func (w WebhookDelivery) FindDeadLetters(ctx context.Context) ([]*domain.WebhookDelivery, error) {
+	return w.findDeliveries(ctx, domain.WebhookDeliveryFailed, deadLettersQuery(""))
+}
+
+// FindDeadLetterByID finds a failed delivery by its id
+func (w WebhookDelivery) FindDeadLetterByID(ctx context.Context, id *domain.ID) (*domain.WebhookDelivery, error) {
+	deliveries, err := w.findDeliveries(ctx, domain.WebhookDeliveryFailed, deadLettersQuery("WHERE d.id = ?"), id.Value())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(deliveries) == 0 {
+		return nil, NewErrRegisterNotFound("id", strconv.FormatUint(id.Value(), 10))
+	}
+
+	return deliveries[0], nil
+}
+
+// deadLettersQuery builds the query of the dead letters with the informed filter, the last attempt is read as the next
+// attempt so both tables are read the same way
+func deadLettersQuery(filter string) string {
+	return `
+		SELECT d.id, d.attempts, d.last_attempt_at, d.last_attempt_at, d.last_error,
+			w.id, w.url, w.event_types, w.secret, w.created_at,
+			o.id, o.event_type, o.aggregate_id, o.payload, o.occurred_at
+		FROM webhook_dead_letters d
+		INNER JOIN webhooks w ON w.id = d.webhook_id
+		INNER JOIN outbox o ON o.id = d.event_id
+		` + filter + `
+		ORDER BY d.last_attempt_at DESC, d.id DESC
+	`
+}
+
+func (w WebhookDelivery) findDeliveries(
+	ctx context.Context,
+	status domain.WebhookDeliveryStatus,
+	query string,
+	args ...interface{},
+) ([]*domain.WebhookDelivery, error) {
+	rows, err := executorOf(ctx, w.conn).QueryContext(ctx, w.dialect.query(query), args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "database error")
+	}
+	defer rows.Close()
+
+	var deliveries []*domain.WebhookDelivery
+
+	for rows.Next() {
+		var (
+			id               uint64
+			attempts         int
+			nextAttemptAt    timestamp
+			lastAttemptAt    timestamp
+			lastError        string
+			webhookID        uint64
+			url              string
+			eventTypes       string
+			secret           string
+			webhookCreatedAt timestamp
+			eventID          uint64
+			eventType        string
+			aggregateID      uint64
+			payload          string
+			eventOccurredAt  timestamp
+		)
+
+		err := rows.Scan(
+			&id,
+			&attempts,
+			&nextAttemptAt,
+			&lastAttemptAt,
+			&lastError,
+			&webhookID,
+			&url,
+			&eventTypes,
+			&secret,
+			&webhookCreatedAt,
+			&eventID,
+			&eventType,
+			&aggregateID,
+			&payload,
+			&eventOccurredAt,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "error to read the webhook delivery")
+		}
+
+		// a delivery never attempted has no last attempt, scanned as the zero time
+		webhook := domain.LoadWebhook(
+			domain.NewID(webhookID),
+			url,
+			columnToEventTypes(eventTypes),
+			secret,
+			webhookCreatedAt.Time,
+		)
+		event := domain.LoadEvent(
+			domain.NewID(eventID),
+			domain.EventType(eventType),
+			domain.NewID(aggregateID),
+			[]byte(payload),
+			eventOccurredAt.Time,
+		)
+
+		deliveries = append(
+			deliveries,
+			domain.LoadWebhookDelivery(
+				domain.NewID(id),
+				webhook,
+				event,
+				status,
+				attempts,
+				nextAttemptAt.Time,
+				lastAttemptAt.Time,
+				lastError,
+			),
+		)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "error to read the webhook deliveries")
+	}
+
+	return deliveries, nil
+}
+
+// nullableTimestamp formats a moment to be stored, the zero value is stored as NULL
+func nullableTimestamp(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+
+	return t.UTC().Format(timestampLayout)
+}
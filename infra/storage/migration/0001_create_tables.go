@@ -0,0 +1,608 @@
+package migration
+
+// createTables creates the tables of the schema, with the built-in operations
+var createTables = &Migration{
+	version: 1,
+	name:    "create_tables",
+	up: map[string]string{
+		driverMySQL: `
+		CREATE TABLE accounts (
+		    id int PRIMARY KEY UNIQUE AUTO_INCREMENT,
+		    document_type VARCHAR(4) NOT NULL DEFAULT 'CPF',
+		    document_number VARCHAR(14) NOT NULL UNIQUE,
+		    currency CHAR(3) NOT NULL DEFAULT 'BRL',
+		    credit_limit DECIMAL(17,4) NOT NULL DEFAULT 0,
+		    available_credit_limit DECIMAL(17,4) NOT NULL DEFAULT 0,
+		    closing_day TINYINT NOT NULL DEFAULT 25,
+		    due_day TINYINT NOT NULL DEFAULT 5,
+		    status VARCHAR(10) NOT NULL DEFAULT 'ACTIVE',
+		    status_reason VARCHAR(30) NULL,
+		    status_changed_at TIMESTAMP NULL,
+		    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE account_status_changes (
+		    id int PRIMARY KEY UNIQUE AUTO_INCREMENT,
+		    account_id int NOT NULL,
+		    from_status VARCHAR(10) NOT NULL,
+		    to_status VARCHAR(10) NOT NULL,
+		    reason VARCHAR(30) NOT NULL,
+		    changed_at TIMESTAMP NOT NULL,
+
+		    FOREIGN KEY (account_id) REFERENCES accounts(id),
+		    INDEX account_status_changes_account_id (account_id)
+		);
+
+		CREATE TABLE operations (
+		    id int PRIMARY KEY UNIQUE AUTO_INCREMENT,
+		    description VARCHAR(50) NOT NULL UNIQUE,
+		    direction VARCHAR(6) NOT NULL,
+		    kind VARCHAR(20) NOT NULL,
+		    enabled BOOLEAN NOT NULL DEFAULT TRUE
+		);
+
+		CREATE TABLE transfers (
+		    id int PRIMARY KEY UNIQUE AUTO_INCREMENT,
+		    source_account_id int NOT NULL,
+		    destination_account_id int NOT NULL,
+		    amount DECIMAL(17,4) NOT NULL,
+		    currency CHAR(3) NOT NULL DEFAULT 'BRL',
+		    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+		    FOREIGN KEY (source_account_id) REFERENCES accounts(id),
+		    FOREIGN KEY (destination_account_id) REFERENCES accounts(id)
+		);
+
+		CREATE TABLE transactions (
+		    id int PRIMARY KEY UNIQUE AUTO_INCREMENT,
+		    account_id int NOT NULL,
+		    operation_id int NOT NULL,
+		    amount DECIMAL(17,4) NOT NULL,
+		    currency CHAR(3) NOT NULL DEFAULT 'BRL',
+		    original_amount DECIMAL(17,4) NOT NULL,
+		    original_currency CHAR(3) NOT NULL DEFAULT 'BRL',
+		    exchange_rate DECIMAL(20,8) NOT NULL DEFAULT 1,
+		    transfer_id int NULL,
+		    reversal_of int NULL,
+		    reversed_amount DECIMAL(17,4) NOT NULL DEFAULT 0,
+		    balance DECIMAL(17,4) NOT NULL DEFAULT 0,
+		    accrual_date DATE NULL,
+		    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+		    FOREIGN KEY (account_id) REFERENCES accounts(id),
+		    FOREIGN KEY (operation_id) REFERENCES operations(id),
+		    FOREIGN KEY (transfer_id) REFERENCES transfers(id),
+		    FOREIGN KEY (reversal_of) REFERENCES transactions(id),
+		    INDEX transactions_account_id_id (account_id, id),
+		    UNIQUE KEY transactions_account_id_operation_id_accrual_date (account_id, operation_id, accrual_date)
+		);
+
+		CREATE TABLE installments (
+		    id int PRIMARY KEY UNIQUE AUTO_INCREMENT,
+		    transaction_id int NOT NULL,
+		    number int NOT NULL,
+		    amount DECIMAL(17,4) NOT NULL,
+		    due_date DATE NOT NULL,
+
+		    FOREIGN KEY (transaction_id) REFERENCES transactions(id),
+		    UNIQUE KEY installments_transaction_id_number (transaction_id, number),
+		    INDEX installments_due_date (due_date)
+		);
+
+		CREATE TABLE payment_allocations (
+		    id int PRIMARY KEY UNIQUE AUTO_INCREMENT,
+		    payment_id int NOT NULL,
+		    transaction_id int NOT NULL,
+		    amount DECIMAL(17,4) NOT NULL,
+
+		    FOREIGN KEY (payment_id) REFERENCES transactions(id),
+		    FOREIGN KEY (transaction_id) REFERENCES transactions(id),
+		    UNIQUE KEY payment_allocations_payment_id_transaction_id (payment_id, transaction_id)
+		);
+
+		CREATE TABLE invoices (
+		    id int PRIMARY KEY UNIQUE AUTO_INCREMENT,
+		    account_id int NOT NULL,
+		    period_start DATETIME NOT NULL,
+		    period_end DATETIME NOT NULL,
+		    due_date DATE NOT NULL,
+		    previous_balance DECIMAL(17,4) NOT NULL DEFAULT 0,
+		    currency CHAR(3) NOT NULL DEFAULT 'BRL',
+		    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+		    FOREIGN KEY (account_id) REFERENCES accounts(id),
+		    UNIQUE KEY invoices_account_id_period_end (account_id, period_end)
+		);
+
+		CREATE TABLE invoice_items (
+		    id int PRIMARY KEY UNIQUE AUTO_INCREMENT,
+		    invoice_id int NOT NULL,
+		    transaction_id int NOT NULL,
+		    description VARCHAR(50) NOT NULL,
+		    amount DECIMAL(17,4) NOT NULL,
+		    date DATETIME NOT NULL,
+		    installment int NULL,
+		    installments int NULL,
+
+		    FOREIGN KEY (invoice_id) REFERENCES invoices(id),
+		    FOREIGN KEY (transaction_id) REFERENCES transactions(id),
+		    INDEX invoice_items_invoice_id (invoice_id)
+		);
+
+		CREATE TABLE idempotency_keys (
+		    idempotency_key VARCHAR(255) PRIMARY KEY,
+		    fingerprint CHAR(64) NOT NULL,
+		    status VARCHAR(20) NOT NULL,
+		    response_status INT NULL,
+		    response_body MEDIUMBLOB NULL,
+		    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE outbox (
+		    id int PRIMARY KEY UNIQUE AUTO_INCREMENT,
+		    event_type VARCHAR(50) NOT NULL,
+		    aggregate_id int NOT NULL,
+		    payload TEXT NOT NULL,
+		    occurred_at DATETIME NOT NULL,
+		    published_at DATETIME NULL,
+		    claimed_until DATETIME NULL,
+
+		    INDEX outbox_published_at_id (published_at, id)
+		);
+
+		CREATE TABLE webhooks (
+		    id int PRIMARY KEY UNIQUE AUTO_INCREMENT,
+		    url VARCHAR(2048) NOT NULL,
+		    event_types VARCHAR(255) NOT NULL,
+		    secret CHAR(64) NOT NULL,
+		    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE webhook_deliveries (
+		    id int PRIMARY KEY UNIQUE AUTO_INCREMENT,
+		    webhook_id int NOT NULL,
+		    event_id int NOT NULL,
+		    attempts int NOT NULL DEFAULT 0,
+		    next_attempt_at DATETIME NOT NULL,
+		    last_attempt_at DATETIME NULL,
+		    last_error VARCHAR(255) NULL,
+		    delivered_at DATETIME NULL,
+
+		    FOREIGN KEY (webhook_id) REFERENCES webhooks(id),
+		    FOREIGN KEY (event_id) REFERENCES outbox(id),
+		    UNIQUE KEY webhook_deliveries_webhook_id_event_id (webhook_id, event_id),
+		    INDEX webhook_deliveries_delivered_at_next_attempt_at (delivered_at, next_attempt_at)
+		);
+
+		CREATE TABLE webhook_dead_letters (
+		    id int PRIMARY KEY,
+		    webhook_id int NOT NULL,
+		    event_id int NOT NULL,
+		    attempts int NOT NULL,
+		    last_attempt_at DATETIME NOT NULL,
+		    last_error VARCHAR(255) NOT NULL,
+
+		    FOREIGN KEY (webhook_id) REFERENCES webhooks(id),
+		    FOREIGN KEY (event_id) REFERENCES outbox(id)
+		);
+
+		INSERT INTO operations (id, description, direction, kind) VALUES
+		    (1, 'COMPRA A VISTA', 'DEBIT', 'STANDARD'),
+		    (2, 'COMPRA PARCELADA', 'DEBIT', 'INSTALLMENT_PURCHASE'),
+		    (3, 'SAQUE', 'DEBIT', 'STANDARD'),
+		    (4, 'PAGAMENTO', 'CREDIT', 'PAYMENT'),
+		    (5, 'TRANSFERENCIA ENVIADA', 'DEBIT', 'TRANSFER'),
+		    (6, 'TRANSFERENCIA RECEBIDA', 'CREDIT', 'TRANSFER'),
+		    (7, 'ESTORNO', 'CREDIT', 'REVERSAL'),
+		    (8, 'JUROS ROTATIVO', 'DEBIT', 'CHARGE'),
+		    (9, 'MULTA POR ATRASO', 'DEBIT', 'CHARGE'),
+		    (10, 'JUROS DE MORA', 'DEBIT', 'CHARGE');
+		`,
+		driverPostgres: `
+		CREATE TABLE accounts (
+		    id SERIAL PRIMARY KEY,
+		    document_type VARCHAR(4) NOT NULL DEFAULT 'CPF',
+		    document_number VARCHAR(14) NOT NULL UNIQUE,
+		    currency CHAR(3) NOT NULL DEFAULT 'BRL',
+		    credit_limit DECIMAL(17,4) NOT NULL DEFAULT 0,
+		    available_credit_limit DECIMAL(17,4) NOT NULL DEFAULT 0,
+		    closing_day SMALLINT NOT NULL DEFAULT 25,
+		    due_day SMALLINT NOT NULL DEFAULT 5,
+		    status VARCHAR(10) NOT NULL DEFAULT 'ACTIVE',
+		    status_reason VARCHAR(30) NULL,
+		    status_changed_at TIMESTAMP NULL,
+		    created_at TIMESTAMP DEFAULT (NOW() AT TIME ZONE 'UTC')
+		);
+
+		CREATE TABLE account_status_changes (
+		    id SERIAL PRIMARY KEY,
+		    account_id INT NOT NULL REFERENCES accounts(id),
+		    from_status VARCHAR(10) NOT NULL,
+		    to_status VARCHAR(10) NOT NULL,
+		    reason VARCHAR(30) NOT NULL,
+		    changed_at TIMESTAMP NOT NULL
+		);
+
+		CREATE INDEX account_status_changes_account_id ON account_status_changes (account_id);
+
+		CREATE TABLE operations (
+		    id SERIAL PRIMARY KEY,
+		    description VARCHAR(50) NOT NULL UNIQUE,
+		    direction VARCHAR(6) NOT NULL,
+		    kind VARCHAR(20) NOT NULL,
+		    enabled BOOLEAN NOT NULL DEFAULT TRUE
+		);
+
+		CREATE TABLE transfers (
+		    id SERIAL PRIMARY KEY,
+		    source_account_id INT NOT NULL REFERENCES accounts(id),
+		    destination_account_id INT NOT NULL REFERENCES accounts(id),
+		    amount DECIMAL(17,4) NOT NULL,
+		    currency CHAR(3) NOT NULL DEFAULT 'BRL',
+		    created_at TIMESTAMP DEFAULT (NOW() AT TIME ZONE 'UTC')
+		);
+
+		CREATE TABLE transactions (
+		    id SERIAL PRIMARY KEY,
+		    account_id INT NOT NULL REFERENCES accounts(id),
+		    operation_id INT NOT NULL REFERENCES operations(id),
+		    amount DECIMAL(17,4) NOT NULL,
+		    currency CHAR(3) NOT NULL DEFAULT 'BRL',
+		    original_amount DECIMAL(17,4) NOT NULL,
+		    original_currency CHAR(3) NOT NULL DEFAULT 'BRL',
+		    exchange_rate DECIMAL(20,8) NOT NULL DEFAULT 1,
+		    transfer_id INT NULL REFERENCES transfers(id),
+		    reversal_of INT NULL REFERENCES transactions(id),
+		    reversed_amount DECIMAL(17,4) NOT NULL DEFAULT 0,
+		    balance DECIMAL(17,4) NOT NULL DEFAULT 0,
+		    accrual_date DATE NULL,
+		    created_at TIMESTAMP DEFAULT (NOW() AT TIME ZONE 'UTC'),
+
+		    CONSTRAINT transactions_account_id_operation_id_accrual_date UNIQUE (account_id, operation_id, accrual_date)
+		);
+
+		CREATE INDEX transactions_account_id_id ON transactions (account_id, id);
+
+		CREATE TABLE installments (
+		    id SERIAL PRIMARY KEY,
+		    transaction_id INT NOT NULL REFERENCES transactions(id),
+		    number INT NOT NULL,
+		    amount DECIMAL(17,4) NOT NULL,
+		    due_date DATE NOT NULL,
+
+		    CONSTRAINT installments_transaction_id_number UNIQUE (transaction_id, number)
+		);
+
+		CREATE INDEX installments_due_date ON installments (due_date);
+
+		CREATE TABLE payment_allocations (
+		    id SERIAL PRIMARY KEY,
+		    payment_id INT NOT NULL REFERENCES transactions(id),
+		    transaction_id INT NOT NULL REFERENCES transactions(id),
+		    amount DECIMAL(17,4) NOT NULL,
+
+		    CONSTRAINT payment_allocations_payment_id_transaction_id UNIQUE (payment_id, transaction_id)
+		);
+
+		CREATE TABLE invoices (
+		    id SERIAL PRIMARY KEY,
+		    account_id INT NOT NULL REFERENCES accounts(id),
+		    period_start TIMESTAMP NOT NULL,
+		    period_end TIMESTAMP NOT NULL,
+		    due_date DATE NOT NULL,
+		    previous_balance DECIMAL(17,4) NOT NULL DEFAULT 0,
+		    currency CHAR(3) NOT NULL DEFAULT 'BRL',
+		    created_at TIMESTAMP DEFAULT (NOW() AT TIME ZONE 'UTC'),
+
+		    CONSTRAINT invoices_account_id_period_end UNIQUE (account_id, period_end)
+		);
+
+		CREATE TABLE invoice_items (
+		    id SERIAL PRIMARY KEY,
+		    invoice_id INT NOT NULL REFERENCES invoices(id),
+		    transaction_id INT NOT NULL REFERENCES transactions(id),
+		    description VARCHAR(50) NOT NULL,
+		    amount DECIMAL(17,4) NOT NULL,
+		    date TIMESTAMP NOT NULL,
+		    installment INT NULL,
+		    installments INT NULL
+		);
+
+		CREATE INDEX invoice_items_invoice_id ON invoice_items (invoice_id);
+
+		CREATE TABLE idempotency_keys (
+		    idempotency_key VARCHAR(255) PRIMARY KEY,
+		    fingerprint CHAR(64) NOT NULL,
+		    status VARCHAR(20) NOT NULL,
+		    response_status INT NULL,
+		    response_body BYTEA NULL,
+		    created_at TIMESTAMP DEFAULT (NOW() AT TIME ZONE 'UTC')
+		);
+
+		CREATE TABLE outbox (
+		    id SERIAL PRIMARY KEY,
+		    event_type VARCHAR(50) NOT NULL,
+		    aggregate_id INT NOT NULL,
+		    payload TEXT NOT NULL,
+		    occurred_at TIMESTAMP NOT NULL,
+		    published_at TIMESTAMP NULL,
+		    claimed_until TIMESTAMP NULL
+		);
+
+		CREATE INDEX outbox_published_at_id ON outbox (published_at, id);
+
+		CREATE TABLE webhooks (
+		    id SERIAL PRIMARY KEY,
+		    url VARCHAR(2048) NOT NULL,
+		    event_types VARCHAR(255) NOT NULL,
+		    secret CHAR(64) NOT NULL,
+		    created_at TIMESTAMP DEFAULT (NOW() AT TIME ZONE 'UTC')
+		);
+
+		CREATE TABLE webhook_deliveries (
+		    id SERIAL PRIMARY KEY,
+		    webhook_id INT NOT NULL REFERENCES webhooks(id),
+		    event_id INT NOT NULL REFERENCES outbox(id),
+		    attempts INT NOT NULL DEFAULT 0,
+		    next_attempt_at TIMESTAMP NOT NULL,
+		    last_attempt_at TIMESTAMP NULL,
+		    last_error VARCHAR(255) NULL,
+		    delivered_at TIMESTAMP NULL,
+
+		    CONSTRAINT webhook_deliveries_webhook_id_event_id UNIQUE (webhook_id, event_id)
+		);
+
+		CREATE INDEX webhook_deliveries_delivered_at_next_attempt_at ON webhook_deliveries (delivered_at, next_attempt_at);
+
+		CREATE TABLE webhook_dead_letters (
+		    id INT PRIMARY KEY,
+		    webhook_id INT NOT NULL REFERENCES webhooks(id),
+		    event_id INT NOT NULL REFERENCES outbox(id),
+		    attempts INT NOT NULL,
+		    last_attempt_at TIMESTAMP NOT NULL,
+		    last_error VARCHAR(255) NOT NULL
+		);
+
+		INSERT INTO operations (id, description, direction, kind) VALUES
+		    (1, 'COMPRA A VISTA', 'DEBIT', 'STANDARD'),
+		    (2, 'COMPRA PARCELADA', 'DEBIT', 'INSTALLMENT_PURCHASE'),
+		    (3, 'SAQUE', 'DEBIT', 'STANDARD'),
+		    (4, 'PAGAMENTO', 'CREDIT', 'PAYMENT'),
+		    (5, 'TRANSFERENCIA ENVIADA', 'DEBIT', 'TRANSFER'),
+		    (6, 'TRANSFERENCIA RECEBIDA', 'CREDIT', 'TRANSFER'),
+		    (7, 'ESTORNO', 'CREDIT', 'REVERSAL'),
+		    (8, 'JUROS ROTATIVO', 'DEBIT', 'CHARGE'),
+		    (9, 'MULTA POR ATRASO', 'DEBIT', 'CHARGE'),
+		    (10, 'JUROS DE MORA', 'DEBIT', 'CHARGE');
+
+		SELECT setval('operations_id_seq', (SELECT MAX(id) FROM operations));
+		`,
+		driverSQLite: `
+		CREATE TABLE accounts (
+		    id INTEGER PRIMARY KEY AUTOINCREMENT,
+		    document_type VARCHAR(4) NOT NULL DEFAULT 'CPF',
+		    document_number VARCHAR(14) NOT NULL UNIQUE,
+		    currency CHAR(3) NOT NULL DEFAULT 'BRL',
+		    credit_limit DECIMAL(17,4) NOT NULL DEFAULT 0,
+		    available_credit_limit DECIMAL(17,4) NOT NULL DEFAULT 0,
+		    closing_day TINYINT NOT NULL DEFAULT 25,
+		    due_day TINYINT NOT NULL DEFAULT 5,
+		    status VARCHAR(10) NOT NULL DEFAULT 'ACTIVE',
+		    status_reason VARCHAR(30) NULL,
+		    status_changed_at TIMESTAMP NULL,
+		    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE account_status_changes (
+		    id INTEGER PRIMARY KEY AUTOINCREMENT,
+		    account_id INTEGER NOT NULL REFERENCES accounts(id),
+		    from_status VARCHAR(10) NOT NULL,
+		    to_status VARCHAR(10) NOT NULL,
+		    reason VARCHAR(30) NOT NULL,
+		    changed_at TIMESTAMP NOT NULL
+		);
+
+		CREATE INDEX account_status_changes_account_id ON account_status_changes (account_id);
+
+		CREATE TABLE operations (
+		    id INTEGER PRIMARY KEY AUTOINCREMENT,
+		    description VARCHAR(50) NOT NULL UNIQUE,
+		    direction VARCHAR(6) NOT NULL,
+		    kind VARCHAR(20) NOT NULL,
+		    enabled BOOLEAN NOT NULL DEFAULT TRUE
+		);
+
+		CREATE TABLE transfers (
+		    id INTEGER PRIMARY KEY AUTOINCREMENT,
+		    source_account_id INTEGER NOT NULL REFERENCES accounts(id),
+		    destination_account_id INTEGER NOT NULL REFERENCES accounts(id),
+		    amount DECIMAL(17,4) NOT NULL,
+		    currency CHAR(3) NOT NULL DEFAULT 'BRL',
+		    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE transactions (
+		    id INTEGER PRIMARY KEY AUTOINCREMENT,
+		    account_id INTEGER NOT NULL REFERENCES accounts(id),
+		    operation_id INTEGER NOT NULL REFERENCES operations(id),
+		    amount DECIMAL(17,4) NOT NULL,
+		    currency CHAR(3) NOT NULL DEFAULT 'BRL',
+		    original_amount DECIMAL(17,4) NOT NULL,
+		    original_currency CHAR(3) NOT NULL DEFAULT 'BRL',
+		    exchange_rate DECIMAL(20,8) NOT NULL DEFAULT 1,
+		    transfer_id INTEGER NULL REFERENCES transfers(id),
+		    reversal_of INTEGER NULL REFERENCES transactions(id),
+		    reversed_amount DECIMAL(17,4) NOT NULL DEFAULT 0,
+		    balance DECIMAL(17,4) NOT NULL DEFAULT 0,
+		    accrual_date DATE NULL,
+		    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+		    UNIQUE (account_id, operation_id, accrual_date)
+		);
+
+		CREATE INDEX transactions_account_id_id ON transactions (account_id, id);
+
+		CREATE TABLE installments (
+		    id INTEGER PRIMARY KEY AUTOINCREMENT,
+		    transaction_id INTEGER NOT NULL REFERENCES transactions(id),
+		    number INTEGER NOT NULL,
+		    amount DECIMAL(17,4) NOT NULL,
+		    due_date DATE NOT NULL,
+
+		    UNIQUE (transaction_id, number)
+		);
+
+		CREATE INDEX installments_due_date ON installments (due_date);
+
+		CREATE TABLE payment_allocations (
+		    id INTEGER PRIMARY KEY AUTOINCREMENT,
+		    payment_id INTEGER NOT NULL REFERENCES transactions(id),
+		    transaction_id INTEGER NOT NULL REFERENCES transactions(id),
+		    amount DECIMAL(17,4) NOT NULL,
+
+		    UNIQUE (payment_id, transaction_id)
+		);
+
+		CREATE TABLE invoices (
+		    id INTEGER PRIMARY KEY AUTOINCREMENT,
+		    account_id INTEGER NOT NULL REFERENCES accounts(id),
+		    period_start DATETIME NOT NULL,
+		    period_end DATETIME NOT NULL,
+		    due_date DATE NOT NULL,
+		    previous_balance DECIMAL(17,4) NOT NULL DEFAULT 0,
+		    currency CHAR(3) NOT NULL DEFAULT 'BRL',
+		    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+		    UNIQUE (account_id, period_end)
+		);
+
+		CREATE TABLE invoice_items (
+		    id INTEGER PRIMARY KEY AUTOINCREMENT,
+		    invoice_id INTEGER NOT NULL REFERENCES invoices(id),
+		    transaction_id INTEGER NOT NULL REFERENCES transactions(id),
+		    description VARCHAR(50) NOT NULL,
+		    amount DECIMAL(17,4) NOT NULL,
+		    date DATETIME NOT NULL,
+		    installment INTEGER NULL,
+		    installments INTEGER NULL
+		);
+
+		CREATE INDEX invoice_items_invoice_id ON invoice_items (invoice_id);
+
+		CREATE TABLE idempotency_keys (
+		    idempotency_key VARCHAR(255) PRIMARY KEY,
+		    fingerprint CHAR(64) NOT NULL,
+		    status VARCHAR(20) NOT NULL,
+		    response_status INTEGER NULL,
+		    response_body BLOB NULL,
+		    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE outbox (
+		    id INTEGER PRIMARY KEY AUTOINCREMENT,
+		    event_type VARCHAR(50) NOT NULL,
+		    aggregate_id INTEGER NOT NULL,
+		    payload TEXT NOT NULL,
+		    occurred_at DATETIME NOT NULL,
+		    published_at DATETIME NULL,
+		    claimed_until DATETIME NULL
+		);
+
+		CREATE INDEX outbox_published_at_id ON outbox (published_at, id);
+
+		CREATE TABLE webhooks (
+		    id INTEGER PRIMARY KEY AUTOINCREMENT,
+		    url VARCHAR(2048) NOT NULL,
+		    event_types VARCHAR(255) NOT NULL,
+		    secret CHAR(64) NOT NULL,
+		    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE webhook_deliveries (
+		    id INTEGER PRIMARY KEY AUTOINCREMENT,
+		    webhook_id INTEGER NOT NULL REFERENCES webhooks(id),
+		    event_id INTEGER NOT NULL REFERENCES outbox(id),
+		    attempts INTEGER NOT NULL DEFAULT 0,
+		    next_attempt_at DATETIME NOT NULL,
+		    last_attempt_at DATETIME NULL,
+		    last_error VARCHAR(255) NULL,
+		    delivered_at DATETIME NULL,
+
+		    UNIQUE (webhook_id, event_id)
+		);
+
+		CREATE INDEX webhook_deliveries_delivered_at_next_attempt_at ON webhook_deliveries (delivered_at, next_attempt_at);
+
+		CREATE TABLE webhook_dead_letters (
+		    id INTEGER PRIMARY KEY,
+		    webhook_id INTEGER NOT NULL REFERENCES webhooks(id),
+		    event_id INTEGER NOT NULL REFERENCES outbox(id),
+		    attempts INTEGER NOT NULL,
+		    last_attempt_at DATETIME NOT NULL,
+		    last_error VARCHAR(255) NOT NULL
+		);
+
+		INSERT INTO operations (id, description, direction, kind) VALUES
+		    (1, 'COMPRA A VISTA', 'DEBIT', 'STANDARD'),
+		    (2, 'COMPRA PARCELADA', 'DEBIT', 'INSTALLMENT_PURCHASE'),
+		    (3, 'SAQUE', 'DEBIT', 'STANDARD'),
+		    (4, 'PAGAMENTO', 'CREDIT', 'PAYMENT'),
+		    (5, 'TRANSFERENCIA ENVIADA', 'DEBIT', 'TRANSFER'),
+		    (6, 'TRANSFERENCIA RECEBIDA', 'CREDIT', 'TRANSFER'),
+		    (7, 'ESTORNO', 'CREDIT', 'REVERSAL'),
+		    (8, 'JUROS ROTATIVO', 'DEBIT', 'CHARGE'),
+		    (9, 'MULTA POR ATRASO', 'DEBIT', 'CHARGE'),
+		    (10, 'JUROS DE MORA', 'DEBIT', 'CHARGE');
+		`,
+	},
+	down: map[string]string{
+		driverMySQL: `
+		DROP TABLE webhook_dead_letters;
+		DROP TABLE webhook_deliveries;
+		DROP TABLE webhooks;
+		DROP TABLE outbox;
+		DROP TABLE idempotency_keys;
+		DROP TABLE invoice_items;
+		DROP TABLE invoices;
+		DROP TABLE payment_allocations;
+		DROP TABLE installments;
+		DROP TABLE transactions;
+		DROP TABLE transfers;
+		DROP TABLE operations;
+		DROP TABLE account_status_changes;
+		DROP TABLE accounts;
+		`,
+		driverPostgres: `
+		DROP TABLE webhook_dead_letters;
+		DROP TABLE webhook_deliveries;
+		DROP TABLE webhooks;
+		DROP TABLE outbox;
+		DROP TABLE idempotency_keys;
+		DROP TABLE invoice_items;
+		DROP TABLE invoices;
+		DROP TABLE payment_allocations;
+		DROP TABLE installments;
+		DROP TABLE transactions;
+		DROP TABLE transfers;
+		DROP TABLE operations;
+		DROP TABLE account_status_changes;
+		DROP TABLE accounts;
+		`,
+		driverSQLite: `
+		DROP TABLE webhook_dead_letters;
+		DROP TABLE webhook_deliveries;
+		DROP TABLE webhooks;
+		DROP TABLE outbox;
+		DROP TABLE idempotency_keys;
+		DROP TABLE invoice_items;
+		DROP TABLE invoices;
+		DROP TABLE payment_allocations;
+		DROP TABLE installments;
+		DROP TABLE transactions;
+		DROP TABLE transfers;
+		DROP TABLE operations;
+		DROP TABLE account_status_changes;
+		DROP TABLE accounts;
+		`,
+	},
+}
@@ -0,0 +1,391 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+	"github.com/pkg/errors"
+)
+
+const (
+	driverMySQL    = "mysql"
+	driverPostgres = "postgres"
+	driverSQLite   = "sqlite"
+
+	// lockName identifies the lock taken while migrating, as the MySQL lock name and the PostgreSQL lock key
+	lockName       = "schema_migrations"
+	lockKey        = 7305218412
+	lockTimeoutSec = 60
+)
+
+// Migration is a numbered change of the schema, applied by its up statements and reverted by its down statements,
+// written for each driver
+type Migration struct {
+	version uint64
+	name    string
+	up      map[string]string
+	down    map[string]string
+}
+
+// Version returns the version value
+func (m *Migration) Version() uint64 {
+	return m.version
+}
+
+// Name returns the name value
+func (m *Migration) Name() string {
+	return m.name
+}
+
+// String returns the version and the name of the migration, as "0001_create_tables"
+func (m *Migration) String() string {
+	return fmt.Sprintf("%04d_%s", m.version, m.name)
+}
+
+// Status represents whether a migration is applied to the schema, and when
+type Status struct {
+	migration *Migration
+	appliedAt *time.Time
+}
+
+// Migration returns the migration value
+func (s *Status) Migration() *Migration {
+	return s.migration
+}
+
+// AppliedAt returns when the migration was applied, nil when it is pending
+func (s *Status) AppliedAt() *time.Time {
+	return s.appliedAt
+}
+
+// Migrator applies and reverts the migrations of the schema, registering the applied versions in the
+// schema_migrations table
+type Migrator struct {
+	conn       *sql.DB
+	migrations []*Migration
+}
+
+// NewMigrator builds a new Migrator struct with all the migrations of the schema
+func NewMigrator(conn *sql.DB) *Migrator {
+	return &Migrator{conn: conn, migrations: migrations}
+}
+
+// Up applies the pending migrations in the order of their versions, returning the ones applied. Each migration runs
+// in a transaction, but MySQL commits each schema change right away, so a migration failing there may be partially
+// applied.
+func (m Migrator) Up(ctx context.Context) ([]*Migration, error) {
+	var applied []*Migration
+
+	err := m.locked(ctx, func(conn *sql.Conn, d driver) error {
+		versions, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range m.sorted() {
+			if _, ok := versions[v.version]; ok {
+				continue
+			}
+
+			ok, err := run(ctx, conn, d, v, v.up[d.name], false)
+			if err != nil {
+				return errors.Wrapf(err, "error to apply the migration %s", v)
+			}
+
+			if ok {
+				applied = append(applied, v)
+			}
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down reverts the last migration applied, returning it, or nil when there is none
+func (m Migrator) Down(ctx context.Context) (*Migration, error) {
+	var reverted *Migration
+
+	err := m.locked(ctx, func(conn *sql.Conn, d driver) error {
+		versions, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		sorted := m.sorted()
+
+		for i := len(sorted) - 1; i >= 0; i-- {
+			if _, ok := versions[sorted[i].version]; !ok {
+				continue
+			}
+
+			ok, err := run(ctx, conn, d, sorted[i], sorted[i].down[d.name], true)
+			if err != nil {
+				return errors.Wrapf(err, "error to revert the migration %s", sorted[i])
+			}
+
+			if ok {
+				reverted = sorted[i]
+			}
+
+			return nil
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Status returns the status of each migration in the order of their versions
+func (m Migrator) Status(ctx context.Context) ([]*Status, error) {
+	var status []*Status
+
+	err := m.locked(ctx, func(conn *sql.Conn, _ driver) error {
+		versions, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range m.sorted() {
+			s := &Status{migration: v}
+
+			if at, ok := versions[v.version]; ok {
+				s.appliedAt = &at
+			}
+
+			status = append(status, s)
+		}
+
+		return nil
+	})
+
+	return status, err
+}
+
+// locked runs fn in a connection holding the migration lock, so only one instance migrates the schema at a time, with
+// the schema_migrations table created
+func (m Migrator) locked(ctx context.Context, fn func(*sql.Conn, driver) error) error {
+	d := driverOf(m.conn)
+
+	conn, err := m.conn.Conn(ctx)
+	if err != nil {
+		return errors.Wrap(err, "database error")
+	}
+	defer conn.Close()
+
+	if err := d.lock(ctx, conn); err != nil {
+		return errors.Wrap(err, "error to take the migration lock")
+	}
+	defer d.unlock(context.Background(), conn)
+
+	var query = `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT NOT NULL PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			applied_at TIMESTAMP NOT NULL
+		)
+	`
+
+	if _, err := conn.ExecContext(ctx, query); err != nil {
+		return errors.Wrap(err, "error to create the schema_migrations table")
+	}
+
+	return fn(conn, d)
+}
+
+// sorted returns the migrations in the order of their versions
+func (m Migrator) sorted() []*Migration {
+	sorted := append([]*Migration(nil), m.migrations...)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].version < sorted[j].version
+	})
+
+	return sorted
+}
+
+// appliedVersions returns the applied versions with the moment each one was applied
+func appliedVersions(ctx context.Context, conn *sql.Conn) (map[uint64]time.Time, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, errors.Wrap(err, "database error")
+	}
+	defer rows.Close()
+
+	versions := make(map[uint64]time.Time)
+
+	for rows.Next() {
+		var (
+			version   uint64
+			appliedAt interface{}
+		)
+
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, errors.Wrap(err, "error to read the schema migration")
+		}
+
+		at, err := toTime(appliedAt)
+		if err != nil {
+			return nil, errors.Wrap(err, "error to read the schema migration")
+		}
+
+		versions[version] = at
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "error to read the schema migrations")
+	}
+
+	return versions, nil
+}
+
+// run runs the statements of a migration in a transaction, registering it as applied, or as reverted when revert is
+// true. The version is checked again in the transaction, returning false when another instance already ran it.
+func run(ctx context.Context, conn *sql.Conn, d driver, m *Migration, statements string, revert bool) (bool, error) {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return false, errors.Wrap(err, "begin transaction error")
+	}
+	defer tx.Rollback()
+
+	var count int
+
+	err = tx.QueryRowContext(ctx, d.query(`SELECT COUNT(*) FROM schema_migrations WHERE version = ?`), m.version).
+		Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "database error")
+	}
+
+	if applied := count > 0; applied != revert {
+		return false, nil
+	}
+
+	for _, statement := range split(statements) {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			return false, err
+		}
+	}
+
+	if revert {
+		_, err = tx.ExecContext(ctx, d.query(`DELETE FROM schema_migrations WHERE version = ?`), m.version)
+	} else {
+		_, err = tx.ExecContext(
+			ctx,
+			d.query(`INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)`),
+			m.version,
+			m.name,
+			time.Now().UTC(),
+		)
+	}
+
+	if err != nil {
+		return false, errors.Wrap(err, "error to register the schema migration")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, errors.Wrap(err, "commit transaction error")
+	}
+
+	return true, nil
+}
+
+// toTime converts a TIMESTAMP column, read as text by MySQL and as time by the other engines
+func toTime(v interface{}) (time.Time, error) {
+	switch t := v.(type) {
+	case time.Time:
+		return t.UTC(), nil
+	case []byte:
+		return time.Parse("2006-01-02 15:04:05", string(t))
+	default:
+		return time.Time{}, fmt.Errorf("invalid timestamp '%v'", v)
+	}
+}
+
+// split splits the statements of a migration, separated by semicolons
+func split(statements string) []string {
+	var split []string
+
+	for _, v := range strings.Split(statements, ";") {
+		if v = strings.TrimSpace(v); v != "" {
+			split = append(split, v)
+		}
+	}
+
+	return split
+}
+
+// driver holds how the migrator deals with each database engine
+type driver struct {
+	name     string
+	numbered bool
+	lock     func(context.Context, *sql.Conn) error
+	unlock   func(context.Context, *sql.Conn) error
+}
+
+// driverOf identifies the database engine of the connection
+func driverOf(conn *sql.DB) driver {
+	switch conn.Driver().(type) {
+	case *pq.Driver:
+		return driver{name: driverPostgres, numbered: true, lock: lockPostgres, unlock: unlockPostgres}
+	case *sqlite3.SQLiteDriver:
+		// SQLite has no locks to take, its transactions are immediate so only one instance writes at a time
+		noop := func(context.Context, *sql.Conn) error { return nil }
+
+		return driver{name: driverSQLite, lock: noop, unlock: noop}
+	default:
+		return driver{name: driverMySQL, lock: lockMySQL, unlock: unlockMySQL}
+	}
+}
+
+// query adapts the placeholders of a query to the engine
+func (d driver) query(query string) string {
+	if !d.numbered {
+		return query
+	}
+
+	for n := 1; strings.Contains(query, "?"); n++ {
+		query = strings.Replace(query, "?", fmt.Sprintf("$%d", n), 1)
+	}
+
+	return query
+}
+
+func lockMySQL(ctx context.Context, conn *sql.Conn) error {
+	var locked sql.NullInt64
+
+	if err := conn.QueryRowContext(ctx, `SELECT GET_LOCK(?, ?)`, lockName, lockTimeoutSec).Scan(&locked); err != nil {
+		return err
+	}
+
+	if locked.Int64 != 1 {
+		return fmt.Errorf("lock '%s' not taken after %d seconds", lockName, lockTimeoutSec)
+	}
+
+	return nil
+}
+
+func unlockMySQL(ctx context.Context, conn *sql.Conn) error {
+	_, err := conn.ExecContext(ctx, `SELECT RELEASE_LOCK(?)`, lockName)
+
+	return err
+}
+
+func lockPostgres(ctx context.Context, conn *sql.Conn) error {
+	_, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockKey)
+
+	return err
+}
+
+func unlockPostgres(ctx context.Context, conn *sql.Conn) error {
+	_, err := conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1)`, lockKey)
+
+	return err
+}
@@ -0,0 +1,127 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/tonytcb/bank-transactions-go/infra/storage"
+)
+
+func newSQLiteStorage(t *testing.T) *sql.DB {
+	dir, err := ioutil.TempDir("", "bank-transactions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	conn, err := storage.NewSQLiteConnection(filepath.Join(dir, "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func TestMigrator(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		conn     = newSQLiteStorage(t)
+		addIndex = &Migration{
+			version: 2,
+			name:    "add_index",
+			up:      map[string]string{driverSQLite: `CREATE INDEX accounts_status ON accounts (status)`},
+			down:    map[string]string{driverSQLite: `DROP INDEX accounts_status`},
+		}
+		migrator = &Migrator{conn: conn, migrations: []*Migration{addIndex, createTables}}
+	)
+
+	assertApplied := func(want ...bool) {
+		t.Helper()
+
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			t.Fatalf("Status() error = %v", err)
+		}
+
+		for i, v := range status {
+			if applied := v.AppliedAt() != nil; applied != want[i] {
+				t.Errorf("Status() %s applied = %v, want %v", v.Migration(), applied, want[i])
+			}
+		}
+	}
+
+	assertApplied(false, false)
+
+	applied, err := migrator.Up(ctx)
+	if err != nil || fmt.Sprint(applied) != "[0001_create_tables 0002_add_index]" {
+		t.Errorf("Up() = %v, error = %v", applied, err)
+	}
+
+	assertApplied(true, true)
+
+	applied, err = migrator.Up(ctx)
+	if err != nil || len(applied) != 0 {
+		t.Errorf("Up() = %v, error = %v, want no migrations", applied, err)
+	}
+
+	for _, want := range []string{"0002_add_index", "0001_create_tables"} {
+		reverted, err := migrator.Down(ctx)
+		if err != nil || reverted.String() != want {
+			t.Errorf("Down() = %v, error = %v, want %v", reverted, err, want)
+		}
+	}
+
+	assertApplied(false, false)
+
+	reverted, err := migrator.Down(ctx)
+	if err != nil || reverted != nil {
+		t.Errorf("Down() = %v, error = %v, want no migration", reverted, err)
+	}
+
+	var tables int
+
+	if err := conn.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE name = 'accounts'`).Scan(&tables); err != nil || tables != 0 {
+		t.Errorf("Down() must drop the tables, %d found, error = %v", tables, err)
+	}
+}
+
+func TestMigrator_Up_Concurrently(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		conn    = newSQLiteStorage(t)
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		applied int
+	)
+
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			migrations, err := NewMigrator(conn).Up(ctx)
+			if err != nil {
+				t.Errorf("Up() error = %v", err)
+				return
+			}
+
+			mu.Lock()
+			applied += len(migrations)
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	if applied != len(migrations) {
+		t.Errorf("Up() applied %d migrations, want %d", applied, len(migrations))
+	}
+}
@@ -0,0 +1,7 @@
+package migration
+
+// migrations are all the migrations of the schema. A new migration takes the next version, in a file named after it,
+// and is never changed once released.
+var migrations = []*Migration{
+	createTables,
+}
@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+
+	_ "github.com/lib/pq" // Register PostgreSQL operations
+	"github.com/pkg/errors"
+)
+
+// NewPostgresConnection creates a new postgres connection
+func NewPostgresConnection(c Config) (*sql.DB, error) {
+	toRetry := func() (*sql.DB, error) {
+		db, err := sql.Open("postgres", fmt.Sprintf(
+			"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+			c.host,
+			c.port,
+			c.user,
+			c.password,
+			c.database,
+		))
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to connect to postgres database")
+		}
+
+		if err = db.Ping(); err != nil {
+			return nil, errors.Wrap(err, "database unavailable")
+		}
+
+		return db, nil
+	}
+
+	return retry(toRetry, 20)
+}
@@ -0,0 +1,24 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+
+	_ "github.com/mattn/go-sqlite3" // Register SQLite operations
+	"github.com/pkg/errors"
+)
+
+// NewSQLiteConnection creates a new sqlite connection to the database file. The foreign keys are enforced and the
+// transactions lock the database for writes as soon as they begin, as SQLite has no row locks.
+func NewSQLiteConnection(file string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?_foreign_keys=on&_txlock=immediate&_busy_timeout=5000", file))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to open the sqlite database")
+	}
+
+	if err = db.Ping(); err != nil {
+		return nil, errors.Wrap(err, "database unavailable")
+	}
+
+	return db, nil
+}
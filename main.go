@@ -1,45 +1,388 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"errors"
-	"log"
+	"flag"
+	"fmt"
+	nethttp "net/http"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/tonytcb/bank-transactions-go/api"
 	"github.com/tonytcb/bank-transactions-go/api/http"
+	"github.com/tonytcb/bank-transactions-go/api/http/middleware"
+	"github.com/tonytcb/bank-transactions-go/api/job"
+	"github.com/tonytcb/bank-transactions-go/domain"
+	logging "github.com/tonytcb/bank-transactions-go/infra/logger"
+	"github.com/tonytcb/bank-transactions-go/infra/publisher"
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+	"github.com/tonytcb/bank-transactions-go/infra/repository/memory"
 	"github.com/tonytcb/bank-transactions-go/infra/storage"
+	"github.com/tonytcb/bank-transactions-go/infra/storage/migration"
 )
 
 func main() {
-	// todo improve the logger struct with common methods (INFO, WARN, ERROR, ...) and a way to track logs through the same process
+	level, levelErr := logging.ParseLevel(envOrDefault("LOG_LEVEL", "info"))
 
-	var logger = log.New(os.Stdout, "", log.LstdFlags|log.Lmicroseconds)
+	var (
+		ctx    = context.Background()
+		logger = logging.NewJSON(os.Stdout, level, time.Now)
+	)
 
-	logger.Println("starting app")
+	if levelErr != nil {
+		fatal(logger, "error to load the log level", domain.NewErrLogField(levelErr))
+		return
+	}
 
-	db, err := newStorage()
+	logger.Info(ctx, "starting app")
+
+	inMemory := flag.Bool("in-memory", false, "keep the data in memory, lost when the app stops, instead of a database")
+	flag.Parse()
+
+	if flag.Arg(0) == "migrate" {
+		if *inMemory {
+			fatal(logger, "the in-memory storage has no schema to migrate")
+			return
+		}
+
+		if err := migrate(logger, flag.Arg(1)); err != nil {
+			fatal(logger, "error to migrate the schema", domain.NewErrLogField(err))
+		}
+
+		return
+	}
+
+	var repos *repository.Repositories
+
+	if *inMemory {
+		logger.Info(ctx, "using the in-memory storage")
+
+		repos = memory.NewStore(time.Now).Repositories()
+	} else {
+		db, err := newStorage()
+		if err != nil {
+			fatal(logger, "error to start storage", domain.NewErrLogField(err))
+			return
+		}
+		defer db.Close()
+
+		repos = repository.NewRepositories(db)
+	}
+
+	rates, err := newExchangeRateProvider()
 	if err != nil {
-		logger.Fatalln("error to start storage:", err.Error())
+		fatal(logger, "error to load the exchange rates", domain.NewErrLogField(err))
+		return
+	}
+
+	policy, err := newInterestPolicy()
+	if err != nil {
+		fatal(logger, "error to load the interest policy", domain.NewErrLogField(err))
+		return
+	}
+
+	eventPublisher, err := newEventPublisher()
+	if err != nil {
+		fatal(logger, "error to load the event publisher", domain.NewErrLogField(err))
 		return
 	}
-	defer db.Close()
 
-	var httpServer api.Server = http.NewServer(logger, db, 8080)
+	retryPolicy, err := newWebhookRetryPolicy()
+	if err != nil {
+		fatal(logger, "error to load the webhook retry policy", domain.NewErrLogField(err))
+		return
+	}
+
+	requestTimeout, err := time.ParseDuration(envOrDefault("HTTP_REQUEST_TIMEOUT", "10s"))
+	if err != nil {
+		fatal(logger, "error to load the http request timeout", domain.NewErrLogField(err))
+		return
+	}
+
+	statementTimeout, err := time.ParseDuration(envOrDefault("HTTP_STATEMENT_TIMEOUT", "5m"))
+	if err != nil {
+		fatal(logger, "error to load the http statement timeout", domain.NewErrLogField(err))
+		return
+	}
+
+	idempotencyKeyTTL, err := time.ParseDuration(envOrDefault("IDEMPOTENCY_KEY_TTL", "24h"))
+	if err != nil {
+		fatal(logger, "error to load the idempotency key ttl", domain.NewErrLogField(err))
+		return
+	}
+
+	redaction, err := newRedaction()
+	if err != nil {
+		fatal(logger, "error to load the log redaction", domain.NewErrLogField(err))
+		return
+	}
+
+	var (
+		billingJob     api.Server = job.NewBilling(logger, repos, time.Hour)
+		interestJob    api.Server = job.NewInterest(logger, repos, policy, time.Hour)
+		outboxJob      api.Server = job.NewOutbox(logger, repos, eventPublisher, 5*time.Second)
+		webhookJob     api.Server = job.NewWebhook(logger, repos, retryPolicy, 5*time.Second)
+		idempotencyJob api.Server = job.NewIdempotency(logger, repos, requestTimeout, idempotencyKeyTTL, time.Hour)
+	)
+
+	go billingJob.Listen()
+	go interestJob.Listen()
+	go outboxJob.Listen()
+	go webhookJob.Listen()
+	go idempotencyJob.Listen()
+
+	var httpServer api.Server = http.NewServer(
+		logger,
+		redaction,
+		repos,
+		rates,
+		os.Getenv("ADMIN_TOKEN"),
+		requestTimeout,
+		statementTimeout,
+		idempotencyKeyTTL,
+		8080,
+	)
 
 	httpServer.Listen()
 }
 
+// newStorage connects to the database of the driver configured in the environment: "mysql", the default, "postgres"
+// or "sqlite".
 func newStorage() (*sql.DB, error) {
-	if os.Getenv("MYSQL_HOST") == "" {
-		return nil, errors.New("storage environment credentials not defined")
+	switch v := envOrDefault("DB_DRIVER", "mysql"); v {
+	case "mysql":
+		if os.Getenv("MYSQL_HOST") == "" {
+			return nil, errors.New("storage environment credentials not defined")
+		}
+
+		return storage.NewMySQLConnection(storage.NewConfig(
+			os.Getenv("MYSQL_PORT"),
+			os.Getenv("MYSQL_HOST"),
+			os.Getenv("MYSQL_PASSWORD"),
+			os.Getenv("MYSQL_DATABASE"),
+			os.Getenv("MYSQL_USER"),
+		))
+	case "postgres":
+		if os.Getenv("POSTGRES_HOST") == "" {
+			return nil, errors.New("storage environment credentials not defined")
+		}
+
+		return storage.NewPostgresConnection(storage.NewConfig(
+			envOrDefault("POSTGRES_PORT", "5432"),
+			os.Getenv("POSTGRES_HOST"),
+			os.Getenv("POSTGRES_PASSWORD"),
+			os.Getenv("POSTGRES_DATABASE"),
+			os.Getenv("POSTGRES_USER"),
+		))
+	case "sqlite":
+		return storage.NewSQLiteConnection(envOrDefault("SQLITE_FILE", "bank-transactions.db"))
+	default:
+		return nil, fmt.Errorf("invalid storage driver '%s'", v)
+	}
+}
+
+// migrate runs a migrate command in the database configured in the environment: "up" applies the pending migrations,
+// "down" reverts the last one applied and "status" lists them all
+func migrate(logger domain.Logger, command string) error {
+	db, err := newStorage()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	var (
+		ctx      = context.Background()
+		migrator = migration.NewMigrator(db)
+	)
+
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, v := range applied {
+			logger.Info(ctx, "migration applied", domain.NewLogField("migration", v))
+		}
+
+		if err == nil && len(applied) == 0 {
+			logger.Info(ctx, "no pending migrations")
+		}
+
+		return err
+	case "down":
+		reverted, err := migrator.Down(ctx)
+		if reverted != nil {
+			logger.Info(ctx, "migration reverted", domain.NewLogField("migration", reverted))
+		}
+
+		if err == nil && reverted == nil {
+			logger.Info(ctx, "no migrations to revert")
+		}
+
+		return err
+	case "status":
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range status {
+			if v.AppliedAt() == nil {
+				logger.Info(ctx, "migration pending", domain.NewLogField("migration", v.Migration()))
+				continue
+			}
+
+			logger.Info(
+				ctx,
+				"migration applied",
+				domain.NewLogField("migration", v.Migration()),
+				domain.NewLogField("applied_at", v.AppliedAt()),
+			)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("invalid migrate command '%s', use up, down or status", command)
+	}
+}
+
+// newExchangeRateProvider loads the exchange rates from the environment, in the format "USD:BRL=5.25,EUR:BRL=6.10"
+func newExchangeRateProvider() (domain.ExchangeRateProvider, error) {
+	var rates []*domain.ExchangeRate
+
+	for _, v := range strings.Split(os.Getenv("EXCHANGE_RATES"), ",") {
+		if strings.TrimSpace(v) == "" {
+			continue
+		}
+
+		var (
+			pair       = strings.SplitN(strings.TrimSpace(v), "=", 2)
+			currencies = strings.SplitN(pair[0], ":", 2)
+		)
+
+		if len(pair) != 2 || len(currencies) != 2 {
+			return nil, fmt.Errorf("invalid exchange rate '%s'", v)
+		}
+
+		from, err := domain.NewCurrency(currencies[0])
+		if err != nil {
+			return nil, err
+		}
+
+		to, err := domain.NewCurrency(currencies[1])
+		if err != nil {
+			return nil, err
+		}
+
+		rate, err := domain.NewExchangeRate(from, to, pair[1])
+		if err != nil {
+			return nil, err
+		}
+
+		rates = append(rates, rate)
+	}
+
+	return domain.NewStaticExchangeRateProvider(rates...), nil
+}
+
+// newInterestPolicy loads the charges on overdue balances from the environment: the daily rotativo interest and the
+// monthly mora as percentages, and the late fee as an amount
+func newInterestPolicy() (*domain.InterestPolicy, error) {
+	lateFee, err := domain.ParseMoney(envOrDefault("INTEREST_LATE_FEE", "0"), "")
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewInterestPolicy(
+		envOrDefault("INTEREST_DAILY_RATE", "0"),
+		lateFee,
+		envOrDefault("INTEREST_MONTHLY_MORA", "0"),
+	)
+}
+
+// newEventPublisher builds the publisher of the events configured in the environment: "webhook", posting them to
+// EVENT_WEBHOOK_URL, "file", appending them to EVENT_FILE, or "stdout", the default
+func newEventPublisher() (domain.EventPublisher, error) {
+	switch v := envOrDefault("EVENT_PUBLISHER", "stdout"); v {
+	case "webhook":
+		url := os.Getenv("EVENT_WEBHOOK_URL")
+		if url == "" {
+			return nil, errors.New("event webhook url not defined")
+		}
+
+		return publisher.NewWebhook(&nethttp.Client{Timeout: 10 * time.Second}, url), nil
+	case "file":
+		file, err := os.OpenFile(envOrDefault("EVENT_FILE", "events.jsonl"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return nil, err
+		}
+
+		return publisher.NewWriter(file), nil
+	case "stdout":
+		return publisher.NewWriter(os.Stdout), nil
+	default:
+		return nil, fmt.Errorf("invalid event publisher '%s'", v)
+	}
+}
+
+// newWebhookRetryPolicy loads how the failed webhook deliveries are retried from the environment: the maximum of
+// attempts and the delay after the first one, doubled at each attempt
+func newWebhookRetryPolicy() (*domain.WebhookRetryPolicy, error) {
+	maxAttempts, err := strconv.Atoi(envOrDefault("WEBHOOK_MAX_ATTEMPTS", "8"))
+	if err != nil {
+		return nil, err
+	}
+
+	delay, err := time.ParseDuration(envOrDefault("WEBHOOK_RETRY_DELAY", "30s"))
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewWebhookRetryPolicy(maxAttempts, delay)
+}
+
+// newRedaction loads the redaction of the logged requests and responses from the environment: the maximum size of
+// the bodies, and the JSON fields, as "document.number", and the headers to redact besides the default ones, comma
+// separated. The fields are masked and the headers dropped.
+func newRedaction() (*middleware.Redaction, error) {
+	maxBodySize, err := strconv.Atoi(envOrDefault("LOG_MAX_BODY_SIZE", "2048"))
+	if err != nil {
+		return nil, err
+	}
+
+	if maxBodySize < 0 {
+		return nil, fmt.Errorf("invalid log max body size '%d'", maxBodySize)
+	}
+
+	redaction := http.DefaultRedaction(maxBodySize)
+
+	for _, v := range strings.Split(os.Getenv("LOG_REDACTED_FIELDS"), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			redaction = redaction.WithField(v, middleware.MaskAll)
+		}
+	}
+
+	for _, v := range strings.Split(os.Getenv("LOG_REDACTED_HEADERS"), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			redaction = redaction.WithHeader(v, nil)
+		}
+	}
+
+	return redaction, nil
+}
+
+// fatal logs the error stopping the app and exits
+func fatal(logger domain.Logger, msg string, fields ...domain.LogField) {
+	logger.Error(context.Background(), msg, fields...)
+	os.Exit(1)
+}
+
+func envOrDefault(key, value string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
 	}
 
-	return storage.NewMySQLConnection(storage.NewConfig(
-		os.Getenv("MYSQL_PORT"),
-		os.Getenv("MYSQL_HOST"),
-		os.Getenv("MYSQL_PASSWORD"),
-		os.Getenv("MYSQL_DATABASE"),
-		os.Getenv("MYSQL_USER"),
-	))
+	return value
 }
@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// AccrueInterest contains all the dependencies to post the charges on the overdue balances of the invoices
+type AccrueInterest struct {
+	accountRepo     domain.AccountRepositoryReader
+	interestRepo    domain.InterestRepositoryReader
+	statementRepo   domain.StatementRepositoryReader
+	transactionRepo domain.TransactionRepositoryWriter
+	unitOfWork      domain.UnitOfWork
+	policy          *domain.InterestPolicy
+	now             func() time.Time
+}
+
+// NewAccrueInterest creates a new AccrueInterest with its dependencies, now is the clock defining the day accrued
+func NewAccrueInterest(
+	accountRepo domain.AccountRepositoryReader,
+	interestRepo domain.InterestRepositoryReader,
+	statementRepo domain.StatementRepositoryReader,
+	transactionRepo domain.TransactionRepositoryWriter,
+	unitOfWork domain.UnitOfWork,
+	policy *domain.InterestPolicy,
+	now func() time.Time,
+) *AccrueInterest {
+	return &AccrueInterest{
+		accountRepo:     accountRepo,
+		interestRepo:    interestRepo,
+		statementRepo:   statementRepo,
+		transactionRepo: transactionRepo,
+		unitOfWork:      unitOfWork,
+		policy:          policy,
+		now:             now,
+	}
+}
+
+// Accrue posts the charges of the current day on the overdue invoices, returning how many charges were posted. Charges
+// already posted in the day are skipped, so it can run again safely; an error accruing one account does not stop the
+// others, and the first error found is returned.
+func (a AccrueInterest) Accrue(ctx context.Context) (int, error) {
+	at := a.now()
+
+	invoices, err := a.interestRepo.FindOverdueInvoices(ctx, at)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		posted   int
+		firstErr error
+	)
+
+	for _, invoice := range invoices {
+		n, err := a.accrueInvoice(ctx, invoice, at)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+
+		posted += n
+	}
+
+	return posted, firstErr
+}
+
+// accrueInvoice posts the charges of the day on the overdue balance of the invoice in a single unit of work, so the
+// charges of the day are posted all together or none of them
+func (a AccrueInterest) accrueInvoice(ctx context.Context, invoice *domain.Invoice, at time.Time) (int, error) {
+	var charges []*domain.Transaction
+
+	err := a.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		account, err := a.accountRepo.FindOneByID(ctx, invoice.AccountID())
+		if err != nil {
+			return err
+		}
+
+		accrual, err := domain.NewAccrual(account, invoice, at).Assess(ctx, a.statementRepo)
+		if err != nil {
+			return err
+		}
+
+		charges, err = accrual.Charges(a.policy)
+		if err != nil {
+			return err
+		}
+
+		for _, charge := range charges {
+			if _, err := charge.Store(ctx, a.transactionRepo); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(charges), nil
+}
@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+func TestAccrueInterest_Accrue(t *testing.T) {
+	var (
+		policy, _ = domain.NewInterestPolicy("0.5", domain.NewMoney(1000, domain.CurrencyBRL), "1")
+		account   = new(domain.Account).WithID(domain.NewID(1)).WithCurrency(domain.CurrencyBRL)
+		closing   = time.Date(2020, 10, 25, 23, 59, 59, 0, time.UTC)
+		dueDate   = time.Date(2020, 11, 5, 0, 0, 0, 0, time.UTC)
+		invoices  = []*domain.Invoice{
+			domain.LoadInvoice(domain.NewID(1), domain.NewID(1), closing.AddDate(0, -1, 0), closing, dueDate, domain.NewMoney(100000, domain.CurrencyBRL), nil, closing),
+			domain.LoadInvoice(domain.NewID(2), domain.NewID(2), closing.AddDate(0, -1, 0), closing, dueDate, domain.NewMoney(0, domain.CurrencyBRL), nil, closing),
+		}
+		clock = func() time.Time { return time.Date(2020, 11, 6, 3, 0, 0, 0, time.UTC) }
+	)
+
+	type fields struct {
+		accountRepo     *domain.AccountRepositoryMock
+		interestRepo    *domain.InterestRepositoryMock
+		statementRepo   *domain.StatementRepositoryMock
+		transactionRepo *domain.TransactionRepositoryWriterMock
+		unitOfWork      *domain.UnitOfWorkMock
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    int
+		wantErr error
+	}{
+		// fails
+		{
+			name: "repository error to find the overdue invoices",
+			fields: fields{
+				accountRepo:     domain.NewAccountRepositoryMock(nil, account, nil),
+				interestRepo:    domain.NewInterestRepositoryMock(nil, errors.New("database error")),
+				statementRepo:   domain.NewStatementRepositoryMock(nil, nil),
+				transactionRepo: domain.NewTransactionRepositoryMock(domain.NewID(1), nil),
+				unitOfWork:      domain.NewUnitOfWorkMock(nil),
+			},
+			want:    0,
+			wantErr: errors.New("database error"),
+		},
+		{
+			name: "repository error to find the account",
+			fields: fields{
+				accountRepo:     domain.NewAccountRepositoryMock(nil, nil, errors.New("account not found")),
+				interestRepo:    domain.NewInterestRepositoryMock(invoices, nil),
+				statementRepo:   domain.NewStatementRepositoryMock(nil, nil),
+				transactionRepo: domain.NewTransactionRepositoryMock(domain.NewID(1), nil),
+				unitOfWork:      domain.NewUnitOfWorkMock(nil),
+			},
+			want:    0,
+			wantErr: errors.New("account not found"),
+		},
+		{
+			name: "error to begin the unit of work",
+			fields: fields{
+				accountRepo:     domain.NewAccountRepositoryMock(nil, account, nil),
+				interestRepo:    domain.NewInterestRepositoryMock(invoices, nil),
+				statementRepo:   domain.NewStatementRepositoryMock(nil, nil),
+				transactionRepo: domain.NewTransactionRepositoryMock(domain.NewID(1), nil),
+				unitOfWork:      domain.NewUnitOfWorkMock(errors.New("begin transaction error")),
+			},
+			want:    0,
+			wantErr: errors.New("begin transaction error"),
+		},
+		{
+			name: "repository error to post the charges",
+			fields: fields{
+				accountRepo:     domain.NewAccountRepositoryMock(nil, account, nil),
+				interestRepo:    domain.NewInterestRepositoryMock(invoices, nil),
+				statementRepo:   domain.NewStatementRepositoryMock(nil, nil),
+				transactionRepo: domain.NewTransactionRepositoryMock(nil, errors.New("database error")),
+				unitOfWork:      domain.NewUnitOfWorkMock(nil),
+			},
+			want:    0,
+			wantErr: errors.New("database error"),
+		},
+
+		// successes
+		{
+			name: "no overdue invoices",
+			fields: fields{
+				accountRepo:     domain.NewAccountRepositoryMock(nil, account, nil),
+				interestRepo:    domain.NewInterestRepositoryMock(nil, nil),
+				statementRepo:   domain.NewStatementRepositoryMock(nil, nil),
+				transactionRepo: domain.NewTransactionRepositoryMock(domain.NewID(1), nil),
+				unitOfWork:      domain.NewUnitOfWorkMock(nil),
+			},
+			want: 0,
+		},
+		{
+			name: "charges posted only on the invoices with a balance to be paid",
+			fields: fields{
+				accountRepo:     domain.NewAccountRepositoryMock(nil, account, nil),
+				interestRepo:    domain.NewInterestRepositoryMock(invoices, nil),
+				statementRepo:   domain.NewStatementRepositoryMock(nil, nil),
+				transactionRepo: domain.NewTransactionRepositoryMock(domain.NewID(1), nil),
+				unitOfWork:      domain.NewUnitOfWorkMock(nil),
+			},
+			want: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAccrueInterest(
+				tt.fields.accountRepo,
+				tt.fields.interestRepo,
+				tt.fields.statementRepo,
+				tt.fields.transactionRepo,
+				tt.fields.unitOfWork,
+				policy,
+				clock,
+			)
+
+			got, err := a.Accrue(context.Background())
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Accrue() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("Accrue() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// ChangeAccountStatus contains all the dependencies to change the status of an account
+type ChangeAccountStatus struct {
+	accountRepo domain.AccountRepositoryReader
+	statusRepo  domain.AccountStatusRepositoryWriter
+}
+
+// NewChangeAccountStatus creates a new ChangeAccountStatus with its dependencies
+func NewChangeAccountStatus(
+	accountRepo domain.AccountRepositoryReader,
+	statusRepo domain.AccountStatusRepositoryWriter,
+) *ChangeAccountStatus {
+	return &ChangeAccountStatus{accountRepo: accountRepo, statusRepo: statusRepo}
+}
+
+// Change changes the status of an account for the informed reason, returning the updated account
+func (c ChangeAccountStatus) Change(ctx context.Context, id *domain.ID, status domain.AccountStatus, reason domain.StatusReason) (*domain.Account, error) {
+	account, err := c.accountRepo.FindOneByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	account, change, err := account.ChangeStatus(status, reason, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.statusRepo.StoreStatusChange(ctx, change); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
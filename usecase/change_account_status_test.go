@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+func TestChangeAccountStatus_Change(t *testing.T) {
+	var (
+		account = new(domain.Account).WithID(domain.NewID(1)).WithCurrency(domain.CurrencyBRL)
+		closed  = account.WithStatus(domain.AccountStatusClosed, domain.StatusReasonCustomerRequest, time.Time{})
+	)
+
+	type fields struct {
+		repo *domain.AccountRepositoryMock
+	}
+	type args struct {
+		status domain.AccountStatus
+		reason domain.StatusReason
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name:    "repository error when the account is not found",
+			fields:  fields{repo: domain.NewAccountRepositoryMock(nil, nil, errors.New("account not found"))},
+			args:    args{status: domain.AccountStatusBlocked, reason: domain.StatusReasonFraudSuspected},
+			wantErr: errors.New("account not found"),
+		},
+		{
+			name:    "domain error when the account is closed",
+			fields:  fields{repo: domain.NewAccountRepositoryMock(nil, closed, nil)},
+			args:    args{status: domain.AccountStatusActive, reason: domain.StatusReasonIssueResolved},
+			wantErr: domain.NewErrDomain("status", "cannot be changed from 'CLOSED' to 'ACTIVE'"),
+		},
+		{
+			name:   "account blocked successfully",
+			fields: fields{repo: domain.NewAccountRepositoryMock(nil, account, nil)},
+			args:   args{status: domain.AccountStatusBlocked, reason: domain.StatusReasonFraudSuspected},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewChangeAccountStatus(tt.fields.repo, tt.fields.repo)
+
+			got, err := c.Change(context.Background(), domain.NewID(1), tt.args.status, tt.args.reason)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Change() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.Status() != tt.args.status || got.StatusReason() != tt.args.reason || got.StatusChangedAt().IsZero() {
+				t.Errorf("Change() = %v %v %v", got.Status(), got.StatusReason(), got.StatusChangedAt())
+			}
+		})
+	}
+}
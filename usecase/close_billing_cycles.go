@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// CloseBillingCycles contains all the dependencies to close the billing cycles of the accounts
+type CloseBillingCycles struct {
+	accountRepo   domain.AccountRepositoryReader
+	statementRepo domain.StatementRepositoryReader
+	invoiceReader domain.InvoiceRepositoryReader
+	invoiceWriter domain.InvoiceRepositoryWriter
+}
+
+// NewCloseBillingCycles creates a new CloseBillingCycles with its dependencies
+func NewCloseBillingCycles(
+	accountRepo domain.AccountRepositoryReader,
+	statementRepo domain.StatementRepositoryReader,
+	invoiceReader domain.InvoiceRepositoryReader,
+	invoiceWriter domain.InvoiceRepositoryWriter,
+) *CloseBillingCycles {
+	return &CloseBillingCycles{
+		accountRepo:   accountRepo,
+		statementRepo: statementRepo,
+		invoiceReader: invoiceReader,
+		invoiceWriter: invoiceWriter,
+	}
+}
+
+// Close generates the invoices of the last billing cycle closed at the informed moment of every account, returning
+// how many invoices were generated. Accounts whose invoice is already generated are skipped, so it can run again
+// safely; an error closing one account does not stop the others, and the first error found is returned.
+func (c CloseBillingCycles) Close(ctx context.Context, at time.Time) (int, error) {
+	var (
+		closed   int
+		firstErr error
+	)
+
+	for day := 1; day <= domain.MaxBillingDay; day++ {
+		cycle, err := domain.NewBillingCycle(day, domain.DefaultDueDay)
+		if err != nil {
+			return closed, err
+		}
+
+		closing := cycle.LastClosing(at)
+
+		ids, err := c.invoiceReader.FindAccountsToClose(ctx, day, closing)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+
+			continue
+		}
+
+		for _, id := range ids {
+			if err := c.closeAccount(ctx, id, closing); err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+
+				continue
+			}
+
+			closed++
+		}
+	}
+
+	return closed, firstErr
+}
+
+// closeAccount generates and stores the invoice of the account billing cycle ending at closing
+func (c CloseBillingCycles) closeAccount(ctx context.Context, accountID *domain.ID, closing time.Time) error {
+	account, err := c.accountRepo.FindOneByID(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	previous, err := c.invoiceReader.FindLastByAccount(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	invoice, err := domain.NewInvoice(account, previous, closing).Gather(ctx, c.statementRepo, c.invoiceReader)
+	if err != nil {
+		return err
+	}
+
+	_, err = invoice.Store(ctx, c.invoiceWriter)
+
+	return err
+}
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+func TestCloseBillingCycles_Close(t *testing.T) {
+	var (
+		at          = time.Date(2020, 10, 26, 3, 0, 0, 0, time.UTC)
+		account     = new(domain.Account).WithID(domain.NewID(1)).WithCurrency(domain.CurrencyBRL)
+		accountIDs  = []*domain.ID{domain.NewID(1), domain.NewID(2)}
+		purchase, _ = domain.NewTransaction(domain.NewID(1), domain.OperationCompraAVista, domain.NewMoney(5000, domain.CurrencyBRL))
+	)
+
+	type fields struct {
+		accountRepo   *domain.AccountRepositoryMock
+		statementRepo *domain.StatementRepositoryMock
+		invoiceRepo   *domain.InvoiceRepositoryMock
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    int
+		wantErr error
+	}{
+		// fails
+		{
+			name: "repository error to find the accounts to close",
+			fields: fields{
+				accountRepo:   domain.NewAccountRepositoryMock(nil, account, nil),
+				statementRepo: domain.NewStatementRepositoryMock(nil, nil),
+				invoiceRepo:   domain.NewInvoiceRepositoryMock(nil, nil, errors.New("database error")),
+			},
+			want:    0,
+			wantErr: errors.New("database error"),
+		},
+		{
+			name: "repository error to find the account",
+			fields: fields{
+				accountRepo:   domain.NewAccountRepositoryMock(nil, nil, errors.New("account not found")),
+				statementRepo: domain.NewStatementRepositoryMock(nil, nil),
+				invoiceRepo:   domain.NewInvoiceRepositoryMock(domain.NewID(1), nil, nil).WithAccountsToClose(accountIDs),
+			},
+			want:    0,
+			wantErr: errors.New("account not found"),
+		},
+		{
+			name: "repository error to gather the transactions",
+			fields: fields{
+				accountRepo:   domain.NewAccountRepositoryMock(nil, account, nil),
+				statementRepo: domain.NewStatementRepositoryMock(nil, errors.New("database error")),
+				invoiceRepo:   domain.NewInvoiceRepositoryMock(domain.NewID(1), nil, nil).WithAccountsToClose(accountIDs),
+			},
+			want:    0,
+			wantErr: errors.New("database error"),
+		},
+
+		// successes
+		{
+			name: "no accounts to close",
+			fields: fields{
+				accountRepo:   domain.NewAccountRepositoryMock(nil, account, nil),
+				statementRepo: domain.NewStatementRepositoryMock(nil, nil),
+				invoiceRepo:   domain.NewInvoiceRepositoryMock(domain.NewID(1), nil, nil),
+			},
+			want: 0,
+		},
+		{
+			name: "invoices generated for every account to close",
+			fields: fields{
+				accountRepo:   domain.NewAccountRepositoryMock(nil, account, nil),
+				statementRepo: domain.NewStatementRepositoryMock([]*domain.Transaction{purchase}, nil),
+				invoiceRepo:   domain.NewInvoiceRepositoryMock(domain.NewID(1), nil, nil).WithAccountsToClose(accountIDs),
+			},
+			want: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCloseBillingCycles(
+				tt.fields.accountRepo,
+				tt.fields.statementRepo,
+				tt.fields.invoiceRepo,
+				tt.fields.invoiceRepo,
+			)
+
+			got, err := c.Close(context.Background(), at)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Close() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("Close() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
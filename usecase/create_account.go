@@ -1,31 +1,68 @@
 package usecase
 
 import (
+	"context"
+
 	"github.com/tonytcb/bank-transactions-go/domain"
 )
 
 // CreateAccount contains all the dependencies to create an account
 type CreateAccount struct {
-	repo domain.AccountRepositoryWriter
+	repo       domain.AccountRepositoryWriter
+	eventRepo  domain.EventRepositoryWriter
+	unitOfWork domain.UnitOfWork
 }
 
 // NewCreateAccount creates a new CreateAccount with its dependencies
-func NewCreateAccount(repo domain.AccountRepositoryWriter) *CreateAccount {
-	return &CreateAccount{repo: repo}
+func NewCreateAccount(
+	repo domain.AccountRepositoryWriter,
+	eventRepo domain.EventRepositoryWriter,
+	unitOfWork domain.UnitOfWork,
+) *CreateAccount {
+	return &CreateAccount{repo: repo, eventRepo: eventRepo, unitOfWork: unitOfWork}
 }
 
-// Create creates a account
-func (c CreateAccount) Create(documentNumber string) (*domain.Account, error) {
+// Create creates a account in the informed currency with the informed credit limit, billed in the informed billing
+// cycle or in the default one when it is zero. The account created event is stored with the account, atomically.
+func (c CreateAccount) Create(
+	ctx context.Context,
+	documentNumber string,
+	currency domain.Currency,
+	creditLimit domain.Money,
+	billingCycle domain.BillingCycle,
+) (*domain.Account, error) {
 	account, err := domain.NewAccount(domain.DocumentNumber(documentNumber))
 	if err != nil {
 		// todo add context to the error
 		return nil, err
 	}
 
-	acc, err := account.Store(c.repo)
+	if creditLimit, err = creditLimit.In(currency); err != nil {
+		return nil, err
+	}
+
+	account = account.
+		WithCurrency(currency).
+		WithCreditLimit(creditLimit).
+		WithBillingCycle(billingCycle)
+
+	err = c.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if account, err = account.Store(ctx, c.repo); err != nil {
+			return err
+		}
+
+		event, err := domain.NewAccountCreated(account)
+		if err != nil {
+			return err
+		}
+
+		_, err = event.Store(ctx, c.eventRepo)
+
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return acc, nil
+	return account, nil
 }
@@ -1,6 +1,8 @@
 package usecase
 
 import (
+	"context"
+	"errors"
 	"reflect"
 	"testing"
 
@@ -10,11 +12,20 @@ import (
 )
 
 func TestCreateAccount(t *testing.T) {
+	var (
+		cycle, _  = domain.NewBillingCycle(10, 20)
+		eventRepo = domain.NewEventRepositoryMock(domain.NewID(1), nil, nil)
+	)
+
 	type fields struct {
-		repo domain.AccountRepositoryWriter
+		repo      domain.AccountRepositoryWriter
+		eventRepo domain.EventRepositoryWriter
 	}
 	type args struct {
 		documentNumber string
+		currency       domain.Currency
+		creditLimit    domain.Money
+		billingCycle   domain.BillingCycle
 	}
 	tests := []struct {
 		name    string
@@ -25,7 +36,8 @@ func TestCreateAccount(t *testing.T) {
 		{
 			name: "domain error when the document number is invalid",
 			fields: fields{
-				repo: domain.NewAccountRepositoryMock(nil, nil, nil),
+				repo:      domain.NewAccountRepositoryMock(nil, nil, nil),
+				eventRepo: eventRepo,
 			},
 			args: args{
 				documentNumber: "00000000000",
@@ -35,30 +47,72 @@ func TestCreateAccount(t *testing.T) {
 		{
 			name: "repository error when the document numbers is duplicate",
 			fields: fields{
-				repo: domain.NewAccountRepositoryMock(nil, nil, repository.NewErrDuplicatedEntry("document number", "duplicate entry 00000000191")),
+				repo:      domain.NewAccountRepositoryMock(nil, nil, repository.NewErrDuplicatedEntry("document number", "duplicate entry 00000000191")),
+				eventRepo: eventRepo,
 			},
 			args: args{
 				documentNumber: "00000000191",
 			},
 			wantErr: repository.NewErrDuplicatedEntry("document number", "duplicate entry 00000000191"),
 		},
+		{
+			name: "repository error to store the account created event",
+			fields: fields{
+				repo:      domain.NewAccountRepositoryMock(domain.NewID(1000), nil, nil),
+				eventRepo: domain.NewEventRepositoryMock(nil, nil, errors.New("database error")),
+			},
+			args: args{
+				documentNumber: "00000000191",
+				currency:       domain.CurrencyBRL,
+				creditLimit:    domain.NewMoney(100000, domain.CurrencyBRL),
+			},
+			wantErr: errors.New("database error"),
+		},
 		{
 			name: "account created successfully",
 			fields: fields{
-				repo: domain.NewAccountRepositoryMock(domain.NewID(1000), nil, nil),
+				repo:      domain.NewAccountRepositoryMock(domain.NewID(1000), nil, nil),
+				eventRepo: eventRepo,
 			},
 			args: args{
 				documentNumber: "00000000191",
+				currency:       domain.CurrencyBRL,
+				creditLimit:    domain.NewMoney(100000, domain.CurrencyBRL),
 			},
 			wantErr: repository.NewErrDuplicatedEntry("document number", "duplicate entry 00000000191"),
 		},
+		{
+			name: "account created successfully in a foreign currency",
+			fields: fields{
+				repo:      domain.NewAccountRepositoryMock(domain.NewID(1001), nil, nil),
+				eventRepo: eventRepo,
+			},
+			args: args{
+				documentNumber: "00000000191",
+				currency:       "USD",
+				creditLimit:    domain.NewMoney(50000, "USD"),
+			},
+		},
+		{
+			name: "account created successfully with a billing cycle",
+			fields: fields{
+				repo:      domain.NewAccountRepositoryMock(domain.NewID(1002), nil, nil),
+				eventRepo: eventRepo,
+			},
+			args: args{
+				documentNumber: "00000000191",
+				currency:       domain.CurrencyBRL,
+				creditLimit:    domain.NewMoney(100000, domain.CurrencyBRL),
+				billingCycle:   cycle,
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c := NewCreateAccount(tt.fields.repo)
+			c := NewCreateAccount(tt.fields.repo, tt.fields.eventRepo, domain.NewUnitOfWorkMock(nil))
 
-			got, err := c.Create(tt.args.documentNumber)
+			got, err := c.Create(context.Background(), tt.args.documentNumber, tt.args.currency, tt.args.creditLimit, tt.args.billingCycle)
 			if (err != nil) && !reflect.DeepEqual(err, tt.wantErr) {
 				t.Errorf("Create() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -72,6 +126,18 @@ func TestCreateAccount(t *testing.T) {
 				t.Errorf("Invalid AccountWriter result: ID it must be greater than zero")
 				return
 			}
+
+			if got.Currency() != tt.args.currency {
+				t.Errorf("Invalid currency: got = %v, want %v", got.Currency(), tt.args.currency)
+			}
+
+			if got.CreditLimit() != tt.args.creditLimit || got.AvailableCreditLimit() != tt.args.creditLimit {
+				t.Errorf("Invalid credit limit: got = %v, available = %v, want %v", got.CreditLimit(), got.AvailableCreditLimit(), tt.args.creditLimit)
+			}
+
+			if !tt.args.billingCycle.IsZero() && got.BillingCycle() != tt.args.billingCycle {
+				t.Errorf("Invalid billing cycle: got = %v, want %v", got.BillingCycle(), tt.args.billingCycle)
+			}
 		})
 	}
 }
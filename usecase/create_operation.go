@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// CreateOperation contains all the dependencies to create an operation
+type CreateOperation struct {
+	repo domain.OperationRepositoryWriter
+}
+
+// NewCreateOperation creates a new CreateOperation with its dependencies
+func NewCreateOperation(repo domain.OperationRepositoryWriter) *CreateOperation {
+	return &CreateOperation{repo: repo}
+}
+
+// Create creates an operation, used by the transactions right away
+func (c CreateOperation) Create(ctx context.Context, description string, direction domain.OperationDirection, kind domain.OperationKind) (*domain.Operation, error) {
+	operation, err := domain.NewOperationType(description, direction, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	operation, err = operation.Store(ctx, c.repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return operation, nil
+}
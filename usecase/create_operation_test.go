@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+func TestCreateOperation_Create(t *testing.T) {
+	type args struct {
+		description string
+		direction   domain.OperationDirection
+		kind        domain.OperationKind
+	}
+	tests := []struct {
+		name    string
+		repo    *domain.OperationRepositoryMock
+		args    args
+		wantErr error
+	}{
+		{
+			name:    "domain error when the direction is invalid",
+			repo:    domain.NewOperationRepositoryMock(domain.NewID(301), nil, nil),
+			args:    args{description: "cashback", direction: domain.OperationDirection("OUT"), kind: domain.OperationKindStandard},
+			wantErr: domain.NewErrDomain("direction", "'OUT' is not a valid operation direction"),
+		},
+		{
+			name:    "repository error",
+			repo:    domain.NewOperationRepositoryMock(nil, nil, errors.New("database error")),
+			args:    args{description: "cashback", direction: domain.OperationDirectionCredit, kind: domain.OperationKindStandard},
+			wantErr: errors.New("database error"),
+		},
+		{
+			name: "operation created successfully",
+			repo: domain.NewOperationRepositoryMock(domain.NewID(301), nil, nil),
+			args: args{description: "cashback", direction: domain.OperationDirectionCredit, kind: domain.OperationKindStandard},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewCreateOperation(tt.repo).Create(context.Background(), tt.args.description, tt.args.direction, tt.args.kind)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.ID().Value() != 301 || got.Description() != "CASHBACK" || !got.IsIncoming() {
+				t.Errorf("Create() got = %v", got)
+			}
+		})
+	}
+}
@@ -1,31 +1,129 @@
 package usecase
 
 import (
+	"context"
+	"fmt"
+	"time"
+
 	"github.com/tonytcb/bank-transactions-go/domain"
 )
 
 // CreateTransaction contains all the dependencies to create a transaction
 type CreateTransaction struct {
-	repo domain.TransactionRepositoryWriter
+	repo          domain.TransactionRepositoryWriter
+	accountRepo   domain.AccountRepositoryReader
+	operationRepo domain.OperationRepositoryReader
+	eventRepo     domain.EventRepositoryWriter
+	unitOfWork    domain.UnitOfWork
+	rates         domain.ExchangeRateProvider
 }
 
 // NewCreateTransaction creates a new CreateTransaction with its dependencies
-func NewCreateTransaction(repo domain.TransactionRepositoryWriter) *CreateTransaction {
-	return &CreateTransaction{repo: repo}
+func NewCreateTransaction(
+	repo domain.TransactionRepositoryWriter,
+	accountRepo domain.AccountRepositoryReader,
+	operationRepo domain.OperationRepositoryReader,
+	eventRepo domain.EventRepositoryWriter,
+	unitOfWork domain.UnitOfWork,
+	rates domain.ExchangeRateProvider,
+) *CreateTransaction {
+	return &CreateTransaction{
+		repo:          repo,
+		accountRepo:   accountRepo,
+		operationRepo: operationRepo,
+		eventRepo:     eventRepo,
+		unitOfWork:    unitOfWork,
+		rates:         rates,
+	}
 }
 
-// Create creates a transaction
-func (c CreateTransaction) Create(accountID, operationID *domain.ID, amount float64) (*domain.Transaction, error) {
-	transaction, err := domain.NewTransaction(accountID, operationID, amount)
+// Create creates a transaction, installment purchases are scheduled in the informed number of installments.
+// Amounts in a foreign currency are converted to the account currency, amounts without currency are considered to be
+// already in the account currency. Transfer postings, reversals and charges are only created through their own use cases,
+// and disabled operations are rejected. The transaction created event is stored with the transaction, atomically.
+func (c CreateTransaction) Create(ctx context.Context, accountID, operationID *domain.ID, amount domain.Money, installments int) (*domain.Transaction, error) {
+	account, err := c.accountRepo.FindOneByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if amount.Currency() == "" {
+		if amount, err = amount.In(account.Currency()); err != nil {
+			return nil, err
+		}
+	}
+
+	operations, err := domain.FindOperations(ctx, c.operationRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	operation, err := operations.Find(operationID)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := domain.NewTransaction(accountID, operation, amount)
 	if err != nil {
 		// todo add context to the error
 		return nil, err
 	}
 
-	t, err := transaction.Store(c.repo)
+	if transaction.Operation().IsTransfer() {
+		description := fmt.Sprintf("'%d' must be registered through a transfer", operationID.Value())
+
+		return nil, domain.NewErrDomain("operation", description)
+	}
+
+	if transaction.Operation().IsReversal() {
+		description := fmt.Sprintf("'%d' must be registered through a reversal", operationID.Value())
+
+		return nil, domain.NewErrDomain("operation", description)
+	}
+
+	if transaction.Operation().IsCharge() {
+		description := fmt.Sprintf("'%d' is registered only by the interest accrual", operationID.Value())
+
+		return nil, domain.NewErrDomain("operation", description)
+	}
+
+	if !transaction.Operation().IsEnabled() {
+		description := fmt.Sprintf("'%d' is disabled", operationID.Value())
+
+		return nil, domain.NewErrDomain("operation", description)
+	}
+
+	transaction, err = transaction.ConvertTo(account.Currency(), c.rates)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := account.Accepts(transaction); err != nil {
+		return nil, err
+	}
+
+	transaction, err = transaction.WithInstallments(installments, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if transaction, err = transaction.Store(ctx, c.repo); err != nil {
+			return err
+		}
+
+		event, err := domain.NewTransactionCreated(transaction)
+		if err != nil {
+			return err
+		}
+
+		_, err = event.Store(ctx, c.eventRepo)
+
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return t, nil
+	return transaction, nil
 }
@@ -1,77 +1,327 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/tonytcb/bank-transactions-go/domain"
 )
 
 func TestCreateTransaction_Create(t *testing.T) {
-	transaction := &domain.Transaction{}
+	var (
+		transaction = &domain.Transaction{}
+		account     = new(domain.Account).WithID(domain.NewID(100)).WithCurrency(domain.CurrencyBRL)
+		accountRepo = domain.NewAccountRepositoryMock(nil, account, nil)
+		usdToBrl, _ = domain.NewExchangeRate("USD", domain.CurrencyBRL, "5.25")
+		rates       = domain.NewStaticExchangeRateProvider(usdToBrl)
+		fee, _      = domain.NewOperationType("tarifa", domain.OperationDirectionDebit, domain.OperationKindStandard)
+		eventRepo   = domain.NewEventRepositoryMock(domain.NewID(1), nil, nil)
+		operations  = []*domain.Operation{
+			domain.OperationCompraAVista,
+			domain.OperationCompraParcelada,
+			domain.OperationSaque,
+			domain.OperationPagamento,
+			domain.OperationTransferenciaEnviada,
+			domain.OperationTransferenciaRecebida,
+			domain.OperationEstorno,
+			domain.OperationJurosRotativo,
+			domain.OperationMultaAtraso,
+			domain.OperationJurosMora,
+			fee.WithID(domain.NewID(200)).WithEnabled(false),
+		}
+		operationRepo = domain.NewOperationRepositoryMock(nil, operations, nil)
+	)
 
 	type fields struct {
-		repo domain.TransactionRepositoryWriter
+		repo        domain.TransactionRepositoryWriter
+		accountRepo domain.AccountRepositoryReader
+		eventRepo   domain.EventRepositoryWriter
 	}
 	type args struct {
-		accountID   *domain.ID
-		operationID *domain.ID
-		amount      float64
+		accountID    *domain.ID
+		operationID  *domain.ID
+		amount       domain.Money
+		installments int
 	}
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		want    *domain.Transaction
-		wantErr error
+		name       string
+		fields     fields
+		args       args
+		want       *domain.Transaction
+		wantAmount domain.Money
+		wantErr    error
 	}{
+		{
+			name: "repository error when the account is not found",
+			fields: fields{
+				repo:        domain.NewTransactionRepositoryMock(nil, nil),
+				accountRepo: domain.NewAccountRepositoryMock(nil, nil, errors.New("account not found")),
+				eventRepo:   eventRepo,
+			},
+			args: args{
+				accountID:   domain.NewID(uint64(100)),
+				operationID: domain.NewID(4),
+				amount:      domain.NewMoney(10000, domain.CurrencyBRL),
+			},
+			wantErr: errors.New("account not found"),
+		},
+		{
+			name: "domain error when there is no exchange rate to the account currency",
+			fields: fields{
+				repo:        domain.NewTransactionRepositoryMock(nil, nil),
+				accountRepo: accountRepo,
+				eventRepo:   eventRepo,
+			},
+			args: args{
+				accountID:   domain.NewID(uint64(100)),
+				operationID: domain.NewID(1),
+				amount:      domain.NewMoney(10000, "EUR"),
+			},
+			wantErr: domain.NewErrDomain("currency", "there is no exchange rate from 'EUR' to 'BRL'"),
+		},
+		{
+			name: "domain error when the account is blocked and the transaction is a debit",
+			fields: fields{
+				repo:        domain.NewTransactionRepositoryMock(nil, nil),
+				accountRepo: domain.NewAccountRepositoryMock(nil, account.WithStatus(domain.AccountStatusBlocked, domain.StatusReasonFraudSuspected, time.Now()), nil),
+				eventRepo:   eventRepo,
+			},
+			args: args{
+				accountID:   domain.NewID(uint64(100)),
+				operationID: domain.NewID(1),
+				amount:      domain.NewMoney(10000, domain.CurrencyBRL),
+			},
+			wantErr: domain.NewErrDomain("account", "is blocked and does not accept debits"),
+		},
+		{
+			name: "domain error when the account is closed",
+			fields: fields{
+				repo:        domain.NewTransactionRepositoryMock(nil, nil),
+				accountRepo: domain.NewAccountRepositoryMock(nil, account.WithStatus(domain.AccountStatusClosed, domain.StatusReasonCustomerRequest, time.Now()), nil),
+				eventRepo:   eventRepo,
+			},
+			args: args{
+				accountID:   domain.NewID(uint64(100)),
+				operationID: domain.NewID(4),
+				amount:      domain.NewMoney(10000, domain.CurrencyBRL),
+			},
+			wantErr: domain.NewErrDomain("account", "is closed and does not accept transactions"),
+		},
+		{
+			name: "payment created successfully in a blocked account",
+			fields: fields{
+				repo:        domain.NewTransactionRepositoryMock(domain.NewID(uint64(104)), nil),
+				accountRepo: domain.NewAccountRepositoryMock(nil, account.WithStatus(domain.AccountStatusBlocked, domain.StatusReasonFraudSuspected, time.Now()), nil),
+				eventRepo:   eventRepo,
+			},
+			args: args{
+				accountID:   domain.NewID(uint64(100)),
+				operationID: domain.NewID(4),
+				amount:      domain.NewMoney(10000, domain.CurrencyBRL),
+			},
+			want:       transaction.WithID(domain.NewID(uint64(104))),
+			wantAmount: domain.NewMoney(10000, domain.CurrencyBRL),
+		},
 		{
 			name: "domain error when the operation is not valid",
 			fields: fields{
-				repo: domain.NewTransactionRepositoryMock(nil, nil),
+				repo:        domain.NewTransactionRepositoryMock(nil, nil),
+				accountRepo: accountRepo,
+				eventRepo:   eventRepo,
 			},
 			args: args{
 				accountID:   domain.NewID(uint64(100)),
 				operationID: domain.NewID(0),
-				amount:      100,
+				amount:      domain.NewMoney(10000, domain.CurrencyBRL),
 			},
 			want:    nil,
 			wantErr: domain.NewErrDomain("operation", "'0' is not a valid operation id"),
 		},
+		{
+			name: "domain error when the operation is a transfer posting",
+			fields: fields{
+				repo:        domain.NewTransactionRepositoryMock(nil, nil),
+				accountRepo: accountRepo,
+				eventRepo:   eventRepo,
+			},
+			args: args{
+				accountID:   domain.NewID(uint64(100)),
+				operationID: domain.NewID(5),
+				amount:      domain.NewMoney(10000, domain.CurrencyBRL),
+			},
+			want:    nil,
+			wantErr: domain.NewErrDomain("operation", "'5' must be registered through a transfer"),
+		},
+		{
+			name: "domain error when the operation is a reversal",
+			fields: fields{
+				repo:        domain.NewTransactionRepositoryMock(nil, nil),
+				accountRepo: accountRepo,
+				eventRepo:   eventRepo,
+			},
+			args: args{
+				accountID:   domain.NewID(uint64(100)),
+				operationID: domain.NewID(7),
+				amount:      domain.NewMoney(10000, domain.CurrencyBRL),
+			},
+			want:    nil,
+			wantErr: domain.NewErrDomain("operation", "'7' must be registered through a reversal"),
+		},
+		{
+			name: "domain error when the operation is a charge",
+			fields: fields{
+				repo:        domain.NewTransactionRepositoryMock(nil, nil),
+				accountRepo: accountRepo,
+				eventRepo:   eventRepo,
+			},
+			args: args{
+				accountID:   domain.NewID(uint64(100)),
+				operationID: domain.NewID(8),
+				amount:      domain.NewMoney(10000, domain.CurrencyBRL),
+			},
+			want:    nil,
+			wantErr: domain.NewErrDomain("operation", "'8' is registered only by the interest accrual"),
+		},
+		{
+			name: "domain error when the operation is disabled",
+			fields: fields{
+				repo:        domain.NewTransactionRepositoryMock(nil, nil),
+				accountRepo: accountRepo,
+				eventRepo:   eventRepo,
+			},
+			args: args{
+				accountID:   domain.NewID(uint64(100)),
+				operationID: domain.NewID(200),
+				amount:      domain.NewMoney(10000, domain.CurrencyBRL),
+			},
+			want:    nil,
+			wantErr: domain.NewErrDomain("operation", "'200' is disabled"),
+		},
+		{
+			name: "domain error when the installments are informed to a payment",
+			fields: fields{
+				repo:        domain.NewTransactionRepositoryMock(nil, nil),
+				accountRepo: accountRepo,
+				eventRepo:   eventRepo,
+			},
+			args: args{
+				accountID:    domain.NewID(uint64(100)),
+				operationID:  domain.NewID(4),
+				amount:       domain.NewMoney(10000, domain.CurrencyBRL),
+				installments: 2,
+			},
+			want:    nil,
+			wantErr: domain.NewErrDomain("installments", "are allowed only for the operation 'COMPRA PARCELADA'"),
+		},
 		{
 			name: "repository error",
 			fields: fields{
-				repo: domain.NewTransactionRepositoryMock(nil, errors.New("repository error")),
+				repo:        domain.NewTransactionRepositoryMock(nil, errors.New("repository error")),
+				accountRepo: accountRepo,
+				eventRepo:   eventRepo,
 			},
 			args: args{
 				accountID:   domain.NewID(uint64(100)),
 				operationID: domain.NewID(4),
-				amount:      100,
+				amount:      domain.NewMoney(10000, domain.CurrencyBRL),
 			},
 			want:    nil,
 			wantErr: errors.New("repository error"),
 		},
+		{
+			name: "repository error to store the transaction created event",
+			fields: fields{
+				repo:        domain.NewTransactionRepositoryMock(domain.NewID(uint64(100)), nil),
+				accountRepo: accountRepo,
+				eventRepo:   domain.NewEventRepositoryMock(nil, nil, errors.New("database error")),
+			},
+			args: args{
+				accountID:   domain.NewID(uint64(100)),
+				operationID: domain.NewID(4),
+				amount:      domain.NewMoney(10000, domain.CurrencyBRL),
+			},
+			want:    nil,
+			wantErr: errors.New("database error"),
+		},
 		{
 			name: "transaction created successfully",
 			fields: fields{
-				repo: domain.NewTransactionRepositoryMock(domain.NewID(uint64(100)), nil),
+				repo:        domain.NewTransactionRepositoryMock(domain.NewID(uint64(100)), nil),
+				accountRepo: accountRepo,
+				eventRepo:   eventRepo,
 			},
 			args: args{
 				accountID:   domain.NewID(uint64(100)),
 				operationID: domain.NewID(4),
-				amount:      100,
+				amount:      domain.NewMoney(10000, domain.CurrencyBRL),
 			},
-			want:    transaction.WithID(domain.NewID(uint64(100))),
-			wantErr: errors.New("repository error"),
+			want:       transaction.WithID(domain.NewID(uint64(100))),
+			wantAmount: domain.NewMoney(10000, domain.CurrencyBRL),
+			wantErr:    errors.New("repository error"),
+		},
+		{
+			name: "transaction without currency created in the account currency",
+			fields: fields{
+				repo:        domain.NewTransactionRepositoryMock(domain.NewID(uint64(102)), nil),
+				accountRepo: accountRepo,
+				eventRepo:   eventRepo,
+			},
+			args: args{
+				accountID:   domain.NewID(uint64(100)),
+				operationID: domain.NewID(4),
+				amount:      domain.NewMoney(1000000, ""),
+			},
+			want:       transaction.WithID(domain.NewID(uint64(102))),
+			wantAmount: domain.NewMoney(10000, domain.CurrencyBRL),
+		},
+		{
+			name: "foreign currency purchase converted to the account currency",
+			fields: fields{
+				repo:        domain.NewTransactionRepositoryMock(domain.NewID(uint64(103)), nil),
+				accountRepo: accountRepo,
+				eventRepo:   eventRepo,
+			},
+			args: args{
+				accountID:   domain.NewID(uint64(100)),
+				operationID: domain.NewID(1),
+				amount:      domain.NewMoney(10000, "USD"),
+			},
+			want:       transaction.WithID(domain.NewID(uint64(103))),
+			wantAmount: domain.NewMoney(-52500, domain.CurrencyBRL),
+		},
+		{
+			name: "installment purchase created successfully",
+			fields: fields{
+				repo:        domain.NewTransactionRepositoryMock(domain.NewID(uint64(101)), nil),
+				accountRepo: accountRepo,
+				eventRepo:   eventRepo,
+			},
+			args: args{
+				accountID:    domain.NewID(uint64(100)),
+				operationID:  domain.NewID(2),
+				amount:       domain.NewMoney(10000, domain.CurrencyBRL),
+				installments: 3,
+			},
+			want:    transaction.WithID(domain.NewID(uint64(101))),
+			wantErr: nil,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c := NewCreateTransaction(tt.fields.repo)
+			c := NewCreateTransaction(
+				tt.fields.repo,
+				tt.fields.accountRepo,
+				operationRepo,
+				tt.fields.eventRepo,
+				domain.NewUnitOfWorkMock(nil),
+				rates,
+			)
 
-			got, err := c.Create(tt.args.accountID, tt.args.operationID, tt.args.amount)
+			got, err := c.Create(context.Background(), tt.args.accountID, tt.args.operationID, tt.args.amount, tt.args.installments)
 			if (err != nil) && !reflect.DeepEqual(err, tt.wantErr) {
 				t.Errorf("Create() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -85,6 +335,14 @@ func TestCreateTransaction_Create(t *testing.T) {
 				t.Errorf("Invalid Transaction result: ID it must be greater than zero")
 				return
 			}
+
+			if !tt.wantAmount.IsZero() && got.Amount() != tt.wantAmount {
+				t.Errorf("Invalid Transaction result: got amount %v %v, want %v %v", got.Amount(), got.Amount().Currency(), tt.wantAmount, tt.wantAmount.Currency())
+			}
+
+			if tt.args.installments > 0 && got.InstallmentPlan().Count() != tt.args.installments {
+				t.Errorf("Invalid Transaction result: got %d installments, want %d", got.InstallmentPlan().Count(), tt.args.installments)
+			}
 		})
 	}
 }
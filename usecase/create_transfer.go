@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// CreateTransfer contains all the dependencies to create a transfer
+type CreateTransfer struct {
+	repo domain.TransferRepositoryWriter
+}
+
+// NewCreateTransfer creates a new CreateTransfer with its dependencies
+func NewCreateTransfer(repo domain.TransferRepositoryWriter) *CreateTransfer {
+	return &CreateTransfer{repo: repo}
+}
+
+// Create creates a transfer between two accounts, amounts without currency are in the source account currency
+func (c CreateTransfer) Create(ctx context.Context, sourceID, destinationID *domain.ID, amount domain.Money) (*domain.Transfer, error) {
+	transfer, err := domain.NewTransfer(sourceID, destinationID, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := transfer.Store(ctx, c.repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
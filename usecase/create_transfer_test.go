@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+func TestCreateTransfer_Create(t *testing.T) {
+	type fields struct {
+		repo domain.TransferRepositoryWriter
+	}
+	type args struct {
+		sourceID      *domain.ID
+		destinationID *domain.ID
+		amount        domain.Money
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantID  uint64
+		wantErr error
+	}{
+		{
+			name:   "domain error when the accounts are the same",
+			fields: fields{repo: domain.NewTransferRepositoryMock(nil, nil)},
+			args: args{
+				sourceID:      domain.NewID(1),
+				destinationID: domain.NewID(1),
+				amount:        domain.NewMoney(1000000, ""),
+			},
+			wantErr: domain.NewErrDomain("destination_account_id", "must be different from the source account"),
+		},
+		{
+			name:   "domain error when the amount is not positive",
+			fields: fields{repo: domain.NewTransferRepositoryMock(nil, nil)},
+			args: args{
+				sourceID:      domain.NewID(1),
+				destinationID: domain.NewID(2),
+				amount:        domain.NewMoney(-10000, ""),
+			},
+			wantErr: domain.NewErrDomain("amount", "must be greater than 0"),
+		},
+		{
+			name:   "repository error",
+			fields: fields{repo: domain.NewTransferRepositoryMock(nil, errors.New("repository error"))},
+			args: args{
+				sourceID:      domain.NewID(1),
+				destinationID: domain.NewID(2),
+				amount:        domain.NewMoney(1000000, ""),
+			},
+			wantErr: errors.New("repository error"),
+		},
+		{
+			name:   "transfer created successfully",
+			fields: fields{repo: domain.NewTransferRepositoryMock(domain.NewID(10), nil)},
+			args: args{
+				sourceID:      domain.NewID(1),
+				destinationID: domain.NewID(2),
+				amount:        domain.NewMoney(1000000, ""),
+			},
+			wantID: 10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCreateTransfer(tt.fields.repo)
+
+			got, err := c.Create(context.Background(), tt.args.sourceID, tt.args.destinationID, tt.args.amount)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.ID().Value() != tt.wantID {
+				t.Errorf("Create() ID = %v, want %v", got.ID().Value(), tt.wantID)
+			}
+		})
+	}
+}
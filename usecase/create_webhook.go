@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// CreateWebhook contains all the dependencies to create a webhook
+type CreateWebhook struct {
+	repo domain.WebhookRepositoryWriter
+}
+
+// NewCreateWebhook creates a new CreateWebhook with its dependencies
+func NewCreateWebhook(repo domain.WebhookRepositoryWriter) *CreateWebhook {
+	return &CreateWebhook{repo: repo}
+}
+
+// Create creates a webhook delivering the events of the informed types to the url
+func (c CreateWebhook) Create(ctx context.Context, url string, eventTypes []string) (*domain.Webhook, error) {
+	webhook, err := domain.NewWebhook(url, eventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	return webhook.Store(ctx, c.repo)
+}
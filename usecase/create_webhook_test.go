@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+func TestCreateWebhook_Create(t *testing.T) {
+	type args struct {
+		url        string
+		eventTypes []string
+	}
+	tests := []struct {
+		name    string
+		repo    *domain.WebhookRepositoryMock
+		args    args
+		wantErr error
+	}{
+		{
+			name:    "domain error when the event type is invalid",
+			repo:    domain.NewWebhookRepositoryMock(domain.NewID(1), nil, nil),
+			args:    args{url: "https://partner.com/events", eventTypes: []string{"account.deleted"}},
+			wantErr: domain.NewErrDomain("event_types", "'account.deleted' is not a valid event type"),
+		},
+		{
+			name:    "repository error",
+			repo:    domain.NewWebhookRepositoryMock(nil, nil, errors.New("database error")),
+			args:    args{url: "https://partner.com/events", eventTypes: []string{"account.created"}},
+			wantErr: errors.New("database error"),
+		},
+		{
+			name: "webhook created successfully",
+			repo: domain.NewWebhookRepositoryMock(domain.NewID(1), nil, nil),
+			args: args{url: "https://partner.com/events", eventTypes: []string{"account.created"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewCreateWebhook(tt.repo).Create(context.Background(), tt.args.url, tt.args.eventTypes)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.ID().Value() != 1 || got.URL() != tt.args.url || got.Secret() == "" {
+				t.Errorf("Create() got = %v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// DeliverWebhooks contains all the dependencies to send the deliveries due to the webhooks
+type DeliverWebhooks struct {
+	reader    domain.WebhookDeliveryRepositoryReader
+	writer    domain.WebhookDeliveryRepositoryWriter
+	sender    domain.WebhookSender
+	policy    *domain.WebhookRetryPolicy
+	batchSize int
+	now       func() time.Time
+}
+
+// NewDeliverWebhooks creates a new DeliverWebhooks with its dependencies, batchSize is the maximum of deliveries sent
+// per run
+func NewDeliverWebhooks(
+	reader domain.WebhookDeliveryRepositoryReader,
+	writer domain.WebhookDeliveryRepositoryWriter,
+	sender domain.WebhookSender,
+	policy *domain.WebhookRetryPolicy,
+	batchSize int,
+	now func() time.Time,
+) *DeliverWebhooks {
+	return &DeliverWebhooks{
+		reader:    reader,
+		writer:    writer,
+		sender:    sender,
+		policy:    policy,
+		batchSize: batchSize,
+		now:       now,
+	}
+}
+
+// Deliver sends the deliveries due, returning how many were acknowledged. A delivery not acknowledged is retried later
+// according to the retry policy, or moved to the dead letters when it has no attempts left; only the errors of the
+// repository are returned.
+func (d DeliverWebhooks) Deliver(ctx context.Context) (int, error) {
+	deliveries, err := d.reader.FindDue(ctx, d.now(), d.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var delivered int
+
+	for _, delivery := range deliveries {
+		if err := d.sender.Send(ctx, delivery); err != nil {
+			delivery = delivery.Fail(err.Error(), d.now(), d.policy)
+
+			if delivery.IsFailed() {
+				err = d.writer.MoveToDeadLetter(ctx, delivery)
+			} else {
+				err = d.writer.Update(ctx, delivery)
+			}
+
+			if err != nil {
+				return delivered, err
+			}
+
+			continue
+		}
+
+		if err := d.writer.Update(ctx, delivery.Succeed(d.now())); err != nil {
+			return delivered, err
+		}
+
+		delivered++
+	}
+
+	return delivered, nil
+}
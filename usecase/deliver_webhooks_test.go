@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+func TestDeliverWebhooks_Deliver(t *testing.T) {
+	var (
+		now       = time.Date(2020, 10, 25, 10, 0, 0, 0, time.UTC)
+		policy, _ = domain.NewWebhookRetryPolicy(3, time.Minute)
+		event     = domain.LoadEvent(domain.NewID(1), domain.EventAccountCreated, domain.NewID(1), []byte(`{"id":1}`), now)
+		types     = []domain.EventType{domain.EventAccountCreated}
+		partnerA  = domain.LoadWebhook(domain.NewID(1), "https://a.com", types, "s", now)
+		partnerB  = domain.LoadWebhook(domain.NewID(2), "https://b.com", types, "s", now)
+		first     = domain.LoadWebhookDelivery(domain.NewID(1), partnerA, event, domain.WebhookDeliveryPending, 0, now, time.Time{}, "")
+		last      = domain.LoadWebhookDelivery(domain.NewID(2), partnerB, event, domain.WebhookDeliveryPending, 2, now, now, "timeout")
+	)
+
+	type fields struct {
+		repo   *domain.WebhookDeliveryRepositoryMock
+		sender *domain.WebhookSenderMock
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		want        int
+		wantUpdated []*domain.WebhookDelivery
+		wantDead    []*domain.WebhookDelivery
+		wantErr     error
+	}{
+		// fails
+		{
+			name: "repository error to find the deliveries due",
+			fields: fields{
+				repo:   domain.NewWebhookDeliveryRepositoryMock(nil, nil, errors.New("database error")),
+				sender: domain.NewWebhookSenderMock(nil),
+			},
+			wantErr: errors.New("database error"),
+		},
+		{
+			name: "repository error to update the delivery",
+			fields: fields{
+				repo: domain.NewWebhookDeliveryRepositoryMock(nil, []*domain.WebhookDelivery{first}, nil).
+					WithWriteErr(errors.New("database error")),
+				sender: domain.NewWebhookSenderMock(nil),
+			},
+			wantErr: errors.New("database error"),
+		},
+
+		// successes
+		{
+			name: "all the deliveries acknowledged",
+			fields: fields{
+				repo:   domain.NewWebhookDeliveryRepositoryMock(nil, []*domain.WebhookDelivery{first, last}, nil),
+				sender: domain.NewWebhookSenderMock(nil),
+			},
+			want:        2,
+			wantUpdated: []*domain.WebhookDelivery{first.Succeed(now), last.Succeed(now)},
+		},
+		{
+			name: "failed deliveries retried later or moved to the dead letters",
+			fields: fields{
+				repo: domain.NewWebhookDeliveryRepositoryMock(nil, []*domain.WebhookDelivery{first, last}, nil),
+				sender: domain.NewWebhookSenderMock(map[uint64]error{
+					1: errors.New("status 500"),
+					2: errors.New("status 503"),
+				}),
+			},
+			want:        0,
+			wantUpdated: []*domain.WebhookDelivery{first.Fail("status 500", now, policy)},
+			wantDead:    []*domain.WebhookDelivery{last.Fail("status 503", now, policy)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDeliverWebhooks(tt.fields.repo, tt.fields.repo, tt.fields.sender, policy, 100, func() time.Time { return now })
+
+			got, err := d.Deliver(context.Background())
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Deliver() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("Deliver() got = %v, want %v", got, tt.want)
+			}
+
+			if updated := tt.fields.repo.Written("Update"); !reflect.DeepEqual(updated, tt.wantUpdated) {
+				t.Errorf("Deliver() updated = %v, want %v", updated, tt.wantUpdated)
+			}
+
+			if dead := tt.fields.repo.Written("MoveToDeadLetter"); !reflect.DeepEqual(dead, tt.wantDead) {
+				t.Errorf("Deliver() dead letters = %v, want %v", dead, tt.wantDead)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// ExportStatement contains all the dependencies to export the statement of an account
+type ExportStatement struct {
+	accountRepo   domain.AccountRepositoryReader
+	statementRepo domain.StatementRepositoryReader
+}
+
+// NewExportStatement creates a new ExportStatement with its dependencies
+func NewExportStatement(
+	accountRepo domain.AccountRepositoryReader,
+	statementRepo domain.StatementRepositoryReader,
+) *ExportStatement {
+	return &ExportStatement{accountRepo: accountRepo, statementRepo: statementRepo}
+}
+
+// Export writes the statement of an account in the informed period. Errors returned before the writer begins mean
+// nothing was written.
+func (e ExportStatement) Export(ctx context.Context, accountID *domain.ID, from, to time.Time, w domain.StatementWriter) error {
+	account, err := e.accountRepo.FindOneByID(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	statement, err := domain.NewStatement(account, from, to)
+	if err != nil {
+		return err
+	}
+
+	statement, err = statement.Open(ctx, e.accountRepo)
+	if err != nil {
+		return err
+	}
+
+	return statement.Write(ctx, e.statementRepo, w)
+}
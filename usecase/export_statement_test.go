@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+func TestExportStatement_Export(t *testing.T) {
+	var (
+		account     = new(domain.Account).WithID(domain.NewID(1)).WithCurrency(domain.CurrencyBRL)
+		from        = time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+		to          = time.Date(2020, 10, 31, 23, 59, 59, 0, time.UTC)
+		purchase, _ = domain.NewTransaction(domain.NewID(1), domain.OperationCompraAVista, domain.NewMoney(5000, domain.CurrencyBRL))
+	)
+
+	type fields struct {
+		accountRepo   *domain.AccountRepositoryMock
+		statementRepo *domain.StatementRepositoryMock
+	}
+	type args struct {
+		from time.Time
+		to   time.Time
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		args        args
+		wantLines   int
+		wantClosing domain.Money
+		wantErr     error
+	}{
+		{
+			name: "repository error when the account is not found",
+			fields: fields{
+				accountRepo:   domain.NewAccountRepositoryMock(nil, nil, errors.New("account not found")),
+				statementRepo: domain.NewStatementRepositoryMock(nil, nil),
+			},
+			args:    args{from: from, to: to},
+			wantErr: errors.New("account not found"),
+		},
+		{
+			name: "domain error when the period is invalid",
+			fields: fields{
+				accountRepo:   domain.NewAccountRepositoryMock(nil, account, nil),
+				statementRepo: domain.NewStatementRepositoryMock(nil, nil),
+			},
+			args:    args{from: to, to: from},
+			wantErr: domain.NewErrDomain("from", "must be before to"),
+		},
+		{
+			name: "repository error when the transactions cannot be read",
+			fields: fields{
+				accountRepo:   domain.NewAccountRepositoryMock(nil, account, nil),
+				statementRepo: domain.NewStatementRepositoryMock(nil, errors.New("database error")),
+			},
+			args:    args{from: from, to: to},
+			wantErr: errors.New("database error"),
+		},
+		{
+			name: "statement exported successfully",
+			fields: fields{
+				accountRepo:   domain.NewAccountRepositoryMock(nil, account, nil).WithBalance(domain.NewMoney(10000, domain.CurrencyBRL)),
+				statementRepo: domain.NewStatementRepositoryMock([]*domain.Transaction{purchase}, nil),
+			},
+			args:        args{from: from, to: to},
+			wantLines:   1,
+			wantClosing: domain.NewMoney(5000, domain.CurrencyBRL),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &fakeStatementWriter{}
+
+			err := NewExportStatement(tt.fields.accountRepo, tt.fields.statementRepo).Export(context.Background(), domain.NewID(1), tt.args.from, tt.args.to, w)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Export() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if w.lines != tt.wantLines {
+				t.Errorf("Export() lines = %v, want %v", w.lines, tt.wantLines)
+			}
+
+			if w.closing != tt.wantClosing {
+				t.Errorf("Export() closing balance = %v, want %v", w.closing, tt.wantClosing)
+			}
+		})
+	}
+}
+
+type fakeStatementWriter struct {
+	lines   int
+	closing domain.Money
+}
+
+func (f *fakeStatementWriter) Begin(*domain.Statement) error {
+	return nil
+}
+
+func (f *fakeStatementWriter) Line(*domain.StatementLine) error {
+	f.lines++
+
+	return nil
+}
+
+func (f *fakeStatementWriter) End(s *domain.Statement) error {
+	f.closing = s.ClosingBalance()
+
+	return nil
+}
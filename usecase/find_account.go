@@ -1,6 +1,8 @@
 package usecase
 
 import (
+	"context"
+
 	"github.com/tonytcb/bank-transactions-go/domain"
 )
 
@@ -15,8 +17,8 @@ func NewFindAccount(repo domain.AccountRepositoryReader) *FindAccount {
 }
 
 // Find finds an account by its id
-func (f FindAccount) Find(id *domain.ID) (*domain.Account, error) {
-	account, err := f.repo.FindOneByID(id)
+func (f FindAccount) Find(ctx context.Context, id *domain.ID) (*domain.Account, error) {
+	account, err := f.repo.FindOneByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// FindAccountBalance contains all the dependencies to find the balance of an account
+type FindAccountBalance struct {
+	repo domain.AccountRepositoryReader
+}
+
+// NewFindAccountBalance creates a new FindAccountBalance with its dependencies
+func NewFindAccountBalance(repo domain.AccountRepositoryReader) *FindAccountBalance {
+	return &FindAccountBalance{repo: repo}
+}
+
+// Find finds the balance of an account at the informed moment
+func (f FindAccountBalance) Find(ctx context.Context, id *domain.ID, at time.Time) (*domain.Balance, error) {
+	account, err := f.repo.FindOneByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := account.Balance(ctx, f.repo, at)
+	if err != nil {
+		return nil, err
+	}
+
+	return balance, nil
+}
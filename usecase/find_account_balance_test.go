@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+func TestFindAccountBalance_Find(t *testing.T) {
+	accountOK, _ := domain.NewAccount("00000000191")
+	accountOK = accountOK.WithID(domain.NewID(uint64(100))).WithCreateAt(time.Now())
+
+	at := time.Date(2020, 10, 4, 13, 0, 0, 0, time.UTC)
+
+	type fields struct {
+		repo domain.AccountRepositoryReader
+	}
+	type args struct {
+		id *domain.ID
+		at time.Time
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    domain.Money
+		wantErr error
+	}{
+		{
+			name: "account not found error",
+			fields: fields{
+				repo: domain.NewAccountRepositoryMock(nil, nil, repository.NewErrRegisterNotFound("account", "100")),
+			},
+			args: args{
+				id: domain.NewID(uint64(100)),
+				at: at,
+			},
+			wantErr: repository.NewErrRegisterNotFound("account", "100"),
+		},
+		{
+			name: "unknown repository error",
+			fields: fields{
+				repo: domain.NewAccountRepositoryMock(nil, nil, errors.New("some repository error")),
+			},
+			args: args{
+				id: domain.NewID(uint64(100)),
+				at: at,
+			},
+			wantErr: errors.New("some repository error"),
+		},
+		{
+			name: "balance found successfully",
+			fields: fields{
+				repo: domain.NewAccountRepositoryMock(nil, accountOK, nil).WithBalance(domain.NewMoney(-5025, domain.CurrencyBRL)),
+			},
+			args: args{
+				id: domain.NewID(uint64(100)),
+				at: at,
+			},
+			want:    domain.NewMoney(-5025, domain.CurrencyBRL),
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewFindAccountBalance(tt.fields.repo)
+
+			got, err := f.Find(context.Background(), tt.args.id, tt.args.at)
+			if (err != nil) && !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Find() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.Amount() != tt.want {
+				t.Errorf("Find() got = %v, want %v", got.Amount(), tt.want)
+			}
+
+			if !got.At().Equal(tt.args.at) {
+				t.Errorf("Find() At got = %v, want %v", got.At(), tt.args.at)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"reflect"
 	"testing"
@@ -67,7 +68,7 @@ func TestFindAccount_Find(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			f := NewFindAccount(tt.fields.repo)
 
-			got, err := f.Find(tt.args.id)
+			got, err := f.Find(context.Background(), tt.args.id)
 			if (err != nil) && !reflect.DeepEqual(err, tt.wantErr) {
 				t.Errorf("Find() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// FindInvoice contains all the dependencies to find an invoice
+type FindInvoice struct {
+	repo domain.InvoiceRepositoryReader
+}
+
+// NewFindInvoice creates a new FindInvoice with its dependencies
+func NewFindInvoice(repo domain.InvoiceRepositoryReader) *FindInvoice {
+	return &FindInvoice{repo: repo}
+}
+
+// Find finds an invoice, with its items, by its id
+func (f FindInvoice) Find(ctx context.Context, id *domain.ID) (*domain.Invoice, error) {
+	return f.repo.FindOneByID(ctx, id)
+}
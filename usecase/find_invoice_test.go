@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+func TestFindInvoice_Find(t *testing.T) {
+	var (
+		account = new(domain.Account).WithID(domain.NewID(1)).WithCurrency(domain.CurrencyBRL)
+		invoice = domain.NewInvoice(account, nil, time.Date(2020, 10, 25, 23, 59, 59, 0, time.UTC))
+	)
+
+	tests := []struct {
+		name    string
+		repo    *domain.InvoiceRepositoryMock
+		want    *domain.Invoice
+		wantErr error
+	}{
+		{
+			name:    "invoice not found error",
+			repo:    domain.NewInvoiceRepositoryMock(nil, nil, repository.NewErrRegisterNotFound("id", "10")),
+			wantErr: repository.NewErrRegisterNotFound("id", "10"),
+		},
+		{
+			name:    "unknown repository error",
+			repo:    domain.NewInvoiceRepositoryMock(nil, nil, errors.New("some repository error")),
+			wantErr: errors.New("some repository error"),
+		},
+		{
+			name: "invoice found successfully",
+			repo: domain.NewInvoiceRepositoryMock(nil, []*domain.Invoice{invoice}, nil),
+			want: invoice,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewFindInvoice(tt.repo).Find(context.Background(), domain.NewID(10))
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Find() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Find() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
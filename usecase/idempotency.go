@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// idempotencyProcessingMargin is added to the request timeout to find out when a request in processing was abandoned,
+// covering the time its handler takes to return after the deadline
+const idempotencyProcessingMargin = 30 * time.Second
+
+// Idempotency contains all the dependencies to process requests identified by idempotency keys
+type Idempotency struct {
+	repo              domain.IdempotencyRepository
+	now               func() time.Time
+	processingTimeout time.Duration
+	ttl               time.Duration
+}
+
+// NewIdempotency creates a new Idempotency with its dependencies. A request in processing is considered abandoned
+// after the request timeout, plus a margin, so a retry never runs along with it. The keys expire after the ttl, which
+// is never shorter than the processing of their requests.
+func NewIdempotency(repo domain.IdempotencyRepository, now func() time.Time, requestTimeout, ttl time.Duration) *Idempotency {
+	processingTimeout := requestTimeout + idempotencyProcessingMargin
+
+	if ttl < processingTimeout {
+		ttl = processingTimeout
+	}
+
+	return &Idempotency{repo: repo, now: now, processingTimeout: processingTimeout, ttl: ttl}
+}
+
+// Start registers the key of a new request as processing. When the key was already used by an identical request
+// already processed, the stored key is returned with replay true, so its response can be sent again. When that
+// request was abandoned, still in processing after the processing timeout, the key is taken over by the new one.
+func (i Idempotency) Start(ctx context.Context, key, fingerprint string) (*domain.IdempotencyKey, bool, error) {
+	var now = i.now()
+
+	idempotencyKey, err := domain.NewIdempotencyKey(key, fingerprint)
+	if err != nil {
+		return nil, false, err
+	}
+
+	idempotencyKey = idempotencyKey.WithCreatedAt(now)
+
+	stored, err := i.repo.Store(ctx, idempotencyKey)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if stored {
+		return idempotencyKey, false, nil
+	}
+
+	existing, err := i.repo.FindByKey(ctx, key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if existing.Fingerprint() == fingerprint && existing.IsAbandoned(now, i.processingTimeout) {
+		takenOver, err := i.repo.TakeOver(ctx, idempotencyKey, now.Add(-i.processingTimeout))
+		if err != nil {
+			return nil, false, err
+		}
+
+		if takenOver {
+			return idempotencyKey, false, nil
+		}
+	}
+
+	if err := existing.Check(fingerprint); err != nil {
+		return nil, false, err
+	}
+
+	return existing, true, nil
+}
+
+// Finish stores the response of the request identified by the key
+func (i Idempotency) Finish(ctx context.Context, key *domain.IdempotencyKey, status int, body []byte) error {
+	return i.repo.Update(ctx, key.Complete(status, body))
+}
+
+// DeleteExpired deletes the keys created before the ttl, returning how many were deleted. A new request with a deleted
+// key is processed as a new one.
+func (i Idempotency) DeleteExpired(ctx context.Context) (int64, error) {
+	return i.repo.DeleteCreatedBefore(ctx, i.now().Add(-i.ttl))
+}
+
+// Release removes the key, allowing a new request with the same key to be processed
+func (i Idempotency) Release(ctx context.Context, key *domain.IdempotencyKey) error {
+	return i.repo.Delete(ctx, key)
+}
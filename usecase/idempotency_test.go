@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+func TestIdempotency_Start(t *testing.T) {
+	var (
+		now        = time.Date(2020, 10, 25, 10, 0, 0, 0, time.UTC)
+		timeout    = 2 * time.Minute
+		key, _     = domain.NewIdempotencyKey("key-1", "fingerprint-1")
+		processing = key.WithCreatedAt(now.Add(-timeout - idempotencyProcessingMargin))
+		abandoned  = key.WithCreatedAt(now.Add(-timeout - idempotencyProcessingMargin - time.Second))
+		completed  = abandoned.Complete(201, []byte(`{"id":1}`))
+	)
+
+	type fields struct {
+		repo domain.IdempotencyRepository
+	}
+	type args struct {
+		key         string
+		fingerprint string
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		args       args
+		wantReplay bool
+		wantStatus int
+		wantErr    error
+	}{
+		// fails
+		{
+			name: "invalid key",
+			fields: fields{
+				repo: domain.NewIdempotencyRepositoryMock(nil, nil),
+			},
+			args: args{
+				key:         "",
+				fingerprint: "fingerprint-1",
+			},
+			wantErr: domain.NewErrDomain("Idempotency-Key", "must have between 1 and 255 characters"),
+		},
+		{
+			name: "repository error",
+			fields: fields{
+				repo: domain.NewIdempotencyRepositoryMock(nil, errors.New("repository error")),
+			},
+			args: args{
+				key:         "key-1",
+				fingerprint: "fingerprint-1",
+			},
+			wantErr: errors.New("repository error"),
+		},
+		{
+			name: "key used by a different request",
+			fields: fields{
+				repo: domain.NewIdempotencyRepositoryMock(completed, nil),
+			},
+			args: args{
+				key:         "key-1",
+				fingerprint: "fingerprint-2",
+			},
+			wantErr: domain.NewErrDomain("Idempotency-Key", "'key-1' was already used with a different request"),
+		},
+		{
+			name: "key used by a request in progress longer than a minute, within the request timeout",
+			fields: fields{
+				repo: domain.NewIdempotencyRepositoryMock(key.WithCreatedAt(now.Add(-timeout)), nil),
+			},
+			args: args{
+				key:         "key-1",
+				fingerprint: "fingerprint-1",
+			},
+			wantErr: domain.NewErrIdempotencyKeyInUse("key-1"),
+		},
+		{
+			name: "key used by a request in progress",
+			fields: fields{
+				repo: domain.NewIdempotencyRepositoryMock(processing, nil),
+			},
+			args: args{
+				key:         "key-1",
+				fingerprint: "fingerprint-1",
+			},
+			wantErr: domain.NewErrIdempotencyKeyInUse("key-1"),
+		},
+		{
+			name: "key abandoned by a different request",
+			fields: fields{
+				repo: domain.NewIdempotencyRepositoryMock(abandoned, nil),
+			},
+			args: args{
+				key:         "key-1",
+				fingerprint: "fingerprint-2",
+			},
+			wantErr: domain.NewErrDomain("Idempotency-Key", "'key-1' was already used with a different request"),
+		},
+
+		// successes
+		{
+			name: "replay a request already processed",
+			fields: fields{
+				repo: domain.NewIdempotencyRepositoryMock(completed, nil),
+			},
+			args: args{
+				key:         "key-1",
+				fingerprint: "fingerprint-1",
+			},
+			wantReplay: true,
+			wantStatus: 201,
+		},
+		{
+			name: "new request",
+			fields: fields{
+				repo: domain.NewIdempotencyRepositoryMock(nil, nil),
+			},
+			args: args{
+				key:         "key-2",
+				fingerprint: "fingerprint-1",
+			},
+			wantReplay: false,
+		},
+		{
+			name: "key abandoned by a request taken over",
+			fields: fields{
+				repo: domain.NewIdempotencyRepositoryMock(abandoned, nil),
+			},
+			args: args{
+				key:         "key-1",
+				fingerprint: "fingerprint-1",
+			},
+			wantReplay: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := NewIdempotency(tt.fields.repo, func() time.Time { return now }, timeout, 24*time.Hour)
+
+			got, replay, err := i.Start(context.Background(), tt.args.key, tt.args.fingerprint)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Start() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if replay != tt.wantReplay {
+				t.Errorf("Start() replay = %v, want %v", replay, tt.wantReplay)
+			}
+
+			if got.Key() != tt.args.key {
+				t.Errorf("Start() key = %v, want %v", got.Key(), tt.args.key)
+			}
+
+			if !replay && !got.CreatedAt().Equal(now) {
+				t.Errorf("Start() created at = %v, want %v", got.CreatedAt(), now)
+			}
+
+			if got.ResponseStatus() != tt.wantStatus {
+				t.Errorf("Start() response status = %v, want %v", got.ResponseStatus(), tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestIdempotency_DeleteExpired(t *testing.T) {
+	var (
+		now    = time.Date(2020, 10, 25, 10, 0, 0, 0, time.UTC)
+		key, _ = domain.NewIdempotencyKey("key-1", "fingerprint-1")
+	)
+
+	tests := []struct {
+		name        string
+		repo        domain.IdempotencyRepository
+		ttl         time.Duration
+		wantDeleted int64
+		wantErr     error
+	}{
+		// fails
+		{
+			name:    "repository error",
+			repo:    domain.NewIdempotencyRepositoryMock(nil, errors.New("repository error")),
+			ttl:     time.Hour,
+			wantErr: errors.New("repository error"),
+		},
+
+		// successes
+		{
+			name:        "key expired",
+			repo:        domain.NewIdempotencyRepositoryMock(key.WithCreatedAt(now.Add(-time.Hour-time.Second)).Complete(201, nil), nil),
+			ttl:         time.Hour,
+			wantDeleted: 1,
+		},
+		{
+			name:        "key within the ttl",
+			repo:        domain.NewIdempotencyRepositoryMock(key.WithCreatedAt(now.Add(-time.Hour)).Complete(201, nil), nil),
+			ttl:         time.Hour,
+			wantDeleted: 0,
+		},
+		{
+			name:        "ttl shorter than the processing of the requests",
+			repo:        domain.NewIdempotencyRepositoryMock(key.WithCreatedAt(now.Add(-time.Minute)), nil),
+			ttl:         time.Second,
+			wantDeleted: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := NewIdempotency(tt.repo, func() time.Time { return now }, time.Minute, tt.ttl)
+
+			deleted, err := i.DeleteExpired(context.Background())
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("DeleteExpired() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if deleted != tt.wantDeleted {
+				t.Errorf("DeleteExpired() = %v, want %v", deleted, tt.wantDeleted)
+			}
+		})
+	}
+}
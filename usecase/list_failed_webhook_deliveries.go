@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// ListFailedWebhookDeliveries contains all the dependencies to list the deliveries moved to the dead letters
+type ListFailedWebhookDeliveries struct {
+	repo domain.WebhookDeliveryRepositoryReader
+}
+
+// NewListFailedWebhookDeliveries creates a new ListFailedWebhookDeliveries with its dependencies
+func NewListFailedWebhookDeliveries(repo domain.WebhookDeliveryRepositoryReader) *ListFailedWebhookDeliveries {
+	return &ListFailedWebhookDeliveries{repo: repo}
+}
+
+// List lists the deliveries whose attempts all failed
+func (l ListFailedWebhookDeliveries) List(ctx context.Context) ([]*domain.WebhookDelivery, error) {
+	return l.repo.FindDeadLetters(ctx)
+}
@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+func TestListFailedWebhookDeliveries_List(t *testing.T) {
+	var (
+		now        = time.Date(2020, 10, 25, 10, 0, 0, 0, time.UTC)
+		webhook    = domain.LoadWebhook(domain.NewID(1), "https://a.com", []domain.EventType{domain.EventAccountCreated}, "s", now)
+		event      = domain.LoadEvent(domain.NewID(1), domain.EventAccountCreated, domain.NewID(1), []byte(`{"id":1}`), now)
+		deliveries = []*domain.WebhookDelivery{
+			domain.LoadWebhookDelivery(domain.NewID(1), webhook, event, domain.WebhookDeliveryFailed, 8, now, now, "status 500"),
+		}
+	)
+
+	tests := []struct {
+		name    string
+		repo    *domain.WebhookDeliveryRepositoryMock
+		want    []*domain.WebhookDelivery
+		wantErr error
+	}{
+		{
+			name:    "repository error",
+			repo:    domain.NewWebhookDeliveryRepositoryMock(nil, nil, errors.New("database error")),
+			wantErr: errors.New("database error"),
+		},
+		{
+			name: "failed deliveries listed successfully",
+			repo: domain.NewWebhookDeliveryRepositoryMock(nil, deliveries, nil),
+			want: deliveries,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewListFailedWebhookDeliveries(tt.repo).List(context.Background())
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("List() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("List() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// ListInvoices contains all the dependencies to list the invoices of an account
+type ListInvoices struct {
+	accountRepo domain.AccountRepositoryReader
+	invoiceRepo domain.InvoiceRepositoryReader
+}
+
+// NewListInvoices creates a new ListInvoices with its dependencies
+func NewListInvoices(accountRepo domain.AccountRepositoryReader, invoiceRepo domain.InvoiceRepositoryReader) *ListInvoices {
+	return &ListInvoices{accountRepo: accountRepo, invoiceRepo: invoiceRepo}
+}
+
+// List lists the invoices of an account, from the newest to the oldest
+func (l ListInvoices) List(ctx context.Context, accountID *domain.ID) ([]*domain.Invoice, error) {
+	if _, err := l.accountRepo.FindOneByID(ctx, accountID); err != nil {
+		return nil, err
+	}
+
+	return l.invoiceRepo.FindByAccount(ctx, accountID)
+}
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+func TestListInvoices_List(t *testing.T) {
+	var (
+		account  = new(domain.Account).WithID(domain.NewID(1)).WithCurrency(domain.CurrencyBRL)
+		closing  = time.Date(2020, 10, 25, 23, 59, 59, 0, time.UTC)
+		invoices = []*domain.Invoice{domain.NewInvoice(account, nil, closing)}
+	)
+
+	type fields struct {
+		accountRepo *domain.AccountRepositoryMock
+		invoiceRepo *domain.InvoiceRepositoryMock
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    []*domain.Invoice
+		wantErr error
+	}{
+		{
+			name: "account not found error",
+			fields: fields{
+				accountRepo: domain.NewAccountRepositoryMock(nil, nil, repository.NewErrRegisterNotFound("id", "1")),
+				invoiceRepo: domain.NewInvoiceRepositoryMock(nil, invoices, nil),
+			},
+			wantErr: repository.NewErrRegisterNotFound("id", "1"),
+		},
+		{
+			name: "unknown repository error",
+			fields: fields{
+				accountRepo: domain.NewAccountRepositoryMock(nil, account, nil),
+				invoiceRepo: domain.NewInvoiceRepositoryMock(nil, nil, errors.New("some repository error")),
+			},
+			wantErr: errors.New("some repository error"),
+		},
+		{
+			name: "invoices listed successfully",
+			fields: fields{
+				accountRepo: domain.NewAccountRepositoryMock(nil, account, nil),
+				invoiceRepo: domain.NewInvoiceRepositoryMock(nil, invoices, nil),
+			},
+			want: invoices,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewListInvoices(tt.fields.accountRepo, tt.fields.invoiceRepo).List(context.Background(), domain.NewID(1))
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("List() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("List() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// ListOperations contains all the dependencies to list the operations
+type ListOperations struct {
+	repo domain.OperationRepositoryReader
+}
+
+// NewListOperations creates a new ListOperations with its dependencies
+func NewListOperations(repo domain.OperationRepositoryReader) *ListOperations {
+	return &ListOperations{repo: repo}
+}
+
+// List lists all the operations, enabled or not
+func (l ListOperations) List(ctx context.Context) ([]*domain.Operation, error) {
+	operations, err := l.repo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return operations, nil
+}
@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+func TestListOperations_List(t *testing.T) {
+	operations := []*domain.Operation{domain.OperationCompraAVista, domain.OperationPagamento}
+
+	tests := []struct {
+		name    string
+		repo    *domain.OperationRepositoryMock
+		want    []*domain.Operation
+		wantErr error
+	}{
+		{
+			name:    "repository error",
+			repo:    domain.NewOperationRepositoryMock(nil, nil, errors.New("database error")),
+			wantErr: errors.New("database error"),
+		},
+		{
+			name: "operations listed successfully",
+			repo: domain.NewOperationRepositoryMock(nil, operations, nil),
+			want: operations,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewListOperations(tt.repo).List(context.Background())
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("List() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("List() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// ListTransactions contains all the dependencies to list the transactions of an account
+type ListTransactions struct {
+	accountRepo     domain.AccountRepositoryReader
+	operationRepo   domain.OperationRepositoryReader
+	transactionRepo domain.TransactionRepositoryReader
+}
+
+// NewListTransactions creates a new ListTransactions with its dependencies
+func NewListTransactions(
+	accountRepo domain.AccountRepositoryReader,
+	operationRepo domain.OperationRepositoryReader,
+	transactionRepo domain.TransactionRepositoryReader,
+) *ListTransactions {
+	return &ListTransactions{accountRepo: accountRepo, operationRepo: operationRepo, transactionRepo: transactionRepo}
+}
+
+// List lists a page of transactions of an account matching the filter, whose operations must exist
+func (l ListTransactions) List(ctx context.Context, filter *domain.TransactionFilter) (*domain.TransactionPage, error) {
+	if _, err := l.accountRepo.FindOneByID(ctx, filter.AccountID()); err != nil {
+		return nil, err
+	}
+
+	if len(filter.Operations()) > 0 {
+		operations, err := domain.FindOperations(ctx, l.operationRepo)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, id := range filter.Operations() {
+			if _, err := operations.Find(id); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	page, err := l.transactionRepo.FindByFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
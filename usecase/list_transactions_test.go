@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/infra/repository"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+func TestListTransactions_List(t *testing.T) {
+	accountOK, _ := domain.NewAccount("00000000191")
+	accountOK = accountOK.WithID(domain.NewID(uint64(100))).WithCreateAt(time.Now())
+
+	filter, _ := domain.NewTransactionFilter(domain.NewID(100), 10)
+	operationRepo := domain.NewOperationRepositoryMock(nil, []*domain.Operation{domain.OperationCompraAVista}, nil)
+
+	transaction, _ := domain.NewTransaction(domain.NewID(100), domain.OperationCompraAVista, domain.NewMoney(5000, domain.CurrencyBRL))
+	pageOK := domain.NewTransactionPage([]*domain.Transaction{transaction.WithID(domain.NewID(1))}, filter)
+
+	type fields struct {
+		accountRepo     domain.AccountRepositoryReader
+		operationRepo   domain.OperationRepositoryReader
+		transactionRepo domain.TransactionRepositoryReader
+	}
+	type args struct {
+		filter *domain.TransactionFilter
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *domain.TransactionPage
+		wantErr error
+	}{
+		{
+			name: "account not found error",
+			fields: fields{
+				accountRepo:     domain.NewAccountRepositoryMock(nil, nil, repository.NewErrRegisterNotFound("id", "100")),
+				operationRepo:   operationRepo,
+				transactionRepo: domain.NewTransactionRepositoryReaderMock(pageOK, nil),
+			},
+			args: args{
+				filter: filter,
+			},
+			wantErr: repository.NewErrRegisterNotFound("id", "100"),
+		},
+		{
+			name: "unknown transaction repository error",
+			fields: fields{
+				accountRepo:     domain.NewAccountRepositoryMock(nil, accountOK, nil),
+				operationRepo:   operationRepo,
+				transactionRepo: domain.NewTransactionRepositoryReaderMock(nil, errors.New("some repository error")),
+			},
+			args: args{
+				filter: filter,
+			},
+			wantErr: errors.New("some repository error"),
+		},
+		{
+			name: "operation repository error",
+			fields: fields{
+				accountRepo:     domain.NewAccountRepositoryMock(nil, accountOK, nil),
+				operationRepo:   domain.NewOperationRepositoryMock(nil, nil, errors.New("some repository error")),
+				transactionRepo: domain.NewTransactionRepositoryReaderMock(pageOK, nil),
+			},
+			args: args{
+				filter: filter.WithOperations(domain.NewID(1)),
+			},
+			wantErr: errors.New("some repository error"),
+		},
+		{
+			name: "domain error when an operation of the filter does not exist",
+			fields: fields{
+				accountRepo:     domain.NewAccountRepositoryMock(nil, accountOK, nil),
+				operationRepo:   operationRepo,
+				transactionRepo: domain.NewTransactionRepositoryReaderMock(pageOK, nil),
+			},
+			args: args{
+				filter: filter.WithOperations(domain.NewID(1), domain.NewID(99)),
+			},
+			wantErr: domain.NewErrDomain("operation", "'99' is not a valid operation id"),
+		},
+		{
+			name: "transactions listed successfully",
+			fields: fields{
+				accountRepo:     domain.NewAccountRepositoryMock(nil, accountOK, nil),
+				operationRepo:   operationRepo,
+				transactionRepo: domain.NewTransactionRepositoryReaderMock(pageOK, nil),
+			},
+			args: args{
+				filter: filter.WithOperations(domain.NewID(1)),
+			},
+			want: pageOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewListTransactions(tt.fields.accountRepo, tt.fields.operationRepo, tt.fields.transactionRepo)
+
+			got, err := l.List(context.Background(), tt.args.filter)
+			if (err != nil) && !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("List() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("List() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
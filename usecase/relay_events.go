@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// eventsClaimTimeout is how long the events claimed by a run are not claimed by the others, so the events of a run
+// that stops abruptly are published again after it
+const eventsClaimTimeout = time.Minute
+
+// RelayEvents contains all the dependencies to publish the events stored in the outbox
+type RelayEvents struct {
+	repo      domain.EventRepositoryWriter
+	publisher domain.EventPublisher
+	batchSize int
+}
+
+// NewRelayEvents creates a new RelayEvents with its dependencies, batchSize is the maximum of events published per run
+func NewRelayEvents(repo domain.EventRepositoryWriter, publisher domain.EventPublisher, batchSize int) *RelayEvents {
+	return &RelayEvents{repo: repo, publisher: publisher, batchSize: batchSize}
+}
+
+// Relay publishes the events not published yet, from the oldest to the newest, returning how many were published. The
+// events are claimed before being published, so concurrent runs, as of other instances, publish different events. It
+// stops at the first event that fails, releasing the claims of the remaining ones, so the events are published in the
+// order they happened, and that event is published again in the next run.
+func (r RelayEvents) Relay(ctx context.Context) (int, error) {
+	events, err := r.repo.ClaimUnpublished(ctx, r.batchSize, time.Now().Add(eventsClaimTimeout))
+	if err != nil {
+		return 0, err
+	}
+
+	for i, event := range events {
+		if err := r.publisher.Publish(ctx, event); err != nil {
+			// the claims expire anyway, the error to release them does not hide why the relay stopped
+			_ = r.repo.ReleaseClaims(ctx, events[i:])
+
+			return i, err
+		}
+
+		if err := r.repo.MarkPublished(ctx, event, time.Now()); err != nil {
+			_ = r.repo.ReleaseClaims(ctx, events[i:])
+
+			return i, err
+		}
+	}
+
+	return len(events), nil
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+func TestRelayEvents_Relay(t *testing.T) {
+	var (
+		occurredAt = time.Date(2020, 10, 25, 10, 0, 0, 0, time.UTC)
+		events     = []*domain.Event{
+			domain.LoadEvent(domain.NewID(1), domain.EventAccountCreated, domain.NewID(1), []byte(`{"id":1}`), occurredAt),
+			domain.LoadEvent(domain.NewID(2), domain.EventTransactionCreated, domain.NewID(10), []byte(`{"id":10}`), occurredAt),
+		}
+	)
+
+	type fields struct {
+		repo      *domain.EventRepositoryMock
+		publisher *domain.EventPublisherMock
+	}
+	tests := []struct {
+		name          string
+		fields        fields
+		want          int
+		wantPublished []*domain.Event
+		wantErr       error
+	}{
+		// fails
+		{
+			name: "repository error to claim the events not published",
+			fields: fields{
+				repo:      domain.NewEventRepositoryMock(nil, nil, errors.New("database error")),
+				publisher: domain.NewEventPublisherMock(0, nil),
+			},
+			want:    0,
+			wantErr: errors.New("database error"),
+		},
+		{
+			name: "publisher error stops the relay at the event not published",
+			fields: fields{
+				repo:      domain.NewEventRepositoryMock(nil, events, nil),
+				publisher: domain.NewEventPublisherMock(1, errors.New("publisher unavailable")),
+			},
+			want:          1,
+			wantPublished: events[:1],
+			wantErr:       errors.New("publisher unavailable"),
+		},
+
+		// successes
+		{
+			name: "no events to be published",
+			fields: fields{
+				repo:      domain.NewEventRepositoryMock(nil, nil, nil),
+				publisher: domain.NewEventPublisherMock(0, nil),
+			},
+			want: 0,
+		},
+		{
+			name: "events published from the oldest to the newest",
+			fields: fields{
+				repo:      domain.NewEventRepositoryMock(nil, events, nil),
+				publisher: domain.NewEventPublisherMock(0, nil),
+			},
+			want:          2,
+			wantPublished: events,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRelayEvents(tt.fields.repo, tt.fields.publisher, 100)
+
+			got, err := r.Relay(context.Background())
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Relay() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("Relay() got = %v, want %v", got, tt.want)
+			}
+
+			if !reflect.DeepEqual(tt.fields.publisher.Published(), tt.wantPublished) {
+				t.Errorf("Relay() published = %v, want %v", tt.fields.publisher.Published(), tt.wantPublished)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// ReplayWebhookDelivery contains all the dependencies to replay a delivery moved to the dead letters
+type ReplayWebhookDelivery struct {
+	reader domain.WebhookDeliveryRepositoryReader
+	writer domain.WebhookDeliveryRepositoryWriter
+	now    func() time.Time
+}
+
+// NewReplayWebhookDelivery creates a new ReplayWebhookDelivery with its dependencies
+func NewReplayWebhookDelivery(
+	reader domain.WebhookDeliveryRepositoryReader,
+	writer domain.WebhookDeliveryRepositoryWriter,
+	now func() time.Time,
+) *ReplayWebhookDelivery {
+	return &ReplayWebhookDelivery{reader: reader, writer: writer, now: now}
+}
+
+// Replay moves a failed delivery back to the deliveries, with all its attempts, to be sent right away
+func (r ReplayWebhookDelivery) Replay(ctx context.Context, id *domain.ID) (*domain.WebhookDelivery, error) {
+	delivery, err := r.reader.FindDeadLetterByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err = delivery.Replay(r.now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.writer.Replay(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+func TestReplayWebhookDelivery_Replay(t *testing.T) {
+	var (
+		now       = time.Date(2020, 10, 25, 10, 0, 0, 0, time.UTC)
+		webhook   = domain.LoadWebhook(domain.NewID(1), "https://a.com", []domain.EventType{domain.EventAccountCreated}, "s", now)
+		event     = domain.LoadEvent(domain.NewID(1), domain.EventAccountCreated, domain.NewID(1), []byte(`{"id":1}`), now)
+		failed    = domain.LoadWebhookDelivery(domain.NewID(1), webhook, event, domain.WebhookDeliveryFailed, 8, now, now, "status 500")
+		pending   = domain.LoadWebhookDelivery(domain.NewID(1), webhook, event, domain.WebhookDeliveryPending, 1, now, now, "")
+		replayed  = domain.LoadWebhookDelivery(domain.NewID(1), webhook, event, domain.WebhookDeliveryPending, 0, now, now, "")
+		replayErr = errors.New("database error")
+	)
+
+	tests := []struct {
+		name    string
+		repo    *domain.WebhookDeliveryRepositoryMock
+		want    *domain.WebhookDelivery
+		wantErr error
+	}{
+		// fails
+		{
+			name:    "repository error to find the delivery",
+			repo:    domain.NewWebhookDeliveryRepositoryMock(nil, nil, errors.New("database error")),
+			wantErr: errors.New("database error"),
+		},
+		{
+			name:    "domain error when the delivery is not failed",
+			repo:    domain.NewWebhookDeliveryRepositoryMock(nil, []*domain.WebhookDelivery{pending}, nil),
+			wantErr: domain.NewErrDomain("status", "'pending' deliveries cannot be replayed, only failed ones"),
+		},
+		{
+			name:    "repository error to replay the delivery",
+			repo:    domain.NewWebhookDeliveryRepositoryMock(nil, []*domain.WebhookDelivery{failed}, nil).WithWriteErr(replayErr),
+			wantErr: replayErr,
+		},
+
+		// successes
+		{
+			name: "delivery replayed successfully",
+			repo: domain.NewWebhookDeliveryRepositoryMock(nil, []*domain.WebhookDelivery{failed}, nil),
+			want: replayed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewReplayWebhookDelivery(tt.repo, tt.repo, func() time.Time { return now })
+
+			got, err := r.Replay(context.Background(), domain.NewID(1))
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Replay() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Replay() got = %v, want %v", got, tt.want)
+			}
+
+			if err == nil && !reflect.DeepEqual(tt.repo.Written("Replay"), []*domain.WebhookDelivery{replayed}) {
+				t.Errorf("Replay() replayed = %v", tt.repo.Written("Replay"))
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// ReverseTransaction contains all the dependencies to reverse a transaction
+type ReverseTransaction struct {
+	repo domain.ReversalRepositoryWriter
+}
+
+// NewReverseTransaction creates a new ReverseTransaction with its dependencies
+func NewReverseTransaction(repo domain.ReversalRepositoryWriter) *ReverseTransaction {
+	return &ReverseTransaction{repo: repo}
+}
+
+// Reverse reverses the informed amount of a transaction, or the whole amount not reversed yet when it is nil
+func (r ReverseTransaction) Reverse(ctx context.Context, transactionID *domain.ID, amount *domain.Money) (*domain.Reversal, error) {
+	reversal, err := domain.NewReversal(transactionID, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := reversal.Store(ctx, r.repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return stored, nil
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+func TestReverseTransaction_Reverse(t *testing.T) {
+	var (
+		purchase, _ = domain.NewTransaction(domain.NewID(1), domain.OperationCompraAVista, domain.NewMoney(10000, domain.CurrencyBRL))
+		account     = new(domain.Account).WithID(domain.NewID(1)).WithCurrency(domain.CurrencyBRL)
+		amount      = func(m domain.Money) *domain.Money { return &m }
+	)
+
+	purchase = purchase.WithID(domain.NewID(10))
+
+	type fields struct {
+		repo domain.ReversalRepositoryWriter
+	}
+	type args struct {
+		transactionID *domain.ID
+		amount        *domain.Money
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		args       args
+		wantID     uint64
+		wantAmount domain.Money
+		wantErr    error
+	}{
+		{
+			name:    "domain error when the amount is not positive",
+			fields:  fields{repo: domain.NewReversalRepositoryMock(nil, purchase, account, nil)},
+			args:    args{transactionID: domain.NewID(10), amount: amount(domain.NewMoney(0, ""))},
+			wantErr: domain.NewErrDomain("amount", "must be greater than 0"),
+		},
+		{
+			name:    "domain error when the amount exceeds the transaction amount",
+			fields:  fields{repo: domain.NewReversalRepositoryMock(nil, purchase, account, nil)},
+			args:    args{transactionID: domain.NewID(10), amount: amount(domain.NewMoney(1000100, ""))},
+			wantErr: domain.NewErrDomain("amount", "'100.01' exceeds the amount available to reverse '100.00'"),
+		},
+		{
+			name:    "repository error",
+			fields:  fields{repo: domain.NewReversalRepositoryMock(nil, nil, nil, errors.New("repository error"))},
+			args:    args{transactionID: domain.NewID(10)},
+			wantErr: errors.New("repository error"),
+		},
+		{
+			name:       "partial reversal",
+			fields:     fields{repo: domain.NewReversalRepositoryMock(domain.NewID(20), purchase, account, nil)},
+			args:       args{transactionID: domain.NewID(10), amount: amount(domain.NewMoney(400000, ""))},
+			wantID:     20,
+			wantAmount: domain.NewMoney(4000, domain.CurrencyBRL),
+		},
+		{
+			name:       "total reversal",
+			fields:     fields{repo: domain.NewReversalRepositoryMock(domain.NewID(21), purchase, account, nil)},
+			args:       args{transactionID: domain.NewID(10)},
+			wantID:     21,
+			wantAmount: domain.NewMoney(10000, domain.CurrencyBRL),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewReverseTransaction(tt.fields.repo)
+
+			got, err := r.Reverse(context.Background(), tt.args.transactionID, tt.args.amount)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Reverse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.Transaction().ID().Value() != tt.wantID {
+				t.Errorf("Reverse() ID = %v, want %v", got.Transaction().ID().Value(), tt.wantID)
+			}
+
+			if got.Transaction().Amount() != tt.wantAmount {
+				t.Errorf("Reverse() Amount = %v, want %v", got.Transaction().Amount(), tt.wantAmount)
+			}
+
+			if got.Transaction().ReversalOf().Value() != tt.args.transactionID.Value() {
+				t.Errorf("Reverse() ReversalOf = %v, want %v", got.Transaction().ReversalOf(), tt.args.transactionID)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// ScheduleWebhookDeliveries contains all the dependencies to schedule the deliveries of the events to the webhooks. It
+// is an EventPublisher, so the events are scheduled as they are relayed from the outbox.
+type ScheduleWebhookDeliveries struct {
+	webhookRepo  domain.WebhookRepositoryReader
+	deliveryRepo domain.WebhookDeliveryRepositoryWriter
+	now          func() time.Time
+}
+
+// NewScheduleWebhookDeliveries creates a new ScheduleWebhookDeliveries with its dependencies
+func NewScheduleWebhookDeliveries(
+	webhookRepo domain.WebhookRepositoryReader,
+	deliveryRepo domain.WebhookDeliveryRepositoryWriter,
+	now func() time.Time,
+) *ScheduleWebhookDeliveries {
+	return &ScheduleWebhookDeliveries{webhookRepo: webhookRepo, deliveryRepo: deliveryRepo, now: now}
+}
+
+// Publish schedules the delivery of the event to each webhook subscribed to its type, attempted right away. Scheduling
+// the same event again does not duplicate its deliveries.
+func (s ScheduleWebhookDeliveries) Publish(ctx context.Context, event *domain.Event) error {
+	webhooks, err := s.webhookRepo.FindByEventType(ctx, event.Type())
+	if err != nil {
+		return err
+	}
+
+	at := s.now()
+
+	for _, webhook := range webhooks {
+		if _, err := domain.NewWebhookDelivery(webhook, event, at).Store(ctx, s.deliveryRepo); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+func TestScheduleWebhookDeliveries_Publish(t *testing.T) {
+	var (
+		now      = time.Date(2020, 10, 25, 10, 0, 0, 0, time.UTC)
+		event    = domain.LoadEvent(domain.NewID(1), domain.EventAccountCreated, domain.NewID(1), []byte(`{"id":1}`), now)
+		accounts = domain.LoadWebhook(domain.NewID(1), "https://a.com", []domain.EventType{domain.EventAccountCreated}, "s", now)
+		all      = domain.LoadWebhook(
+			domain.NewID(2),
+			"https://b.com",
+			[]domain.EventType{domain.EventAccountCreated, domain.EventTransactionCreated},
+			"s",
+			now,
+		)
+		transactions = domain.LoadWebhook(domain.NewID(3), "https://c.com", []domain.EventType{domain.EventTransactionCreated}, "s", now)
+	)
+
+	type fields struct {
+		webhookRepo  *domain.WebhookRepositoryMock
+		deliveryRepo *domain.WebhookDeliveryRepositoryMock
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    []*domain.WebhookDelivery
+		wantErr error
+	}{
+		// fails
+		{
+			name: "repository error to find the webhooks",
+			fields: fields{
+				webhookRepo:  domain.NewWebhookRepositoryMock(nil, nil, errors.New("database error")),
+				deliveryRepo: domain.NewWebhookDeliveryRepositoryMock(domain.NewID(1), nil, nil),
+			},
+			wantErr: errors.New("database error"),
+		},
+		{
+			name: "repository error to store the delivery",
+			fields: fields{
+				webhookRepo:  domain.NewWebhookRepositoryMock(nil, []*domain.Webhook{accounts}, nil),
+				deliveryRepo: domain.NewWebhookDeliveryRepositoryMock(nil, nil, errors.New("database error")),
+			},
+			wantErr: errors.New("database error"),
+		},
+
+		// successes
+		{
+			name: "no webhooks subscribed to the event type",
+			fields: fields{
+				webhookRepo:  domain.NewWebhookRepositoryMock(nil, []*domain.Webhook{transactions}, nil),
+				deliveryRepo: domain.NewWebhookDeliveryRepositoryMock(domain.NewID(1), nil, nil),
+			},
+		},
+		{
+			name: "one delivery scheduled per webhook subscribed",
+			fields: fields{
+				webhookRepo:  domain.NewWebhookRepositoryMock(nil, []*domain.Webhook{accounts, all, transactions}, nil),
+				deliveryRepo: domain.NewWebhookDeliveryRepositoryMock(domain.NewID(1), nil, nil),
+			},
+			want: []*domain.WebhookDelivery{
+				domain.NewWebhookDelivery(accounts, event, now),
+				domain.NewWebhookDelivery(all, event, now),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewScheduleWebhookDeliveries(tt.fields.webhookRepo, tt.fields.deliveryRepo, func() time.Time { return now })
+
+			err := s.Publish(context.Background(), event)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Publish() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got := tt.fields.deliveryRepo.Written("Store"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Publish() stored = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+// UpdateOperation contains all the dependencies to enable or disable an operation
+type UpdateOperation struct {
+	reader domain.OperationRepositoryReader
+	writer domain.OperationRepositoryWriter
+}
+
+// NewUpdateOperation creates a new UpdateOperation with its dependencies
+func NewUpdateOperation(reader domain.OperationRepositoryReader, writer domain.OperationRepositoryWriter) *UpdateOperation {
+	return &UpdateOperation{reader: reader, writer: writer}
+}
+
+// Update enables or disables an operation, the change is applied to the transactions right away.
+// Transactions already registered with a disabled operation are kept.
+func (u UpdateOperation) Update(ctx context.Context, id *domain.ID, enabled bool) (*domain.Operation, error) {
+	operation, err := u.reader.FindOneByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if enabled {
+		operation = operation.Enable()
+	} else if operation, err = operation.Disable(); err != nil {
+		return nil, err
+	}
+
+	if err := u.writer.UpdateEnabled(ctx, operation); err != nil {
+		return nil, err
+	}
+
+	return operation, nil
+}
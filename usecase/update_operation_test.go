@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/tonytcb/bank-transactions-go/domain"
+)
+
+func TestUpdateOperation_Update(t *testing.T) {
+	fee, _ := domain.NewOperationType("tarifa", domain.OperationDirectionDebit, domain.OperationKindStandard)
+	fee = fee.WithID(domain.NewID(302))
+
+	tests := []struct {
+		name        string
+		repo        *domain.OperationRepositoryMock
+		enabled     bool
+		wantEnabled bool
+		wantErr     error
+	}{
+		{
+			name:    "repository error when the operation is not found",
+			repo:    domain.NewOperationRepositoryMock(nil, nil, errors.New("operation not found")),
+			wantErr: errors.New("operation not found"),
+		},
+		{
+			name:    "domain error when the operation is reserved",
+			repo:    domain.NewOperationRepositoryMock(nil, []*domain.Operation{domain.OperationEstorno}, nil),
+			wantErr: domain.NewErrDomain("operation", "'7' is reserved and cannot be disabled"),
+		},
+		{
+			name:        "operation disabled successfully",
+			repo:        domain.NewOperationRepositoryMock(nil, []*domain.Operation{fee}, nil),
+			enabled:     false,
+			wantEnabled: false,
+		},
+		{
+			name:        "operation enabled successfully",
+			repo:        domain.NewOperationRepositoryMock(nil, []*domain.Operation{fee.WithEnabled(false)}, nil),
+			enabled:     true,
+			wantEnabled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewUpdateOperation(tt.repo, tt.repo).Update(context.Background(), domain.NewID(302), tt.enabled)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Update() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.IsEnabled() != tt.wantEnabled {
+				t.Errorf("IsEnabled() = %v, want %v", got.IsEnabled(), tt.wantEnabled)
+			}
+		})
+	}
+}